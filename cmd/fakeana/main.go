@@ -0,0 +1,54 @@
+package main
+
+import (
+	"analog-be/pkg/fakeana"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// 로컬 개발용 An-Account / An-Americano 대역 서버입니다.
+//
+//	go run ./cmd/fakeana -addr :9090 -users users.json
+//
+// .env 에서 AN_ACCOUNT_BASE_URL=http://localhost:9090 로 바꾸면 실제 계정 없이 로그인할 수 있습니다.
+// 로그인할 사용자는 /oauth2/authorize?login_hint=<id> 로 고를 수 있고, 없으면 첫 번째 사용자로 로그인됩니다.
+func main() {
+	addr := flag.String("addr", ":9090", "listen 주소")
+	usersFile := flag.String("users", "", "사용자 목록 JSON 파일 ([{\"id\":1,\"name\":\"...\",\"email\":\"...\"}])")
+	clientID := flag.String("client-id", os.Getenv("AN_ACCOUNT_CLIENT_ID"), "허용할 client_id (비우면 검사하지 않음)")
+	clientSecret := flag.String("client-secret", os.Getenv("AN_ACCOUNT_CLIENT_SECRET"), "허용할 client_secret (비우면 검사하지 않음)")
+	apiToken := flag.String("api-token", os.Getenv("AN_ACCOUNT_API_TOKEN"), "권한 API Bearer 토큰 (비우면 검사하지 않음)")
+	flag.Parse()
+
+	server := fakeana.New(fakeana.Config{
+		ClientID:     *clientID,
+		ClientSecret: *clientSecret,
+		APIToken:     *apiToken,
+	})
+
+	users := []fakeana.User{{ID: 1, Name: "테스트 사용자", Email: "test@ana.st", PreferredUsername: "test"}}
+	if *usersFile != "" {
+		data, err := os.ReadFile(*usersFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "사용자 파일을 읽을 수 없습니다: %v\n", err)
+			os.Exit(1)
+		}
+		users = nil
+		if err := json.Unmarshal(data, &users); err != nil {
+			fmt.Fprintf(os.Stderr, "사용자 파일 형식이 올바르지 않습니다: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	for _, user := range users {
+		server.AddUser(user)
+	}
+
+	fmt.Printf("fakeana listening on %s (%d users)\n", *addr, len(users))
+	if err := http.ListenAndServe(*addr, server); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
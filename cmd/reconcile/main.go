@@ -0,0 +1,58 @@
+package main
+
+import (
+	"analog-be/pkg"
+	"analog-be/repository"
+	"analog-be/server"
+	"analog-be/service"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/joho/godotenv"
+)
+
+// log_to_users와 An-Americano의 로그 권한을 비교해 어긋난 권한을 바로잡습니다.
+//
+//	go run ./cmd/reconcile -dry-run
+func main() {
+	dryRun := flag.Bool("dry-run", false, "변경 없이 어긋난 권한만 출력합니다")
+	flag.Parse()
+
+	err := godotenv.Load()
+	if err != nil {
+		panic(err)
+	}
+
+	if err := pkg.InitLogger(); err != nil {
+		panic(err)
+	}
+	logger := pkg.GetLogger()
+	defer logger.Sync()
+
+	db := server.NewDB(server.DBConfigFromEnv("analog-reconcile"))
+	defer db.Close()
+
+	reconciler := service.NewPermissionReconciler(
+		repository.NewLogRepository(db),
+		repository.NewUserRepository(db),
+		service.NewAnAmericanoService(),
+		logger,
+	)
+
+	report, err := reconciler.Reconcile(context.Background(), *dryRun)
+	if report != nil {
+		fmt.Printf("검사한 로그: %d, 검사한 사용자: %d, 추가: %d, 회수: %d, 작성자를 가져온 예전 로그: %d\n",
+			report.CheckedLogs, report.CheckedUsers, report.Written, report.Deleted, report.Imported)
+		for _, e := range report.Errors {
+			fmt.Println("오류:", e)
+		}
+	}
+	if err != nil {
+		panic(err)
+	}
+	if report != nil && len(report.Errors) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"analog-be/pkg"
+	"analog-be/service"
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/NARUBROWN/spine/pkg/httperr"
+	"github.com/NARUBROWN/spine/pkg/httpx"
+	"github.com/NARUBROWN/spine/pkg/path"
+	"github.com/NARUBROWN/spine/pkg/spine"
+)
+
+type AccessTokenController struct {
+	accessTokenService service.AccessTokenService
+}
+
+func NewAccessTokenController(accessTokenService service.AccessTokenService) *AccessTokenController {
+	return &AccessTokenController{
+		accessTokenService: accessTokenService,
+	}
+}
+
+// ListAccessTokens lists the current user's personal access tokens.
+// @Summary      ListAccessTokens
+// @Description  List the current user's personal access tokens, newest first. Token values are never returned again.
+// @Tags         Auth
+// @Produce      json
+// @Success      200 {array} dto.AccessTokenResponse
+// @Failure      401 "Unauthorized"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /auth/tokens [get]
+func (c *AccessTokenController) ListAccessTokens(ctx context.Context, spineCtx spine.Ctx) httpx.Response[[]dto.AccessTokenResponse] {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[[]dto.AccessTokenResponse]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusUnauthorized, // authentication required
+			},
+		}
+	}
+
+	userID := v.(entity.ID)
+
+	tokens, err := c.accessTokenService.List(ctx, &userID)
+	if err != nil {
+		return httpx.Response[[]dto.AccessTokenResponse]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusInternalServerError, // internal server error
+			},
+		}
+	}
+
+	tokenResponses := make([]dto.AccessTokenResponse, len(tokens))
+	for i, token := range tokens {
+		tokenResponses[i] = dto.NewAccessTokenResponse(token)
+	}
+
+	return httpx.Response[[]dto.AccessTokenResponse]{
+		Body: tokenResponses,
+	}
+}
+
+// CreateAccessToken creates a personal access token for scripts and CI.
+// @Summary      CreateAccessToken
+// @Description  Create a named personal access token with scopes (logs:write, comments:write, reactions:write, user:read) and an optional expiry. The token is only returned once.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.AccessTokenCreateRequest true "Access token to create"
+// @Success      201 {object} dto.AccessTokenCreateResponse
+// @Failure      400 "Bad Request"
+// @Failure      401 "Unauthorized"
+// @Failure      409 "Too many tokens"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /auth/tokens [post]
+func (c *AccessTokenController) CreateAccessToken(ctx context.Context, req *dto.AccessTokenCreateRequest, spineCtx spine.Ctx) httpx.Response[dto.AccessTokenCreateResponse] {
+	if err := pkg.Validate(req); err != nil {
+		return httpx.Response[dto.AccessTokenCreateResponse]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusBadRequest, // validation error
+			},
+		}
+	}
+
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[dto.AccessTokenCreateResponse]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusUnauthorized, // authentication required
+			},
+		}
+	}
+
+	userID := v.(entity.ID)
+
+	token, err := c.accessTokenService.Create(ctx, &userID, req)
+	if err != nil {
+		return httpx.Response[dto.AccessTokenCreateResponse]{
+			Options: httpx.ResponseOptions{
+				Status: statusFromError(err),
+			},
+		}
+	}
+
+	return httpx.Response[dto.AccessTokenCreateResponse]{
+		Body: dto.AccessTokenCreateResponse{
+			AccessTokenResponse: dto.NewAccessTokenResponse(token),
+			Token:               token.Token,
+		},
+		Options: httpx.ResponseOptions{
+			Status: http.StatusCreated,
+		},
+	}
+}
+
+// RevokeAccessToken revokes one of the current user's personal access tokens.
+// @Summary      RevokeAccessToken
+// @Description  Revoke one of the current user's personal access tokens.
+// @Tags         Auth
+// @Param        id path int true "Access token ID"
+// @Success      204 "No Content"
+// @Failure      401 "Unauthorized"
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /auth/tokens/{id} [delete]
+func (c *AccessTokenController) RevokeAccessToken(ctx context.Context, id path.Int, spineCtx spine.Ctx) error {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	err := c.accessTokenService.Revoke(ctx, &userID, &id.Value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return httperr.NotFound("Access token not found")
+	}
+	if err != nil {
+		return &httperr.HTTPError{
+			Status:  500,
+			Message: "Internal Server Error",
+			Cause:   err,
+		}
+	}
+
+	return nil
+}
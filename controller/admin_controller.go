@@ -0,0 +1,165 @@
+package controller
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"analog-be/pkg"
+	"analog-be/service"
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NARUBROWN/spine/pkg/httperr"
+	"github.com/NARUBROWN/spine/pkg/httpx"
+	"github.com/NARUBROWN/spine/pkg/path"
+	"github.com/NARUBROWN/spine/pkg/query"
+	"github.com/NARUBROWN/spine/pkg/spine"
+)
+
+type AdminController struct {
+	impersonationService service.ImpersonationService
+}
+
+func NewAdminController(impersonationService service.ImpersonationService) *AdminController {
+	return &AdminController{
+		impersonationService: impersonationService,
+	}
+}
+
+// StartImpersonation starts a time-limited session as another user.
+// @Summary      StartImpersonation
+// @Description  Start a time-limited session as another user to debug their report. Admins only. Every request is audited, and mutations are blocked unless allowWrites is set; account, session and token management is always blocked.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ImpersonationStartRequest true "Impersonation to start"
+// @Success      201 {object} dto.ImpersonationResponse
+// @Failure      400 "Bad Request"
+// @Failure      401 "Unauthorized"
+// @Failure      403 "Not an admin, or the target is an admin"
+// @Failure      404 "User not found"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/impersonations [post]
+func (c *AdminController) StartImpersonation(ctx context.Context, req *dto.ImpersonationStartRequest, spineCtx spine.Ctx) (httpx.Response[dto.ImpersonationResponse], error) {
+	if err := pkg.Validate(req); err != nil {
+		return httpx.Response[dto.ImpersonationResponse]{}, httpErrorFromError(err)
+	}
+
+	adminID, err := adminFromContext(spineCtx)
+	if err != nil {
+		return httpx.Response[dto.ImpersonationResponse]{}, err
+	}
+
+	session, err := c.impersonationService.Start(ctx, &adminID, req)
+	if err != nil {
+		return httpx.Response[dto.ImpersonationResponse]{}, httpErrorFromError(err)
+	}
+
+	return httpx.Response[dto.ImpersonationResponse]{
+		Body: dto.ImpersonationResponse{
+			ID:           session.ID,
+			SessionToken: session.SessionToken,
+			UserID:       session.UserID,
+			AllowWrites:  session.AllowWrites,
+			ExpiresAt:    session.ExpiresAt,
+		},
+		Options: httpx.ResponseOptions{
+			Status: http.StatusCreated,
+		},
+	}, nil
+}
+
+// EndImpersonation ends an impersonation session started by the current admin.
+// @Summary      EndImpersonation
+// @Description  End an impersonation session started by the current admin.
+// @Tags         Admin
+// @Param        id path int true "Impersonation session ID"
+// @Success      204 "No Content"
+// @Failure      401 "Unauthorized"
+// @Failure      403 "Not an admin"
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/impersonations/{id} [delete]
+func (c *AdminController) EndImpersonation(ctx context.Context, id path.Int, spineCtx spine.Ctx) error {
+	adminID, err := adminFromContext(spineCtx)
+	if err != nil {
+		return err
+	}
+
+	err = c.impersonationService.End(ctx, &adminID, &id.Value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return httperr.NotFound("Impersonation session not found")
+	}
+	if err != nil {
+		return httpErrorFromError(err)
+	}
+
+	return nil
+}
+
+// ListImpersonationAudits lists impersonation audit records, newest first.
+// @Summary      ListImpersonationAudits
+// @Description  List impersonation audit records (start, request, blocked, end), newest first. Admins only.
+// @Tags         Admin
+// @Produce      json
+// @Param        userId query int false "Only records for this impersonated user"
+// @Param        page query int false "Page number"
+// @Param        size query int false "Page size"
+// @Success      200 {object} dto.PaginatedResult[dto.ImpersonationAuditResponse]
+// @Failure      400 "Bad Request"
+// @Failure      401 "Unauthorized"
+// @Failure      403 "Not an admin"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/impersonations/audits [get]
+func (c *AdminController) ListImpersonationAudits(ctx context.Context, q query.Values, page query.Pagination, spineCtx spine.Ctx) (httpx.Response[dto.PaginatedResult[dto.ImpersonationAuditResponse]], error) {
+	adminID, err := adminFromContext(spineCtx)
+	if err != nil {
+		return httpx.Response[dto.PaginatedResult[dto.ImpersonationAuditResponse]]{}, err
+	}
+
+	var targetUserID *entity.ID
+	if raw := q.Get("userId"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return httpx.Response[dto.PaginatedResult[dto.ImpersonationAuditResponse]]{}, httperr.BadRequest("userId must be an integer")
+		}
+		targetUserID = &id
+	}
+
+	limit, offset := pageToLimitOffset(page)
+	result, err := c.impersonationService.ListAudits(ctx, &adminID, targetUserID, limit, offset)
+	if err != nil {
+		return httpx.Response[dto.PaginatedResult[dto.ImpersonationAuditResponse]]{}, httpErrorFromError(err)
+	}
+
+	audits := make([]dto.ImpersonationAuditResponse, len(result.Items))
+	for i, audit := range result.Items {
+		audits[i] = dto.NewImpersonationAuditResponse(audit)
+	}
+
+	return httpx.Response[dto.PaginatedResult[dto.ImpersonationAuditResponse]]{
+		Body: dto.PaginatedResult[dto.ImpersonationAuditResponse]{
+			Items:  audits,
+			Total:  result.Total,
+			Limit:  result.Limit,
+			Offset: result.Offset,
+		},
+	}, nil
+}
+
+// adminFromContext 는 인증된 사용자 아이디를 꺼냅니다. 대리 세션으로는 관리자 기능을 쓸 수 없습니다.
+func adminFromContext(spineCtx spine.Ctx) (entity.ID, error) {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return 0, httperr.Unauthorized("Authentication required")
+	}
+	if _, impersonating := spineCtx.Get(string(pkg.ImpersonatorIDKey)); impersonating {
+		return 0, &httperr.HTTPError{Status: http.StatusForbidden, Message: "Admin endpoints cannot be used while impersonating"}
+	}
+	return v.(entity.ID), nil
+}
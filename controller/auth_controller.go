@@ -2,94 +2,91 @@ package controller
 
 import (
 	"analog-be/dto"
+	"analog-be/entity"
 	"analog-be/pkg"
 	"analog-be/service"
 	"context"
+	"database/sql"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/NARUBROWN/spine/pkg/httperr"
 	"github.com/NARUBROWN/spine/pkg/httpx"
+	"github.com/NARUBROWN/spine/pkg/path"
 
 	"github.com/NARUBROWN/spine/pkg/query"
+	"github.com/NARUBROWN/spine/pkg/spine"
 )
 
 type AuthController struct {
 	anAccountOAuthService service.AnAccountService
 	userService           service.UserService
+	sessionService        service.SessionService
+	cookies               *pkg.SessionCookiePolicy
 }
 
-func NewAuthController(anAccountOAuthService service.AnAccountService, userService service.UserService) *AuthController {
+func NewAuthController(anAccountOAuthService service.AnAccountService, userService service.UserService, sessionService service.SessionService, cookies *pkg.SessionCookiePolicy) *AuthController {
 	return &AuthController{
 		anAccountOAuthService: anAccountOAuthService,
 		userService:           userService,
+		sessionService:        sessionService,
+		cookies:               cookies,
 	}
 }
 
 // InitiateLogin initiates the OAuth2 login flow.
 // @Summary      InitiateLogin
-// @Description  Initiate the OAuth2 login flow.
+// @Description  Initiate the OAuth2 login flow. provider defaults to an-account; see GET /auth/providers.
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
 // @Param        request body dto.LoginInitRequest true "Login initiation request"
 // @Success      200 {object} dto.LoginInitResponse
-// @Failure      400 "Bad Request"
+// @Failure      400 "redirectUri is missing or not in the allowlist, or the provider is unknown"
 // @Failure      500 "Internal Server Error"
 // @Router       /auth/login/init [post]
-func (c *AuthController) InitiateLogin(ctx context.Context, req *dto.LoginInitRequest) httpx.Response[dto.LoginInitResponse] {
+func (c *AuthController) InitiateLogin(ctx context.Context, req *dto.LoginInitRequest) (httpx.Response[dto.LoginInitResponse], error) {
 	if req.RedirectUri == "" {
-		return httpx.Response[dto.LoginInitResponse]{
-			Options: httpx.ResponseOptions{
-				Status: http.StatusBadRequest, // redirectUri is required
-			},
-		}
+		return httpx.Response[dto.LoginInitResponse]{}, httperr.BadRequest("redirectUri is required")
 	}
 
-	result, err := c.anAccountOAuthService.InitiateLogin(ctx, req.RedirectUri)
+	result, err := c.anAccountOAuthService.InitiateLogin(ctx, req.Provider, req.RedirectUri)
 	if err != nil {
-		return httpx.Response[dto.LoginInitResponse]{
-			Options: httpx.ResponseOptions{
-				Status: http.StatusInternalServerError, // internal server error
-			},
-		}
+		// 허용되지 않은 redirectUri는 400
+		return httpx.Response[dto.LoginInitResponse]{}, httpErrorFromError(err)
 	}
 	return httpx.Response[dto.LoginInitResponse]{
 		Body: *result,
-	}
+	}, nil
 }
 
 // InitiateSignup initiates the OAuth2 signup flow.
 // @Summary      InitiateSignup
-// @Description  Initiate the OAuth2 signup flow.
+// @Description  Initiate the OAuth2 signup flow. provider defaults to an-account; see GET /auth/providers. handle defaults to user<id>.
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
 // @Param        request body dto.SignupInitRequest true "Signup initiation request"
 // @Success      200 {object} dto.SignupInitResponse
-// @Failure      400 "Bad Request"
+// @Failure      400 "redirectUri is missing or not in the allowlist, the provider is unknown, or the handle is invalid or reserved"
+// @Failure      409 "Handle is already taken"
 // @Failure      500 "Internal Server Error"
 // @Router       /auth/signup/init [post]
-func (c *AuthController) InitiateSignup(ctx context.Context, req *dto.SignupInitRequest) httpx.Response[dto.SignupInitResponse] {
+func (c *AuthController) InitiateSignup(ctx context.Context, req *dto.SignupInitRequest) (httpx.Response[dto.SignupInitResponse], error) {
 	if req.RedirectUri == "" {
-		return httpx.Response[dto.SignupInitResponse]{
-			Options: httpx.ResponseOptions{
-				Status: http.StatusBadRequest, // redirectUri is required
-			},
-		}
+		return httpx.Response[dto.SignupInitResponse]{}, httperr.BadRequest("redirectUri is required")
 	}
 
-	result, err := c.anAccountOAuthService.InitiateSignup(ctx, req.RedirectUri)
+	result, err := c.anAccountOAuthService.InitiateSignup(ctx, req.Provider, req.Handle, req.RedirectUri)
 	if err != nil {
-		return httpx.Response[dto.SignupInitResponse]{
-			Options: httpx.ResponseOptions{
-				Status: http.StatusInternalServerError, // internal server error
-			},
-		}
+		// 허용되지 않은 redirectUri는 400
+		return httpx.Response[dto.SignupInitResponse]{}, httpErrorFromError(err)
 	}
 
 	return httpx.Response[dto.SignupInitResponse]{
 		Body: *result,
-	}
+	}, nil
 }
 
 // HandleLoginCallback handles the OAuth2 callback after a successful login.
@@ -99,8 +96,10 @@ func (c *AuthController) InitiateSignup(ctx context.Context, req *dto.SignupInit
 // @Produce      json
 // @Param        code query string true "Authorization code"
 // @Param        state query string true "State"
+// @Param        session query string false "Set to cookie to receive the session as an HttpOnly cookie instead of in the body"
 // @Success      200 {object} dto.AuthResponse
 // @Failure      400 "Bad Request"
+// @Failure      404 "Not Found"
 // @Failure      500 "Internal Server Error"
 // @Router       /auth/login/callback [get]
 func (c *AuthController) HandleLoginCallback(ctx context.Context, q query.Values) httpx.Response[dto.AuthResponse] {
@@ -126,14 +125,12 @@ func (c *AuthController) HandleLoginCallback(ctx context.Context, q query.Values
 	if err != nil {
 		return httpx.Response[dto.AuthResponse]{
 			Options: httpx.ResponseOptions{
-				Status: http.StatusInternalServerError, // internal server error
+				Status: statusFromError(err), // invalid state, user conflict, or internal error
 			},
 		}
 	}
 
-	return httpx.Response[dto.AuthResponse]{
-		Body: *result,
-	}
+	return c.authResponse(result, q.Get("session"))
 }
 
 // HandleSignupCallback handles the OAuth2 callback after a successful signup.
@@ -143,8 +140,10 @@ func (c *AuthController) HandleLoginCallback(ctx context.Context, q query.Values
 // @Produce      json
 // @Param        code query string true "Authorization code"
 // @Param        state query string true "State"
+// @Param        session query string false "Set to cookie to receive the session as an HttpOnly cookie instead of in the body"
 // @Success      200 {object} dto.AuthResponse
 // @Failure      400 "Bad Request"
+// @Failure      409 "Conflict"
 // @Failure      500 "Internal Server Error"
 // @Router       /auth/signup/callback [get]
 func (c *AuthController) HandleSignupCallback(ctx context.Context, q query.Values) httpx.Response[dto.AuthResponse] {
@@ -170,13 +169,31 @@ func (c *AuthController) HandleSignupCallback(ctx context.Context, q query.Value
 	if err != nil {
 		return httpx.Response[dto.AuthResponse]{
 			Options: httpx.ResponseOptions{
-				Status: http.StatusInternalServerError, // internal server error
+				Status: statusFromError(err), // invalid state, user conflict, or internal error
 			},
 		}
 	}
 
+	return c.authResponse(result, q.Get("session"))
+}
+
+// authResponse 는 쿠키 세션 모드이면 세션 토큰을 본문 대신 HttpOnly 쿠키로 내려주고, 본문에는 CSRF 토큰을 담습니다.
+func (c *AuthController) authResponse(result *dto.AuthResponse, mode string) httpx.Response[dto.AuthResponse] {
+	if mode != "cookie" {
+		return httpx.Response[dto.AuthResponse]{
+			Body: *result,
+		}
+	}
+
+	cookies := c.cookies.Cookies(result.SessionToken)
+	result.CSRFToken = pkg.CSRFToken(result.SessionToken)
+	result.SessionToken = ""
+
 	return httpx.Response[dto.AuthResponse]{
 		Body: *result,
+		Options: httpx.ResponseOptions{
+			Cookies: cookies,
+		},
 	}
 }
 
@@ -187,12 +204,14 @@ func (c *AuthController) HandleSignupCallback(ctx context.Context, q query.Value
 // @Accept       json
 // @Success      204 "No Content"
 // @Failure      400 "Bad Request"
+// @Failure      401 "Unauthorized"
 // @Failure      500 "Internal Server Error"
 // @Security     ApiKeyAuth
 // @Router       /auth/logout [post]
-func (c *AuthController) Logout(ctx context.Context, req *dto.LogoutRequest) error {
-	sessionToken, ok := pkg.GetSessionToken(ctx)
-	if !ok || sessionToken == "" {
+func (c *AuthController) Logout(ctx context.Context, req *dto.LogoutRequest, spineCtx spine.Ctx) error {
+	v, _ := spineCtx.Get(string(pkg.SessionTokenKey))
+	sessionToken, _ := v.(string)
+	if sessionToken == "" {
 		sessionToken = req.SessionToken
 	}
 
@@ -209,6 +228,10 @@ func (c *AuthController) Logout(ctx context.Context, req *dto.LogoutRequest) err
 		}
 	}
 
+	if fromCookie(spineCtx) {
+		setCookies(spineCtx, c.cookies.ClearCookies()...)
+	}
+
 	return nil
 }
 
@@ -222,9 +245,10 @@ func (c *AuthController) Logout(ctx context.Context, req *dto.LogoutRequest) err
 // @Failure      500 "Internal Server Error"
 // @Security     ApiKeyAuth
 // @Router       /auth/me [get]
-func (c *AuthController) GetCurrentUser(ctx context.Context) httpx.Response[dto.UserDTO] {
-	sessionToken, ok := pkg.GetSessionToken(ctx)
-	if !ok || sessionToken == "" {
+func (c *AuthController) GetCurrentUser(ctx context.Context, spineCtx spine.Ctx) httpx.Response[dto.UserDTO] {
+	// 세션이든 개인 액세스 토큰이든 인증 인터셉터가 확인한 사용자를 돌려줌
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
 		return httpx.Response[dto.UserDTO]{
 			Options: httpx.ResponseOptions{
 				Status: http.StatusUnauthorized, // unauthorized
@@ -232,7 +256,9 @@ func (c *AuthController) GetCurrentUser(ctx context.Context) httpx.Response[dto.
 		}
 	}
 
-	user, err := c.anAccountOAuthService.ValidateSession(ctx, sessionToken)
+	id := v.(entity.ID)
+
+	user, err := c.userService.Get(ctx, &id)
 	if err != nil {
 		return httpx.Response[dto.UserDTO]{
 			Options: httpx.ResponseOptions{
@@ -245,6 +271,7 @@ func (c *AuthController) GetCurrentUser(ctx context.Context) httpx.Response[dto.
 		Body: dto.UserDTO{
 			ID:           user.ID,
 			Name:         user.Name,
+			Handle:       user.Handle,
 			ProfileImage: user.ProfileImage,
 			PartOf:       user.PartOf,
 			Generation:   user.Generation,
@@ -253,6 +280,177 @@ func (c *AuthController) GetCurrentUser(ctx context.Context) httpx.Response[dto.
 	}
 }
 
+// RefreshSession rotates the current session token.
+// @Summary      RefreshSession
+// @Description  Issue a new token for the current Analog session and invalidate the old one. The session's maximum lifetime still counts from the original login.
+// @Tags         Auth
+// @Produce      json
+// @Success      200 {object} dto.SessionRefreshResponse
+// @Failure      401 "Unauthorized"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /auth/session/refresh [post]
+func (c *AuthController) RefreshSession(ctx context.Context, spineCtx spine.Ctx) httpx.Response[dto.SessionRefreshResponse] {
+	v, _ := spineCtx.Get(string(pkg.SessionTokenKey))
+	sessionToken, _ := v.(string)
+	if sessionToken == "" {
+		return httpx.Response[dto.SessionRefreshResponse]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusUnauthorized, // authentication required
+			},
+		}
+	}
+
+	session, err := c.sessionService.Rotate(ctx, sessionToken)
+	if errors.Is(err, sql.ErrNoRows) {
+		return httpx.Response[dto.SessionRefreshResponse]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusUnauthorized, // already rotated by another request
+			},
+		}
+	}
+	if err != nil {
+		return httpx.Response[dto.SessionRefreshResponse]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusInternalServerError, // internal server error
+			},
+		}
+	}
+
+	// 쿠키 세션이면 새 토큰도 쿠키로만 내려줌
+	if fromCookie(spineCtx) {
+		return httpx.Response[dto.SessionRefreshResponse]{
+			Body: dto.SessionRefreshResponse{
+				CSRFToken: pkg.CSRFToken(session.SessionToken),
+				ExpiresAt: session.ExpiresAt.Format(time.RFC3339),
+			},
+			Options: httpx.ResponseOptions{
+				Cookies: c.cookies.Cookies(session.SessionToken),
+			},
+		}
+	}
+
+	return httpx.Response[dto.SessionRefreshResponse]{
+		Body: dto.SessionRefreshResponse{
+			SessionToken: session.SessionToken,
+			ExpiresAt:    session.ExpiresAt.Format(time.RFC3339),
+		},
+	}
+}
+
+// ListSessions lists the current user's active sessions.
+// @Summary      ListSessions
+// @Description  List the current user's active sessions, most recently used first.
+// @Tags         Auth
+// @Produce      json
+// @Success      200 {array} dto.SessionResponse
+// @Failure      401 "Unauthorized"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /auth/sessions [get]
+func (c *AuthController) ListSessions(ctx context.Context, spineCtx spine.Ctx) httpx.Response[[]dto.SessionResponse] {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[[]dto.SessionResponse]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusUnauthorized, // authentication required
+			},
+		}
+	}
+
+	userID := v.(entity.ID)
+
+	sid, _ := spineCtx.Get(string(pkg.SessionIDKey))
+	currentSessionID, _ := sid.(entity.ID)
+
+	sessions, err := c.sessionService.List(ctx, &userID)
+	if err != nil {
+		return httpx.Response[[]dto.SessionResponse]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusInternalServerError, // internal server error
+			},
+		}
+	}
+
+	sessionResponses := make([]dto.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		sessionResponses[i] = dto.NewSessionResponse(session, currentSessionID)
+	}
+
+	return httpx.Response[[]dto.SessionResponse]{
+		Body: sessionResponses,
+	}
+}
+
+// RevokeSession revokes one of the current user's sessions.
+// @Summary      RevokeSession
+// @Description  Revoke one of the current user's sessions.
+// @Tags         Auth
+// @Param        id path int true "Session ID"
+// @Success      204 "No Content"
+// @Failure      401 "Unauthorized"
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /auth/sessions/{id} [delete]
+func (c *AuthController) RevokeSession(ctx context.Context, id path.Int, spineCtx spine.Ctx) error {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	err := c.sessionService.Revoke(ctx, &userID, &id.Value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return httperr.NotFound("Session not found")
+	}
+	if err != nil {
+		return &httperr.HTTPError{
+			Status:  500,
+			Message: "Internal Server Error",
+			Cause:   err,
+		}
+	}
+
+	return nil
+}
+
+// RevokeOtherSessions revokes every session of the current user except the one making the request.
+// @Summary      RevokeOtherSessions
+// @Description  Revoke every session of the current user except the one making the request.
+// @Tags         Auth
+// @Success      204 "No Content"
+// @Failure      401 "Unauthorized"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /auth/sessions [delete]
+func (c *AuthController) RevokeOtherSessions(ctx context.Context, spineCtx spine.Ctx) error {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	t, _ := spineCtx.Get(string(pkg.SessionTokenKey))
+	currentToken, _ := t.(string)
+	if currentToken == "" {
+		return httperr.Unauthorized("Authentication required")
+	}
+
+	err := c.sessionService.RevokeOthers(ctx, &userID, currentToken)
+	if err != nil {
+		return &httperr.HTTPError{
+			Status:  500,
+			Message: "Internal Server Error",
+			Cause:   err,
+		}
+	}
+
+	return nil
+}
+
 // RefreshToken refreshes the access token using a refresh token.
 // @Summary      RefreshToken
 // @Description  Refresh the access token using a refresh token.
@@ -0,0 +1,449 @@
+package controller
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"analog-be/pkg"
+	"analog-be/service"
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NARUBROWN/spine/pkg/httperr"
+	"github.com/NARUBROWN/spine/pkg/httpx"
+	"github.com/NARUBROWN/spine/pkg/path"
+	"github.com/NARUBROWN/spine/pkg/query"
+	"github.com/NARUBROWN/spine/pkg/spine"
+)
+
+type BookmarkController struct {
+	bookmarkService service.BookmarkService
+	reactionService service.ReactionService
+}
+
+func NewBookmarkController(bookmarkService service.BookmarkService, reactionService service.ReactionService) *BookmarkController {
+	return &BookmarkController{
+		bookmarkService: bookmarkService,
+		reactionService: reactionService,
+	}
+}
+
+// ListBookmarks lists the logs the current user saved.
+// @Summary      ListBookmarks
+// @Description  List the logs you saved to read later, most recently saved first.
+// @Tags         Bookmark
+// @Produce      json
+// @Param        page query int false "Page number"
+// @Param        size query int false "Page size"
+// @Success      200 {object} dto.PaginatedResult[dto.BookmarkResponse]
+// @Failure      401 "Unauthorized"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /bookmarks [get]
+func (c *BookmarkController) ListBookmarks(ctx context.Context, page query.Pagination, spineCtx spine.Ctx) (httpx.Response[dto.PaginatedResult[dto.BookmarkResponse]], error) {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[dto.PaginatedResult[dto.BookmarkResponse]]{}, httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	limit, offset := pageToLimitOffset(page)
+	result, err := c.bookmarkService.ListBookmarks(ctx, &userID, limit, offset)
+	if err != nil {
+		return httpx.Response[dto.PaginatedResult[dto.BookmarkResponse]]{}, httpErrorFromError(err)
+	}
+
+	logs := make([]*entity.Log, len(result.Items))
+	for i, bookmark := range result.Items {
+		logs[i] = bookmark.Log
+	}
+	if err := c.reactionService.MarkLogReactions(ctx, logs, &userID); err != nil {
+		return httpx.Response[dto.PaginatedResult[dto.BookmarkResponse]]{}, httpErrorFromError(err)
+	}
+
+	bookmarkResponses := make([]dto.BookmarkResponse, len(result.Items))
+	for i, bookmark := range result.Items {
+		bookmarkResponses[i] = dto.NewBookmarkResponse(bookmark)
+	}
+
+	return httpx.Response[dto.PaginatedResult[dto.BookmarkResponse]]{
+		Body: dto.PaginatedResult[dto.BookmarkResponse]{
+			Items:  bookmarkResponses,
+			Total:  result.Total,
+			Limit:  result.Limit,
+			Offset: result.Offset,
+		},
+	}, nil
+}
+
+// AddBookmark saves a log to read later.
+// @Summary      AddBookmark
+// @Description  Save a log to read later. Saving it again keeps the original time.
+// @Tags         Bookmark
+// @Produce      json
+// @Param        id path int true "Log ID"
+// @Success      200 {object} dto.BookmarkResponse
+// @Failure      401 "Unauthorized"
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /logs/{id}/bookmark [put]
+func (c *BookmarkController) AddBookmark(ctx context.Context, id path.Int, spineCtx spine.Ctx) (httpx.Response[dto.BookmarkResponse], error) {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[dto.BookmarkResponse]{}, httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	bookmark, err := c.bookmarkService.AddBookmark(ctx, &userID, &id.Value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return httpx.Response[dto.BookmarkResponse]{}, httperr.NotFound("Log not found")
+	}
+	if err != nil {
+		return httpx.Response[dto.BookmarkResponse]{}, httpErrorFromError(err)
+	}
+
+	if err := c.reactionService.MarkLogReactions(ctx, []*entity.Log{bookmark.Log}, &userID); err != nil {
+		return httpx.Response[dto.BookmarkResponse]{}, httpErrorFromError(err)
+	}
+
+	return httpx.Response[dto.BookmarkResponse]{
+		Body: dto.NewBookmarkResponse(bookmark),
+	}, nil
+}
+
+// RemoveBookmark removes a saved log.
+// @Summary      RemoveBookmark
+// @Description  Remove a log from your saved logs and from all of your reading lists.
+// @Tags         Bookmark
+// @Param        id path int true "Log ID"
+// @Success      204 "No Content"
+// @Failure      401 "Unauthorized"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /logs/{id}/bookmark [delete]
+func (c *BookmarkController) RemoveBookmark(ctx context.Context, id path.Int, spineCtx spine.Ctx) error {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	if err := c.bookmarkService.RemoveBookmark(ctx, &userID, &id.Value); err != nil {
+		return httpErrorFromError(err)
+	}
+
+	return nil
+}
+
+// ListReadingLists lists reading lists.
+// @Summary      ListReadingLists
+// @Description  List your reading lists, public and private, in your order. Set userId to list another user's public lists instead.
+// @Tags         Bookmark
+// @Produce      json
+// @Param        userId query int false "Owner's user ID. Defaults to you"
+// @Success      200 {array} dto.ReadingListResponse
+// @Failure      400 "Bad Request"
+// @Failure      401 "Unauthorized"
+// @Failure      500 "Internal Server Error"
+// @Router       /reading-lists [get]
+func (c *BookmarkController) ListReadingLists(ctx context.Context, q query.Values, spineCtx spine.Ctx) (httpx.Response[[]dto.ReadingListResponse], error) {
+	viewerID := viewerFromContext(spineCtx)
+
+	ownerID := viewerID
+	if raw := q.Get("userId"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return httpx.Response[[]dto.ReadingListResponse]{}, httperr.BadRequest("Invalid userId")
+		}
+		ownerID = &id
+	}
+	if ownerID == nil {
+		return httpx.Response[[]dto.ReadingListResponse]{}, httperr.Unauthorized("Authentication required")
+	}
+
+	return c.listReadingLists(ctx, ownerID, viewerID)
+}
+
+func (c *BookmarkController) listReadingLists(ctx context.Context, ownerID *entity.ID, viewerID *entity.ID) (httpx.Response[[]dto.ReadingListResponse], error) {
+	lists, err := c.bookmarkService.ListReadingLists(ctx, ownerID, viewerID)
+	if err != nil {
+		return httpx.Response[[]dto.ReadingListResponse]{}, httpErrorFromError(err)
+	}
+
+	res := make([]dto.ReadingListResponse, len(lists))
+	for i, list := range lists {
+		res[i] = dto.NewReadingListResponse(list)
+	}
+
+	return httpx.Response[[]dto.ReadingListResponse]{
+		Body: res,
+	}, nil
+}
+
+// CreateReadingList creates a reading list.
+// @Summary      CreateReadingList
+// @Description  Create a named reading list after your other lists. Private lists are only visible to you.
+// @Tags         Bookmark
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ReadingListCreateRequest true "Reading list to create"
+// @Success      201 {object} dto.ReadingListResponse
+// @Failure      400 "Bad Request"
+// @Failure      401 "Unauthorized"
+// @Failure      409 "Name already used or too many lists"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /reading-lists [post]
+func (c *BookmarkController) CreateReadingList(ctx context.Context, req *dto.ReadingListCreateRequest, spineCtx spine.Ctx) (httpx.Response[dto.ReadingListResponse], error) {
+	if err := pkg.Validate(req); err != nil {
+		return httpx.Response[dto.ReadingListResponse]{}, httpErrorFromError(err)
+	}
+
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[dto.ReadingListResponse]{}, httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	list, err := c.bookmarkService.CreateReadingList(ctx, &userID, req)
+	if err != nil {
+		return httpx.Response[dto.ReadingListResponse]{}, httpErrorFromError(err)
+	}
+
+	return httpx.Response[dto.ReadingListResponse]{
+		Body: dto.NewReadingListResponse(list),
+		Options: httpx.ResponseOptions{
+			Status: http.StatusCreated,
+		},
+	}, nil
+}
+
+// GetReadingList gets a reading list.
+// @Summary      GetReadingList
+// @Description  Get a public reading list, or one of your own. Load its logs with ListReadingListItems.
+// @Tags         Bookmark
+// @Produce      json
+// @Param        id path int true "Reading list ID"
+// @Success      200 {object} dto.ReadingListResponse
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Router       /reading-lists/{id} [get]
+func (c *BookmarkController) GetReadingList(ctx context.Context, id path.Int, spineCtx spine.Ctx) (httpx.Response[dto.ReadingListResponse], error) {
+	list, err := c.bookmarkService.GetReadingList(ctx, &id.Value, viewerFromContext(spineCtx))
+	if errors.Is(err, sql.ErrNoRows) {
+		return httpx.Response[dto.ReadingListResponse]{}, httperr.NotFound("Reading list not found")
+	}
+	if err != nil {
+		return httpx.Response[dto.ReadingListResponse]{}, httpErrorFromError(err)
+	}
+
+	return httpx.Response[dto.ReadingListResponse]{
+		Body: dto.NewReadingListResponse(list),
+	}, nil
+}
+
+// UpdateReadingList renames, describes, publishes or moves a reading list.
+// @Summary      UpdateReadingList
+// @Description  Change the fields you send. Set position to move the list among your lists (0 is first).
+// @Tags         Bookmark
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Reading list ID"
+// @Param        request body dto.ReadingListUpdateRequest true "Fields to change"
+// @Success      200 {object} dto.ReadingListResponse
+// @Failure      400 "Bad Request"
+// @Failure      401 "Unauthorized"
+// @Failure      403 "Not the owner"
+// @Failure      404 "Not Found"
+// @Failure      409 "Name already used"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /reading-lists/{id} [put]
+func (c *BookmarkController) UpdateReadingList(ctx context.Context, id path.Int, req *dto.ReadingListUpdateRequest, spineCtx spine.Ctx) (httpx.Response[dto.ReadingListResponse], error) {
+	if err := pkg.Validate(req); err != nil {
+		return httpx.Response[dto.ReadingListResponse]{}, httpErrorFromError(err)
+	}
+
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[dto.ReadingListResponse]{}, httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	list, err := c.bookmarkService.UpdateReadingList(ctx, &id.Value, &userID, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return httpx.Response[dto.ReadingListResponse]{}, httperr.NotFound("Reading list not found")
+	}
+	if err != nil {
+		return httpx.Response[dto.ReadingListResponse]{}, httpErrorFromError(err)
+	}
+
+	return httpx.Response[dto.ReadingListResponse]{
+		Body: dto.NewReadingListResponse(list),
+	}, nil
+}
+
+// DeleteReadingList deletes a reading list.
+// @Summary      DeleteReadingList
+// @Description  Delete one of your reading lists. The logs in it stay saved.
+// @Tags         Bookmark
+// @Param        id path int true "Reading list ID"
+// @Success      204 "No Content"
+// @Failure      401 "Unauthorized"
+// @Failure      403 "Not the owner"
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /reading-lists/{id} [delete]
+func (c *BookmarkController) DeleteReadingList(ctx context.Context, id path.Int, spineCtx spine.Ctx) error {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	err := c.bookmarkService.DeleteReadingList(ctx, &id.Value, &userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return httperr.NotFound("Reading list not found")
+	}
+	if err != nil {
+		return httpErrorFromError(err)
+	}
+
+	return nil
+}
+
+// ListReadingListItems lists the logs in a reading list.
+// @Summary      ListReadingListItems
+// @Description  List the logs in a public reading list, or one of your own, in the list's order.
+// @Tags         Bookmark
+// @Produce      json
+// @Param        id path int true "Reading list ID"
+// @Param        page query int false "Page number"
+// @Param        size query int false "Page size"
+// @Success      200 {object} dto.PaginatedResult[dto.ReadingListItemResponse]
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Router       /reading-lists/{id}/items [get]
+func (c *BookmarkController) ListReadingListItems(ctx context.Context, id path.Int, page query.Pagination, spineCtx spine.Ctx) (httpx.Response[dto.PaginatedResult[dto.ReadingListItemResponse]], error) {
+	viewerID := viewerFromContext(spineCtx)
+
+	limit, offset := pageToLimitOffset(page)
+	result, err := c.bookmarkService.ListItems(ctx, &id.Value, viewerID, limit, offset)
+	if errors.Is(err, sql.ErrNoRows) {
+		return httpx.Response[dto.PaginatedResult[dto.ReadingListItemResponse]]{}, httperr.NotFound("Reading list not found")
+	}
+	if err != nil {
+		return httpx.Response[dto.PaginatedResult[dto.ReadingListItemResponse]]{}, httpErrorFromError(err)
+	}
+
+	logs := make([]*entity.Log, len(result.Items))
+	for i, item := range result.Items {
+		logs[i] = item.Log
+	}
+	if err := c.reactionService.MarkLogReactions(ctx, logs, viewerID); err != nil {
+		return httpx.Response[dto.PaginatedResult[dto.ReadingListItemResponse]]{}, httpErrorFromError(err)
+	}
+
+	itemResponses := make([]dto.ReadingListItemResponse, len(result.Items))
+	for i, item := range result.Items {
+		itemResponses[i] = dto.NewReadingListItemResponse(item)
+	}
+
+	return httpx.Response[dto.PaginatedResult[dto.ReadingListItemResponse]]{
+		Body: dto.PaginatedResult[dto.ReadingListItemResponse]{
+			Items:  itemResponses,
+			Total:  result.Total,
+			Limit:  result.Limit,
+			Offset: result.Offset,
+		},
+	}, nil
+}
+
+// PutReadingListItem adds a log to a reading list or moves it within the list.
+// @Summary      PutReadingListItem
+// @Description  Add a log to one of your reading lists, or move it if it is already there. Adding a log also saves it. Without a position a new log goes last.
+// @Tags         Bookmark
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Reading list ID"
+// @Param        logId path int true "Log ID"
+// @Param        request body dto.ReadingListItemRequest false "Position in the list (0 is first)"
+// @Success      200 {object} dto.ReadingListItemResponse
+// @Failure      400 "Bad Request"
+// @Failure      401 "Unauthorized"
+// @Failure      403 "Not the owner"
+// @Failure      404 "Not Found"
+// @Failure      409 "List is full"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /reading-lists/{id}/items/{logId} [put]
+func (c *BookmarkController) PutReadingListItem(ctx context.Context, id path.Int, logId path.Int, req *dto.ReadingListItemRequest, spineCtx spine.Ctx) (httpx.Response[dto.ReadingListItemResponse], error) {
+	if err := pkg.Validate(req); err != nil {
+		return httpx.Response[dto.ReadingListItemResponse]{}, httpErrorFromError(err)
+	}
+
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[dto.ReadingListItemResponse]{}, httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	item, err := c.bookmarkService.PutItem(ctx, &id.Value, &logId.Value, &userID, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return httpx.Response[dto.ReadingListItemResponse]{}, httperr.NotFound("Reading list not found")
+	}
+	if err != nil {
+		return httpx.Response[dto.ReadingListItemResponse]{}, httpErrorFromError(err)
+	}
+
+	if err := c.reactionService.MarkLogReactions(ctx, []*entity.Log{item.Log}, &userID); err != nil {
+		return httpx.Response[dto.ReadingListItemResponse]{}, httpErrorFromError(err)
+	}
+
+	return httpx.Response[dto.ReadingListItemResponse]{
+		Body: dto.NewReadingListItemResponse(item),
+	}, nil
+}
+
+// RemoveReadingListItem removes a log from a reading list.
+// @Summary      RemoveReadingListItem
+// @Description  Remove a log from one of your reading lists. It stays saved.
+// @Tags         Bookmark
+// @Param        id path int true "Reading list ID"
+// @Param        logId path int true "Log ID"
+// @Success      204 "No Content"
+// @Failure      401 "Unauthorized"
+// @Failure      403 "Not the owner"
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /reading-lists/{id}/items/{logId} [delete]
+func (c *BookmarkController) RemoveReadingListItem(ctx context.Context, id path.Int, logId path.Int, spineCtx spine.Ctx) error {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	err := c.bookmarkService.RemoveItem(ctx, &id.Value, &logId.Value, &userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return httperr.NotFound("Reading list not found")
+	}
+	if err != nil {
+		return httpErrorFromError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"analog-be/pkg"
+	"net/http"
+
+	"github.com/NARUBROWN/spine/core"
+	"github.com/NARUBROWN/spine/pkg/httpx"
+	"github.com/NARUBROWN/spine/pkg/spine"
+)
+
+// fromCookie 는 AuthInterceptor가 세션을 쿠키로 받았는지 확인합니다.
+func fromCookie(spineCtx spine.Ctx) bool {
+	v, _ := spineCtx.Get(string(pkg.SessionFromCookieKey))
+	b, _ := v.(bool)
+	return b
+}
+
+// setCookies 는 error만 반환해 httpx.ResponseOptions를 쓸 수 없는 핸들러에서 쿠키를 내려줍니다.
+func setCookies(spineCtx spine.Ctx, cookies ...httpx.Cookie) {
+	v, _ := spineCtx.Get("spine.response_writer")
+	rw, ok := v.(core.ResponseWriter)
+	if !ok {
+		return
+	}
+
+	for _, c := range cookies {
+		cookie := &http.Cookie{
+			Name:     c.Name,
+			Value:    c.Value,
+			Path:     c.Path,
+			Domain:   c.Domain,
+			MaxAge:   c.MaxAge,
+			HttpOnly: c.HttpOnly,
+			Secure:   c.Secure,
+		}
+		switch c.SameSite {
+		case httpx.SameSiteLax:
+			cookie.SameSite = http.SameSiteLaxMode
+		case httpx.SameSiteStrict:
+			cookie.SameSite = http.SameSiteStrictMode
+		case httpx.SameSiteNone:
+			cookie.SameSite = http.SameSiteNoneMode
+		}
+		rw.AddHeader("Set-Cookie", cookie.String())
+	}
+}
@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"analog-be/pkg"
+	"analog-be/service"
+	"context"
+	"strings"
+
+	"github.com/NARUBROWN/spine/pkg/httperr"
+	"github.com/NARUBROWN/spine/pkg/httpx"
+	"github.com/NARUBROWN/spine/pkg/query"
+	"github.com/NARUBROWN/spine/pkg/spine"
+)
+
+type EmailController struct {
+	emailService service.EmailService
+}
+
+func NewEmailController(emailService service.EmailService) *EmailController {
+	return &EmailController{
+		emailService: emailService,
+	}
+}
+
+// GetEmailSettings gets the current user's email settings.
+// @Summary      GetEmailSettings
+// @Description  Get whether you receive notifications and the weekly digest by email, and which addresses you can choose from. Addresses come from your linked sign-in accounts. Everything is off until you turn it on.
+// @Tags         Notification
+// @Produce      json
+// @Success      200 {object} dto.EmailSettingsResponse
+// @Failure      401 "Unauthorized"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /notifications/email [get]
+func (c *EmailController) GetEmailSettings(ctx context.Context, spineCtx spine.Ctx) (httpx.Response[dto.EmailSettingsResponse], error) {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[dto.EmailSettingsResponse]{}, httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	settings, addresses, err := c.emailService.Settings(ctx, &userID)
+	if err != nil {
+		return httpx.Response[dto.EmailSettingsResponse]{}, httpErrorFromError(err)
+	}
+
+	return httpx.Response[dto.EmailSettingsResponse]{
+		Body: dto.NewEmailSettingsResponse(settings, addresses),
+	}, nil
+}
+
+// UpdateEmailSettings changes the current user's email settings.
+// @Summary      UpdateEmailSettings
+// @Description  Opt in to or out of email notifications and the weekly digest of new logs. Fields you do not send keep their value. The address must be one of your linked accounts' addresses.
+// @Description  Email notifications follow the per-type `email` setting in /notifications/preferences. The digest includes new logs with any of the chosen topics or generations, or all new logs if both are empty, and starts a week after you turn it on.
+// @Tags         Notification
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.EmailSettingsUpdateRequest true "Settings to change"
+// @Success      200 {object} dto.EmailSettingsResponse
+// @Failure      400 "Bad Request"
+// @Failure      401 "Unauthorized"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /notifications/email [put]
+func (c *EmailController) UpdateEmailSettings(ctx context.Context, req *dto.EmailSettingsUpdateRequest, spineCtx spine.Ctx) (httpx.Response[dto.EmailSettingsResponse], error) {
+	if err := pkg.Validate(req); err != nil {
+		return httpx.Response[dto.EmailSettingsResponse]{}, httpErrorFromError(err)
+	}
+
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[dto.EmailSettingsResponse]{}, httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	settings, addresses, err := c.emailService.UpdateSettings(ctx, &userID, req)
+	if err != nil {
+		return httpx.Response[dto.EmailSettingsResponse]{}, httpErrorFromError(err)
+	}
+
+	return httpx.Response[dto.EmailSettingsResponse]{
+		Body: dto.NewEmailSettingsResponse(settings, addresses),
+	}, nil
+}
+
+// GetEmailSubscription shows what an unsubscribe link would turn off.
+// @Summary      GetEmailSubscription
+// @Description  Look up the subscription behind the token in an email's unsubscribe link, without signing in, so the unsubscribe page can show what is on.
+// @Tags         Email
+// @Produce      json
+// @Param        token query string true "Unsubscribe token from the email"
+// @Success      200 {object} dto.EmailUnsubscribeResponse
+// @Failure      400 "Bad Request"
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Router       /email/unsubscribe [get]
+func (c *EmailController) GetEmailSubscription(ctx context.Context, q query.Values) (httpx.Response[dto.EmailUnsubscribeResponse], error) {
+	settings, err := c.emailService.FindByUnsubscribeToken(ctx, q.Get("token"))
+	if err != nil {
+		return httpx.Response[dto.EmailUnsubscribeResponse]{}, httpErrorFromError(err)
+	}
+
+	return newEmailUnsubscribeResponse(settings), nil
+}
+
+// Unsubscribe turns off emails with the token from an email.
+// @Summary      Unsubscribe
+// @Description  Turn off emails without signing in, using the token in an email's unsubscribe link. This is also the one-click List-Unsubscribe URL (RFC 8058) that mail apps POST to. Unsubscribing again does nothing.
+// @Tags         Email
+// @Produce      json
+// @Param        token query string true "Unsubscribe token from the email"
+// @Param        list query string false "notifications, digest or all (default)"
+// @Success      200 {object} dto.EmailUnsubscribeResponse
+// @Failure      400 "Bad Request"
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Router       /email/unsubscribe [post]
+func (c *EmailController) Unsubscribe(ctx context.Context, q query.Values) (httpx.Response[dto.EmailUnsubscribeResponse], error) {
+	settings, err := c.emailService.Unsubscribe(ctx, q.Get("token"), q.Get("list"))
+	if err != nil {
+		return httpx.Response[dto.EmailUnsubscribeResponse]{}, httpErrorFromError(err)
+	}
+
+	return newEmailUnsubscribeResponse(settings), nil
+}
+
+func newEmailUnsubscribeResponse(settings *entity.EmailSettings) httpx.Response[dto.EmailUnsubscribeResponse] {
+	return httpx.Response[dto.EmailUnsubscribeResponse]{
+		Body: dto.EmailUnsubscribeResponse{
+			Email:         maskEmail(settings.Email),
+			Notifications: settings.Notifications,
+			Digest:        settings.Digest,
+		},
+	}
+}
+
+// maskEmail 은 링크만 가진 사람에게 주소 전체가 드러나지 않도록 앞 두 글자만 남깁니다.
+func maskEmail(email string) string {
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return "***"
+	}
+
+	runes := []rune(local)
+	if len(runes) > 2 {
+		runes = runes[:2]
+	}
+	return string(runes) + "***@" + domain
+}
@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"analog-be/pkg"
+	"errors"
+	"net/http"
+
+	"github.com/NARUBROWN/spine/pkg/httperr"
+)
+
+// statusFromError 는 서비스가 돌려준 AppError의 상태 코드를 사용하고, 그 외에는 500으로 처리합니다.
+func statusFromError(err error) int {
+	var appErr *pkg.AppError
+	if errors.As(err, &appErr) {
+		return appErr.StatusCode
+	}
+	return http.StatusInternalServerError
+}
+
+// httpErrorFromError 는 AppError를 메시지가 담긴 응답으로 바꾸고, 그 외에는 500으로 처리합니다.
+func httpErrorFromError(err error) error {
+	var appErr *pkg.AppError
+	if errors.As(err, &appErr) {
+		return &httperr.HTTPError{Status: appErr.StatusCode, Message: appErr.Message, Cause: err}
+	}
+	return &httperr.HTTPError{Status: http.StatusInternalServerError, Message: "Internal Server Error", Cause: err}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/NARUBROWN/spine/pkg/httpx"
+	"github.com/NARUBROWN/spine/pkg/path"
 )
 
 type FeedController struct {
@@ -26,8 +27,8 @@ func (c *FeedController) GetFeed(ctx context.Context) httpx.Response[string] {
 	}
 }
 
-func (c *FeedController) GetSitemap(ctx context.Context, file string) httpx.Response[string] {
-	if !strings.HasPrefix(file, "sitemap-") || !strings.HasSuffix(file, ".xml") {
+func (c *FeedController) GetSitemap(ctx context.Context, file path.String) httpx.Response[string] {
+	if !strings.HasPrefix(file.Value, "sitemap-") || !strings.HasSuffix(file.Value, ".xml") {
 		return httpx.Response[string]{
 			Options: httpx.ResponseOptions{
 				Status: http.StatusBadRequest,
@@ -35,7 +36,7 @@ func (c *FeedController) GetSitemap(ctx context.Context, file string) httpx.Resp
 		}
 	}
 
-	st := c.service.GetSitemap(file)
+	st := c.service.GetSitemap(file.Value)
 	if st == "" {
 		return httpx.Response[string]{
 			Options: httpx.ResponseOptions{
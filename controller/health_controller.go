@@ -1,8 +1,11 @@
 package controller
 
 import (
+	"analog-be/pkg"
 	"context"
+	"github.com/NARUBROWN/spine/pkg/httperr"
 	"github.com/NARUBROWN/spine/pkg/httpx"
+	"os"
 	"time"
 
 	"github.com/uptrace/bun"
@@ -10,14 +13,19 @@ import (
 )
 
 type HealthController struct {
-	db     *bun.DB
-	logger *zap.Logger
+	db           *bun.DB
+	sessionCache *pkg.SessionCache
+	logger       *zap.Logger
+	// 내부 지표는 HEALTH_METRICS_ENABLED=true일 때만 공개
+	metricsEnabled bool
 }
 
-func NewHealthController(db *bun.DB, logger *zap.Logger) *HealthController {
+func NewHealthController(db *bun.DB, sessionCache *pkg.SessionCache, logger *zap.Logger) *HealthController {
 	return &HealthController{
-		db:     db,
-		logger: logger,
+		db:             db,
+		sessionCache:   sessionCache,
+		logger:         logger,
+		metricsEnabled: os.Getenv("HEALTH_METRICS_ENABLED") == "true",
 	}
 }
 
@@ -27,6 +35,10 @@ type HealthResponse struct {
 	Services  map[string]string `json:"services"`
 }
 
+type MetricsResponse struct {
+	SessionCache pkg.SessionCacheStats `json:"sessionCache"`
+}
+
 // Health checks the health of the service.
 // @Summary      Health
 // @Description  Checks the health of the service.
@@ -95,4 +107,24 @@ func (c *HealthController) Live(ctx context.Context) httpx.Response[HealthRespon
 			Timestamp: time.Now().Format(time.RFC3339),
 		},
 	}
-}
\ No newline at end of file
+}
+
+// Metrics returns runtime metrics of the service.
+// @Summary      Metrics
+// @Description  Returns runtime metrics such as session cache hits and misses. Only available when HEALTH_METRICS_ENABLED is true.
+// @Tags         Health
+// @Produce      json
+// @Success      200 {object} MetricsResponse
+// @Failure      404 "Metrics are not enabled"
+// @Router       /health/metrics [get]
+func (c *HealthController) Metrics(ctx context.Context) (httpx.Response[MetricsResponse], error) {
+	if !c.metricsEnabled {
+		return httpx.Response[MetricsResponse]{}, httperr.NotFound("Not found")
+	}
+
+	return httpx.Response[MetricsResponse]{
+		Body: MetricsResponse{
+			SessionCache: c.sessionCache.Stats(),
+		},
+	}, nil
+}
@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"analog-be/pkg"
+	"analog-be/service"
+	"context"
+	"net/http"
+
+	"github.com/NARUBROWN/spine/pkg/httperr"
+	"github.com/NARUBROWN/spine/pkg/httpx"
+	"github.com/NARUBROWN/spine/pkg/path"
+	"github.com/NARUBROWN/spine/pkg/query"
+	"github.com/NARUBROWN/spine/pkg/spine"
+)
+
+type IdentityController struct {
+	anAccountOAuthService service.AnAccountService
+	identityService       service.IdentityService
+	providers             *service.IdentityProviders
+}
+
+func NewIdentityController(anAccountOAuthService service.AnAccountService, identityService service.IdentityService, providers *service.IdentityProviders) *IdentityController {
+	return &IdentityController{
+		anAccountOAuthService: anAccountOAuthService,
+		identityService:       identityService,
+		providers:             providers,
+	}
+}
+
+// ListProviders lists the identity providers that can be used to log in.
+// @Summary      ListProviders
+// @Description  List the identity providers that can be used to log in, sign up or link an account.
+// @Tags         Auth
+// @Produce      json
+// @Success      200 {object} dto.IdentityProvidersResponse
+// @Router       /auth/providers [get]
+func (c *IdentityController) ListProviders(ctx context.Context) httpx.Response[dto.IdentityProvidersResponse] {
+	return httpx.Response[dto.IdentityProvidersResponse]{
+		Body: dto.IdentityProvidersResponse{Providers: c.providers.Names()},
+	}
+}
+
+// ListIdentities lists the identities linked to the current user.
+// @Summary      ListIdentities
+// @Description  List the external identities linked to the current user, oldest first.
+// @Tags         Auth
+// @Produce      json
+// @Success      200 {array} dto.IdentityResponse
+// @Failure      401 "Unauthorized"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /auth/identities [get]
+func (c *IdentityController) ListIdentities(ctx context.Context, spineCtx spine.Ctx) httpx.Response[[]dto.IdentityResponse] {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[[]dto.IdentityResponse]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusUnauthorized, // authentication required
+			},
+		}
+	}
+
+	userID := v.(entity.ID)
+
+	identities, err := c.identityService.List(ctx, &userID)
+	if err != nil {
+		return httpx.Response[[]dto.IdentityResponse]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusInternalServerError, // internal server error
+			},
+		}
+	}
+
+	identityResponses := make([]dto.IdentityResponse, len(identities))
+	for i, identity := range identities {
+		identityResponses[i] = dto.NewIdentityResponse(identity)
+	}
+
+	return httpx.Response[[]dto.IdentityResponse]{
+		Body: identityResponses,
+	}
+}
+
+// InitiateLink initiates linking another provider's account to the current user.
+// @Summary      InitiateLink
+// @Description  Initiate the OAuth2 flow that links an account of another provider to the current user.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.IdentityLinkInitRequest true "Link initiation request"
+// @Success      200 {object} dto.LoginInitResponse
+// @Failure      400 "redirectUri is missing or not in the allowlist, or the provider is unknown"
+// @Failure      401 "Unauthorized"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /auth/identities/link/init [post]
+func (c *IdentityController) InitiateLink(ctx context.Context, req *dto.IdentityLinkInitRequest, spineCtx spine.Ctx) (httpx.Response[dto.LoginInitResponse], error) {
+	if req.Provider == "" || req.RedirectUri == "" {
+		return httpx.Response[dto.LoginInitResponse]{}, httperr.BadRequest("provider and redirectUri are required")
+	}
+
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[dto.LoginInitResponse]{}, httperr.Unauthorized("Authentication required")
+	}
+
+	result, err := c.anAccountOAuthService.InitiateLink(ctx, v.(entity.ID), req.Provider, req.RedirectUri)
+	if err != nil {
+		return httpx.Response[dto.LoginInitResponse]{}, httpErrorFromError(err)
+	}
+
+	return httpx.Response[dto.LoginInitResponse]{
+		Body: *result,
+	}, nil
+}
+
+// HandleLinkCallback completes linking another provider's account to the current user.
+// @Summary      HandleLinkCallback
+// @Description  Complete the link flow. The state must have been issued to the current user.
+// @Tags         Auth
+// @Produce      json
+// @Param        code query string true "Authorization code"
+// @Param        state query string true "State"
+// @Success      200 {object} dto.IdentityResponse
+// @Failure      400 "Bad Request"
+// @Failure      401 "Unauthorized"
+// @Failure      403 "State was issued for another user"
+// @Failure      409 "Account is already linked"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /auth/identities/link/callback [get]
+func (c *IdentityController) HandleLinkCallback(ctx context.Context, q query.Values, spineCtx spine.Ctx) (httpx.Response[dto.IdentityResponse], error) {
+	code := q.Get("code")
+	state := q.Get("state")
+	if code == "" || state == "" {
+		return httpx.Response[dto.IdentityResponse]{}, httperr.BadRequest("code and state are required")
+	}
+
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[dto.IdentityResponse]{}, httperr.Unauthorized("Authentication required")
+	}
+
+	identity, err := c.anAccountOAuthService.HandleLinkCallback(ctx, v.(entity.ID), code, state)
+	if err != nil {
+		return httpx.Response[dto.IdentityResponse]{}, httpErrorFromError(err)
+	}
+
+	return httpx.Response[dto.IdentityResponse]{
+		Body: dto.NewIdentityResponse(identity),
+	}, nil
+}
+
+// UnlinkIdentity removes one of the identities linked to the current user.
+// @Summary      UnlinkIdentity
+// @Description  Unlink one of the current user's identities. The last remaining identity cannot be unlinked.
+// @Tags         Auth
+// @Param        id path int true "Identity ID"
+// @Success      204 "No Content"
+// @Failure      401 "Unauthorized"
+// @Failure      404 "Not Found"
+// @Failure      409 "Cannot unlink the only identity"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /auth/identities/{id} [delete]
+func (c *IdentityController) UnlinkIdentity(ctx context.Context, id path.Int, spineCtx spine.Ctx) error {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	if err := c.identityService.Unlink(ctx, &userID, &id.Value); err != nil {
+		return httpErrorFromError(err)
+	}
+
+	return nil
+}
@@ -6,7 +6,10 @@ import (
 	"analog-be/pkg"
 	"analog-be/service"
 	"context"
+	"database/sql"
+	"errors"
 	"net/http"
+	"slices"
 
 	"github.com/NARUBROWN/spine/pkg/httperr"
 	"github.com/NARUBROWN/spine/pkg/httpx"
@@ -16,14 +19,16 @@ import (
 )
 
 type LogController struct {
-	logService     service.LogService
-	commentService service.CommentService
+	logService      service.LogService
+	commentService  service.CommentService
+	reactionService service.ReactionService
 }
 
-func NewLogController(logService service.LogService, commentService service.CommentService) *LogController {
+func NewLogController(logService service.LogService, commentService service.CommentService, reactionService service.ReactionService) *LogController {
 	return &LogController{
-		logService:     logService,
-		commentService: commentService,
+		logService:      logService,
+		commentService:  commentService,
+		reactionService: reactionService,
 	}
 }
 
@@ -37,8 +42,9 @@ func NewLogController(logService service.LogService, commentService service.Comm
 // @Success      200 {object} dto.PaginatedResult[dto.LogResponse]
 // @Failure		 404 "Not Found"
 // @Router       /logs [get]
-func (c *LogController) GetListOfLog(ctx context.Context, page query.Pagination) httpx.Response[dto.PaginatedResult[dto.LogResponse]] {
-	paginatedResult, err := c.logService.GetList(ctx, page.Size, page.Page)
+func (c *LogController) GetListOfLog(ctx context.Context, page query.Pagination, spineCtx spine.Ctx) httpx.Response[dto.PaginatedResult[dto.LogResponse]] {
+	limit, offset := pageToLimitOffset(page)
+	paginatedResult, err := c.logService.GetList(ctx, limit, offset)
 	if err != nil {
 		return httpx.Response[dto.PaginatedResult[dto.LogResponse]]{
 			Options: httpx.ResponseOptions{
@@ -47,6 +53,14 @@ func (c *LogController) GetListOfLog(ctx context.Context, page query.Pagination)
 		}
 	}
 
+	if err := c.reactionService.MarkLogReactions(ctx, paginatedResult.Items, viewerFromContext(spineCtx)); err != nil {
+		return httpx.Response[dto.PaginatedResult[dto.LogResponse]]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusInternalServerError, // internal server error
+			},
+		}
+	}
+
 	logResponses := make([]dto.LogResponse, len(paginatedResult.Items))
 	for i, log := range paginatedResult.Items {
 		logResponses[i] = dto.NewLogResponse(log)
@@ -73,8 +87,9 @@ func (c *LogController) GetListOfLog(ctx context.Context, page query.Pagination)
 // @Success      200 {object} dto.PaginatedResult[dto.LogResponse]
 // @Failure      404 "Not Found"
 // @Router       /logs/topic/list/{topicId} [get]
-func (c *LogController) GetListOfTopicLog(ctx context.Context, topicID path.Int, page query.Pagination) httpx.Response[dto.PaginatedResult[dto.LogResponse]] {
-	paginatedResult, err := c.logService.GetListByTopicID(ctx, &topicID.Value, page.Size, page.Page)
+func (c *LogController) GetListOfTopicLog(ctx context.Context, topicID path.Int, page query.Pagination, spineCtx spine.Ctx) httpx.Response[dto.PaginatedResult[dto.LogResponse]] {
+	limit, offset := pageToLimitOffset(page)
+	paginatedResult, err := c.logService.GetListByTopicID(ctx, &topicID.Value, limit, offset)
 	if err != nil {
 		return httpx.Response[dto.PaginatedResult[dto.LogResponse]]{
 			Options: httpx.ResponseOptions{
@@ -83,6 +98,14 @@ func (c *LogController) GetListOfTopicLog(ctx context.Context, topicID path.Int,
 		}
 	}
 
+	if err := c.reactionService.MarkLogReactions(ctx, paginatedResult.Items, viewerFromContext(spineCtx)); err != nil {
+		return httpx.Response[dto.PaginatedResult[dto.LogResponse]]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusInternalServerError, // internal server error
+			},
+		}
+	}
+
 	logResponses := make([]dto.LogResponse, len(paginatedResult.Items))
 	for i, log := range paginatedResult.Items {
 		logResponses[i] = dto.NewLogResponse(log)
@@ -109,8 +132,9 @@ func (c *LogController) GetListOfTopicLog(ctx context.Context, topicID path.Int,
 // @Success      200 {object} dto.PaginatedResult[dto.LogResponse]
 // @Failure      404 "Not Found"
 // @Router       /logs/generation/list/{generation} [get]
-func (c *LogController) GetListOfGenerationLog(ctx context.Context, generation path.Int, page query.Pagination) httpx.Response[dto.PaginatedResult[dto.LogResponse]] {
-	paginatedResult, err := c.logService.GetListByGeneration(ctx, uint16(generation.Value), page.Size, page.Page)
+func (c *LogController) GetListOfGenerationLog(ctx context.Context, generation path.Int, page query.Pagination, spineCtx spine.Ctx) httpx.Response[dto.PaginatedResult[dto.LogResponse]] {
+	limit, offset := pageToLimitOffset(page)
+	paginatedResult, err := c.logService.GetListByGeneration(ctx, uint16(generation.Value), limit, offset)
 	if err != nil {
 		return httpx.Response[dto.PaginatedResult[dto.LogResponse]]{
 			Options: httpx.ResponseOptions{
@@ -119,6 +143,14 @@ func (c *LogController) GetListOfGenerationLog(ctx context.Context, generation p
 		}
 	}
 
+	if err := c.reactionService.MarkLogReactions(ctx, paginatedResult.Items, viewerFromContext(spineCtx)); err != nil {
+		return httpx.Response[dto.PaginatedResult[dto.LogResponse]]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusInternalServerError, // internal server error
+			},
+		}
+	}
+
 	logResponses := make([]dto.LogResponse, len(paginatedResult.Items))
 	for i, log := range paginatedResult.Items {
 		logResponses[i] = dto.NewLogResponse(log)
@@ -143,7 +175,7 @@ func (c *LogController) GetListOfGenerationLog(ctx context.Context, generation p
 // @Success      200 {object} dto.LogResponse
 // @Failure      404 "Not Found"
 // @Router       /logs/{id} [get]
-func (c *LogController) GetLog(ctx context.Context, id path.Int) httpx.Response[dto.LogResponse] {
+func (c *LogController) GetLog(ctx context.Context, id path.Int, spineCtx spine.Ctx) httpx.Response[dto.LogResponse] {
 	log, err := c.logService.Get(ctx, &id.Value)
 	if err != nil {
 		return httpx.Response[dto.LogResponse]{
@@ -153,6 +185,14 @@ func (c *LogController) GetLog(ctx context.Context, id path.Int) httpx.Response[
 		}
 	}
 
+	if err := c.reactionService.MarkLogReactions(ctx, []*entity.Log{log}, viewerFromContext(spineCtx)); err != nil {
+		return httpx.Response[dto.LogResponse]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusInternalServerError, // internal server error
+			},
+		}
+	}
+
 	res := dto.NewLogResponse(log)
 	return httpx.Response[dto.LogResponse]{
 		Body: res,
@@ -168,12 +208,21 @@ func (c *LogController) GetLog(ctx context.Context, id path.Int) httpx.Response[
 // @Param        page query int false "Page number"
 // @Param        size query int false "Page size"
 // @Success      200 {object} dto.PaginatedResult[dto.LogResponse]
+// @Failure      400 "Bad Request"
 // @Failure      404 "Not Found"
 // @Router       /logs/search/list [get]
-func (c *LogController) SearchLogs(ctx context.Context, q query.Values, page query.Pagination) httpx.Response[dto.PaginatedResult[dto.LogResponse]] {
+func (c *LogController) SearchLogs(ctx context.Context, q query.Values, page query.Pagination, spineCtx spine.Ctx) httpx.Response[dto.PaginatedResult[dto.LogResponse]] {
 	searchQuery := q.Get("q")
+	if searchQuery == "" {
+		return httpx.Response[dto.PaginatedResult[dto.LogResponse]]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusBadRequest, // search query is required
+			},
+		}
+	}
 
-	paginatedResult, err := c.logService.Search(ctx, searchQuery, page.Size, page.Page)
+	limit, offset := pageToLimitOffset(page)
+	paginatedResult, err := c.logService.Search(ctx, searchQuery, limit, offset)
 	if err != nil {
 		return httpx.Response[dto.PaginatedResult[dto.LogResponse]]{
 			Options: httpx.ResponseOptions{
@@ -182,6 +231,14 @@ func (c *LogController) SearchLogs(ctx context.Context, q query.Values, page que
 		}
 	}
 
+	if err := c.reactionService.MarkLogReactions(ctx, paginatedResult.Items, viewerFromContext(spineCtx)); err != nil {
+		return httpx.Response[dto.PaginatedResult[dto.LogResponse]]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusInternalServerError, // internal server error
+			},
+		}
+	}
+
 	logResponses := make([]dto.LogResponse, len(paginatedResult.Items))
 	for i, log := range paginatedResult.Items {
 		logResponses[i] = dto.NewLogResponse(log)
@@ -210,7 +267,7 @@ func (c *LogController) SearchLogs(ctx context.Context, q query.Values, page que
 // @Failure      500 "Internal Server Error"
 // @Security     ApiKeyAuth
 // @Router       /logs [post]
-func (c *LogController) CreateLog(ctx context.Context, req *dto.LogCreateRequest) httpx.Response[dto.LogResponse] {
+func (c *LogController) CreateLog(ctx context.Context, req *dto.LogCreateRequest, spineCtx spine.Ctx) httpx.Response[dto.LogResponse] {
 	if err := pkg.Validate(req); err != nil {
 		return httpx.Response[dto.LogResponse]{
 			Options: httpx.ResponseOptions{
@@ -219,7 +276,7 @@ func (c *LogController) CreateLog(ctx context.Context, req *dto.LogCreateRequest
 		}
 	}
 
-	authorID, ok := pkg.GetUserID(ctx)
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
 	if !ok {
 		return httpx.Response[dto.LogResponse]{
 			Options: httpx.ResponseOptions{
@@ -228,6 +285,8 @@ func (c *LogController) CreateLog(ctx context.Context, req *dto.LogCreateRequest
 		}
 	}
 
+	authorID := v.(entity.ID)
+
 	log, err := c.logService.Create(ctx, req, &authorID)
 	if err != nil {
 		return httpx.Response[dto.LogResponse]{
@@ -254,12 +313,13 @@ func (c *LogController) CreateLog(ctx context.Context, req *dto.LogCreateRequest
 // @Success      200 {object} dto.LogResponse
 // @Failure      401 "Unauthorized"
 // @Failure      403 "Forbidden"
+// @Failure      404 "Not Found"
 // @Failure      500 "Internal Server Error"
 // @Security     ApiKeyAuth
 // @Router       /logs/{id} [put]
-func (c *LogController) UpdateLog(ctx context.Context, id path.Int, req *dto.LogUpdateRequest) httpx.Response[dto.LogResponse] {
+func (c *LogController) UpdateLog(ctx context.Context, id path.Int, req *dto.LogUpdateRequest, spineCtx spine.Ctx) httpx.Response[dto.LogResponse] {
 
-	userID, ok := pkg.GetUserID(ctx)
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
 	if !ok {
 		return httpx.Response[dto.LogResponse]{
 			Options: httpx.ResponseOptions{
@@ -268,7 +328,16 @@ func (c *LogController) UpdateLog(ctx context.Context, id path.Int, req *dto.Log
 		}
 	}
 
+	userID := v.(entity.ID)
+
 	log, err := c.logService.Get(ctx, &id.Value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return httpx.Response[dto.LogResponse]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusNotFound, // not found
+			},
+		}
+	}
 	if err != nil {
 		return httpx.Response[dto.LogResponse]{
 			Options: httpx.ResponseOptions{
@@ -302,6 +371,14 @@ func (c *LogController) UpdateLog(ctx context.Context, id path.Int, req *dto.Log
 		}
 	}
 
+	if err := c.reactionService.MarkLogReactions(ctx, []*entity.Log{updatedLog}, &userID); err != nil {
+		return httpx.Response[dto.LogResponse]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusInternalServerError, // internal server error
+			},
+		}
+	}
+
 	res := dto.NewLogResponse(updatedLog)
 	return httpx.Response[dto.LogResponse]{
 		Body: res,
@@ -316,17 +393,23 @@ func (c *LogController) UpdateLog(ctx context.Context, id path.Int, req *dto.Log
 // @Success      204 "No Content"
 // @Failure      401 "Unauthorized"
 // @Failure      403 "Forbidden"
+// @Failure      404 "Not Found"
 // @Failure      500 "Internal Server Error"
 // @Security     ApiKeyAuth
 // @Router       /logs/{id} [delete]
-func (c *LogController) DeleteLog(ctx context.Context, id path.Int) error {
+func (c *LogController) DeleteLog(ctx context.Context, id path.Int, spineCtx spine.Ctx) error {
 
-	userID, ok := pkg.GetUserID(ctx)
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
 	if !ok {
 		return httperr.Unauthorized("Authentication required")
 	}
 
+	userID := v.(entity.ID)
+
 	log, err := c.logService.Get(ctx, &id.Value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return httperr.NotFound("Log not found")
+	}
 	if err != nil {
 		return &httperr.HTTPError{
 			Status:  500,
@@ -365,7 +448,7 @@ func (c *LogController) DeleteLog(ctx context.Context, id path.Int) error {
 
 // CreateComment creates a new comment on a specific log.
 // @Summary      CreateComment
-// @Description  Create a new comment on a specific log.
+// @Description  Create a new comment on a specific log. Set parentId to reply to another comment on the same log.
 // @Tags         Comment
 // @Accept       json
 // @Produce      json
@@ -374,6 +457,7 @@ func (c *LogController) DeleteLog(ctx context.Context, id path.Int) error {
 // @Success      200 {object} dto.CommentResponse
 // @Failure      400 "Bad Request"
 // @Failure      401 "Unauthorized"
+// @Failure      404 "Not Found"
 // @Failure      500 "Internal Server Error"
 // @Security     ApiKeyAuth
 // @Router       /logs/{id}/comments [post]
@@ -399,10 +483,17 @@ func (c *LogController) CreateComment(ctx context.Context, id path.Int, req *dto
 	authorID := v.(entity.ID)
 
 	comment, err := c.commentService.Create(ctx, req, &id.Value, &authorID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return httpx.Response[dto.CommentResponse]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusNotFound, // log not found
+			},
+		}
+	}
 	if err != nil {
 		return httpx.Response[dto.CommentResponse]{
 			Options: httpx.ResponseOptions{
-				Status: http.StatusInternalServerError, // internal server error
+				Status: statusFromError(err), // invalid parent or internal server error
 			},
 		}
 	}
@@ -426,10 +517,11 @@ func (c *LogController) CreateComment(ctx context.Context, id path.Int, req *dto
 // @Failure      400 "Bad Request"
 // @Failure      401 "Unauthorized"
 // @Failure      403 "Forbidden"
+// @Failure      404 "Not Found"
 // @Failure      500 "Internal Server Error"
 // @Security     ApiKeyAuth
 // @Router       /logs/{id}/comments/{commentId} [put]
-func (c *LogController) UpdateComment(ctx context.Context, id path.Int, commentId path.Int, req *dto.CommentUpdateRequest) httpx.Response[dto.CommentResponse] {
+func (c *LogController) UpdateComment(ctx context.Context, id path.Int, commentId path.Int, req *dto.CommentUpdateRequest, spineCtx spine.Ctx) httpx.Response[dto.CommentResponse] {
 
 	if err := pkg.Validate(req); err != nil {
 		return httpx.Response[dto.CommentResponse]{
@@ -439,7 +531,7 @@ func (c *LogController) UpdateComment(ctx context.Context, id path.Int, commentI
 		}
 	}
 
-	authorID, ok := pkg.GetUserID(ctx)
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
 	if !ok {
 		return httpx.Response[dto.CommentResponse]{
 			Options: httpx.ResponseOptions{
@@ -448,7 +540,16 @@ func (c *LogController) UpdateComment(ctx context.Context, id path.Int, commentI
 		}
 	}
 
+	authorID := v.(entity.ID)
+
 	comment, err := c.commentService.GetById(ctx, &commentId.Value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return httpx.Response[dto.CommentResponse]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusNotFound, // comment not found
+			},
+		}
+	}
 	if err != nil {
 		return httpx.Response[dto.CommentResponse]{
 			Options: httpx.ResponseOptions{
@@ -473,8 +574,16 @@ func (c *LogController) UpdateComment(ctx context.Context, id path.Int, commentI
 		}
 	}
 
-	comment, err = c.commentService.Update(ctx, &id.Value, req)
+	comment, err = c.commentService.Update(ctx, &commentId.Value, &authorID, req)
 	if err != nil {
+		return httpx.Response[dto.CommentResponse]{
+			Options: httpx.ResponseOptions{
+				Status: statusFromError(err), // comments locked or internal server error
+			},
+		}
+	}
+
+	if err := c.reactionService.MarkCommentReactions(ctx, []*entity.Comment{comment}, &authorID); err != nil {
 		return httpx.Response[dto.CommentResponse]{
 			Options: httpx.ResponseOptions{
 				Status: http.StatusInternalServerError, // internal server error
@@ -490,7 +599,7 @@ func (c *LogController) UpdateComment(ctx context.Context, id path.Int, commentI
 
 // DeleteComment deletes a comment by its ID.
 // @Summary      DeleteComment
-// @Description  Delete a comment by its ID.
+// @Description  Delete a comment by its ID. The comment author and the log's authors can delete it. A comment with replies leaves a deleted placeholder.
 // @Tags         Comment
 // @Param        id path int true "Log ID"
 // @Param        commentId path int true "Comment ID"
@@ -501,22 +610,35 @@ func (c *LogController) UpdateComment(ctx context.Context, id path.Int, commentI
 // @Failure      500 "Internal Server Error"
 // @Security     ApiKeyAuth
 // @Router       /logs/{id}/comments/{commentId} [delete]
-func (c *LogController) DeleteComment(ctx context.Context, id path.Int, commentId path.Int) error {
-	authorID, ok := pkg.GetUserID(ctx)
+func (c *LogController) DeleteComment(ctx context.Context, id path.Int, commentId path.Int, spineCtx spine.Ctx) error {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
 	if !ok {
 		return httperr.Unauthorized("Authentication required")
 	}
 
+	authorID := v.(entity.ID)
+
 	comment, err := c.commentService.GetById(ctx, &commentId.Value)
 	if err != nil {
 		return httperr.NotFound("Comment not found")
 	}
 
 	if comment.AuthorID != authorID {
-		return &httperr.HTTPError{
-			Status:  403,
-			Message: "Forbidden",
-			Cause:   nil,
+		// 로그 작성자는 자기 로그의 댓글을 지울 수 있음
+		canModerate, err := c.commentService.CanModerate(ctx, &id.Value, &authorID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return &httperr.HTTPError{
+				Status:  500,
+				Message: "Internal Server Error",
+				Cause:   err,
+			}
+		}
+		if !canModerate {
+			return &httperr.HTTPError{
+				Status:  403,
+				Message: "Forbidden",
+				Cause:   nil,
+			}
 		}
 	}
 
@@ -524,7 +646,7 @@ func (c *LogController) DeleteComment(ctx context.Context, id path.Int, commentI
 		return httperr.BadRequest("Invalid Log ID")
 	}
 
-	err = c.commentService.Delete(ctx, &id.Value)
+	err = c.commentService.Delete(ctx, &commentId.Value)
 	if err != nil {
 		return &httperr.HTTPError{
 			Status:  500,
@@ -536,20 +658,36 @@ func (c *LogController) DeleteComment(ctx context.Context, id path.Int, commentI
 	return nil
 }
 
-// FindAllCommentByLogID gets a paginated list of all comments for a specific log.
+// FindAllCommentByLogID gets a paginated list of top-level comments for a specific log.
 // @Summary      FindAllCommentByLogID
-// @Description  Get a paginated list of all comments for a specific log.
+// @Description  Get a paginated list of top-level comments for a specific log, with their authors and reply counts. Load replies with FindRepliesByCommentID.
+// @Description  Hidden comments have no author or content unless the viewer is a log author. Logs with comments turned off return an empty list to everyone else.
 // @Tags         Comment
 // @Produce      json
 // @Param        id path int true "Log ID"
+// @Param        sort query string false "Sort order" Enums(oldest, newest, reactions) default(oldest)
 // @Param        page query int false "Page number"
 // @Param        size query int false "Page size"
 // @Success      200 {object} dto.PaginatedResult[dto.CommentResponse]
+// @Failure      400 "Bad Request"
 // @Failure      404 "Not Found"
 // @Router       /logs/{id}/comments [get]
-func (c *LogController) FindAllCommentByLogID(ctx context.Context, page query.Pagination, id path.Int) httpx.Response[dto.PaginatedResult[dto.CommentResponse]] {
+func (c *LogController) FindAllCommentByLogID(ctx context.Context, q query.Values, page query.Pagination, id path.Int, spineCtx spine.Ctx) httpx.Response[dto.PaginatedResult[dto.CommentResponse]] {
 
-	result, err := c.commentService.FindByLogID(ctx, &id.Value, page.Size, page.Page)
+	sort := q.Get("sort")
+	if sort == "" {
+		sort = entity.CommentSortOldest
+	}
+	if !slices.Contains(entity.CommentSorts, sort) {
+		return httpx.Response[dto.PaginatedResult[dto.CommentResponse]]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusBadRequest, // unknown sort
+			},
+		}
+	}
+
+	limit, offset := pageToLimitOffset(page)
+	result, err := c.commentService.FindByLogID(ctx, &id.Value, viewerFromContext(spineCtx), sort, limit, offset)
 	if err != nil {
 		return httpx.Response[dto.PaginatedResult[dto.CommentResponse]]{
 			Options: httpx.ResponseOptions{
@@ -558,6 +696,14 @@ func (c *LogController) FindAllCommentByLogID(ctx context.Context, page query.Pa
 		}
 	}
 
+	if err := c.reactionService.MarkCommentReactions(ctx, result.Items, viewerFromContext(spineCtx)); err != nil {
+		return httpx.Response[dto.PaginatedResult[dto.CommentResponse]]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusInternalServerError, // internal server error
+			},
+		}
+	}
+
 	commentResponses := make([]dto.CommentResponse, len(result.Items))
 	for i, item := range result.Items {
 		commentResponses[i] = dto.NewCommentResponse(item)
@@ -572,3 +718,347 @@ func (c *LogController) FindAllCommentByLogID(ctx context.Context, page query.Pa
 		},
 	}
 }
+
+// FindRepliesByCommentID gets a paginated list of direct replies to a comment.
+// @Summary      FindRepliesByCommentID
+// @Description  Get a paginated list of direct replies to a comment, oldest first, with their own reply counts.
+// @Tags         Comment
+// @Produce      json
+// @Param        id path int true "Log ID"
+// @Param        commentId path int true "Comment ID"
+// @Param        page query int false "Page number"
+// @Param        size query int false "Page size"
+// @Success      200 {object} dto.PaginatedResult[dto.CommentResponse]
+// @Failure      400 "Bad Request"
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Router       /logs/{id}/comments/{commentId}/replies [get]
+func (c *LogController) FindRepliesByCommentID(ctx context.Context, page query.Pagination, id path.Int, commentId path.Int, spineCtx spine.Ctx) httpx.Response[dto.PaginatedResult[dto.CommentResponse]] {
+	limit, offset := pageToLimitOffset(page)
+	result, err := c.commentService.FindReplies(ctx, &id.Value, &commentId.Value, viewerFromContext(spineCtx), limit, offset)
+	if errors.Is(err, sql.ErrNoRows) {
+		return httpx.Response[dto.PaginatedResult[dto.CommentResponse]]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusNotFound, // comment not found
+			},
+		}
+	}
+	if err != nil {
+		return httpx.Response[dto.PaginatedResult[dto.CommentResponse]]{
+			Options: httpx.ResponseOptions{
+				Status: statusFromError(err),
+			},
+		}
+	}
+
+	if err := c.reactionService.MarkCommentReactions(ctx, result.Items, viewerFromContext(spineCtx)); err != nil {
+		return httpx.Response[dto.PaginatedResult[dto.CommentResponse]]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusInternalServerError, // internal server error
+			},
+		}
+	}
+
+	commentResponses := make([]dto.CommentResponse, len(result.Items))
+	for i, item := range result.Items {
+		commentResponses[i] = dto.NewCommentResponse(item)
+	}
+
+	return httpx.Response[dto.PaginatedResult[dto.CommentResponse]]{
+		Body: dto.PaginatedResult[dto.CommentResponse]{
+			Items:  commentResponses,
+			Total:  result.Total,
+			Limit:  result.Limit,
+			Offset: result.Offset,
+		},
+	}
+}
+
+// GetCommentRevisions gets the edit history of a comment.
+// @Summary      GetCommentRevisions
+// @Description  Get the previous versions of a comment, newest first. Only the comment author and the log's authors can view them.
+// @Tags         Comment
+// @Produce      json
+// @Param        id path int true "Log ID"
+// @Param        commentId path int true "Comment ID"
+// @Success      200 {array} dto.CommentRevisionResponse
+// @Failure      400 "Bad Request"
+// @Failure      401 "Unauthorized"
+// @Failure      403 "Forbidden"
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /logs/{id}/comments/{commentId}/revisions [get]
+func (c *LogController) GetCommentRevisions(ctx context.Context, id path.Int, commentId path.Int, spineCtx spine.Ctx) (httpx.Response[[]dto.CommentRevisionResponse], error) {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[[]dto.CommentRevisionResponse]{}, httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	revisions, err := c.commentService.FindRevisions(ctx, &id.Value, &commentId.Value, &userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return httpx.Response[[]dto.CommentRevisionResponse]{}, httperr.NotFound("Comment not found")
+	}
+	if err != nil {
+		return httpx.Response[[]dto.CommentRevisionResponse]{}, httpErrorFromError(err)
+	}
+
+	res := make([]dto.CommentRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		res[i] = dto.NewCommentRevisionResponse(revision)
+	}
+
+	return httpx.Response[[]dto.CommentRevisionResponse]{
+		Body: res,
+	}, nil
+}
+
+// HideComment hides a comment on the caller's log.
+// @Summary      HideComment
+// @Description  Hide a comment on a log you own or edit. Others see it as hidden by the author, without its author or content.
+// @Tags         Comment
+// @Produce      json
+// @Param        id path int true "Log ID"
+// @Param        commentId path int true "Comment ID"
+// @Success      200 {object} dto.CommentResponse
+// @Failure      400 "Bad Request"
+// @Failure      401 "Unauthorized"
+// @Failure      403 "Not a log author"
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /logs/{id}/comments/{commentId}/hidden [put]
+func (c *LogController) HideComment(ctx context.Context, id path.Int, commentId path.Int, spineCtx spine.Ctx) (httpx.Response[dto.CommentResponse], error) {
+	return c.setCommentHidden(ctx, id, commentId, spineCtx, true)
+}
+
+// UnhideComment shows a hidden comment again.
+// @Summary      UnhideComment
+// @Description  Show a comment you hid on a log you own or edit.
+// @Tags         Comment
+// @Produce      json
+// @Param        id path int true "Log ID"
+// @Param        commentId path int true "Comment ID"
+// @Success      200 {object} dto.CommentResponse
+// @Failure      400 "Bad Request"
+// @Failure      401 "Unauthorized"
+// @Failure      403 "Not a log author"
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /logs/{id}/comments/{commentId}/hidden [delete]
+func (c *LogController) UnhideComment(ctx context.Context, id path.Int, commentId path.Int, spineCtx spine.Ctx) (httpx.Response[dto.CommentResponse], error) {
+	return c.setCommentHidden(ctx, id, commentId, spineCtx, false)
+}
+
+func (c *LogController) setCommentHidden(ctx context.Context, id path.Int, commentId path.Int, spineCtx spine.Ctx, hidden bool) (httpx.Response[dto.CommentResponse], error) {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[dto.CommentResponse]{}, httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	comment, err := c.commentService.SetHidden(ctx, &id.Value, &commentId.Value, &userID, hidden)
+	if errors.Is(err, sql.ErrNoRows) {
+		return httpx.Response[dto.CommentResponse]{}, httperr.NotFound("Log or comment not found")
+	}
+	if err != nil {
+		return httpx.Response[dto.CommentResponse]{}, httpErrorFromError(err)
+	}
+
+	if err := c.reactionService.MarkCommentReactions(ctx, []*entity.Comment{comment}, &userID); err != nil {
+		return httpx.Response[dto.CommentResponse]{}, err
+	}
+
+	return httpx.Response[dto.CommentResponse]{
+		Body: dto.NewCommentResponse(comment),
+	}, nil
+}
+
+// UpdateCommentMode opens, locks or turns off comments on a log.
+// @Summary      UpdateCommentMode
+// @Description  Change who can comment on a log you own or edit. open: anyone, locked: only log authors can write or edit comments, off: comments are hidden from everyone but log authors and nobody can write.
+// @Tags         Comment
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Log ID"
+// @Param        request body dto.CommentModeUpdateRequest true "New comment mode"
+// @Success      200 {object} dto.LogResponse
+// @Failure      400 "Bad Request"
+// @Failure      401 "Unauthorized"
+// @Failure      403 "Not a log author"
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /logs/{id}/comment-mode [put]
+func (c *LogController) UpdateCommentMode(ctx context.Context, id path.Int, req *dto.CommentModeUpdateRequest, spineCtx spine.Ctx) (httpx.Response[dto.LogResponse], error) {
+	if err := pkg.Validate(req); err != nil {
+		return httpx.Response[dto.LogResponse]{}, httpErrorFromError(err)
+	}
+
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[dto.LogResponse]{}, httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	log, err := c.commentService.SetCommentMode(ctx, &id.Value, &userID, req.Mode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return httpx.Response[dto.LogResponse]{}, httperr.NotFound("Log not found")
+	}
+	if err != nil {
+		return httpx.Response[dto.LogResponse]{}, httpErrorFromError(err)
+	}
+
+	if err := c.reactionService.MarkLogReactions(ctx, []*entity.Log{log}, &userID); err != nil {
+		return httpx.Response[dto.LogResponse]{}, err
+	}
+
+	return httpx.Response[dto.LogResponse]{
+		Body: dto.NewLogResponse(log),
+	}, nil
+}
+
+// viewerFromContext 는 OptionalAuth 라우트에서 로그인한 사용자 아이디를 꺼내고, 익명이면 nil을 돌려줍니다.
+func viewerFromContext(spineCtx spine.Ctx) *entity.ID {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return nil
+	}
+	userID := v.(entity.ID)
+	return &userID
+}
+
+// GetReactionKinds lists the reactions that can be added to logs and comments.
+// @Summary      GetReactionKinds
+// @Description  List the reaction kinds that can be added to logs and comments, in display order.
+// @Tags         Reaction
+// @Produce      json
+// @Success      200 {array} string
+// @Router       /reactions [get]
+func (c *LogController) GetReactionKinds(ctx context.Context) httpx.Response[[]string] {
+	return httpx.Response[[]string]{
+		Body: c.reactionService.Kinds(),
+	}
+}
+
+// AddLogReaction adds the caller's reaction to a log.
+// @Summary      AddLogReaction
+// @Description  Add a reaction to a log. Each user can add each kind once; adding it again changes nothing.
+// @Tags         Reaction
+// @Produce      json
+// @Param        id path int true "Log ID"
+// @Param        kind path string true "Reaction kind"
+// @Success      200 {object} dto.ReactionsResponse
+// @Failure      400 "Unknown reaction"
+// @Failure      401 "Unauthorized"
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /logs/{id}/reactions/{kind} [put]
+func (c *LogController) AddLogReaction(ctx context.Context, id path.Int, kind path.String, spineCtx spine.Ctx) (httpx.Response[dto.ReactionsResponse], error) {
+	return c.setLogReaction(ctx, id, kind, spineCtx, true)
+}
+
+// RemoveLogReaction removes the caller's reaction from a log.
+// @Summary      RemoveLogReaction
+// @Description  Remove a reaction you added to a log.
+// @Tags         Reaction
+// @Produce      json
+// @Param        id path int true "Log ID"
+// @Param        kind path string true "Reaction kind"
+// @Success      200 {object} dto.ReactionsResponse
+// @Failure      400 "Unknown reaction"
+// @Failure      401 "Unauthorized"
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /logs/{id}/reactions/{kind} [delete]
+func (c *LogController) RemoveLogReaction(ctx context.Context, id path.Int, kind path.String, spineCtx spine.Ctx) (httpx.Response[dto.ReactionsResponse], error) {
+	return c.setLogReaction(ctx, id, kind, spineCtx, false)
+}
+
+func (c *LogController) setLogReaction(ctx context.Context, id path.Int, kind path.String, spineCtx spine.Ctx, reacted bool) (httpx.Response[dto.ReactionsResponse], error) {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[dto.ReactionsResponse]{}, httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	log, err := c.reactionService.SetLogReaction(ctx, &id.Value, &userID, kind.Value, reacted)
+	if errors.Is(err, sql.ErrNoRows) {
+		return httpx.Response[dto.ReactionsResponse]{}, httperr.NotFound("Log not found")
+	}
+	if err != nil {
+		return httpx.Response[dto.ReactionsResponse]{}, httpErrorFromError(err)
+	}
+
+	return httpx.Response[dto.ReactionsResponse]{
+		Body: dto.NewReactionsResponse(log.ReactionCounts, log.MyReactions),
+	}, nil
+}
+
+// AddCommentReaction adds the caller's reaction to a comment.
+// @Summary      AddCommentReaction
+// @Description  Add a reaction to a comment. Hidden comments and logs with locked or turned off comments don't take new reactions, except from log authors on locked logs.
+// @Tags         Reaction
+// @Produce      json
+// @Param        id path int true "Log ID"
+// @Param        commentId path int true "Comment ID"
+// @Param        kind path string true "Reaction kind"
+// @Success      200 {object} dto.ReactionsResponse
+// @Failure      400 "Bad Request"
+// @Failure      401 "Unauthorized"
+// @Failure      403 "Comments are locked or turned off"
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /logs/{id}/comments/{commentId}/reactions/{kind} [put]
+func (c *LogController) AddCommentReaction(ctx context.Context, id path.Int, commentId path.Int, kind path.String, spineCtx spine.Ctx) (httpx.Response[dto.ReactionsResponse], error) {
+	return c.setCommentReaction(ctx, id, commentId, kind, spineCtx, true)
+}
+
+// RemoveCommentReaction removes the caller's reaction from a comment.
+// @Summary      RemoveCommentReaction
+// @Description  Remove a reaction you added to a comment.
+// @Tags         Reaction
+// @Produce      json
+// @Param        id path int true "Log ID"
+// @Param        commentId path int true "Comment ID"
+// @Param        kind path string true "Reaction kind"
+// @Success      200 {object} dto.ReactionsResponse
+// @Failure      400 "Bad Request"
+// @Failure      401 "Unauthorized"
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /logs/{id}/comments/{commentId}/reactions/{kind} [delete]
+func (c *LogController) RemoveCommentReaction(ctx context.Context, id path.Int, commentId path.Int, kind path.String, spineCtx spine.Ctx) (httpx.Response[dto.ReactionsResponse], error) {
+	return c.setCommentReaction(ctx, id, commentId, kind, spineCtx, false)
+}
+
+func (c *LogController) setCommentReaction(ctx context.Context, id path.Int, commentId path.Int, kind path.String, spineCtx spine.Ctx, reacted bool) (httpx.Response[dto.ReactionsResponse], error) {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[dto.ReactionsResponse]{}, httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	comment, err := c.reactionService.SetCommentReaction(ctx, &id.Value, &commentId.Value, &userID, kind.Value, reacted)
+	if errors.Is(err, sql.ErrNoRows) {
+		return httpx.Response[dto.ReactionsResponse]{}, httperr.NotFound("Log or comment not found")
+	}
+	if err != nil {
+		return httpx.Response[dto.ReactionsResponse]{}, httpErrorFromError(err)
+	}
+
+	return httpx.Response[dto.ReactionsResponse]{
+		Body: dto.NewReactionsResponse(comment.ReactionCounts, comment.MyReactions),
+	}, nil
+}
@@ -0,0 +1,219 @@
+package controller
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"analog-be/pkg"
+	"analog-be/service"
+	"context"
+
+	"github.com/NARUBROWN/spine/pkg/httperr"
+	"github.com/NARUBROWN/spine/pkg/httpx"
+	"github.com/NARUBROWN/spine/pkg/path"
+	"github.com/NARUBROWN/spine/pkg/query"
+	"github.com/NARUBROWN/spine/pkg/spine"
+)
+
+type NotificationController struct {
+	notificationService service.NotificationService
+}
+
+func NewNotificationController(notificationService service.NotificationService) *NotificationController {
+	return &NotificationController{
+		notificationService: notificationService,
+	}
+}
+
+// ListNotifications lists the current user's notifications.
+// @Summary      ListNotifications
+// @Description  List your notifications, most recently updated first. Similar unread notifications are grouped into one, showing the latest actor and how many people acted.
+// @Tags         Notification
+// @Produce      json
+// @Param        unread query bool false "Only unread notifications"
+// @Param        page query int false "Page number"
+// @Param        size query int false "Page size"
+// @Success      200 {object} dto.PaginatedResult[dto.NotificationResponse]
+// @Failure      401 "Unauthorized"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /notifications [get]
+func (c *NotificationController) ListNotifications(ctx context.Context, q query.Values, page query.Pagination, spineCtx spine.Ctx) (httpx.Response[dto.PaginatedResult[dto.NotificationResponse]], error) {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[dto.PaginatedResult[dto.NotificationResponse]]{}, httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	limit, offset := pageToLimitOffset(page)
+	result, err := c.notificationService.List(ctx, &userID, q.Get("unread") == "true", limit, offset)
+	if err != nil {
+		return httpx.Response[dto.PaginatedResult[dto.NotificationResponse]]{}, httpErrorFromError(err)
+	}
+
+	notificationResponses := make([]dto.NotificationResponse, len(result.Items))
+	for i, notification := range result.Items {
+		notificationResponses[i] = dto.NewNotificationResponse(notification)
+	}
+
+	return httpx.Response[dto.PaginatedResult[dto.NotificationResponse]]{
+		Body: dto.PaginatedResult[dto.NotificationResponse]{
+			Items:  notificationResponses,
+			Total:  result.Total,
+			Limit:  result.Limit,
+			Offset: result.Offset,
+		},
+	}, nil
+}
+
+// GetUnreadCount counts the current user's unread notifications.
+// @Summary      GetUnreadCount
+// @Description  Count your unread notifications. A grouped notification counts once.
+// @Tags         Notification
+// @Produce      json
+// @Success      200 {object} dto.NotificationUnreadCountResponse
+// @Failure      401 "Unauthorized"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /notifications/unread-count [get]
+func (c *NotificationController) GetUnreadCount(ctx context.Context, spineCtx spine.Ctx) (httpx.Response[dto.NotificationUnreadCountResponse], error) {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[dto.NotificationUnreadCountResponse]{}, httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	count, err := c.notificationService.UnreadCount(ctx, &userID)
+	if err != nil {
+		return httpx.Response[dto.NotificationUnreadCountResponse]{}, httpErrorFromError(err)
+	}
+
+	return httpx.Response[dto.NotificationUnreadCountResponse]{
+		Body: dto.NotificationUnreadCountResponse{Count: count},
+	}, nil
+}
+
+// MarkNotificationRead marks a notification as read.
+// @Summary      MarkNotificationRead
+// @Description  Mark one of your notifications as read. Marking a read notification again does nothing.
+// @Tags         Notification
+// @Param        id path int true "Notification ID"
+// @Success      204 "No Content"
+// @Failure      401 "Unauthorized"
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /notifications/{id}/read [put]
+func (c *NotificationController) MarkNotificationRead(ctx context.Context, id path.Int, spineCtx spine.Ctx) error {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	if err := c.notificationService.MarkRead(ctx, &userID, &id.Value); err != nil {
+		return httpErrorFromError(err)
+	}
+
+	return nil
+}
+
+// MarkAllNotificationsRead marks all notifications as read.
+// @Summary      MarkAllNotificationsRead
+// @Description  Mark all of your unread notifications as read.
+// @Tags         Notification
+// @Produce      json
+// @Success      200 {object} dto.NotificationReadAllResponse
+// @Failure      401 "Unauthorized"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /notifications/read-all [put]
+func (c *NotificationController) MarkAllNotificationsRead(ctx context.Context, spineCtx spine.Ctx) (httpx.Response[dto.NotificationReadAllResponse], error) {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[dto.NotificationReadAllResponse]{}, httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	updated, err := c.notificationService.MarkAllRead(ctx, &userID)
+	if err != nil {
+		return httpx.Response[dto.NotificationReadAllResponse]{}, httpErrorFromError(err)
+	}
+
+	return httpx.Response[dto.NotificationReadAllResponse]{
+		Body: dto.NotificationReadAllResponse{Updated: updated},
+	}, nil
+}
+
+// GetNotificationPreferences gets the current user's notification settings.
+// @Summary      GetNotificationPreferences
+// @Description  Get whether you receive each type of notification in the app and by email. Types you never changed are on. Email only goes out after you turn on email notifications in /notifications/email.
+// @Tags         Notification
+// @Produce      json
+// @Success      200 {array} dto.NotificationPreferenceResponse
+// @Failure      401 "Unauthorized"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /notifications/preferences [get]
+func (c *NotificationController) GetNotificationPreferences(ctx context.Context, spineCtx spine.Ctx) (httpx.Response[[]dto.NotificationPreferenceResponse], error) {
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[[]dto.NotificationPreferenceResponse]{}, httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	preferences, err := c.notificationService.Preferences(ctx, &userID)
+	if err != nil {
+		return httpx.Response[[]dto.NotificationPreferenceResponse]{}, httpErrorFromError(err)
+	}
+
+	return newNotificationPreferencesResponse(preferences), nil
+}
+
+// UpdateNotificationPreferences turns notification types on or off.
+// @Summary      UpdateNotificationPreferences
+// @Description  Turn notification types on or off in the app or by email. Types and channels you do not send keep their setting. Turning a type off stops new notifications of that type; existing ones stay. Only notifications shown in the app are emailed.
+// @Tags         Notification
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.NotificationPreferencesUpdateRequest true "Settings to change"
+// @Success      200 {array} dto.NotificationPreferenceResponse
+// @Failure      400 "Bad Request"
+// @Failure      401 "Unauthorized"
+// @Failure      500 "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /notifications/preferences [put]
+func (c *NotificationController) UpdateNotificationPreferences(ctx context.Context, req *dto.NotificationPreferencesUpdateRequest, spineCtx spine.Ctx) (httpx.Response[[]dto.NotificationPreferenceResponse], error) {
+	if err := pkg.Validate(req); err != nil {
+		return httpx.Response[[]dto.NotificationPreferenceResponse]{}, httpErrorFromError(err)
+	}
+
+	v, ok := spineCtx.Get(string(pkg.UserIDKey))
+	if !ok {
+		return httpx.Response[[]dto.NotificationPreferenceResponse]{}, httperr.Unauthorized("Authentication required")
+	}
+
+	userID := v.(entity.ID)
+
+	preferences, err := c.notificationService.UpdatePreferences(ctx, &userID, req)
+	if err != nil {
+		return httpx.Response[[]dto.NotificationPreferenceResponse]{}, httpErrorFromError(err)
+	}
+
+	return newNotificationPreferencesResponse(preferences), nil
+}
+
+func newNotificationPreferencesResponse(preferences []*entity.NotificationPreference) httpx.Response[[]dto.NotificationPreferenceResponse] {
+	res := make([]dto.NotificationPreferenceResponse, len(preferences))
+	for i, preference := range preferences {
+		res[i] = dto.NewNotificationPreferenceResponse(preference)
+	}
+
+	return httpx.Response[[]dto.NotificationPreferenceResponse]{
+		Body: res,
+	}
+}
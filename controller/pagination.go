@@ -0,0 +1,27 @@
+package controller
+
+import "github.com/NARUBROWN/spine/pkg/query"
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// pageToLimitOffset 는 1부터 시작하는 page/size 쿼리를 limit/offset으로 바꿉니다.
+// 범위를 벗어난 값은 기본값이나 최대값으로 보정합니다.
+func pageToLimitOffset(page query.Pagination) (int, int) {
+	size := page.Size
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	if size > maxPageSize {
+		size = maxPageSize
+	}
+
+	p := page.Page
+	if p < 1 {
+		p = 1
+	}
+
+	return size, (p - 1) * size
+}
@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"analog-be/entity"
+	"analog-be/pkg"
+	"analog-be/service"
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NARUBROWN/spine/pkg/header"
+	"github.com/NARUBROWN/spine/pkg/httperr"
+	"github.com/NARUBROWN/spine/pkg/query"
+	"github.com/NARUBROWN/spine/pkg/spine"
+)
+
+// 연결 하나가 구독할 수 있는 로그 수
+const maxRealtimeLogs = 20
+
+type RealtimeController struct {
+	realtimeService service.RealtimeService
+	realtimePolicy  *pkg.RealtimePolicy
+}
+
+func NewRealtimeController(realtimeService service.RealtimeService, realtimePolicy *pkg.RealtimePolicy) *RealtimeController {
+	return &RealtimeController{
+		realtimeService: realtimeService,
+		realtimePolicy:  realtimePolicy,
+	}
+}
+
+// StreamEvents streams real-time events over Server-Sent Events.
+// @Summary      StreamEvents
+// @Description  Open a text/event-stream of events for you. Everyone gets `log` events for new logs. Set logs to the IDs of the logs being viewed to get their new `comment` events. Signed-in users also get their own `notification` events.
+// @Description  Each event's data is the same JSON the REST API returns for it, and its id can be sent back as the Last-Event-ID header (or as lastEventId when opening a new EventSource, which cannot set headers) to receive what was missed while disconnected.
+// @Description  The first event is `ready`. If too much was missed or it is too old to replay, a `reset` event is sent instead and the client should reload. Comment lines are sent as heartbeats, and the stream closes now and then so the session is checked again; reconnect after the advertised retry delay.
+// @Tags         Realtime
+// @Produce      text/event-stream
+// @Param        logs query string false "Comma-separated log IDs being viewed (at most 20)"
+// @Param        lastEventId query int false "ID of the last event received, if the Last-Event-ID header cannot be sent"
+// @Param        Last-Event-ID header int false "ID of the last event received"
+// @Success      200 "Event stream"
+// @Failure      400 "Bad Request"
+// @Failure      500 "Internal Server Error"
+// @Router       /events [get]
+func (c *RealtimeController) StreamEvents(ctx context.Context, q query.Values, headers header.Values, spineCtx spine.Ctx) error {
+	channels := []string{entity.RealtimeLogsChannel}
+	if viewerID := viewerFromContext(spineCtx); viewerID != nil {
+		channels = append(channels, entity.UserRealtimeChannel(*viewerID))
+	}
+
+	if raw := q.Get("logs"); raw != "" {
+		ids := strings.Split(raw, ",")
+		if len(ids) > maxRealtimeLogs {
+			return httperr.BadRequest("Too many logs")
+		}
+		for _, s := range ids {
+			id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+			if err != nil {
+				return httperr.BadRequest("Invalid logs")
+			}
+			channels = append(channels, entity.LogRealtimeChannel(id))
+		}
+	}
+
+	lastEventID := headers.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = q.Get("lastEventId")
+	}
+	var afterID *entity.ID
+	if lastEventID != "" {
+		id, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil {
+			return httperr.BadRequest("Invalid Last-Event-ID")
+		}
+		afterID = &id
+	}
+
+	w, ok := pkg.GetResponseWriter(ctx)
+	if !ok {
+		return pkg.NewInternalError("Streaming is not supported")
+	}
+
+	// 되돌려 줄 이벤트를 읽기 전에 구독해야 그 사이에 온 이벤트를 놓치지 않음
+	sub := c.realtimeService.Subscribe(channels)
+	defer sub.Close()
+
+	stream, ok := pkg.NewSSEWriter(w)
+	if !ok {
+		return pkg.NewInternalError("Streaming is not supported")
+	}
+
+	if err := stream.Retry(c.realtimePolicy.RetryDelay); err != nil {
+		return nil
+	}
+
+	replayed, err := c.replay(ctx, stream, channels, afterID)
+	if err != nil {
+		return nil
+	}
+
+	heartbeat := time.NewTicker(c.realtimePolicy.HeartbeatInterval)
+	defer heartbeat.Stop()
+	expire := time.NewTimer(c.realtimePolicy.MaxStreamDuration)
+	defer expire.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-expire.C:
+			return nil
+		case <-heartbeat.C:
+			err = stream.Comment("heartbeat")
+		case event, ok := <-sub.Events:
+			if !ok {
+				return nil
+			}
+			if replayed[event.ID] {
+				continue
+			}
+			err = stream.Event(strconv.FormatInt(event.ID, 10), event.Type, event.Data)
+		}
+		if err != nil {
+			return nil
+		}
+	}
+}
+
+// replay 는 afterID 이후에 놓친 이벤트를 보내고 ready 이벤트를 보냅니다.
+// 새로 연결했거나 다 보낼 수 없으면 지금 위치를 id로 하는 ready나 reset 이벤트만 보내 다음 연결부터 이어 받게 합니다.
+func (c *RealtimeController) replay(ctx context.Context, stream *pkg.SSEWriter, channels []string, afterID *entity.ID) (map[entity.ID]bool, error) {
+	replayed := make(map[entity.ID]bool)
+
+	if afterID != nil {
+		events, complete, err := c.realtimeService.Replay(ctx, channels, *afterID, c.realtimePolicy.ReplayLimit)
+		if err == nil && complete {
+			for _, event := range events {
+				if err := stream.Event(strconv.FormatInt(event.ID, 10), event.Type, event.Data); err != nil {
+					return nil, err
+				}
+				replayed[event.ID] = true
+			}
+			return replayed, stream.Event("", "ready", []byte("{}"))
+		}
+	}
+
+	kind := "ready"
+	if afterID != nil {
+		kind = "reset"
+	}
+
+	var id string
+	if lastID, err := c.realtimeService.LastEventID(ctx); err == nil {
+		id = strconv.FormatInt(lastID, 10)
+	}
+	return replayed, stream.Event(id, kind, []byte("{}"))
+}
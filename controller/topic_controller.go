@@ -32,7 +32,8 @@ func NewTopicController(topicService service.TopicService) *TopicController {
 // @Router       /topic [get]
 func (c *TopicController) GetList(ctx context.Context, page query.Pagination) httpx.Response[[]dto.TopicResponse] {
 
-	topics, err := c.topicService.FindAll(ctx, page.Size, page.Page)
+	limit, offset := pageToLimitOffset(page)
+	topics, err := c.topicService.FindAll(ctx, limit, offset)
 	if err != nil {
 		return httpx.Response[[]dto.TopicResponse]{
 			Options: httpx.ResponseOptions{
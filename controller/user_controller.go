@@ -7,6 +7,7 @@ import (
 	"analog-be/service"
 	"context"
 	"net/http"
+	"net/url"
 
 	"github.com/NARUBROWN/spine/pkg/httperr"
 	"github.com/NARUBROWN/spine/pkg/httpx"
@@ -98,7 +99,9 @@ func (c *UserController) Create(ctx context.Context, req *dto.UserCreateRequest)
 // @Produce      json
 // @Param        user body dto.UserUpdateRequest true "User data to update"
 // @Success      200 {object} dto.UserResponse
+// @Failure      400 "Handle is invalid or reserved"
 // @Failure      401 "Unauthorized"
+// @Failure      409 "Handle is already taken"
 // @Failure      500 "Internal Server Error"
 // @Security     ApiKeyAuth
 // @Router       /users [put]
@@ -118,7 +121,7 @@ func (c *UserController) Update(ctx context.Context, req *dto.UserUpdateRequest,
 	if err != nil {
 		return httpx.Response[dto.UserResponse]{
 			Options: httpx.ResponseOptions{
-				Status: http.StatusInternalServerError, // internal server error
+				Status: statusFromError(err), // invalid or taken handle, or internal error
 			},
 		}
 	}
@@ -185,7 +188,8 @@ func (c *UserController) Search(ctx context.Context, q query.Values, page query.
 		}
 	}
 
-	paginatedResult, err := c.userService.Search(ctx, searchQuery, page.Size, page.Page)
+	limit, offset := pageToLimitOffset(page)
+	paginatedResult, err := c.userService.Search(ctx, searchQuery, limit, offset)
 	if err != nil {
 		return httpx.Response[dto.PaginatedResult[dto.UserResponse]]{
 			Options: httpx.ResponseOptions{
@@ -209,6 +213,68 @@ func (c *UserController) Search(ctx context.Context, q query.Values, page query.
 	}
 }
 
+// GetByHandle gets a single user by their handle.
+// @Summary      GetUserByHandle
+// @Description  Get a single user by their handle. A handle the user has changed away from redirects (301) to the current one.
+// @Tags         User
+// @Produce      json
+// @Param        handle path string true "Handle"
+// @Success      200 {object} dto.UserResponse
+// @Success      301 {object} dto.UserResponse "Moved to the current handle"
+// @Failure      404 "Not Found"
+// @Failure      500 "Internal Server Error"
+// @Router       /users/handles/{handle} [get]
+func (c *UserController) GetByHandle(ctx context.Context, handle path.String) httpx.Response[dto.UserResponse] {
+	user, moved, err := c.userService.GetByHandle(ctx, handle.Value)
+	if err != nil {
+		return httpx.Response[dto.UserResponse]{
+			Options: httpx.ResponseOptions{
+				Status: statusFromError(err), // user not found, or internal error
+			},
+		}
+	}
+
+	res := dto.NewUserResponse(user)
+	if moved {
+		// 이전 핸들로 만든 주소도 계속 열리도록 지금 핸들로 넘겨줌
+		return httpx.Response[dto.UserResponse]{
+			Body: res,
+			Options: httpx.ResponseOptions{
+				Status:  http.StatusMovedPermanently,
+				Headers: map[string]string{"Location": "/users/handles/" + url.PathEscape(user.Handle)},
+			},
+		}
+	}
+
+	return httpx.Response[dto.UserResponse]{
+		Body: res,
+	}
+}
+
+// CheckHandle checks whether a handle can be chosen.
+// @Summary      CheckHandleAvailability
+// @Description  Check whether a handle is well-formed, not reserved and not used (now or previously) by another user.
+// @Tags         User
+// @Produce      json
+// @Param        handle path string true "Handle"
+// @Success      200 {object} dto.HandleAvailabilityResponse
+// @Failure      500 "Internal Server Error"
+// @Router       /users/handles/{handle}/availability [get]
+func (c *UserController) CheckHandle(ctx context.Context, handle path.String) httpx.Response[dto.HandleAvailabilityResponse] {
+	res, err := c.userService.CheckHandle(ctx, handle.Value)
+	if err != nil {
+		return httpx.Response[dto.HandleAvailabilityResponse]{
+			Options: httpx.ResponseOptions{
+				Status: http.StatusInternalServerError, // internal server error
+			},
+		}
+	}
+
+	return httpx.Response[dto.HandleAvailabilityResponse]{
+		Body: *res,
+	}
+}
+
 // GetMe gets the currently authenticated user's information.
 // @Summary      GetCurrentUser
 // @Description  Get the currently authenticated user's information.
@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"analog-be/entity"
+	"time"
+)
+
+// ImpersonationStartRequest 의 durationMinutes를 비우면 기본 길이(IMPERSONATION_DEFAULT_DURATION)를 사용합니다.
+type ImpersonationStartRequest struct {
+	UserID          entity.ID `json:"userId" validate:"required"`
+	Reason          string    `json:"reason" validate:"required,min=1,max=500"`
+	DurationMinutes int       `json:"durationMinutes" validate:"min=0"`
+	AllowWrites     bool      `json:"allowWrites"`
+}
+
+// ImpersonationResponse 는 대리 세션을 만든 직후에만 세션 토큰을 담습니다.
+type ImpersonationResponse struct {
+	ID           entity.ID `json:"id"`
+	SessionToken string    `json:"sessionToken"`
+	UserID       entity.ID `json:"userId"`
+	AllowWrites  bool      `json:"allowWrites"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+}
+
+type ImpersonationAuditResponse struct {
+	ID             entity.ID `json:"id"`
+	SessionID      entity.ID `json:"sessionId"`
+	ImpersonatorID entity.ID `json:"impersonatorId"`
+	TargetUserID   entity.ID `json:"targetUserId"`
+	Action         string    `json:"action"`
+	Method         string    `json:"method,omitempty"`
+	Path           string    `json:"path,omitempty"`
+	Reason         string    `json:"reason,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+func NewImpersonationAuditResponse(a *entity.ImpersonationAudit) ImpersonationAuditResponse {
+	return ImpersonationAuditResponse{
+		ID:             a.ID,
+		SessionID:      a.SessionID,
+		ImpersonatorID: a.ImpersonatorID,
+		TargetUserID:   a.TargetUserID,
+		Action:         a.Action,
+		Method:         a.Method,
+		Path:           a.Path,
+		Reason:         a.Reason,
+		CreatedAt:      a.CreatedAt,
+	}
+}
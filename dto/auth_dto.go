@@ -1,8 +1,13 @@
 package dto
 
-import "analog-be/entity"
+import (
+	"analog-be/entity"
+	"time"
+)
 
+// LoginInitRequest 의 provider를 비우면 An-Account로 로그인합니다.
 type LoginInitRequest struct {
+	Provider    string `json:"provider,omitempty"`
 	RedirectUri string `json:"redirectUri" binding:"required"`
 }
 
@@ -11,7 +16,10 @@ type LoginInitResponse struct {
 	State            string `json:"state"`
 }
 
+// SignupInitRequest 의 handle을 비우면 아이디로 만든 기본 핸들(user<아이디>)을 사용합니다.
 type SignupInitRequest struct {
+	Provider    string `json:"provider,omitempty"`
+	Handle      string `json:"handle,omitempty"`
 	RedirectUri string `json:"redirectUri" binding:"required"`
 }
 
@@ -29,8 +37,10 @@ type OAuthCallbackRequest struct {
 	State string `form:"state" binding:"required"`
 }
 
+// AuthResponse 는 쿠키 세션 모드(session=cookie)이면 sessionToken 대신 csrfToken을 담습니다.
 type AuthResponse struct {
-	SessionToken string   `json:"sessionToken"`
+	SessionToken string   `json:"sessionToken,omitempty"`
+	CSRFToken    string   `json:"csrfToken,omitempty"`
 	User         *UserDTO `json:"user"`
 	ExpiresAt    string   `json:"expiresAt"`
 }
@@ -38,6 +48,7 @@ type AuthResponse struct {
 type UserDTO struct {
 	ID           entity.ID `json:"id"`
 	Name         string    `json:"name"`
+	Handle       string    `json:"handle"`
 	ProfileImage string    `json:"profileImage"`
 	PartOf       string    `json:"partOf"`
 	Generation   uint16    `json:"generation"`
@@ -54,6 +65,7 @@ type TokenResponse struct {
 	ExpiresIn    int    `json:"expires_in"`
 	RefreshToken string `json:"refresh_token,omitempty"`
 	Scope        string `json:"scope,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
 }
 
 type UserInfoResponse struct {
@@ -63,4 +75,111 @@ type UserInfoResponse struct {
 	Name              string `json:"name"`
 	PreferredUsername string `json:"preferred_username"`
 	Picture           string `json:"picture,omitempty"`
+	// 동아리 부원이면 소속 학과와 기수
+	Department string `json:"department,omitempty"`
+	Generation uint16 `json:"generation,omitempty"`
+}
+
+type SessionRefreshResponse struct {
+	SessionToken string `json:"sessionToken,omitempty"`
+	CSRFToken    string `json:"csrfToken,omitempty"`
+	ExpiresAt    string `json:"expiresAt"`
+}
+
+type SessionResponse struct {
+	ID         entity.ID `json:"id"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	IPAddress  string    `json:"ipAddress"`
+	UserAgent  string    `json:"userAgent"`
+	Current    bool      `json:"current"`
+	// 관리자가 이 사용자로 보기 위해 만든 대리 세션
+	Impersonated bool `json:"impersonated"`
+}
+
+func NewSessionResponse(s *entity.Session, currentSessionID entity.ID) SessionResponse {
+	return SessionResponse{
+		ID:         s.ID,
+		CreatedAt:  s.CreatedAt,
+		LastSeenAt: s.LastSeenAt,
+		ExpiresAt:  s.ExpiresAt,
+		IPAddress:  s.IPAddress,
+		UserAgent:  s.UserAgent,
+		Current:    s.ID == currentSessionID,
+
+		Impersonated: s.Impersonated(),
+	}
+}
+
+type AccessTokenCreateRequest struct {
+	Name   string   `json:"name" validate:"required,min=1,max=100"`
+	Scopes []string `json:"scopes" validate:"required,min=1,max=10,dive,oneof=logs:write comments:write reactions:write user:read"`
+	// 0이면 만료되지 않음
+	ExpiresInDays int `json:"expiresInDays" validate:"min=0,max=365"`
+}
+
+type AccessTokenResponse struct {
+	ID          entity.ID  `json:"id"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"tokenPrefix"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   *time.Time `json:"expiresAt"`
+	LastUsedAt  *time.Time `json:"lastUsedAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// AccessTokenCreateResponse 는 발급 직후에만 원본 토큰을 담아 돌려줍니다.
+type AccessTokenCreateResponse struct {
+	AccessTokenResponse
+	Token string `json:"token"`
+}
+
+func NewAccessTokenResponse(t *entity.PersonalAccessToken) AccessTokenResponse {
+	return AccessTokenResponse{
+		ID:          t.ID,
+		Name:        t.Name,
+		TokenPrefix: t.TokenPrefix,
+		Scopes:      t.Scopes,
+		ExpiresAt:   optionalTime(t.ExpiresAt),
+		LastUsedAt:  optionalTime(t.LastUsedAt),
+		CreatedAt:   t.CreatedAt,
+	}
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+type IdentityProvidersResponse struct {
+	Providers []string `json:"providers"`
+}
+
+type IdentityLinkInitRequest struct {
+	Provider    string `json:"provider" binding:"required"`
+	RedirectUri string `json:"redirectUri" binding:"required"`
+}
+
+// IdentityResponse 는 사용자에게 연결된 외부 계정입니다.
+type IdentityResponse struct {
+	ID         entity.ID `json:"id"`
+	Provider   string    `json:"provider"`
+	Subject    string    `json:"subject"`
+	Email      string    `json:"email,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+}
+
+func NewIdentityResponse(i *entity.UserIdentity) IdentityResponse {
+	return IdentityResponse{
+		ID:         i.ID,
+		Provider:   i.Provider,
+		Subject:    i.Subject,
+		Email:      i.Email,
+		CreatedAt:  i.CreatedAt,
+		LastUsedAt: i.LastUsedAt,
+	}
 }
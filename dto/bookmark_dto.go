@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"analog-be/entity"
+	"time"
+)
+
+type BookmarkResponse struct {
+	Log       LogResponse `json:"log"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+type ReadingListCreateRequest struct {
+	Name        string `json:"name" validate:"required,min=1,max=100"`
+	Description string `json:"description" validate:"max=500"`
+	Public      bool   `json:"public"`
+}
+
+// ReadingListUpdateRequest 는 보낸 필드만 바꿉니다. position을 보내면 내 목록 중 그 자리(0부터)로 옮깁니다.
+type ReadingListUpdateRequest struct {
+	Name        *string `json:"name" validate:"omitempty,min=1,max=100"`
+	Description *string `json:"description" validate:"omitempty,max=500"`
+	Public      *bool   `json:"public"`
+	Position    *int    `json:"position" validate:"omitempty,min=0"`
+}
+
+// ReadingListItemRequest 는 로그를 목록에 담거나 옮길 때 쓰입니다. position을 비우면 새 로그는 맨 뒤에 담고 담긴 로그는 그대로 둡니다.
+type ReadingListItemRequest struct {
+	Position *int `json:"position" validate:"omitempty,min=0"`
+}
+
+type ReadingListResponse struct {
+	ID          entity.ID `json:"id"`
+	OwnerID     entity.ID `json:"ownerId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Public      bool      `json:"public"`
+	Position    int       `json:"position"`
+	ItemCount   int       `json:"itemCount"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type ReadingListItemResponse struct {
+	Log       LogResponse `json:"log"`
+	Position  int         `json:"position"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+func NewBookmarkResponse(b *entity.Bookmark) BookmarkResponse {
+	return BookmarkResponse{
+		Log:       NewLogResponse(b.Log),
+		CreatedAt: b.CreatedAt,
+	}
+}
+
+func NewReadingListResponse(l *entity.ReadingList) ReadingListResponse {
+	return ReadingListResponse{
+		ID:          l.ID,
+		OwnerID:     l.UserID,
+		Name:        l.Name,
+		Description: l.Description,
+		Public:      l.Public,
+		Position:    l.Position,
+		ItemCount:   l.ItemCount,
+		CreatedAt:   l.CreatedAt,
+		UpdatedAt:   l.UpdatedAt,
+	}
+}
+
+func NewReadingListItemResponse(i *entity.ReadingListItem) ReadingListItemResponse {
+	return ReadingListItemResponse{
+		Log:       NewLogResponse(i.Log),
+		Position:  i.Position,
+		CreatedAt: i.CreatedAt,
+	}
+}
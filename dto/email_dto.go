@@ -0,0 +1,44 @@
+package dto
+
+import "analog-be/entity"
+
+type EmailSettingsResponse struct {
+	// 메일을 받을 주소. 연결된 계정에 주소가 없으면 비어 있음
+	Email string `json:"email"`
+	// 고를 수 있는 주소 (연결된 계정에 등록된 메일 주소)
+	Addresses         []string    `json:"addresses"`
+	Notifications     bool        `json:"notifications"`
+	Digest            bool        `json:"digest"`
+	DigestTopicIDs    []entity.ID `json:"digestTopicIds"`
+	DigestGenerations []uint16    `json:"digestGenerations"`
+}
+
+// EmailSettingsUpdateRequest 는 보낸 항목만 바꿉니다.
+type EmailSettingsUpdateRequest struct {
+	Email         *string `json:"email,omitempty" validate:"omitempty,email,max=320"`
+	Notifications *bool   `json:"notifications,omitempty"`
+	Digest        *bool   `json:"digest,omitempty"`
+	// 주간 소식에 담을 주제. 주제와 기수가 모두 비어 있으면 모든 새 로그를 담음
+	DigestTopicIDs *[]entity.ID `json:"digestTopicIds,omitempty" validate:"omitempty,max=50"`
+	// 주간 소식에 담을 기수
+	DigestGenerations *[]uint16 `json:"digestGenerations,omitempty" validate:"omitempty,max=50"`
+}
+
+// EmailUnsubscribeResponse 는 수신 거부 토큰으로 확인할 수 있는 현재 수신 상태입니다.
+type EmailUnsubscribeResponse struct {
+	// 받는 사람이 알아볼 수 있도록 일부를 가린 주소
+	Email         string `json:"email"`
+	Notifications bool   `json:"notifications"`
+	Digest        bool   `json:"digest"`
+}
+
+func NewEmailSettingsResponse(settings *entity.EmailSettings, addresses []string) EmailSettingsResponse {
+	return EmailSettingsResponse{
+		Email:             settings.Email,
+		Addresses:         addresses,
+		Notifications:     settings.Notifications,
+		Digest:            settings.Digest,
+		DigestTopicIDs:    settings.DigestTopicIDs,
+		DigestGenerations: settings.DigestGenerations,
+	}
+}
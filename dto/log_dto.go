@@ -40,22 +40,70 @@ type LogResponse struct {
 	Content     string          `json:"content"`
 	CreatedAt   time.Time       `json:"createdAt"`
 	LoggedBy    []UserResponse  `json:"loggedBy"`
+
+	CommentCount int    `json:"commentCount"`
+	CommentMode  string `json:"commentMode"`
+
+	Reactions ReactionsResponse `json:"reactions"`
 }
 
 type CommentCreateRequest struct {
 	Content string `json:"content" validate:"required,min=1,max=5000"`
+	// 답글을 달 댓글. 비우면 최상위 댓글
+	ParentID *entity.ID `json:"parentId"`
 }
 
 type CommentUpdateRequest struct {
 	Content string `json:"content" validate:"required,min=1,max=5000"`
 }
 
+type CommentModeUpdateRequest struct {
+	Mode string `json:"mode" validate:"required,oneof=open locked off"`
+}
+
 type CommentResponse struct {
 	ID        entity.ID    `json:"id"`
 	LogID     entity.ID    `json:"logId"`
 	Author    UserResponse `json:"author"`
 	Content   string       `json:"content"`
 	CreatedAt time.Time    `json:"createdAt"`
+
+	ParentID   entity.ID `json:"parentId,omitempty"`
+	Depth      int       `json:"depth"`
+	ReplyCount int       `json:"replyCount"`
+	// 답글이 남아 있어 자리만 남은 댓글이면 true. 작성자와 내용은 비어 있음
+	Deleted bool `json:"deleted"`
+
+	// 허용한 마크다운만 렌더링한 HTML. content는 고칠 때 쓰는 원문
+	RenderedContent string                   `json:"renderedContent"`
+	Mentions        []CommentMentionResponse `json:"mentions"`
+
+	// 로그 작성자가 숨겼으면 true. 로그 작성자와 댓글 작성자가 아니면 작성자와 내용이 비어 있음
+	Hidden bool `json:"hidden"`
+
+	// 고친 적 있으면 true. 고치기 전 내용은 작성자와 로그 작성자만 볼 수 있음
+	Edited    bool       `json:"edited"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+
+	Reactions ReactionsResponse `json:"reactions"`
+}
+
+// ReactionsResponse 는 반응 종류별 개수와 조회한 사용자가 단 반응입니다. 익명이면 mine은 비어 있습니다.
+type ReactionsResponse struct {
+	Counts map[string]int `json:"counts"`
+	Mine   []string       `json:"mine"`
+}
+
+type CommentRevisionResponse struct {
+	ID         entity.ID `json:"id"`
+	Content    string    `json:"content"`
+	CreatedAt  time.Time `json:"createdAt"`
+	ReplacedAt time.Time `json:"replacedAt"`
+}
+
+type CommentMentionResponse struct {
+	ID     entity.ID `json:"id"`
+	Handle string    `json:"handle"`
 }
 
 func NewLogResponse(l *entity.Log) LogResponse {
@@ -83,20 +131,73 @@ func NewLogResponse(l *entity.Log) LogResponse {
 		Content:     l.PreRendered,
 		CreatedAt:   l.CreatedAt,
 		LoggedBy:    loggedBy,
+
+		CommentCount: l.CommentCount,
+		CommentMode:  l.CommentMode,
+
+		Reactions: NewReactionsResponse(l.ReactionCounts, l.MyReactions),
 	}
 }
 
 func NewCommentResponse(c *entity.Comment) CommentResponse {
 	var author UserResponse
-	if c.Author != nil {
+	if c.Author != nil && !c.Deleted() {
 		author = NewUserResponse(c.Author)
 	}
 
+	content, rendered := c.Content, c.PreRendered
+	if c.Deleted() {
+		content, rendered = "", ""
+	}
+
+	var updatedAt *time.Time
+	if c.Edited() {
+		updatedAt = &c.UpdatedAt
+	}
+
+	mentions := make([]CommentMentionResponse, 0, len(c.Mentions))
+	for _, user := range c.Mentions {
+		mentions = append(mentions, CommentMentionResponse{ID: user.ID, Handle: user.Handle})
+	}
+
 	return CommentResponse{
-		ID:        c.ID,
-		LogID:     c.LogID,
-		Author:    author,
-		Content:   c.Content,
-		CreatedAt: c.CreatedAt,
+		ID:         c.ID,
+		LogID:      c.LogID,
+		Author:     author,
+		Content:    content,
+		CreatedAt:  c.CreatedAt,
+		ParentID:   c.ParentID,
+		Depth:      c.Depth,
+		ReplyCount: c.ReplyCount,
+		Deleted:    c.Deleted(),
+
+		RenderedContent: rendered,
+		Mentions:        mentions,
+
+		Hidden: c.Hidden(),
+
+		Edited:    c.Edited(),
+		UpdatedAt: updatedAt,
+
+		Reactions: NewReactionsResponse(c.ReactionCounts, c.MyReactions),
+	}
+}
+
+func NewReactionsResponse(counts map[string]int, mine []string) ReactionsResponse {
+	if counts == nil {
+		counts = map[string]int{}
+	}
+	if mine == nil {
+		mine = []string{}
+	}
+	return ReactionsResponse{Counts: counts, Mine: mine}
+}
+
+func NewCommentRevisionResponse(r *entity.CommentRevision) CommentRevisionResponse {
+	return CommentRevisionResponse{
+		ID:         r.ID,
+		Content:    r.Content,
+		CreatedAt:  r.CreatedAt,
+		ReplacedAt: r.ReplacedAt,
 	}
 }
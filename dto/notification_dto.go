@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"analog-be/entity"
+	"time"
+)
+
+type NotificationResponse struct {
+	ID   entity.ID `json:"id"`
+	Type string    `json:"type"`
+	// 마지막으로 행동한 사람. 탈퇴했으면 비어 있음
+	Actor *UserResponse `json:"actor,omitempty"`
+	// 묶인 알림에서 행동한 사람 수. "actor 외 (actorCount-1)명"으로 보여 줄 수 있음
+	ActorCount int       `json:"actorCount"`
+	LogID      entity.ID `json:"logId,omitempty"`
+	LogTitle   string    `json:"logTitle,omitempty"`
+	CommentID  entity.ID `json:"commentId,omitempty"`
+	Read       bool      `json:"read"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+type NotificationUnreadCountResponse struct {
+	Count int `json:"count"`
+}
+
+type NotificationReadAllResponse struct {
+	Updated int `json:"updated"`
+}
+
+// NotificationPreferenceRequest 는 보낸 채널의 설정만 바꿉니다.
+type NotificationPreferenceRequest struct {
+	Type  string `json:"type" validate:"required,oneof=comment reply mention coauthor reaction"`
+	InApp *bool  `json:"inApp,omitempty"`
+	// 메일 알림을 켰을 때 이 종류를 메일로도 받을지
+	Email *bool `json:"email,omitempty"`
+}
+
+// NotificationPreferencesUpdateRequest 는 보낸 종류의 설정만 바꿉니다.
+type NotificationPreferencesUpdateRequest struct {
+	Preferences []NotificationPreferenceRequest `json:"preferences" validate:"required,max=20,dive"`
+}
+
+type NotificationPreferenceResponse struct {
+	Type  string `json:"type"`
+	InApp bool   `json:"inApp"`
+	Email bool   `json:"email"`
+}
+
+func NewNotificationResponse(n *entity.Notification) NotificationResponse {
+	var actor *UserResponse
+	if n.Actor != nil {
+		user := NewUserResponse(n.Actor)
+		actor = &user
+	}
+
+	var logTitle string
+	if n.Log != nil {
+		logTitle = n.Log.Title
+	}
+
+	return NotificationResponse{
+		ID:         n.ID,
+		Type:       n.Type,
+		Actor:      actor,
+		ActorCount: n.ActorCount,
+		LogID:      n.LogID,
+		LogTitle:   logTitle,
+		CommentID:  n.CommentID,
+		Read:       n.Read(),
+		CreatedAt:  n.CreatedAt,
+		UpdatedAt:  n.UpdatedAt,
+	}
+}
+
+func NewNotificationPreferenceResponse(p *entity.NotificationPreference) NotificationPreferenceResponse {
+	return NotificationPreferenceResponse{
+		Type:  p.Type,
+		InApp: p.InApp,
+		Email: p.Email,
+	}
+}
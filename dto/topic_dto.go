@@ -14,7 +14,8 @@ type TopicResponse struct {
 
 func NewTopicResponse(t *entity.Topic) TopicResponse {
 	return TopicResponse{
-		ID:   t.ID,
-		Name: t.Name,
+		ID:    t.ID,
+		Name:  t.Name,
+		Count: t.Count,
 	}
 }
@@ -14,8 +14,10 @@ type UserCreateRequest struct {
 	Connections  []string  `json:"connections"`
 }
 
+// UserUpdateRequest 의 handle을 바꾸면 이전 핸들은 새 핸들로 넘겨주는 데 쓰이고 다른 사용자가 가져갈 수 없습니다.
 type UserUpdateRequest struct {
 	Name         *string   `json:"name"`
+	Handle       *string   `json:"handle"`
 	ProfileImage *string   `json:"profileImage"`
 	PartOf       *string   `json:"partOf"`
 	Generation   *uint16   `json:"generation"`
@@ -25,6 +27,7 @@ type UserUpdateRequest struct {
 type UserResponse struct {
 	ID           entity.ID `json:"id"`
 	Name         string    `json:"name"`
+	Handle       string    `json:"handle"`
 	ProfileImage string    `json:"profileImage"`
 	JoinedAt     time.Time `json:"joinedAt"`
 	PartOf       string    `json:"partOf"`
@@ -43,6 +46,7 @@ func NewUserResponse(user *entity.User) UserResponse {
 	return UserResponse{
 		ID:           user.ID,
 		Name:         user.Name,
+		Handle:       user.Handle,
 		ProfileImage: user.ProfileImage,
 		JoinedAt:     user.JoinedAt,
 		PartOf:       user.PartOf,
@@ -50,3 +54,10 @@ func NewUserResponse(user *entity.User) UserResponse {
 		Connections:  user.Connections,
 	}
 }
+
+// HandleAvailabilityResponse 는 쓸 수 없으면 reason에 이유를 담습니다.
+type HandleAvailabilityResponse struct {
+	Handle    string `json:"handle"`
+	Available bool   `json:"available"`
+	Reason    string `json:"reason,omitempty"`
+}
@@ -0,0 +1,135 @@
+package e2e
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"analog-be/repository"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func createAccessToken(t *testing.T, sessionToken string, req dto.AccessTokenCreateRequest) dto.AccessTokenCreateResponse {
+	t.Helper()
+
+	res := doRequest(t, http.MethodPost, "/auth/tokens", req, sessionToken)
+	expectStatus(t, res, http.StatusCreated)
+	return decode[dto.AccessTokenCreateResponse](t, res)
+}
+
+func TestAccessTokenScopes(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "배포 봇")
+	created := createAccessToken(t, user.SessionToken, dto.AccessTokenCreateRequest{Name: "릴리스 노트", Scopes: []string{entity.ScopeLogsWrite}})
+	if !strings.HasPrefix(created.Token, entity.AccessTokenPrefix) || !strings.HasPrefix(created.Token, created.TokenPrefix) || created.ExpiresAt != nil {
+		t.Fatalf("unexpected token: %+v", created)
+	}
+
+	log := createLog(t, created.Token, dto.LogCreateRequest{Title: "릴리스 노트 1.0"})
+
+	// 권한이 없는 엔드포인트
+	expectStatus(t, doRequest(t, http.MethodPost, fmt.Sprintf("/logs/%d/comments", log.ID), dto.CommentCreateRequest{Content: "봇 댓글"}, created.Token), http.StatusForbidden)
+	expectStatus(t, doRequest(t, http.MethodGet, "/auth/me", nil, created.Token), http.StatusForbidden)
+
+	// 개인 액세스 토큰으로는 계정, 세션, 토큰을 관리할 수 없음
+	expectStatus(t, doRequest(t, http.MethodDelete, "/users", nil, created.Token), http.StatusForbidden)
+	expectStatus(t, doRequest(t, http.MethodGet, "/auth/sessions", nil, created.Token), http.StatusForbidden)
+	expectStatus(t, doRequest(t, http.MethodPost, "/auth/tokens", dto.AccessTokenCreateRequest{Name: "복제", Scopes: []string{entity.ScopeLogsWrite}}, created.Token), http.StatusForbidden)
+
+	res := doRequest(t, http.MethodGet, "/auth/tokens", nil, user.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if strings.Contains(string(res.Body), created.Token) {
+		t.Fatal("token value listed")
+	}
+	tokens := decode[[]dto.AccessTokenResponse](t, res)
+	if len(tokens) != 1 || tokens[0].ID != created.ID || tokens[0].LastUsedAt == nil {
+		t.Fatalf("unexpected tokens: %+v", tokens)
+	}
+}
+
+func TestAccessTokenRevokeAndExpiry(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "CI")
+	stranger := signup(t, "남의 토큰")
+
+	revoked := createAccessToken(t, user.SessionToken, dto.AccessTokenCreateRequest{Name: "지울 토큰", Scopes: []string{entity.ScopeUserRead}})
+	expectStatus(t, doRequest(t, http.MethodGet, "/auth/me", nil, revoked.Token), http.StatusOK)
+
+	tokenPath := fmt.Sprintf("/auth/tokens/%d", revoked.ID)
+	expectStatus(t, doRequest(t, http.MethodDelete, tokenPath, nil, stranger.SessionToken), http.StatusNotFound)
+	expectStatus(t, doRequest(t, http.MethodDelete, tokenPath, nil, user.SessionToken), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodGet, "/auth/me", nil, revoked.Token), http.StatusUnauthorized)
+
+	expiring := createAccessToken(t, user.SessionToken, dto.AccessTokenCreateRequest{Name: "만료될 토큰", Scopes: []string{entity.ScopeUserRead}, ExpiresInDays: 7})
+	if expiring.ExpiresAt == nil || expiring.ExpiresAt.Before(time.Now().Add(6*24*time.Hour)) {
+		t.Fatalf("expiresAt = %v", expiring.ExpiresAt)
+	}
+
+	execSQL(t, "UPDATE personal_access_tokens SET expires_at = ? WHERE id = ?", time.Now().UTC().Add(-time.Minute), expiring.ID)
+	expectStatus(t, doRequest(t, http.MethodGet, "/auth/me", nil, expiring.Token), http.StatusUnauthorized)
+}
+
+func TestAccessTokenValidation(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "검증 토큰")
+
+	for _, req := range []dto.AccessTokenCreateRequest{
+		{Scopes: []string{entity.ScopeLogsWrite}},
+		{Name: "권한 없음"},
+		{Name: "없는 권한", Scopes: []string{"admin"}},
+		{Name: "너무 긴 만료", Scopes: []string{entity.ScopeLogsWrite}, ExpiresInDays: 1000},
+	} {
+		expectStatus(t, doRequest(t, http.MethodPost, "/auth/tokens", req, user.SessionToken), http.StatusBadRequest)
+	}
+}
+
+func TestAccessTokenLimitUnderConcurrentCreates(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "동시 토큰")
+
+	// 사용자 행을 잠근 뒤에 토큰 수를 세므로 동시에 만들어도 한도를 넘지 않음
+	const maxTokens = 3
+	repo := repository.NewAccessTokenRepository(testDB)
+	errs := make([]error, 8)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Go(func() {
+			token := &entity.PersonalAccessToken{
+				UserID:      user.User.ID,
+				Name:        fmt.Sprintf("동시 토큰 %d", i),
+				Token:       fmt.Sprintf("%sconcurrent-%d-%d", entity.AccessTokenPrefix, user.User.ID, i),
+				TokenPrefix: entity.AccessTokenPrefix,
+				Scopes:      []string{entity.ScopeLogsWrite},
+				CreatedAt:   time.Now().UTC(),
+			}
+			errs[i] = repo.Create(context.Background(), token, maxTokens)
+		})
+	}
+	wg.Wait()
+
+	created := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, repository.ErrAccessTokenLimit):
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	if created != maxTokens {
+		t.Fatalf("created = %d, want %d", created, maxTokens)
+	}
+
+	if n := countRows(t, "SELECT count(*) FROM personal_access_tokens WHERE user_id = ?", user.User.ID); n != maxTokens {
+		t.Fatalf("tokens = %d, want %d", n, maxTokens)
+	}
+}
@@ -0,0 +1,222 @@
+package e2e
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"analog-be/repository"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBookmarks(t *testing.T) {
+	requireDB(t)
+
+	author := signup(t, "저장될글쓴이")
+	reader := signup(t, "저장하는이")
+
+	first := createLog(t, author.SessionToken, dto.LogCreateRequest{Title: "먼저 저장"})
+	second := createLog(t, author.SessionToken, dto.LogCreateRequest{Title: "나중에 저장"})
+
+	expectStatus(t, doRequest(t, http.MethodGet, "/bookmarks", nil, ""), http.StatusUnauthorized)
+	expectStatus(t, doRequest(t, http.MethodPut, fmt.Sprintf("/logs/%d/bookmark", second.ID+100000), nil, reader.SessionToken), http.StatusNotFound)
+
+	for _, log := range []dto.LogResponse{first, second, first} {
+		expectStatus(t, doRequest(t, http.MethodPut, fmt.Sprintf("/logs/%d/bookmark", log.ID), nil, reader.SessionToken), http.StatusOK)
+	}
+
+	res := doRequest(t, http.MethodGet, "/bookmarks", nil, reader.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	list := decode[dto.PaginatedResult[dto.BookmarkResponse]](t, res)
+	if list.Total != 2 || list.Items[0].Log.ID != second.ID || list.Items[1].Log.ID != first.ID {
+		t.Fatalf("bookmarks = %+v", list)
+	}
+
+	expectStatus(t, doRequest(t, http.MethodDelete, fmt.Sprintf("/logs/%d/bookmark", second.ID), nil, reader.SessionToken), http.StatusOK)
+
+	// 로그가 지워지면 저장도 사라짐
+	expectStatus(t, doRequest(t, http.MethodDelete, fmt.Sprintf("/logs/%d", first.ID), nil, author.SessionToken), http.StatusOK)
+	res = doRequest(t, http.MethodGet, "/bookmarks", nil, reader.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if list := decode[dto.PaginatedResult[dto.BookmarkResponse]](t, res); list.Total != 0 {
+		t.Fatalf("bookmarks after delete = %+v", list)
+	}
+}
+
+func TestReadingLists(t *testing.T) {
+	requireDB(t)
+
+	owner := signup(t, "목록주인")
+	stranger := signup(t, "목록구경꾼")
+
+	logs := make([]dto.LogResponse, 3)
+	for i := range logs {
+		logs[i] = createLog(t, owner.SessionToken, dto.LogCreateRequest{Title: fmt.Sprintf("목록 로그 %d", i)})
+	}
+
+	res := doRequest(t, http.MethodPost, "/reading-lists", dto.ReadingListCreateRequest{Name: "면접 준비"}, owner.SessionToken)
+	expectStatus(t, res, http.StatusCreated)
+	private := decode[dto.ReadingListResponse](t, res)
+	expectStatus(t, doRequest(t, http.MethodPost, "/reading-lists", dto.ReadingListCreateRequest{Name: "면접 준비"}, owner.SessionToken), http.StatusConflict)
+
+	res = doRequest(t, http.MethodPost, "/reading-lists", dto.ReadingListCreateRequest{Name: "추천", Public: true}, owner.SessionToken)
+	expectStatus(t, res, http.StatusCreated)
+	shared := decode[dto.ReadingListResponse](t, res)
+	if private.Position != 0 || shared.Position != 1 {
+		t.Fatalf("positions = %d, %d", private.Position, shared.Position)
+	}
+
+	// 담은 순서대로 쌓이고, position으로 옮길 수 있음
+	itemPath := func(list dto.ReadingListResponse, log dto.LogResponse) string {
+		return fmt.Sprintf("/reading-lists/%d/items/%d", list.ID, log.ID)
+	}
+	for _, log := range logs {
+		expectStatus(t, doRequest(t, http.MethodPut, itemPath(shared, log), dto.ReadingListItemRequest{}, owner.SessionToken), http.StatusOK)
+	}
+	first := 0
+	res = doRequest(t, http.MethodPut, itemPath(shared, logs[2]), dto.ReadingListItemRequest{Position: &first}, owner.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if item := decode[dto.ReadingListItemResponse](t, res); item.Position != 0 {
+		t.Fatalf("moved item position = %d", item.Position)
+	}
+
+	itemIDs := func(list dto.ReadingListResponse, token string) []entity.ID {
+		t.Helper()
+		res := doRequest(t, http.MethodGet, fmt.Sprintf("/reading-lists/%d/items", list.ID), nil, token)
+		expectStatus(t, res, http.StatusOK)
+		var ids []entity.ID
+		for _, item := range decode[dto.PaginatedResult[dto.ReadingListItemResponse]](t, res).Items {
+			ids = append(ids, item.Log.ID)
+		}
+		return ids
+	}
+	if got, want := itemIDs(shared, ""), []entity.ID{logs[2].ID, logs[0].ID, logs[1].ID}; !slices.Equal(got, want) {
+		t.Fatalf("items = %v, want %v", got, want)
+	}
+
+	// 목록에 담으면 저장도 됨
+	res = doRequest(t, http.MethodGet, "/bookmarks", nil, owner.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if list := decode[dto.PaginatedResult[dto.BookmarkResponse]](t, res); list.Total != 3 {
+		t.Fatalf("bookmarks = %d", list.Total)
+	}
+
+	// 비공개 목록은 다른 사람에게 없는 목록, 공개 목록은 볼 수만 있음
+	expectStatus(t, doRequest(t, http.MethodGet, fmt.Sprintf("/reading-lists/%d", private.ID), nil, stranger.SessionToken), http.StatusNotFound)
+	expectStatus(t, doRequest(t, http.MethodGet, fmt.Sprintf("/reading-lists/%d/items", private.ID), nil, ""), http.StatusNotFound)
+	expectStatus(t, doRequest(t, http.MethodGet, fmt.Sprintf("/reading-lists/%d", shared.ID), nil, ""), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodPut, itemPath(shared, logs[0]), dto.ReadingListItemRequest{}, stranger.SessionToken), http.StatusForbidden)
+
+	res = doRequest(t, http.MethodGet, fmt.Sprintf("/reading-lists?userId=%d", owner.User.ID), nil, stranger.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if lists := decode[[]dto.ReadingListResponse](t, res); len(lists) != 1 || lists[0].ID != shared.ID || lists[0].ItemCount != 3 {
+		t.Fatalf("public lists = %+v", lists)
+	}
+
+	// 목록 순서 바꾸기
+	res = doRequest(t, http.MethodPut, fmt.Sprintf("/reading-lists/%d", shared.ID), dto.ReadingListUpdateRequest{Position: &first}, owner.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	res = doRequest(t, http.MethodGet, "/reading-lists", nil, owner.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if lists := decode[[]dto.ReadingListResponse](t, res); len(lists) != 2 || lists[0].ID != shared.ID || lists[1].Position != 1 {
+		t.Fatalf("my lists = %+v", lists)
+	}
+
+	// 저장을 취소하거나 로그가 지워지면 목록에서도 빠짐
+	expectStatus(t, doRequest(t, http.MethodDelete, fmt.Sprintf("/logs/%d/bookmark", logs[0].ID), nil, owner.SessionToken), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodDelete, fmt.Sprintf("/logs/%d", logs[2].ID), nil, owner.SessionToken), http.StatusOK)
+	if got, want := itemIDs(shared, owner.SessionToken), []entity.ID{logs[1].ID}; !slices.Equal(got, want) {
+		t.Fatalf("items after cleanup = %v, want %v", got, want)
+	}
+
+	expectStatus(t, doRequest(t, http.MethodDelete, fmt.Sprintf("/reading-lists/%d", private.ID), nil, stranger.SessionToken), http.StatusNotFound)
+	expectStatus(t, doRequest(t, http.MethodDelete, fmt.Sprintf("/reading-lists/%d", private.ID), nil, owner.SessionToken), http.StatusOK)
+}
+
+func TestReadingListLimitUnderConcurrentPuts(t *testing.T) {
+	requireDB(t)
+
+	owner := signup(t, "동시에담는이")
+	res := doRequest(t, http.MethodPost, "/reading-lists", dto.ReadingListCreateRequest{Name: "한도"}, owner.SessionToken)
+	expectStatus(t, res, http.StatusCreated)
+	created := decode[dto.ReadingListResponse](t, res)
+
+	logs := make([]dto.LogResponse, 8)
+	for i := range logs {
+		logs[i] = createLog(t, owner.SessionToken, dto.LogCreateRequest{Title: fmt.Sprintf("한도 로그 %d", i)})
+	}
+
+	// 목록 행을 잠근 뒤에 개수를 세므로 동시에 담아도 한도를 넘지 않음
+	const maxItems = 3
+	repo := repository.NewReadingListRepository(testDB)
+	list := &entity.ReadingList{ID: created.ID, UserID: owner.User.ID}
+	errs := make([]error, len(logs))
+	var wg sync.WaitGroup
+	for i, log := range logs {
+		wg.Go(func() {
+			_, errs[i] = repo.PutItem(context.Background(), list, &log.ID, nil, maxItems)
+		})
+	}
+	wg.Wait()
+
+	added := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			added++
+		case !errors.Is(err, repository.ErrReadingListFull):
+			t.Fatalf("PutItem: %v", err)
+		}
+	}
+	if added != maxItems {
+		t.Fatalf("added = %d, want %d", added, maxItems)
+	}
+
+	count, err := testDB.NewSelect().Model((*entity.ReadingListItem)(nil)).Where("list_id = ?", created.ID).Count(context.Background())
+	if err != nil || count != maxItems {
+		t.Fatalf("items = %d, %v", count, err)
+	}
+}
+
+func TestReadingListCountLimitUnderConcurrentCreates(t *testing.T) {
+	requireDB(t)
+
+	owner := signup(t, "동시에만드는이")
+
+	// 사용자 행을 잠근 뒤에 목록 수를 세므로 동시에 만들어도 한도를 넘지 않음
+	const maxLists = 3
+	repo := repository.NewReadingListRepository(testDB)
+	errs := make([]error, 8)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Go(func() {
+			now := time.Now().UTC()
+			list := &entity.ReadingList{UserID: owner.User.ID, Name: fmt.Sprintf("동시 목록 %d", i), CreatedAt: now, UpdatedAt: now}
+			errs[i] = repo.Create(context.Background(), list, maxLists)
+		})
+	}
+	wg.Wait()
+
+	created := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, repository.ErrReadingListLimit):
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	if created != maxLists {
+		t.Fatalf("created = %d, want %d", created, maxLists)
+	}
+
+	count, err := testDB.NewSelect().Model((*entity.ReadingList)(nil)).Where("user_id = ?", owner.User.ID).Count(context.Background())
+	if err != nil || count != maxLists {
+		t.Fatalf("lists = %d, %v", count, err)
+	}
+}
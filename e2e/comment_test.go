@@ -0,0 +1,366 @@
+package e2e
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCommentLifecycle(t *testing.T) {
+	requireDB(t)
+
+	author := signup(t, "글쓴이")
+	commenter := signup(t, "댓글러")
+
+	created := createLog(t, author.SessionToken, dto.LogCreateRequest{Title: "댓글 달릴 로그"})
+	commentsPath := fmt.Sprintf("/logs/%d/comments", created.ID)
+
+	res := doRequest(t, http.MethodPost, commentsPath, dto.CommentCreateRequest{Content: "좋은 글"}, commenter.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	comment := decode[dto.CommentResponse](t, res)
+	if comment.LogID != created.ID || comment.Content != "좋은 글" {
+		t.Fatalf("unexpected comment: %+v", comment)
+	}
+
+	res = doRequest(t, http.MethodGet, commentsPath, nil, "")
+	expectStatus(t, res, http.StatusOK)
+	if list := decode[dto.PaginatedResult[dto.CommentResponse]](t, res); list.Total != 1 || list.Items[0].ID != comment.ID {
+		t.Fatalf("unexpected comments: %+v", list)
+	}
+
+	commentPath := fmt.Sprintf("%s/%d", commentsPath, comment.ID)
+
+	// 댓글은 작성자만 고칠 수 있음
+	expectStatus(t, doRequest(t, http.MethodPut, commentPath, dto.CommentUpdateRequest{Content: "남의 댓글"}, author.SessionToken), http.StatusForbidden)
+
+	res = doRequest(t, http.MethodPut, commentPath, dto.CommentUpdateRequest{Content: "고친 댓글"}, commenter.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if updated := decode[dto.CommentResponse](t, res); updated.Content != "고친 댓글" {
+		t.Fatalf("content = %q", updated.Content)
+	}
+
+	// 로그 작성자가 아닌 다른 사람은 지울 수 없음
+	stranger := signup(t, "지나가는 사람")
+	expectStatus(t, doRequest(t, http.MethodDelete, commentPath, nil, stranger.SessionToken), http.StatusForbidden)
+	expectStatus(t, doRequest(t, http.MethodDelete, commentPath, nil, commenter.SessionToken), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodDelete, commentPath, nil, commenter.SessionToken), http.StatusNotFound)
+	expectStatus(t, doRequest(t, http.MethodPut, commentPath, dto.CommentUpdateRequest{Content: "없는 댓글"}, commenter.SessionToken), http.StatusNotFound)
+}
+
+func TestCommentOnOtherLogIsRejected(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "엇갈림")
+	first := createLog(t, user.SessionToken, dto.LogCreateRequest{Title: "첫째"})
+	second := createLog(t, user.SessionToken, dto.LogCreateRequest{Title: "둘째"})
+
+	res := doRequest(t, http.MethodPost, fmt.Sprintf("/logs/%d/comments", first.ID), dto.CommentCreateRequest{Content: "첫째에 단 댓글"}, user.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	comment := decode[dto.CommentResponse](t, res)
+
+	wrongPath := fmt.Sprintf("/logs/%d/comments/%d", second.ID, comment.ID)
+	expectStatus(t, doRequest(t, http.MethodPut, wrongPath, dto.CommentUpdateRequest{Content: "엉뚱한 로그"}, user.SessionToken), http.StatusBadRequest)
+	expectStatus(t, doRequest(t, http.MethodDelete, wrongPath, nil, user.SessionToken), http.StatusBadRequest)
+}
+
+func TestCommentValidation(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "빈 댓글")
+	created := createLog(t, user.SessionToken, dto.LogCreateRequest{Title: "검증 로그"})
+
+	expectStatus(t, doRequest(t, http.MethodPost, fmt.Sprintf("/logs/%d/comments", created.ID), dto.CommentCreateRequest{}, user.SessionToken), http.StatusBadRequest)
+	expectStatus(t, doRequest(t, http.MethodPost, "/logs/999999999/comments", dto.CommentCreateRequest{Content: "없는 로그"}, user.SessionToken), http.StatusNotFound)
+	expectStatus(t, doRequest(t, http.MethodGet, "/logs/999999999/comments", nil, ""), http.StatusNotFound)
+}
+
+func TestDeletingLogRemovesComments(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "정리")
+	created := createLog(t, user.SessionToken, dto.LogCreateRequest{Title: "지울 로그"})
+	commentsPath := fmt.Sprintf("/logs/%d/comments", created.ID)
+
+	expectStatus(t, doRequest(t, http.MethodPost, commentsPath, dto.CommentCreateRequest{Content: "곧 사라짐"}, user.SessionToken), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodDelete, fmt.Sprintf("/logs/%d", created.ID), nil, user.SessionToken), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodGet, commentsPath, nil, ""), http.StatusNotFound)
+}
+
+func postComment(t *testing.T, logID entity.ID, token string, req dto.CommentCreateRequest) dto.CommentResponse {
+	t.Helper()
+
+	res := doRequest(t, http.MethodPost, fmt.Sprintf("/logs/%d/comments", logID), req, token)
+	expectStatus(t, res, http.StatusOK)
+	return decode[dto.CommentResponse](t, res)
+}
+
+func TestCommentReplies(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "답글러")
+	created := createLog(t, user.SessionToken, dto.LogCreateRequest{Title: "스레드 로그"})
+	commentsPath := fmt.Sprintf("/logs/%d/comments", created.ID)
+
+	root := postComment(t, created.ID, user.SessionToken, dto.CommentCreateRequest{Content: "뿌리"})
+	reply := postComment(t, created.ID, user.SessionToken, dto.CommentCreateRequest{Content: "답글", ParentID: &root.ID})
+	if reply.ParentID != root.ID || reply.Depth != 1 {
+		t.Fatalf("unexpected reply: %+v", reply)
+	}
+	nested := postComment(t, created.ID, user.SessionToken, dto.CommentCreateRequest{Content: "답글의 답글", ParentID: &reply.ID})
+	if nested.Depth != 2 {
+		t.Fatalf("depth = %d", nested.Depth)
+	}
+
+	// COMMENT_MAX_DEPTH=2 보다 깊게는 달 수 없음
+	expectStatus(t, doRequest(t, http.MethodPost, commentsPath, dto.CommentCreateRequest{Content: "너무 깊음", ParentID: &nested.ID}, user.SessionToken), http.StatusBadRequest)
+
+	other := createLog(t, user.SessionToken, dto.LogCreateRequest{Title: "다른 로그"})
+	expectStatus(t, doRequest(t, http.MethodPost, fmt.Sprintf("/logs/%d/comments", other.ID), dto.CommentCreateRequest{Content: "엉뚱한 부모", ParentID: &root.ID}, user.SessionToken), http.StatusBadRequest)
+
+	res := doRequest(t, http.MethodGet, commentsPath, nil, "")
+	expectStatus(t, res, http.StatusOK)
+	list := decode[dto.PaginatedResult[dto.CommentResponse]](t, res)
+	if list.Total != 1 || list.Items[0].ID != root.ID || list.Items[0].ReplyCount != 1 {
+		t.Fatalf("unexpected top-level comments: %+v", list)
+	}
+
+	res = doRequest(t, http.MethodGet, fmt.Sprintf("%s/%d/replies", commentsPath, root.ID), nil, "")
+	expectStatus(t, res, http.StatusOK)
+	replies := decode[dto.PaginatedResult[dto.CommentResponse]](t, res)
+	if replies.Total != 1 || replies.Items[0].ID != reply.ID || replies.Items[0].ReplyCount != 1 {
+		t.Fatalf("unexpected replies: %+v", replies)
+	}
+
+	expectStatus(t, doRequest(t, http.MethodGet, fmt.Sprintf("/logs/%d/comments/%d/replies", other.ID, root.ID), nil, ""), http.StatusBadRequest)
+}
+
+func TestDeletingCommentWithRepliesLeavesPlaceholder(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "자리표시")
+	created := createLog(t, user.SessionToken, dto.LogCreateRequest{Title: "지워진 부모"})
+	commentsPath := fmt.Sprintf("/logs/%d/comments", created.ID)
+
+	root := postComment(t, created.ID, user.SessionToken, dto.CommentCreateRequest{Content: "지울 부모"})
+	reply := postComment(t, created.ID, user.SessionToken, dto.CommentCreateRequest{Content: "남을 답글", ParentID: &root.ID})
+
+	rootPath := fmt.Sprintf("%s/%d", commentsPath, root.ID)
+	expectStatus(t, doRequest(t, http.MethodDelete, rootPath, nil, user.SessionToken), http.StatusOK)
+
+	res := doRequest(t, http.MethodGet, commentsPath, nil, "")
+	expectStatus(t, res, http.StatusOK)
+	list := decode[dto.PaginatedResult[dto.CommentResponse]](t, res)
+	if list.Total != 1 || !list.Items[0].Deleted || list.Items[0].Content != "" || list.Items[0].Author.ID != 0 {
+		t.Fatalf("expected placeholder, got %+v", list)
+	}
+
+	// 지워진 자리는 고치거나 다시 지울 수 없고, 답글도 달 수 없음
+	expectStatus(t, doRequest(t, http.MethodPut, rootPath, dto.CommentUpdateRequest{Content: "되살리기"}, user.SessionToken), http.StatusNotFound)
+	expectStatus(t, doRequest(t, http.MethodDelete, rootPath, nil, user.SessionToken), http.StatusNotFound)
+	expectStatus(t, doRequest(t, http.MethodPost, commentsPath, dto.CommentCreateRequest{Content: "늦은 답글", ParentID: &root.ID}, user.SessionToken), http.StatusBadRequest)
+
+	// 마지막 답글을 지우면 자리도 사라짐
+	expectStatus(t, doRequest(t, http.MethodDelete, fmt.Sprintf("%s/%d", commentsPath, reply.ID), nil, user.SessionToken), http.StatusOK)
+
+	res = doRequest(t, http.MethodGet, commentsPath, nil, "")
+	expectStatus(t, res, http.StatusOK)
+	if list := decode[dto.PaginatedResult[dto.CommentResponse]](t, res); list.Total != 0 {
+		t.Fatalf("expected no comments, got %+v", list)
+	}
+}
+
+func TestCommentPaginationAndSort(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "페이지")
+	created := createLog(t, user.SessionToken, dto.LogCreateRequest{Title: "댓글 많은 로그"})
+	commentsPath := fmt.Sprintf("/logs/%d/comments", created.ID)
+
+	var comments []dto.CommentResponse
+	for i := range 3 {
+		comments = append(comments, postComment(t, created.ID, user.SessionToken, dto.CommentCreateRequest{Content: fmt.Sprintf("댓글 %d", i)}))
+	}
+	postComment(t, created.ID, user.SessionToken, dto.CommentCreateRequest{Content: "답글", ParentID: &comments[0].ID})
+
+	res := doRequest(t, http.MethodGet, commentsPath+"?size=2", nil, "")
+	expectStatus(t, res, http.StatusOK)
+	page := decode[dto.PaginatedResult[dto.CommentResponse]](t, res)
+	if page.Total != 3 || len(page.Items) != 2 || page.Items[0].ID != comments[0].ID || page.Items[1].ID != comments[1].ID {
+		t.Fatalf("unexpected first page: %+v", page)
+	}
+	if page.Items[0].Author.ID != user.User.ID {
+		t.Fatalf("author not loaded: %+v", page.Items[0].Author)
+	}
+
+	res = doRequest(t, http.MethodGet, commentsPath+"?size=2&page=2", nil, "")
+	expectStatus(t, res, http.StatusOK)
+	if page := decode[dto.PaginatedResult[dto.CommentResponse]](t, res); len(page.Items) != 1 || page.Items[0].ID != comments[2].ID {
+		t.Fatalf("unexpected second page: %+v", page)
+	}
+
+	res = doRequest(t, http.MethodGet, commentsPath+"?sort=newest", nil, "")
+	expectStatus(t, res, http.StatusOK)
+	if page := decode[dto.PaginatedResult[dto.CommentResponse]](t, res); page.Items[0].ID != comments[2].ID {
+		t.Fatalf("unexpected newest first: %+v", page)
+	}
+
+	expectStatus(t, doRequest(t, http.MethodGet, commentsPath+"?sort=random", nil, ""), http.StatusBadRequest)
+
+	// 로그 응답의 댓글 수에는 답글도 포함
+	res = doRequest(t, http.MethodGet, fmt.Sprintf("/logs/%d", created.ID), nil, "")
+	expectStatus(t, res, http.StatusOK)
+	if got := decode[dto.LogResponse](t, res); got.CommentCount != 4 {
+		t.Fatalf("comment count = %d", got.CommentCount)
+	}
+
+	expectStatus(t, doRequest(t, http.MethodDelete, fmt.Sprintf("%s/%d", commentsPath, comments[0].ID), nil, user.SessionToken), http.StatusOK)
+	res = doRequest(t, http.MethodGet, fmt.Sprintf("/logs/%d", created.ID), nil, "")
+	expectStatus(t, res, http.StatusOK)
+	if got := decode[dto.LogResponse](t, res); got.CommentCount != 3 {
+		t.Fatalf("comment count after delete = %d", got.CommentCount)
+	}
+}
+
+func TestCommentMarkdownAndMentions(t *testing.T) {
+	requireDB(t)
+
+	author := signup(t, "멘션하는")
+	mentioned := signup(t, "멘션된")
+	created := createLog(t, author.SessionToken, dto.LogCreateRequest{Title: "멘션 로그"})
+
+	content := fmt.Sprintf("**고마워요** @%s, 그리고 @nobody-here <script>", mentioned.User.Handle)
+	comment := postComment(t, created.ID, author.SessionToken, dto.CommentCreateRequest{Content: content})
+	if comment.Content != content {
+		t.Fatalf("raw content changed: %q", comment.Content)
+	}
+	if !strings.Contains(comment.RenderedContent, "<strong>고마워요</strong>") || strings.Contains(comment.RenderedContent, "<script>") {
+		t.Fatalf("unexpected rendering: %q", comment.RenderedContent)
+	}
+	if !strings.Contains(comment.RenderedContent, `class="mention">@`+mentioned.User.Handle+"</a>") {
+		t.Fatalf("mention not linked: %q", comment.RenderedContent)
+	}
+	if len(comment.Mentions) != 1 || comment.Mentions[0].ID != mentioned.User.ID {
+		t.Fatalf("unexpected mentions: %+v", comment.Mentions)
+	}
+
+	// 고치면 멘션 목록도 바뀜
+	res := doRequest(t, http.MethodPut, fmt.Sprintf("/logs/%d/comments/%d", created.ID, comment.ID), dto.CommentUpdateRequest{Content: "멘션 없음"}, author.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if updated := decode[dto.CommentResponse](t, res); len(updated.Mentions) != 0 || updated.RenderedContent != "<p>멘션 없음</p>\n" {
+		t.Fatalf("unexpected update: %+v", updated)
+	}
+}
+
+func TestCommentEditHistory(t *testing.T) {
+	requireDB(t)
+
+	logAuthor := signup(t, "기록 로그 주인")
+	commenter := signup(t, "고치는 사람")
+	stranger := signup(t, "구경꾼")
+	created := createLog(t, logAuthor.SessionToken, dto.LogCreateRequest{Title: "고친 기록"})
+
+	comment := postComment(t, created.ID, commenter.SessionToken, dto.CommentCreateRequest{Content: "처음"})
+	if comment.Edited || comment.UpdatedAt != nil {
+		t.Fatalf("new comment marked edited: %+v", comment)
+	}
+
+	commentPath := fmt.Sprintf("/logs/%d/comments/%d", created.ID, comment.ID)
+	for _, content := range []string{"두 번째", "세 번째", "세 번째"} {
+		expectStatus(t, doRequest(t, http.MethodPut, commentPath, dto.CommentUpdateRequest{Content: content}, commenter.SessionToken), http.StatusOK)
+	}
+
+	res := doRequest(t, http.MethodGet, fmt.Sprintf("/logs/%d/comments", created.ID), nil, "")
+	expectStatus(t, res, http.StatusOK)
+	if list := decode[dto.PaginatedResult[dto.CommentResponse]](t, res); !list.Items[0].Edited || list.Items[0].UpdatedAt == nil {
+		t.Fatalf("comment not marked edited: %+v", list.Items[0])
+	}
+
+	// 같은 내용으로 고친 것은 기록하지 않음
+	for _, token := range []string{commenter.SessionToken, logAuthor.SessionToken} {
+		res = doRequest(t, http.MethodGet, commentPath+"/revisions", nil, token)
+		expectStatus(t, res, http.StatusOK)
+		revisions := decode[[]dto.CommentRevisionResponse](t, res)
+		if len(revisions) != 2 || revisions[0].Content != "두 번째" || revisions[1].Content != "처음" {
+			t.Fatalf("unexpected revisions: %+v", revisions)
+		}
+	}
+
+	expectStatus(t, doRequest(t, http.MethodGet, commentPath+"/revisions", nil, stranger.SessionToken), http.StatusForbidden)
+	expectStatus(t, doRequest(t, http.MethodGet, commentPath+"/revisions", nil, ""), http.StatusUnauthorized)
+}
+
+func TestLogAuthorsModerateComments(t *testing.T) {
+	requireDB(t)
+
+	owner := signup(t, "모더레이터")
+	commenter := signup(t, "숨겨질 사람")
+	reader := signup(t, "읽는 사람")
+	created := createLog(t, owner.SessionToken, dto.LogCreateRequest{Title: "관리되는 로그"})
+	commentsPath := fmt.Sprintf("/logs/%d/comments", created.ID)
+
+	comment := postComment(t, created.ID, commenter.SessionToken, dto.CommentCreateRequest{Content: "숨겨질 댓글"})
+	hiddenPath := fmt.Sprintf("%s/%d/hidden", commentsPath, comment.ID)
+
+	expectStatus(t, doRequest(t, http.MethodPut, hiddenPath, nil, commenter.SessionToken), http.StatusForbidden)
+	res := doRequest(t, http.MethodPut, hiddenPath, nil, owner.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if hidden := decode[dto.CommentResponse](t, res); !hidden.Hidden {
+		t.Fatalf("comment not hidden: %+v", hidden)
+	}
+
+	listFor := func(token string) dto.CommentResponse {
+		t.Helper()
+		res := doRequest(t, http.MethodGet, commentsPath, nil, token)
+		expectStatus(t, res, http.StatusOK)
+		list := decode[dto.PaginatedResult[dto.CommentResponse]](t, res)
+		if len(list.Items) != 1 {
+			t.Fatalf("unexpected comments: %+v", list)
+		}
+		return list.Items[0]
+	}
+
+	// 다른 사람에게는 숨김 표시만, 로그 작성자에게는 내용까지 보임
+	for _, token := range []string{"", reader.SessionToken} {
+		if got := listFor(token); !got.Hidden || got.Content != "" || got.Author.ID != 0 {
+			t.Fatalf("hidden comment leaked: %+v", got)
+		}
+	}
+	if got := listFor(owner.SessionToken); !got.Hidden || got.Content != "숨겨질 댓글" {
+		t.Fatalf("moderator cannot see hidden comment: %+v", got)
+	}
+
+	expectStatus(t, doRequest(t, http.MethodDelete, hiddenPath, nil, owner.SessionToken), http.StatusOK)
+	if got := listFor(reader.SessionToken); got.Hidden || got.Content != "숨겨질 댓글" {
+		t.Fatalf("comment still hidden: %+v", got)
+	}
+
+	// 잠그면 로그 작성자만 쓸 수 있음
+	modePath := fmt.Sprintf("/logs/%d/comment-mode", created.ID)
+	expectStatus(t, doRequest(t, http.MethodPut, modePath, dto.CommentModeUpdateRequest{Mode: "locked"}, reader.SessionToken), http.StatusForbidden)
+	expectStatus(t, doRequest(t, http.MethodPut, modePath, dto.CommentModeUpdateRequest{Mode: "closed"}, owner.SessionToken), http.StatusBadRequest)
+	res = doRequest(t, http.MethodPut, modePath, dto.CommentModeUpdateRequest{Mode: "locked"}, owner.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if got := decode[dto.LogResponse](t, res); got.CommentMode != "locked" {
+		t.Fatalf("comment mode = %q", got.CommentMode)
+	}
+
+	expectStatus(t, doRequest(t, http.MethodPost, commentsPath, dto.CommentCreateRequest{Content: "잠김"}, reader.SessionToken), http.StatusForbidden)
+	expectStatus(t, doRequest(t, http.MethodPut, fmt.Sprintf("%s/%d", commentsPath, comment.ID), dto.CommentUpdateRequest{Content: "잠긴 뒤 수정"}, commenter.SessionToken), http.StatusForbidden)
+	postComment(t, created.ID, owner.SessionToken, dto.CommentCreateRequest{Content: "작성자는 가능"})
+
+	// 끄면 아무도 쓸 수 없고, 로그 작성자 외에는 목록이 비어 있음
+	expectStatus(t, doRequest(t, http.MethodPut, modePath, dto.CommentModeUpdateRequest{Mode: "off"}, owner.SessionToken), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodPost, commentsPath, dto.CommentCreateRequest{Content: "꺼짐"}, owner.SessionToken), http.StatusForbidden)
+
+	res = doRequest(t, http.MethodGet, commentsPath, nil, reader.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if list := decode[dto.PaginatedResult[dto.CommentResponse]](t, res); list.Total != 0 || len(list.Items) != 0 {
+		t.Fatalf("comments visible while off: %+v", list)
+	}
+
+	// 로그 작성자는 남의 댓글을 지울 수 있음
+	expectStatus(t, doRequest(t, http.MethodDelete, fmt.Sprintf("%s/%d", commentsPath, comment.ID), nil, owner.SessionToken), http.StatusOK)
+}
@@ -0,0 +1,120 @@
+package e2e
+
+import (
+	"analog-be/dto"
+	"analog-be/pkg"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// cookieSignup 은 쿠키 세션 모드로 가입하고 세션 쿠키와 CSRF 토큰을 돌려줍니다.
+func cookieSignup(t *testing.T, name string) (*http.Cookie, string) {
+	t.Helper()
+
+	res := authorizeWith(t, "signup", newAccount(name), url.Values{"session": {"cookie"}})
+	expectStatus(t, res, http.StatusOK)
+
+	body := decode[dto.AuthResponse](t, res)
+	if body.SessionToken != "" {
+		t.Fatalf("session token leaked in body: %s", res.Body)
+	}
+	if body.CSRFToken == "" {
+		t.Fatalf("missing csrf token: %s", res.Body)
+	}
+
+	session := findCookie(res, "analog_session")
+	if session == nil || !session.HttpOnly || !session.Secure || session.SameSite != http.SameSiteLaxMode {
+		t.Fatalf("unexpected session cookie: %v", res.Header.Values("Set-Cookie"))
+	}
+	if csrf := findCookie(res, "analog_session_csrf"); csrf == nil || csrf.HttpOnly || csrf.Value != body.CSRFToken {
+		t.Fatalf("unexpected csrf cookie: %v", res.Header.Values("Set-Cookie"))
+	}
+
+	return session, body.CSRFToken
+}
+
+func findCookie(res *response, name string) *http.Cookie {
+	for _, c := range (&http.Response{Header: res.Header}).Cookies() {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func TestCookieSession(t *testing.T) {
+	requireDB(t)
+
+	session, csrf := cookieSignup(t, "쿠키 세션")
+	cookie := session.Name + "=" + session.Value
+
+	expectStatus(t, doRequestWithHeaders(t, http.MethodGet, "/auth/me", nil, map[string]string{"Cookie": cookie}), http.StatusOK)
+
+	// 상태를 바꾸는 요청은 CSRF 토큰이 있어야 함
+	expectStatus(t, doRequestWithHeaders(t, http.MethodPost, "/auth/session/refresh", nil, map[string]string{
+		"Cookie": cookie,
+	}), http.StatusForbidden)
+	expectStatus(t, doRequestWithHeaders(t, http.MethodPost, "/auth/session/refresh", nil, map[string]string{
+		"Cookie":       cookie,
+		pkg.CSRFHeader: "forged",
+	}), http.StatusForbidden)
+	expectStatus(t, doRequestWithHeaders(t, http.MethodPost, "/auth/session/refresh", nil, map[string]string{
+		"Cookie":       cookie,
+		pkg.CSRFHeader: csrf,
+		"Origin":       "https://evil.example",
+	}), http.StatusForbidden)
+
+	res := doRequestWithHeaders(t, http.MethodPost, "/auth/session/refresh", nil, map[string]string{
+		"Cookie":       cookie,
+		pkg.CSRFHeader: csrf,
+		"Origin":       "http://localhost:3000",
+	})
+	expectStatus(t, res, http.StatusOK)
+	refreshed := decode[dto.SessionRefreshResponse](t, res)
+	rotated := findCookie(res, session.Name)
+	if refreshed.SessionToken != "" || rotated == nil || rotated.Value == session.Value || refreshed.CSRFToken != pkg.CSRFToken(rotated.Value) {
+		t.Fatalf("unexpected refresh response: %s; cookies = %v", res.Body, res.Header.Values("Set-Cookie"))
+	}
+
+	// 이전 쿠키는 더 이상 쓸 수 없음
+	expectStatus(t, doRequestWithHeaders(t, http.MethodGet, "/auth/me", nil, map[string]string{"Cookie": cookie}), http.StatusUnauthorized)
+
+	cookie = rotated.Name + "=" + rotated.Value
+	res = doRequestWithHeaders(t, http.MethodPost, "/auth/logout", dto.LogoutRequest{}, map[string]string{
+		"Cookie":       cookie,
+		pkg.CSRFHeader: refreshed.CSRFToken,
+	})
+	expectStatus(t, res, http.StatusOK)
+	if cleared := findCookie(res, session.Name); cleared == nil || cleared.MaxAge >= 0 {
+		t.Fatalf("session cookie not cleared: %v", res.Header.Values("Set-Cookie"))
+	}
+
+	expectStatus(t, doRequestWithHeaders(t, http.MethodGet, "/auth/me", nil, map[string]string{"Cookie": cookie}), http.StatusUnauthorized)
+}
+
+func TestBearerSessionDoesNotNeedCSRFToken(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "베어러 세션")
+	res := doRequestWithHeaders(t, http.MethodPost, "/auth/session/refresh", nil, map[string]string{
+		"Authorization": "Bearer " + user.SessionToken,
+		"Origin":        "https://evil.example",
+	})
+	expectStatus(t, res, http.StatusOK)
+	if strings.Contains(strings.Join(res.Header.Values("Set-Cookie"), ";"), "analog_session") {
+		t.Fatalf("bearer session should not set cookies: %v", res.Header.Values("Set-Cookie"))
+	}
+}
+
+func TestAccessTokenIsNotAcceptedFromCookie(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "쿠키 액세스 토큰")
+	res := doRequest(t, http.MethodPost, "/auth/tokens", dto.AccessTokenCreateRequest{Name: "cli", Scopes: []string{"user:read"}}, user.SessionToken)
+	expectStatus(t, res, http.StatusCreated)
+	token := decode[dto.AccessTokenCreateResponse](t, res).Token
+
+	expectStatus(t, doRequestWithHeaders(t, http.MethodGet, "/auth/me", nil, map[string]string{"Cookie": "analog_session=" + token}), http.StatusUnauthorized)
+}
@@ -0,0 +1,270 @@
+package e2e
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"analog-be/pkg"
+	"analog-be/pkg/fakesmtp"
+	"analog-be/pkg/mailer"
+	"analog-be/repository"
+	"analog-be/service"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/http"
+	netmail "net/mail"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// sendQueuedEmails 는 쌓인 메일을 smtp 대역 서버로 보내고 받은 메일을 돌려줍니다.
+func sendQueuedEmails(t *testing.T, smtp *fakesmtp.Server) []*netmail.Message {
+	t.Helper()
+
+	smtp.Reset()
+	sender := &mailer.SMTPSender{
+		Host: smtp.Host(),
+		Port: smtp.Port(),
+		TLS:  mailer.TLSNone,
+		From: &netmail.Address{Name: "AnAlog", Address: "no-reply@ana.st"},
+	}
+	worker := service.NewEmailOutboxWorker(repository.NewEmailOutboxRepository(testDB), repository.NewEmailSettingsRepository(testDB), sender, pkg.NewEmailPolicy(zap.NewNop()), zap.NewNop())
+	if _, err := worker.ProcessBatch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	var messages []*netmail.Message
+	for _, m := range smtp.Messages() {
+		parsed, err := netmail.ReadMessage(strings.NewReader(m.Data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		messages = append(messages, parsed)
+	}
+	return messages
+}
+
+func emailSubject(t *testing.T, m *netmail.Message) string {
+	t.Helper()
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return subject
+}
+
+// emailText 는 메일의 텍스트 본문을 돌려줍니다.
+func emailText(t *testing.T, m *netmail.Message) string {
+	t.Helper()
+
+	_, params, err := mime.ParseMediaType(m.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	part, err := multipart.NewReader(m.Body, params["boundary"]).NextPart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	text, err := io.ReadAll(quotedprintable.NewReader(part))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(text)
+}
+
+// unsubscribeToken 은 원클릭 수신 거부 헤더에서 토큰을 꺼냅니다.
+func unsubscribeToken(t *testing.T, m *netmail.Message) string {
+	t.Helper()
+
+	if m.Header.Get("List-Unsubscribe-Post") != "List-Unsubscribe=One-Click" {
+		t.Fatalf("List-Unsubscribe-Post = %q", m.Header.Get("List-Unsubscribe-Post"))
+	}
+	link, err := url.Parse(strings.Trim(m.Header.Get("List-Unsubscribe"), "<>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return link.Query().Get("token")
+}
+
+func TestEmailUnsubscribeRequiresToken(t *testing.T) {
+	expectStatus(t, doRequest(t, http.MethodPost, "/email/unsubscribe", nil, ""), http.StatusBadRequest)
+	expectStatus(t, doRequest(t, http.MethodPost, "/email/unsubscribe?token=x&list=spam", nil, ""), http.StatusBadRequest)
+}
+
+func TestEmailNotificationsAndDigest(t *testing.T) {
+	requireDB(t)
+
+	smtp, err := fakesmtp.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer smtp.Close()
+
+	author := signup(t, "메일받는이")
+	fan := signup(t, "메일보내는이")
+
+	expectStatus(t, doRequest(t, http.MethodGet, "/notifications/email", nil, ""), http.StatusUnauthorized)
+
+	res := doRequest(t, http.MethodGet, "/notifications/email", nil, author.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	settings := decode[dto.EmailSettingsResponse](t, res)
+	address := fmt.Sprintf("%d@ana.st", author.User.ID)
+	if settings.Email != address || len(settings.Addresses) != 1 || settings.Notifications || settings.Digest {
+		t.Fatalf("default settings = %+v", settings)
+	}
+
+	// 연결된 계정에 없는 주소는 쓸 수 없음
+	other := "someone@example.com"
+	expectStatus(t, doRequest(t, http.MethodPut, "/notifications/email", dto.EmailSettingsUpdateRequest{Email: &other}, author.SessionToken), http.StatusBadRequest)
+
+	on := true
+	res = doRequest(t, http.MethodPut, "/notifications/email", dto.EmailSettingsUpdateRequest{Notifications: &on, Digest: &on}, author.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if settings := decode[dto.EmailSettingsResponse](t, res); !settings.Notifications || !settings.Digest {
+		t.Fatalf("settings after opt-in = %+v", settings)
+	}
+
+	created := createLog(t, author.SessionToken, dto.LogCreateRequest{Title: "메일 알림 로그"})
+	commentsPath := fmt.Sprintf("/logs/%d/comments", created.ID)
+	expectStatus(t, doRequest(t, http.MethodPost, commentsPath, dto.CommentCreateRequest{Content: "첫 댓글"}, fan.SessionToken), http.StatusOK)
+
+	messages := sendQueuedEmails(t, smtp)
+	if len(messages) != 1 || messages[0].Header.Get("To") != "<"+address+">" {
+		t.Fatalf("notification emails = %+v", messages)
+	}
+	if subject := emailSubject(t, messages[0]); subject != "[AnAlog] 메일보내는이님이 내 로그에 댓글을 남겼어요" {
+		t.Fatalf("subject = %q", subject)
+	}
+	token := unsubscribeToken(t, messages[0])
+
+	// 토큰 원문은 DB에 남지 않고 해시만 저장됨
+	if n, err := testDB.NewSelect().Table("email_unsubscribe_tokens").Where("token_hash = ?", pkg.HashToken(token)).Count(context.Background()); err != nil || n != 1 {
+		t.Fatalf("hashed unsubscribe tokens = %d, %v", n, err)
+	}
+	if n, err := testDB.NewSelect().Table("email_outbox").Where("data::text LIKE ?", "%"+token+"%").Count(context.Background()); err != nil || n != 0 {
+		t.Fatalf("outbox rows containing the unsubscribe token = %d, %v", n, err)
+	}
+
+	// 읽지 않은 알림에 묶이면 다시 보내지 않음
+	expectStatus(t, doRequest(t, http.MethodPost, commentsPath, dto.CommentCreateRequest{Content: "또 댓글"}, fan.SessionToken), http.StatusOK)
+	if messages := sendQueuedEmails(t, smtp); len(messages) != 0 {
+		t.Fatalf("emails for grouped notification = %d", len(messages))
+	}
+
+	// 종류별로 메일만 끌 수 있음
+	email := false
+	preferences := dto.NotificationPreferencesUpdateRequest{Preferences: []dto.NotificationPreferenceRequest{{Type: entity.NotificationComment, Email: &email}}}
+	expectStatus(t, doRequest(t, http.MethodPut, "/notifications/preferences", preferences, author.SessionToken), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodPut, "/notifications/read-all", nil, author.SessionToken), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodPost, commentsPath, dto.CommentCreateRequest{Content: "메일 없는 댓글"}, fan.SessionToken), http.StatusOK)
+	if messages := sendQueuedEmails(t, smtp); len(messages) != 0 {
+		t.Fatalf("emails with comment emails off = %d", len(messages))
+	}
+	if got := unreadCount(t, author.SessionToken); got != 1 {
+		t.Fatalf("unread count = %d", got)
+	}
+
+	// 주간 소식: 일주일이 지났으면 그동안 올라온 다른 사람의 로그를 담아 보냄
+	createLog(t, fan.SessionToken, dto.LogCreateRequest{Title: "이번 주의 새 로그"})
+	if _, err := testDB.NewUpdate().
+		Model((*entity.EmailSettings)(nil)).
+		Set("last_digest_at = ?", time.Now().UTC().Add(-8*24*time.Hour)).
+		Where("user_id = ?", author.User.ID).
+		Exec(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	emailService := service.NewEmailService(
+		repository.NewEmailSettingsRepository(testDB),
+		repository.NewEmailOutboxRepository(testDB),
+		repository.NewNotificationRepository(testDB),
+		repository.NewLogRepository(testDB),
+		repository.NewUserIdentityRepository(testDB),
+		pkg.NewEmailPolicy(zap.NewNop()),
+		zap.NewNop(),
+	)
+	digests := service.NewEmailDigestRunner(repository.NewLockRepository(testDB), emailService, zap.NewNop())
+	if err := digests.RunOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	messages = sendQueuedEmails(t, smtp)
+	var digest *netmail.Message
+	for _, m := range messages {
+		if m.Header.Get("To") == "<"+address+">" {
+			digest = m
+		}
+	}
+	if digest == nil || !strings.HasPrefix(emailSubject(t, digest), "[AnAlog] 이번 주 새 로그") {
+		t.Fatalf("digest emails = %+v", messages)
+	}
+	// 내가 쓴 로그는 담지 않음
+	if text := emailText(t, digest); !strings.Contains(text, "이번 주의 새 로그") || strings.Contains(text, "메일 알림 로그") {
+		t.Fatalf("digest text = %s", text)
+	}
+
+	// 이미 보냈으면 다음 주기까지 다시 보내지 않음
+	if err := digests.RunOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if messages := sendQueuedEmails(t, smtp); len(messages) != 0 {
+		t.Fatalf("digest sent twice: %d", len(messages))
+	}
+
+	// 로그인 없이 메일의 토큰으로 수신 거부
+	res = doRequest(t, http.MethodGet, "/email/unsubscribe?token="+url.QueryEscape(token), nil, "")
+	expectStatus(t, res, http.StatusOK)
+	if info := decode[dto.EmailUnsubscribeResponse](t, res); !info.Notifications || !info.Digest || strings.Contains(info.Email, fmt.Sprint(author.User.ID)) {
+		t.Fatalf("subscription = %+v", info)
+	}
+
+	res = doRequest(t, http.MethodPost, "/email/unsubscribe?list=digest&token="+url.QueryEscape(token), "List-Unsubscribe=One-Click", "")
+	expectStatus(t, res, http.StatusOK)
+	if info := decode[dto.EmailUnsubscribeResponse](t, res); !info.Notifications || info.Digest {
+		t.Fatalf("after digest unsubscribe = %+v", info)
+	}
+
+	res = doRequest(t, http.MethodPost, "/email/unsubscribe?token="+url.QueryEscape(token), nil, "")
+	expectStatus(t, res, http.StatusOK)
+	if info := decode[dto.EmailUnsubscribeResponse](t, res); info.Notifications || info.Digest {
+		t.Fatalf("after unsubscribing from all = %+v", info)
+	}
+
+	expectStatus(t, doRequest(t, http.MethodPost, "/email/unsubscribe?token=unknown", nil, ""), http.StatusNotFound)
+}
+
+func TestQueuedEmailIsCancelledAfterOptOut(t *testing.T) {
+	requireDB(t)
+
+	smtp, err := fakesmtp.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer smtp.Close()
+
+	author := signup(t, "메일을끈이")
+	fan := signup(t, "메일을보낸이")
+
+	on, off := true, false
+	expectStatus(t, doRequest(t, http.MethodPut, "/notifications/email", dto.EmailSettingsUpdateRequest{Notifications: &on}, author.SessionToken), http.StatusOK)
+
+	created := createLog(t, author.SessionToken, dto.LogCreateRequest{Title: "보내기 전에 끈 로그"})
+	expectStatus(t, doRequest(t, http.MethodPost, fmt.Sprintf("/logs/%d/comments", created.ID), dto.CommentCreateRequest{Content: "댓글"}, fan.SessionToken), http.StatusOK)
+
+	// 쌓인 뒤에 수신을 끄면 보내지 않고 취소함
+	expectStatus(t, doRequest(t, http.MethodPut, "/notifications/email", dto.EmailSettingsUpdateRequest{Notifications: &off}, author.SessionToken), http.StatusOK)
+	if messages := sendQueuedEmails(t, smtp); len(messages) != 0 {
+		t.Fatalf("emails after opt-out = %d", len(messages))
+	}
+	if n := countRows(t, "SELECT count(*) FROM email_outbox WHERE user_id = ? AND status = ?", author.User.ID, entity.EmailOutboxCancelled); n != 1 {
+		t.Fatalf("cancelled emails = %d", n)
+	}
+}
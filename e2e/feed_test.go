@@ -0,0 +1,36 @@
+package e2e
+
+import (
+	"analog-be/dto"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSitemapRejectsOtherFiles(t *testing.T) {
+	expectStatus(t, doRequest(t, http.MethodGet, "/sitemaps/passwd", nil, ""), http.StatusBadRequest)
+	expectStatus(t, doRequest(t, http.MethodGet, "/sitemaps/sitemap-999.xml", nil, ""), http.StatusNotFound)
+}
+
+func TestFeedIncludesNewLog(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "피드")
+	createLog(t, user.SessionToken, dto.LogCreateRequest{Title: "피드에 나올 로그"})
+
+	// 피드는 비동기로 갱신됨
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		res := doRequest(t, http.MethodGet, "/feed", nil, "")
+		expectStatus(t, res, http.StatusOK)
+		if strings.Contains(string(res.Body), "피드에 나올 로그") {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("feed does not contain new log: %s", res.Body)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -0,0 +1,196 @@
+package e2e
+
+import (
+	"analog-be/dto"
+	migrations "analog-be/migration"
+	"analog-be/pkg/fakeana"
+	"analog-be/server"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/uptrace/bun/migrate"
+)
+
+// signupWithHandle 은 handle을 골라 accountID 사용자로 가입하는 흐름을 진행하고 콜백 응답을 돌려줍니다.
+func signupWithHandle(t *testing.T, accountID int64, handle string) *response {
+	t.Helper()
+
+	res := doRequest(t, http.MethodPost, "/auth/signup/init", dto.SignupInitRequest{Handle: handle, RedirectUri: testRedirectURI}, "")
+	expectStatus(t, res, http.StatusOK)
+	started := decode[dto.SignupInitResponse](t, res)
+
+	code, state := grantAuthorization(t, started.AuthorizationUrl, accountID)
+	callback := url.Values{"code": {code}, "state": {state}}
+	return doRequest(t, http.MethodGet, "/auth/signup/callback?"+callback.Encode(), nil, "")
+}
+
+func uniqueHandle(prefix string) string {
+	return prefix + strconv.FormatInt(nextUserID.Add(1), 36)
+}
+
+func TestSignupWithHandle(t *testing.T) {
+	requireDB(t)
+
+	handle := uniqueHandle("ana_")
+	res := signupWithHandle(t, newAccount("핸들 고르기"), "@"+handle)
+	expectStatus(t, res, http.StatusOK)
+	if got := decode[dto.AuthResponse](t, res); got.User.Handle != handle {
+		t.Fatalf("handle = %q, want %q", got.User.Handle, handle)
+	}
+
+	// 잘못된 핸들, 예약어, 이미 쓰는 핸들은 가입을 시작할 수 없음
+	for h, status := range map[string]int{
+		"a":                  http.StatusBadRequest,
+		"admin":              http.StatusBadRequest,
+		"user1":              http.StatusBadRequest,
+		handle:               http.StatusConflict,
+		"  " + handle + "  ": http.StatusConflict,
+	} {
+		res := doRequest(t, http.MethodPost, "/auth/signup/init", dto.SignupInitRequest{Handle: h, RedirectUri: testRedirectURI}, "")
+		expectStatus(t, res, status)
+	}
+
+	// 핸들을 고르지 않으면 아이디로 만든 기본 핸들
+	user := signup(t, "기본 핸들")
+	if want := fmt.Sprintf("user%d", user.User.ID); user.User.Handle != want {
+		t.Fatalf("handle = %q, want %q", user.User.Handle, want)
+	}
+}
+
+func TestSignupHandleTakenDuringFlow(t *testing.T) {
+	requireDB(t)
+
+	handle := uniqueHandle("race_")
+	res := doRequest(t, http.MethodPost, "/auth/signup/init", dto.SignupInitRequest{Handle: handle, RedirectUri: testRedirectURI}, "")
+	expectStatus(t, res, http.StatusOK)
+	started := decode[dto.SignupInitResponse](t, res)
+
+	// 가입을 마치기 전에 다른 사용자가 핸들을 가져감
+	expectStatus(t, signupWithHandle(t, newAccount("먼저 가입"), handle), http.StatusOK)
+
+	code, state := grantAuthorization(t, started.AuthorizationUrl, newAccount("늦게 가입"))
+	callback := url.Values{"code": {code}, "state": {state}}
+	expectStatus(t, doRequest(t, http.MethodGet, "/auth/signup/callback?"+callback.Encode(), nil, ""), http.StatusConflict)
+}
+
+func TestSignupImportsClubProfile(t *testing.T) {
+	requireDB(t)
+
+	id := nextUserID.Add(1)
+	fake.AddUser(fakeana.User{ID: id, Name: "부원", Department: "소프트웨어과", Generation: 12})
+
+	res := authorize(t, "signup", id)
+	expectStatus(t, res, http.StatusOK)
+	if got := decode[dto.AuthResponse](t, res); got.User.PartOf != "소프트웨어과" || got.User.Generation != 12 {
+		t.Fatalf("unexpected club profile: %+v", got.User)
+	}
+}
+
+func TestHandleAvailability(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "핸들 확인")
+	checkHandle := func(handle string) dto.HandleAvailabilityResponse {
+		t.Helper()
+		res := doRequest(t, http.MethodGet, "/users/handles/"+url.PathEscape(handle)+"/availability", nil, "")
+		expectStatus(t, res, http.StatusOK)
+		return decode[dto.HandleAvailabilityResponse](t, res)
+	}
+
+	free := uniqueHandle("free_")
+	if got := checkHandle(free); !got.Available || got.Handle != free {
+		t.Fatalf("unexpected availability: %+v", got)
+	}
+	if got := checkHandle(user.User.Handle); got.Available || got.Reason == "" {
+		t.Fatalf("taken handle reported available: %+v", got)
+	}
+	if got := checkHandle("settings"); got.Available {
+		t.Fatalf("reserved handle reported available: %+v", got)
+	}
+}
+
+func TestChangeHandleRedirectsOldHandle(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "핸들 바꾸기")
+	oldHandle := user.User.Handle
+	newHandle := uniqueHandle("renamed_")
+
+	res := doRequest(t, http.MethodPut, "/users", dto.UserUpdateRequest{Handle: &newHandle}, user.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if got := decode[dto.UserResponse](t, res); got.Handle != newHandle {
+		t.Fatalf("handle = %q, want %q", got.Handle, newHandle)
+	}
+
+	res = doRequest(t, http.MethodGet, "/users/handles/"+newHandle, nil, "")
+	expectStatus(t, res, http.StatusOK)
+	if got := decode[dto.UserResponse](t, res); got.ID != user.User.ID {
+		t.Fatalf("id = %d, want %d", got.ID, user.User.ID)
+	}
+
+	res = doRequest(t, http.MethodGet, "/users/handles/"+oldHandle, nil, "")
+	expectStatus(t, res, http.StatusMovedPermanently)
+	if location := res.Header.Get("Location"); location != "/users/handles/"+newHandle {
+		t.Fatalf("Location = %q", location)
+	}
+
+	// 이전 핸들은 다른 사용자가 가져갈 수 없지만 본인은 되돌릴 수 있음
+	other := signup(t, "이전 핸들 노리기")
+	expectStatus(t, doRequest(t, http.MethodPut, "/users", dto.UserUpdateRequest{Handle: &newHandle}, other.SessionToken), http.StatusConflict)
+
+	reclaimed := uniqueHandle("again_")
+	expectStatus(t, doRequest(t, http.MethodPut, "/users", dto.UserUpdateRequest{Handle: &reclaimed}, user.SessionToken), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodPut, "/users", dto.UserUpdateRequest{Handle: &newHandle}, user.SessionToken), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodGet, "/users/handles/"+reclaimed, nil, ""), http.StatusMovedPermanently)
+	expectStatus(t, doRequest(t, http.MethodGet, "/users/handles/"+newHandle, nil, ""), http.StatusOK)
+
+	invalid := "No Spaces"
+	expectStatus(t, doRequest(t, http.MethodPut, "/users", dto.UserUpdateRequest{Handle: &invalid}, user.SessionToken), http.StatusBadRequest)
+	expectStatus(t, doRequest(t, http.MethodGet, "/users/handles/nobody_here", nil, ""), http.StatusNotFound)
+}
+
+// 예전 가입 흐름으로 빈 핸들이 저장된 사용자는 마이그레이션에서 기본 핸들을 받음
+func TestMigrationBackfillsEmptyHandles(t *testing.T) {
+	requireDB(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	db, drop, err := createDatabase(ctx, adminDBConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer drop()
+
+	// 첫 마이그레이션만 적용한 예전 스키마
+	initial := migrate.NewMigrations()
+	initial.Add(migrations.Migrations.Sorted()[0])
+	migrator := migrate.NewMigrator(db, initial)
+	if err := migrator.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Migrate(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.ExecContext(ctx, "INSERT INTO users (id, name, handle) VALUES (42, '예전 사용자', '')"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := server.Migrate(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+
+	var handle string
+	if err := db.QueryRowContext(ctx, "SELECT handle FROM users WHERE id = 42").Scan(&handle); err != nil {
+		t.Fatal(err)
+	}
+	if handle != "user42" {
+		t.Fatalf("handle = %q, want user42", handle)
+	}
+}
@@ -0,0 +1,204 @@
+package e2e
+
+import (
+	"analog-be/dto"
+	"analog-be/pkg/fakeana"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+const testRedirectURI = "http://localhost:3000/auth/callback"
+
+var (
+	httpClient = &http.Client{
+		// 인가 서버의 리다이렉트는 따라가지 않고 Location에서 code를 꺼냄
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+
+	nextUserID atomic.Int64
+)
+
+func init() {
+	nextUserID.Store(10_000)
+}
+
+// requireDB 는 디비가 준비되지 않았으면 테스트를 건너뜁니다.
+func requireDB(t *testing.T) {
+	t.Helper()
+
+	if dbSkipReason != "" {
+		t.Skip(dbSkipReason)
+	}
+}
+
+type response struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+func doRequest(t *testing.T, method, path string, body any, token string) *response {
+	t.Helper()
+
+	var headers map[string]string
+	if token != "" {
+		headers = map[string]string{"Authorization": "Bearer " + token}
+	}
+	return doRequestWithHeaders(t, method, path, body, headers)
+}
+
+// doRequestWithHeaders 는 쿠키, Origin 같은 헤더를 직접 지정해 요청합니다.
+func doRequestWithHeaders(t *testing.T, method, path string, body any, headers map[string]string) *response {
+	t.Helper()
+
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case string:
+		reader = bytes.NewBufferString(b)
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, baseURL+path, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &response{Status: resp.StatusCode, Header: resp.Header, Body: data}
+}
+
+func expectStatus(t *testing.T, res *response, status int) {
+	t.Helper()
+
+	if res.Status != status {
+		t.Fatalf("status = %d, want %d; body = %s", res.Status, status, res.Body)
+	}
+}
+
+func decode[T any](t *testing.T, res *response) T {
+	t.Helper()
+
+	var v T
+	if err := json.Unmarshal(res.Body, &v); err != nil {
+		t.Fatalf("decode %T: %v; body = %s", v, err, res.Body)
+	}
+	return v
+}
+
+// newAccount 는 대역 서버에 새 An-Account 사용자를 추가합니다.
+func newAccount(name string) int64 {
+	id := nextUserID.Add(1)
+	fake.AddUser(fakeana.User{
+		ID:                id,
+		Name:              name,
+		Email:             strconv.FormatInt(id, 10) + "@ana.st",
+		PreferredUsername: "user" + strconv.FormatInt(id, 10),
+	})
+	return id
+}
+
+// authorize 는 init → 인가 → 콜백 흐름을 끝까지 진행하고 콜백 응답을 돌려줍니다.
+func authorize(t *testing.T, flow string, accountID int64) *response {
+	t.Helper()
+
+	return authorizeWith(t, flow, accountID, url.Values{})
+}
+
+// authorizeWith 는 콜백에 callbackParams를 더해 authorize와 같은 흐름을 진행합니다.
+func authorizeWith(t *testing.T, flow string, accountID int64, callbackParams url.Values) *response {
+	t.Helper()
+
+	return authorizeProvider(t, flow, "", accountID, callbackParams)
+}
+
+// authorizeProvider 는 provider 제공자로 authorize와 같은 흐름을 진행합니다.
+func authorizeProvider(t *testing.T, flow, provider string, accountID int64, callbackParams url.Values) *response {
+	t.Helper()
+
+	res := doRequest(t, http.MethodPost, "/auth/"+flow+"/init", dto.LoginInitRequest{Provider: provider, RedirectUri: testRedirectURI}, "")
+	expectStatus(t, res, http.StatusOK)
+	started := decode[dto.LoginInitResponse](t, res)
+
+	callback := callbackParams
+	code, state := grantAuthorization(t, started.AuthorizationUrl, accountID)
+	callback.Set("code", code)
+	callback.Set("state", state)
+	return doRequest(t, http.MethodGet, "/auth/"+flow+"/callback?"+callback.Encode(), nil, "")
+}
+
+// grantAuthorization 은 대역 서버에서 accountID 사용자로 인가하고 콜백에 넘어온 code와 state를 돌려줍니다.
+func grantAuthorization(t *testing.T, authorizationURL string, accountID int64) (string, string) {
+	t.Helper()
+
+	authURL, err := url.Parse(authorizationURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := authURL.Query()
+	q.Set("login_hint", strconv.FormatInt(accountID, 10))
+	authURL.RawQuery = q.Encode()
+
+	authResp, err := httpClient.Get(authURL.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	authResp.Body.Close()
+	if authResp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize status = %d", authResp.StatusCode)
+	}
+
+	location, err := url.Parse(authResp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+// signup 은 새 사용자를 가입시키고 세션을 돌려줍니다.
+func signup(t *testing.T, name string) dto.AuthResponse {
+	t.Helper()
+
+	res := authorize(t, "signup", newAccount(name))
+	expectStatus(t, res, http.StatusOK)
+	return decode[dto.AuthResponse](t, res)
+}
+
+func createLog(t *testing.T, token string, req dto.LogCreateRequest) dto.LogResponse {
+	t.Helper()
+
+	if req.Content == "" {
+		req.Content = "# 본문\n\n내용"
+	}
+
+	res := doRequest(t, http.MethodPost, "/logs", req, token)
+	expectStatus(t, res, http.StatusOK)
+	return decode[dto.LogResponse](t, res)
+}
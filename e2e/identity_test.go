@@ -0,0 +1,145 @@
+package e2e
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"testing"
+
+	"analog-be/pkg/fakeana"
+)
+
+// newGuestAccount 는 대역 서버에 guest 제공자로 로그인할 사용자를 추가합니다. sub가 숫자가 아니어서 An-Account로는 로그인할 수 없습니다.
+func newGuestAccount(name string) int64 {
+	id := nextUserID.Add(1)
+	fake.AddUser(fakeana.User{
+		ID:      id,
+		Subject: "guest-" + strconv.FormatInt(id, 10),
+		Name:    name,
+		Email:   strconv.FormatInt(id, 10) + "@guest.example",
+	})
+	return id
+}
+
+func listIdentities(t *testing.T, token string) []dto.IdentityResponse {
+	t.Helper()
+
+	res := doRequest(t, http.MethodGet, "/auth/identities", nil, token)
+	expectStatus(t, res, http.StatusOK)
+	return decode[[]dto.IdentityResponse](t, res)
+}
+
+// linkIdentity 는 token 사용자에게 provider 제공자의 accountID 계정을 연결하는 흐름을 진행하고 콜백 응답을 돌려줍니다.
+func linkIdentity(t *testing.T, token, provider string, accountID int64) *response {
+	t.Helper()
+
+	res := doRequest(t, http.MethodPost, "/auth/identities/link/init", dto.IdentityLinkInitRequest{Provider: provider, RedirectUri: testRedirectURI}, token)
+	expectStatus(t, res, http.StatusOK)
+	started := decode[dto.LoginInitResponse](t, res)
+
+	code, state := grantAuthorization(t, started.AuthorizationUrl, accountID)
+	callback := url.Values{"code": {code}, "state": {state}}
+	return doRequest(t, http.MethodGet, "/auth/identities/link/callback?"+callback.Encode(), nil, token)
+}
+
+func TestListProviders(t *testing.T) {
+	res := doRequest(t, http.MethodGet, "/auth/providers", nil, "")
+	expectStatus(t, res, http.StatusOK)
+	providers := decode[dto.IdentityProvidersResponse](t, res).Providers
+	if !slices.Equal(providers, []string{entity.ProviderAnAccount, "guest"}) {
+		t.Fatalf("providers = %v", providers)
+	}
+
+	expectStatus(t, doRequest(t, http.MethodPost, "/auth/login/init", dto.LoginInitRequest{Provider: "unknown", RedirectUri: testRedirectURI}, ""), http.StatusBadRequest)
+}
+
+func TestSignupWithOtherProvider(t *testing.T) {
+	requireDB(t)
+
+	accountID := newGuestAccount("게스트")
+	res := authorizeProvider(t, "signup", "guest", accountID, url.Values{})
+	expectStatus(t, res, http.StatusOK)
+	signedUp := decode[dto.AuthResponse](t, res)
+	if signedUp.User.ID >= 0 {
+		t.Fatalf("user from another provider must have a negative id, got %d", signedUp.User.ID)
+	}
+
+	res = authorizeProvider(t, "login", "guest", accountID, url.Values{})
+	expectStatus(t, res, http.StatusOK)
+	if loggedIn := decode[dto.AuthResponse](t, res); loggedIn.User.ID != signedUp.User.ID {
+		t.Fatalf("login user = %d, want %d", loggedIn.User.ID, signedUp.User.ID)
+	}
+
+	// 같은 계정으로 다시 가입할 수 없음
+	expectStatus(t, authorizeProvider(t, "signup", "guest", accountID, url.Values{}), http.StatusConflict)
+
+	identities := listIdentities(t, signedUp.SessionToken)
+	if len(identities) != 1 || identities[0].Provider != "guest" || identities[0].Subject != fmt.Sprintf("guest-%d", accountID) {
+		t.Fatalf("unexpected identities: %+v", identities)
+	}
+
+	// 마지막 계정은 끊을 수 없음
+	expectStatus(t, doRequest(t, http.MethodDelete, fmt.Sprintf("/auth/identities/%d", identities[0].ID), nil, signedUp.SessionToken), http.StatusConflict)
+}
+
+func TestLinkAndUnlinkIdentity(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "연결하는 사람")
+	guestID := newGuestAccount("연결할 계정")
+
+	res := linkIdentity(t, user.SessionToken, "guest", guestID)
+	expectStatus(t, res, http.StatusOK)
+	linked := decode[dto.IdentityResponse](t, res)
+
+	identities := listIdentities(t, user.SessionToken)
+	if len(identities) != 2 || identities[0].Provider != entity.ProviderAnAccount || identities[1].ID != linked.ID {
+		t.Fatalf("unexpected identities: %+v", identities)
+	}
+
+	// 연결한 계정으로 같은 사용자에 로그인
+	res = authorizeProvider(t, "login", "guest", guestID, url.Values{})
+	expectStatus(t, res, http.StatusOK)
+	if loggedIn := decode[dto.AuthResponse](t, res); loggedIn.User.ID != user.User.ID {
+		t.Fatalf("login user = %d, want %d", loggedIn.User.ID, user.User.ID)
+	}
+
+	// 이미 연결된 계정은 다른 사용자에게 연결할 수 없음
+	other := signup(t, "다른 사람")
+	expectStatus(t, linkIdentity(t, other.SessionToken, "guest", guestID), http.StatusConflict)
+
+	// 다른 사용자의 계정 연결은 끊을 수 없음
+	expectStatus(t, doRequest(t, http.MethodDelete, fmt.Sprintf("/auth/identities/%d", linked.ID), nil, other.SessionToken), http.StatusNotFound)
+
+	// An-Account 연결을 끊으면 An-Account로는 로그인할 수 없음
+	expectStatus(t, doRequest(t, http.MethodDelete, fmt.Sprintf("/auth/identities/%d", identities[0].ID), nil, user.SessionToken), http.StatusOK)
+	expectStatus(t, authorize(t, "login", int64(user.User.ID)), http.StatusNotFound)
+	expectStatus(t, authorize(t, "signup", int64(user.User.ID)), http.StatusConflict)
+}
+
+func TestLinkStateBelongsToUser(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "연결 시작")
+	other := signup(t, "가로채는 사람")
+
+	res := doRequest(t, http.MethodPost, "/auth/identities/link/init", dto.IdentityLinkInitRequest{Provider: "guest", RedirectUri: testRedirectURI}, user.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	started := decode[dto.LoginInitResponse](t, res)
+
+	code, state := grantAuthorization(t, started.AuthorizationUrl, newGuestAccount("가로챌 계정"))
+	callback := url.Values{"code": {code}, "state": {state}}
+	expectStatus(t, doRequest(t, http.MethodGet, "/auth/identities/link/callback?"+callback.Encode(), nil, other.SessionToken), http.StatusForbidden)
+
+	// 연결용 state로 로그인할 수 없음
+	res = doRequest(t, http.MethodPost, "/auth/identities/link/init", dto.IdentityLinkInitRequest{Provider: "guest", RedirectUri: testRedirectURI}, user.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	started = decode[dto.LoginInitResponse](t, res)
+	code, state = grantAuthorization(t, started.AuthorizationUrl, newGuestAccount("로그인 시도"))
+	callback = url.Values{"code": {code}, "state": {state}}
+	expectStatus(t, doRequest(t, http.MethodGet, "/auth/login/callback?"+callback.Encode(), nil, ""), http.StatusBadRequest)
+}
@@ -0,0 +1,138 @@
+package e2e
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/sunrin-ana/anamericano-golang"
+)
+
+// newAdmin 은 An-Americano에 관리자 관계가 있는 사용자를 가입시킵니다.
+func newAdmin(t *testing.T, name string) dto.AuthResponse {
+	t.Helper()
+
+	admin := signup(t, name)
+	fake.GrantPermission(anamericano.Permission{
+		ObjectNamespace: entity.SystemPermissionNamespace,
+		ObjectID:        entity.SystemObjectID,
+		Relation:        entity.RelationAdmin,
+		SubjectType:     "user",
+		SubjectID:       strconv.FormatInt(admin.User.ID, 10),
+	})
+	return admin
+}
+
+func startImpersonation(t *testing.T, adminToken string, req dto.ImpersonationStartRequest) dto.ImpersonationResponse {
+	t.Helper()
+
+	res := doRequest(t, http.MethodPost, "/admin/impersonations", req, adminToken)
+	expectStatus(t, res, http.StatusCreated)
+	return decode[dto.ImpersonationResponse](t, res)
+}
+
+func TestImpersonationIsReadOnlyByDefault(t *testing.T) {
+	requireDB(t)
+
+	admin := newAdmin(t, "관리자")
+	target := signup(t, "제보한 사람")
+
+	started := startImpersonation(t, admin.SessionToken, dto.ImpersonationStartRequest{UserID: target.User.ID, Reason: "글 수정이 안 된다는 제보"})
+	if started.UserID != target.User.ID || started.AllowWrites {
+		t.Fatalf("unexpected impersonation: %+v", started)
+	}
+
+	res := doRequest(t, http.MethodGet, "/auth/me", nil, started.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if me := decode[dto.UserDTO](t, res); me.ID != target.User.ID {
+		t.Fatalf("me = %d, want %d", me.ID, target.User.ID)
+	}
+
+	// 쓰기를 허용하지 않았으면 변경 요청은 막힘
+	expectStatus(t, doRequest(t, http.MethodPost, "/logs", dto.LogCreateRequest{Title: "대리 작성", Content: "내용"}, started.SessionToken), http.StatusForbidden)
+	expectStatus(t, doRequest(t, http.MethodPost, "/auth/session/refresh", nil, started.SessionToken), http.StatusForbidden)
+
+	// 대상 사용자는 대리 세션을 볼 수 있음
+	res = doRequest(t, http.MethodGet, "/auth/sessions", nil, target.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	impersonated := false
+	for _, s := range decode[[]dto.SessionResponse](t, res) {
+		impersonated = impersonated || (s.ID == started.ID && s.Impersonated)
+	}
+	if !impersonated {
+		t.Fatal("impersonation session is not visible to the target user")
+	}
+
+	// 대리 세션으로는 관리자 기능을 쓸 수 없음
+	expectStatus(t, doRequest(t, http.MethodGet, "/admin/impersonations/audits", nil, started.SessionToken), http.StatusForbidden)
+
+	res = doRequest(t, http.MethodGet, fmt.Sprintf("/admin/impersonations/audits?userId=%d", target.User.ID), nil, admin.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	audits := decode[dto.PaginatedResult[dto.ImpersonationAuditResponse]](t, res)
+	actions := map[string]int{}
+	for _, a := range audits.Items {
+		if a.SessionID != started.ID || a.ImpersonatorID != admin.User.ID {
+			t.Fatalf("unexpected audit: %+v", a)
+		}
+		actions[a.Action]++
+	}
+	if actions[entity.ImpersonationActionStart] != 1 || actions[entity.ImpersonationActionRequest] < 2 || actions[entity.ImpersonationActionBlocked] != 2 {
+		t.Fatalf("unexpected audit actions: %v", actions)
+	}
+
+	expectStatus(t, doRequest(t, http.MethodDelete, fmt.Sprintf("/admin/impersonations/%d", started.ID), nil, admin.SessionToken), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodGet, "/auth/me", nil, started.SessionToken), http.StatusUnauthorized)
+	expectStatus(t, doRequest(t, http.MethodDelete, fmt.Sprintf("/admin/impersonations/%d", started.ID), nil, admin.SessionToken), http.StatusNotFound)
+}
+
+func TestImpersonationWithWrites(t *testing.T) {
+	requireDB(t)
+
+	admin := newAdmin(t, "쓰기 허용 관리자")
+	target := signup(t, "쓰기 대상")
+
+	started := startImpersonation(t, admin.SessionToken, dto.ImpersonationStartRequest{UserID: target.User.ID, Reason: "재현", DurationMinutes: 5, AllowWrites: true})
+
+	log := createLog(t, started.SessionToken, dto.LogCreateRequest{Title: "대리 작성"})
+	if len(log.LoggedBy) == 0 || log.LoggedBy[0].ID != target.User.ID {
+		t.Fatalf("log was not written as the target: %+v", log.LoggedBy)
+	}
+
+	// 쓰기를 허용해도 계정 관리는 막힘
+	name := "바꾼 이름"
+	expectStatus(t, doRequest(t, http.MethodPut, "/users", dto.UserUpdateRequest{Name: &name}, started.SessionToken), http.StatusForbidden)
+	expectStatus(t, doRequest(t, http.MethodPost, "/auth/tokens", dto.AccessTokenCreateRequest{Name: "몰래", Scopes: []string{entity.ScopeLogsWrite}}, started.SessionToken), http.StatusForbidden)
+	expectStatus(t, doRequest(t, http.MethodDelete, "/users", nil, started.SessionToken), http.StatusForbidden)
+}
+
+func TestImpersonationRequiresAdmin(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "일반 사용자")
+	admin := newAdmin(t, "다른 관리자")
+	target := signup(t, "대상")
+
+	expectStatus(t, doRequest(t, http.MethodPost, "/admin/impersonations", dto.ImpersonationStartRequest{UserID: target.User.ID, Reason: "궁금"}, user.SessionToken), http.StatusForbidden)
+	expectStatus(t, doRequest(t, http.MethodGet, "/admin/impersonations/audits", nil, user.SessionToken), http.StatusForbidden)
+
+	other := newAdmin(t, "관리자 2")
+	for _, tc := range []struct {
+		req    dto.ImpersonationStartRequest
+		status int
+	}{
+		{dto.ImpersonationStartRequest{UserID: target.User.ID}, http.StatusBadRequest},
+		{dto.ImpersonationStartRequest{UserID: target.User.ID, Reason: "길게", DurationMinutes: 24 * 60}, http.StatusBadRequest},
+		{dto.ImpersonationStartRequest{UserID: admin.User.ID, Reason: "본인"}, http.StatusBadRequest},
+		{dto.ImpersonationStartRequest{UserID: other.User.ID, Reason: "다른 관리자"}, http.StatusForbidden},
+		{dto.ImpersonationStartRequest{UserID: 999999999, Reason: "없는 사용자"}, http.StatusNotFound},
+	} {
+		expectStatus(t, doRequest(t, http.MethodPost, "/admin/impersonations", tc.req, admin.SessionToken), tc.status)
+	}
+
+	// 다른 관리자가 시작한 대리 세션은 끝낼 수 없음
+	started := startImpersonation(t, admin.SessionToken, dto.ImpersonationStartRequest{UserID: target.User.ID, Reason: "확인"})
+	expectStatus(t, doRequest(t, http.MethodDelete, fmt.Sprintf("/admin/impersonations/%d", started.ID), nil, other.SessionToken), http.StatusNotFound)
+}
@@ -0,0 +1,177 @@
+package e2e
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestLogLifecycle(t *testing.T) {
+	requireDB(t)
+
+	author := signup(t, "작성자")
+	other := signup(t, "다른 사람")
+
+	created := createLog(t, author.SessionToken, dto.LogCreateRequest{
+		Title:       "첫 로그",
+		Generations: []uint16{15},
+		Content:     "# 제목\n\n**굵게**",
+	})
+	if created.Title != "첫 로그" || len(created.LoggedBy) != 1 || created.LoggedBy[0].ID != author.User.ID {
+		t.Fatalf("unexpected created log: %+v", created)
+	}
+	if !strings.Contains(created.Content, "<strong>굵게</strong>") {
+		t.Fatalf("content not rendered: %q", created.Content)
+	}
+
+	logPath := fmt.Sprintf("/logs/%d", created.ID)
+
+	res := doRequest(t, http.MethodGet, logPath, nil, "")
+	expectStatus(t, res, http.StatusOK)
+	if got := decode[dto.LogResponse](t, res); got.ID != created.ID {
+		t.Fatalf("id = %d", got.ID)
+	}
+
+	title := "고친 로그"
+	expectStatus(t, doRequest(t, http.MethodPut, logPath, dto.LogUpdateRequest{Title: &title}, other.SessionToken), http.StatusForbidden)
+
+	res = doRequest(t, http.MethodPut, logPath, dto.LogUpdateRequest{Title: &title}, author.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if got := decode[dto.LogResponse](t, res); got.Title != title {
+		t.Fatalf("title = %q", got.Title)
+	}
+
+	expectStatus(t, doRequest(t, http.MethodDelete, logPath, nil, other.SessionToken), http.StatusForbidden)
+	expectStatus(t, doRequest(t, http.MethodDelete, logPath, nil, author.SessionToken), http.StatusOK)
+
+	expectStatus(t, doRequest(t, http.MethodGet, logPath, nil, ""), http.StatusNotFound)
+	expectStatus(t, doRequest(t, http.MethodPut, logPath, dto.LogUpdateRequest{Title: &title}, author.SessionToken), http.StatusNotFound)
+	expectStatus(t, doRequest(t, http.MethodDelete, logPath, nil, author.SessionToken), http.StatusNotFound)
+}
+
+func TestCoAuthorCanEditLog(t *testing.T) {
+	requireDB(t)
+
+	author := signup(t, "주 작성자")
+	coAuthor := signup(t, "공동 작성자")
+
+	created := createLog(t, author.SessionToken, dto.LogCreateRequest{
+		Title:       "같이 쓴 로그",
+		CoAuthorIDs: []entity.ID{coAuthor.User.ID},
+	})
+	if len(created.LoggedBy) != 2 {
+		t.Fatalf("loggedBy = %+v", created.LoggedBy)
+	}
+
+	title := "공동 작성자가 고침"
+	expectStatus(t, doRequest(t, http.MethodPut, fmt.Sprintf("/logs/%d", created.ID), dto.LogUpdateRequest{Title: &title}, coAuthor.SessionToken), http.StatusOK)
+}
+
+func TestCreateLogValidation(t *testing.T) {
+	requireDB(t)
+
+	author := signup(t, "검증")
+
+	expectStatus(t, doRequest(t, http.MethodPost, "/logs", dto.LogCreateRequest{Content: "본문만"}, author.SessionToken), http.StatusBadRequest)
+	expectStatus(t, doRequest(t, http.MethodPost, "/logs", dto.LogCreateRequest{Title: "제목만"}, author.SessionToken), http.StatusBadRequest)
+	expectStatus(t, doRequest(t, http.MethodPost, "/logs", dto.LogCreateRequest{Title: strings.Repeat("가", 201), Content: "본문"}, author.SessionToken), http.StatusBadRequest)
+}
+
+func TestLogPagination(t *testing.T) {
+	requireDB(t)
+
+	author := signup(t, "페이지")
+	for i := 0; i < 3; i++ {
+		createLog(t, author.SessionToken, dto.LogCreateRequest{Title: fmt.Sprintf("페이지 로그 %d", i)})
+	}
+
+	list := func(query string) dto.PaginatedResult[dto.LogResponse] {
+		t.Helper()
+
+		res := doRequest(t, http.MethodGet, "/logs"+query, nil, "")
+		expectStatus(t, res, http.StatusOK)
+		return decode[dto.PaginatedResult[dto.LogResponse]](t, res)
+	}
+
+	first := list("?page=1&size=2")
+	if len(first.Items) != 2 || first.Total < 3 || first.Limit != 2 || first.Offset != 0 {
+		t.Fatalf("page 1 = %+v", first)
+	}
+
+	second := list("?page=2&size=2")
+	if second.Offset != 2 || len(second.Items) == 0 || second.Items[0].ID == first.Items[0].ID {
+		t.Fatalf("page 2 = %+v", second)
+	}
+
+	// 최신 글이 먼저 나옴
+	if first.Items[0].CreatedAt.Before(second.Items[0].CreatedAt) {
+		t.Fatalf("not ordered by createdAt desc")
+	}
+
+	if zero := list("?page=0&size=2"); zero.Offset != 0 {
+		t.Fatalf("page 0 offset = %d", zero.Offset)
+	}
+	if negative := list("?page=-3&size=-1"); negative.Offset != 0 || negative.Limit != 20 {
+		t.Fatalf("negative page = %+v", negative)
+	}
+	if huge := list("?size=100000"); huge.Limit != 100 {
+		t.Fatalf("size not capped: %d", huge.Limit)
+	}
+	if beyond := list("?page=100000&size=2"); len(beyond.Items) != 0 || beyond.Total < 3 {
+		t.Fatalf("beyond last page = %+v", beyond)
+	}
+
+	// 숫자가 아니면 기본값을 사용
+	if invalid := list("?page=abc&size=xyz"); invalid.Offset != 0 || invalid.Limit != 20 {
+		t.Fatalf("non-numeric page = %+v", invalid)
+	}
+}
+
+func TestLogFilters(t *testing.T) {
+	requireDB(t)
+
+	author := signup(t, "필터")
+
+	res := doRequest(t, http.MethodPost, "/topic", dto.TopicCreateRequest{Name: "필터 토픽"}, "")
+	expectStatus(t, res, http.StatusCreated)
+	topic := decode[dto.TopicResponse](t, res)
+
+	created := createLog(t, author.SessionToken, dto.LogCreateRequest{
+		Title:       "검색되는 유니크한 제목",
+		TopicIDs:    []entity.ID{topic.ID},
+		Generations: []uint16{7},
+	})
+
+	contains := func(result dto.PaginatedResult[dto.LogResponse]) bool {
+		for _, item := range result.Items {
+			if item.ID == created.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	res = doRequest(t, http.MethodGet, fmt.Sprintf("/logs/topic/list/%d", topic.ID), nil, "")
+	expectStatus(t, res, http.StatusOK)
+	if byTopic := decode[dto.PaginatedResult[dto.LogResponse]](t, res); !contains(byTopic) || byTopic.Total != 1 {
+		t.Fatalf("by topic = %+v", byTopic)
+	}
+
+	res = doRequest(t, http.MethodGet, "/logs/generation/list/7", nil, "")
+	expectStatus(t, res, http.StatusOK)
+	if !contains(decode[dto.PaginatedResult[dto.LogResponse]](t, res)) {
+		t.Fatalf("log not listed by generation")
+	}
+
+	res = doRequest(t, http.MethodGet, "/logs/search/list?q="+url.QueryEscape("유니크한"), nil, "")
+	expectStatus(t, res, http.StatusOK)
+	if !contains(decode[dto.PaginatedResult[dto.LogResponse]](t, res)) {
+		t.Fatalf("log not found by search")
+	}
+
+	expectStatus(t, doRequest(t, http.MethodGet, "/logs/search/list", nil, ""), http.StatusBadRequest)
+}
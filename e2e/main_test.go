@@ -0,0 +1,282 @@
+// Package e2e 는 실제 Spine 앱을 띄워 HTTP로 API를 검증하는 테스트입니다.
+//
+// 디비가 필요한 테스트는 다음 중 하나가 준비되어 있어야 실행되며, 없으면 건너뜁니다.
+//   - E2E_DB_HOST(E2E_DB_PORT, E2E_DB_USER, E2E_DB_PASSWORD, E2E_DB_NAME) 환경 변수
+//     (docker compose --profile test up -d postgres-test 로 띄운 디비 등)
+//   - PATH 또는 /usr/lib/postgresql/*/bin 에 있는 initdb, pg_ctl
+//
+// 매 실행마다 새 데이터베이스를 만들고 마이그레이션한 뒤, 끝나면 삭제합니다.
+// An-Account, An-Americano는 pkg/fakeana 대역 서버로 대체합니다.
+package e2e
+
+import (
+	"analog-be/pkg"
+	"analog-be/pkg/fakeana"
+	"analog-be/server"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+var (
+	baseURL string
+	fake    *fakeana.Server
+
+	// 디비를 준비하지 못했으면 그 이유가 담김
+	dbSkipReason string
+
+	// HTTP로 만들 수 없는 상태(만료된 세션 등)를 준비할 때 사용
+	testDB *bun.DB
+
+	// 마이그레이션 전 상태가 필요한 테스트가 별도 데이터베이스를 만들 때 사용
+	adminDBConfig server.DBConfig
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	// 사이트맵을 작업 디렉토리에 쓰므로 임시 디렉토리에서 실행
+	workDir, err := os.MkdirTemp("", "analog-e2e-*")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer os.RemoveAll(workDir)
+
+	if err := os.Chdir(workDir); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	pkg.Logger = zap.NewNop()
+
+	os.Setenv("ARITCLE_URL_FORMAT", "https://log.ana.st/%s/logs/%s")
+	os.Setenv("SITEMAP_PREFIX", "https://log.ana.st/sitemaps/")
+	os.Setenv("RATE_LIMIT_RPS", "10000")
+	os.Setenv("RATE_LIMIT_BURST", "10000")
+	os.Setenv("COMMENT_MAX_DEPTH", "2")
+	os.Setenv("HEALTH_METRICS_ENABLED", "true")
+	os.Setenv("EMAIL_UNSUBSCRIBE_URL", "https://api.ana.st/api/email/unsubscribe")
+
+	fake = fakeana.New(fakeana.Config{
+		ClientID:     fakeana.TestClientID,
+		ClientSecret: fakeana.TestClientSecret,
+		APIToken:     fakeana.TestAPIToken,
+	})
+	fake.Start()
+	defer fake.Close()
+
+	os.Setenv("AN_ACCOUNT_BASE_URL", fake.URL())
+	os.Setenv("AN_AMERICANO_BASE_URL", fake.URL())
+	os.Setenv("AN_ACCOUNT_CLIENT_ID", fakeana.TestClientID)
+	os.Setenv("AN_ACCOUNT_CLIENT_SECRET", fakeana.TestClientSecret)
+	os.Setenv("OAUTH_STATE_SECRET", "e2e-oauth-state-secret-0123456789abcdef")
+	os.Setenv("AN_ACCOUNT_API_TOKEN", fakeana.TestAPIToken)
+	os.Setenv("OAUTH_REDIRECT_ALLOWLIST", testRedirectURI)
+	// 대역 서버를 An-Account 외의 OIDC 제공자로도 사용
+	os.Setenv("IDP_PROVIDERS", "guest")
+	os.Setenv("IDP_GUEST_ISSUER", fake.URL())
+	os.Setenv("IDP_GUEST_CLIENT_ID", fakeana.TestClientID)
+	os.Setenv("IDP_GUEST_CLIENT_SECRET", fakeana.TestClientSecret)
+
+	db, cleanup, err := openTestDB(workDir)
+	if err != nil {
+		// 디비 없이도 인증 실패, 라우팅 같은 테스트는 돌 수 있도록 닿지 않는 주소로 연결
+		dbSkipReason = err.Error()
+		db = server.NewDB(server.DBConfig{Host: "127.0.0.1", Port: "1", User: "none", Database: "none"})
+	} else {
+		testDB = db
+		defer cleanup()
+	}
+	defer db.Close()
+
+	e, err := startApp(db)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		e.Shutdown(ctx)
+	}()
+
+	return m.Run()
+}
+
+// startApp 은 main과 같은 구성으로 앱을 임의 포트에 띄우고 주소가 잡힐 때까지 기다립니다.
+func startApp(db *bun.DB) (*echo.Echo, error) {
+	app := server.NewApp(db, zap.NewNop())
+
+	echoCh := make(chan *echo.Echo, 1)
+	app.Transport(func(t any) {
+		echoCh <- t.(*echo.Echo)
+	})
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- app.Run(server.BootOptions("127.0.0.1:0", false))
+	}()
+
+	var e *echo.Echo
+	select {
+	case e = <-echoCh:
+	case err := <-errCh:
+		return nil, err
+	case <-time.After(10 * time.Second):
+		return nil, errors.New("app did not start")
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		if addr := e.ListenerAddr(); addr != nil {
+			baseURL = "http://" + addr.String() + server.GlobalPrefix
+			return e, nil
+		}
+
+		select {
+		case err := <-errCh:
+			return nil, err
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	return nil, errors.New("app did not start listening")
+}
+
+// openTestDB 는 테스트용 데이터베이스를 새로 만들고 마이그레이션합니다.
+func openTestDB(workDir string) (*bun.DB, func(), error) {
+	admin := server.DBConfig{
+		Host:     os.Getenv("E2E_DB_HOST"),
+		Port:     server.GetEnv("E2E_DB_PORT", "5434"),
+		User:     server.GetEnv("E2E_DB_USER", "test"),
+		Password: server.GetEnv("E2E_DB_PASSWORD", "test"),
+		Database: server.GetEnv("E2E_DB_NAME", "postgres"),
+	}
+
+	stopCluster := func() {}
+	if admin.Host == "" {
+		port, stop, err := startLocalPostgres(filepath.Join(workDir, "pgdata"))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		stopCluster = stop
+		admin = server.DBConfig{Host: "127.0.0.1", Port: port, User: "analog", Database: "postgres"}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	db, dropDB, err := createDatabase(ctx, admin)
+	if err != nil {
+		stopCluster()
+		return nil, nil, err
+	}
+	adminDBConfig = admin
+
+	cleanup := func() {
+		dropDB()
+		stopCluster()
+	}
+
+	if _, err := server.Migrate(ctx, db); err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("migrate: %w", err)
+	}
+
+	return db, cleanup, nil
+}
+
+// createDatabase 는 admin 클러스터에 빈 데이터베이스를 만들어 연결합니다. 돌려준 함수는 연결을 닫고 데이터베이스를 지웁니다.
+func createDatabase(ctx context.Context, admin server.DBConfig) (*bun.DB, func(), error) {
+	adminDB := server.NewDB(admin)
+	name := fmt.Sprintf("analog_e2e_%d", time.Now().UnixNano())
+	if _, err := adminDB.ExecContext(ctx, "CREATE DATABASE "+name); err != nil {
+		adminDB.Close()
+		return nil, nil, fmt.Errorf("create database: %w", err)
+	}
+
+	config := admin
+	config.Database = name
+	config.ApplicationName = "analog-e2e"
+	db := server.NewDB(config)
+
+	drop := func() {
+		db.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		adminDB.ExecContext(ctx, "DROP DATABASE IF EXISTS "+name+" WITH (FORCE)")
+		adminDB.Close()
+	}
+
+	return db, drop, nil
+}
+
+// startLocalPostgres 는 로컬 PostgreSQL 바이너리로 일회용 클러스터를 띄웁니다.
+func startLocalPostgres(dataDir string) (string, func(), error) {
+	binDir, err := findPostgresBin()
+	if err != nil {
+		return "", nil, err
+	}
+
+	port, err := freePort()
+	if err != nil {
+		return "", nil, err
+	}
+
+	initdb := exec.Command(filepath.Join(binDir, "initdb"), "-D", dataDir, "-U", "analog", "--auth=trust", "--no-sync")
+	if out, err := initdb.CombinedOutput(); err != nil {
+		return "", nil, fmt.Errorf("initdb: %w: %s", err, out)
+	}
+
+	pgCtl := filepath.Join(binDir, "pg_ctl")
+	options := fmt.Sprintf("-p %s -k %s -c listen_addresses=127.0.0.1 -c fsync=off", port, dataDir)
+	start := exec.Command(pgCtl, "-D", dataDir, "-o", options, "-l", filepath.Join(dataDir, "postgres.log"), "-w", "start")
+	if out, err := start.CombinedOutput(); err != nil {
+		return "", nil, fmt.Errorf("pg_ctl start: %w: %s", err, out)
+	}
+
+	stop := func() {
+		exec.Command(pgCtl, "-D", dataDir, "-m", "immediate", "stop").Run()
+	}
+
+	return port, stop, nil
+}
+
+func findPostgresBin() (string, error) {
+	if path, err := exec.LookPath("initdb"); err == nil {
+		return filepath.Dir(path), nil
+	}
+
+	matches, _ := filepath.Glob("/usr/lib/postgresql/*/bin/initdb")
+	if len(matches) > 0 {
+		return filepath.Dir(matches[len(matches)-1]), nil
+	}
+
+	return "", errors.New("no database: set E2E_DB_HOST or install PostgreSQL (initdb, pg_ctl)")
+}
+
+func freePort() (string, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer l.Close()
+
+	return strconv.Itoa(l.Addr().(*net.TCPAddr).Port), nil
+}
@@ -0,0 +1,72 @@
+package e2e
+
+import (
+	"analog-be/repository"
+	"analog-be/service"
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func countRows(t *testing.T, query string, args ...any) int {
+	t.Helper()
+
+	var n int
+	if err := testDB.QueryRowContext(context.Background(), query, args...).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestMaintenanceRemovesStaleRecords(t *testing.T) {
+	requireDB(t)
+
+	ctx := context.Background()
+	user := signup(t, "정리 대상")
+	execSQL(t, "UPDATE sessions SET expires_at = ? WHERE user_id = ?", time.Now().UTC().Add(-time.Minute), user.User.ID)
+	execSQL(t, `INSERT INTO permission_outbox (operation, namespace, object_id, relation, user_id, status, processed_at)
+		VALUES ('write', 'maintenance', 1, 'owner', ?, 'done', ?)`, user.User.ID, time.Now().UTC().Add(-30*24*time.Hour))
+	execSQL(t, "INSERT INTO email_unsubscribe_tokens (token_hash, user_id, email, expires_at) VALUES ('maintenance', ?, 'a@ana.st', ?)",
+		user.User.ID, time.Now().UTC().Add(-time.Minute))
+
+	locks := repository.NewLockRepository(testDB)
+	runner := service.NewMaintenanceRunner(
+		locks,
+		repository.NewSessionRepository(testDB, nil),
+		repository.NewOAuthStateRepository(testDB),
+		repository.NewPermissionOutboxRepository(testDB),
+		repository.NewAccessTokenRepository(testDB),
+		repository.NewRealtimeEventRepository(testDB),
+		repository.NewEmailOutboxRepository(testDB),
+		repository.NewEmailSettingsRepository(testDB),
+		zap.NewNop(),
+	)
+
+	// 다른 인스턴스가 잠금을 잡고 있으면 아무것도 지우지 않음
+	unlock, err := locks.TryLock(ctx, service.MaintenanceLockKey)
+	if err != nil || unlock == nil {
+		t.Fatalf("lock = %v, %v", unlock != nil, err)
+	}
+	if err := runner.RunOnce(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n := countRows(t, "SELECT count(*) FROM sessions WHERE user_id = ?", user.User.ID); n != 1 {
+		t.Fatalf("sessions removed while locked: %d left", n)
+	}
+	unlock()
+
+	if err := runner.RunOnce(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n := countRows(t, "SELECT count(*) FROM sessions WHERE user_id = ?", user.User.ID); n != 0 {
+		t.Fatalf("%d expired sessions left", n)
+	}
+	if n := countRows(t, "SELECT count(*) FROM permission_outbox WHERE namespace = 'maintenance'"); n != 0 {
+		t.Fatalf("%d processed outbox entries left", n)
+	}
+	if n := countRows(t, "SELECT count(*) FROM email_unsubscribe_tokens WHERE user_id = ?", user.User.ID); n != 0 {
+		t.Fatalf("%d expired unsubscribe tokens left", n)
+	}
+}
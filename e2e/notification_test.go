@@ -0,0 +1,161 @@
+package e2e
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func listNotifications(t *testing.T, token string) dto.PaginatedResult[dto.NotificationResponse] {
+	t.Helper()
+
+	res := doRequest(t, http.MethodGet, "/notifications", nil, token)
+	expectStatus(t, res, http.StatusOK)
+	return decode[dto.PaginatedResult[dto.NotificationResponse]](t, res)
+}
+
+func unreadCount(t *testing.T, token string) int {
+	t.Helper()
+
+	res := doRequest(t, http.MethodGet, "/notifications/unread-count", nil, token)
+	expectStatus(t, res, http.StatusOK)
+	return decode[dto.NotificationUnreadCountResponse](t, res).Count
+}
+
+func TestNotificationGrouping(t *testing.T) {
+	requireDB(t)
+
+	author := signup(t, "알림받는이")
+	first := signup(t, "첫댓글러")
+	second := signup(t, "둘째댓글러")
+
+	created := createLog(t, author.SessionToken, dto.LogCreateRequest{Title: "알림 로그"})
+	commentsPath := fmt.Sprintf("/logs/%d/comments", created.ID)
+
+	expectStatus(t, doRequest(t, http.MethodGet, "/notifications", nil, ""), http.StatusUnauthorized)
+
+	res := doRequest(t, http.MethodPost, commentsPath, dto.CommentCreateRequest{Content: "첫 댓글"}, first.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	firstComment := decode[dto.CommentResponse](t, res)
+
+	expectStatus(t, doRequest(t, http.MethodPost, commentsPath, dto.CommentCreateRequest{Content: "둘째 댓글"}, second.SessionToken), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodPost, commentsPath, dto.CommentCreateRequest{Content: "또 댓글"}, first.SessionToken), http.StatusOK)
+
+	// 같은 로그의 읽지 않은 댓글 알림은 하나로 묶이고, 같은 사람은 한 번만 셈
+	list := listNotifications(t, author.SessionToken)
+	if list.Total != 1 {
+		t.Fatalf("notifications = %+v", list)
+	}
+	grouped := list.Items[0]
+	if grouped.Type != entity.NotificationComment || grouped.ActorCount != 2 || grouped.Actor == nil || grouped.Actor.ID != first.User.ID || grouped.LogTitle != "알림 로그" || grouped.Read {
+		t.Fatalf("grouped notification = %+v", grouped)
+	}
+	if got := unreadCount(t, author.SessionToken); got != 1 {
+		t.Fatalf("unread count = %d", got)
+	}
+
+	// 답글에서 멘션까지 했으면 멘션 알림 하나만 감
+	reply := dto.CommentCreateRequest{Content: fmt.Sprintf("@%s 동의해요", first.User.Handle), ParentID: &firstComment.ID}
+	expectStatus(t, doRequest(t, http.MethodPost, commentsPath, reply, second.SessionToken), http.StatusOK)
+	if list := listNotifications(t, first.SessionToken); list.Total != 1 || list.Items[0].Type != entity.NotificationMention {
+		t.Fatalf("replied user's notifications = %+v", list)
+	}
+
+	// 남의 알림은 읽음 처리할 수 없음
+	readPath := fmt.Sprintf("/notifications/%d/read", grouped.ID)
+	expectStatus(t, doRequest(t, http.MethodPut, readPath, nil, first.SessionToken), http.StatusNotFound)
+	expectStatus(t, doRequest(t, http.MethodPut, readPath, nil, author.SessionToken), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodPut, readPath, nil, author.SessionToken), http.StatusOK)
+	if got := unreadCount(t, author.SessionToken); got != 0 {
+		t.Fatalf("unread count after read = %d", got)
+	}
+
+	// 읽은 뒤에 온 댓글은 새 알림이 됨
+	expectStatus(t, doRequest(t, http.MethodPost, commentsPath, dto.CommentCreateRequest{Content: "읽은 뒤 댓글"}, second.SessionToken), http.StatusOK)
+	list = listNotifications(t, author.SessionToken)
+	if list.Total != 2 || list.Items[0].ActorCount != 1 || list.Items[0].Read || !list.Items[1].Read {
+		t.Fatalf("notifications after read = %+v", list)
+	}
+
+	res = doRequest(t, http.MethodGet, "/notifications?unread=true", nil, author.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if unread := decode[dto.PaginatedResult[dto.NotificationResponse]](t, res); unread.Total != 1 {
+		t.Fatalf("unread notifications = %+v", unread)
+	}
+
+	res = doRequest(t, http.MethodPut, "/notifications/read-all", nil, author.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if got := decode[dto.NotificationReadAllResponse](t, res); got.Updated != 1 {
+		t.Fatalf("read all = %+v", got)
+	}
+	if got := unreadCount(t, author.SessionToken); got != 0 {
+		t.Fatalf("unread count after read all = %d", got)
+	}
+}
+
+func TestNotificationPreferences(t *testing.T) {
+	requireDB(t)
+
+	author := signup(t, "알림끄는이")
+	fan := signup(t, "알림보내는이")
+
+	res := doRequest(t, http.MethodGet, "/notifications/preferences", nil, author.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	preferences := decode[[]dto.NotificationPreferenceResponse](t, res)
+	if len(preferences) != len(entity.NotificationTypes) {
+		t.Fatalf("preferences = %+v", preferences)
+	}
+	for _, p := range preferences {
+		if !p.InApp || !p.Email {
+			t.Fatalf("default preference = %+v", p)
+		}
+	}
+
+	invalid := dto.NotificationPreferencesUpdateRequest{Preferences: []dto.NotificationPreferenceRequest{{Type: "spam"}}}
+	expectStatus(t, doRequest(t, http.MethodPut, "/notifications/preferences", invalid, author.SessionToken), http.StatusBadRequest)
+
+	inApp := false
+	off := dto.NotificationPreferencesUpdateRequest{Preferences: []dto.NotificationPreferenceRequest{{Type: entity.NotificationReaction, InApp: &inApp}}}
+	res = doRequest(t, http.MethodPut, "/notifications/preferences", off, author.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	for _, p := range decode[[]dto.NotificationPreferenceResponse](t, res) {
+		// 보내지 않은 메일 설정은 그대로
+		if p.InApp != (p.Type != entity.NotificationReaction) || !p.Email {
+			t.Fatalf("preference after update = %+v", p)
+		}
+	}
+
+	created := createLog(t, author.SessionToken, dto.LogCreateRequest{Title: "반응 알림 끈 로그", CoAuthorIDs: []entity.ID{fan.User.ID}})
+	expectStatus(t, doRequest(t, http.MethodPut, fmt.Sprintf("/logs/%d/reactions/heart", created.ID), nil, fan.SessionToken), http.StatusOK)
+	if got := unreadCount(t, author.SessionToken); got != 0 {
+		t.Fatalf("unread count with reactions off = %d", got)
+	}
+
+	// 공동 작성자로 추가된 사람은 알림을 받음
+	if list := listNotifications(t, fan.SessionToken); list.Total != 1 || list.Items[0].Type != entity.NotificationCoAuthor || list.Items[0].LogID != created.ID {
+		t.Fatalf("co-author notifications = %+v", list)
+	}
+}
+
+func TestRepeatedReactionDoesNotNotifyAgain(t *testing.T) {
+	requireDB(t)
+
+	author := signup(t, "반응받는이")
+	fan := signup(t, "반응다시다는이")
+
+	created := createLog(t, author.SessionToken, dto.LogCreateRequest{Title: "반응 알림 로그"})
+	reactionPath := fmt.Sprintf("/logs/%d/reactions/heart", created.ID)
+	expectStatus(t, doRequest(t, http.MethodPut, reactionPath, nil, fan.SessionToken), http.StatusOK)
+	if got := unreadCount(t, author.SessionToken); got != 1 {
+		t.Fatalf("unread count after reaction = %d", got)
+	}
+	expectStatus(t, doRequest(t, http.MethodPut, "/notifications/read-all", nil, author.SessionToken), http.StatusOK)
+
+	// 이미 단 반응을 다시 달면 알림이 새로 생기지 않음
+	expectStatus(t, doRequest(t, http.MethodPut, reactionPath, nil, fan.SessionToken), http.StatusOK)
+	if got := unreadCount(t, author.SessionToken); got != 0 {
+		t.Fatalf("unread count after repeated reaction = %d", got)
+	}
+}
@@ -0,0 +1,119 @@
+package e2e
+
+import (
+	"analog-be/entity"
+	migrations "analog-be/migration"
+	"analog-be/repository"
+	"analog-be/server"
+	"analog-be/service"
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sunrin-ana/anamericano-golang"
+	"github.com/uptrace/bun/migrate"
+	"go.uber.org/zap"
+)
+
+// 권한 관계를 기록하기 전에 만들어진 로그는 An-Americano의 권한으로 작성자를 가져오고 권한을 회수하지 않아야 함
+func TestPermissionReconcileLogBeforeRelations(t *testing.T) {
+	requireDB(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	db, drop, err := createDatabase(ctx, adminDBConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer drop()
+
+	// 첫 마이그레이션만 적용한 예전 스키마
+	initial := migrate.NewMigrations()
+	initial.Add(migrations.Migrations.Sorted()[0])
+	migrator := migrate.NewMigrator(db, initial)
+	if err := migrator.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Migrate(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	owner := newAccount("예전작성자")
+	editor := newAccount("예전공동작성자")
+	// 테스트 앱의 로그와 An-Americano 권한이 겹치지 않는 아이디
+	logID := int64(900_000_000) + owner
+	// 수정하면서 작성자 행이 모두 지워진 로그
+	emptiedLogID := logID + 1
+
+	for _, id := range []int64{owner, editor} {
+		if _, err := db.ExecContext(ctx, "INSERT INTO users (id, name, handle) VALUES (?, ?, ?)", id, "user", "user"+strconv.FormatInt(id, 10)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, id := range []int64{logID, emptiedLogID} {
+		if _, err := db.ExecContext(ctx, "INSERT INTO logs (id, title, description, content, pre_rendered) VALUES (?, '예전 로그', '', '', '')", id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 예전에는 공동 작성자만 행으로 저장하고 작성자는 An-Americano 권한만 기록했음
+	if _, err := db.ExecContext(ctx, "INSERT INTO log_to_users (user_id, log_id) VALUES (?, ?)", editor, logID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := server.Migrate(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+
+	grants := []struct {
+		logID    int64
+		userID   int64
+		relation string
+	}{
+		{logID, owner, entity.RelationOwner},
+		{logID, editor, entity.RelationEditor},
+		{emptiedLogID, owner, entity.RelationOwner},
+	}
+	for _, g := range grants {
+		fake.GrantPermission(anamericano.Permission{
+			ObjectNamespace: entity.LogPermissionNamespace,
+			ObjectID:        strconv.FormatInt(g.logID, 10),
+			Relation:        g.relation,
+			SubjectType:     "user",
+			SubjectID:       strconv.FormatInt(g.userID, 10),
+		})
+	}
+
+	reconciler := service.NewPermissionReconciler(repository.NewLogRepository(db), repository.NewUserRepository(db), service.NewAnAmericanoService(), zap.NewNop())
+	report, err := reconciler.Reconcile(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Written != 0 || report.Deleted != 0 || report.Imported != 2 || len(report.Errors) != 0 {
+		t.Fatalf("report = %+v", report)
+	}
+
+	for _, g := range grants {
+		if !fake.HasPermission(entity.LogPermissionNamespace, strconv.FormatInt(g.logID, 10), g.relation, "user", strconv.FormatInt(g.userID, 10)) {
+			t.Fatalf("%s permission on log %d was revoked", g.relation, g.logID)
+		}
+
+		var relation string
+		if err := db.QueryRowContext(ctx, "SELECT relation FROM log_to_users WHERE log_id = ? AND user_id = ?", g.logID, g.userID).Scan(&relation); err != nil {
+			t.Fatalf("author %d of log %d was not imported: %v", g.userID, g.logID, err)
+		}
+		if relation != g.relation {
+			t.Fatalf("log %d user %d relation = %q, want %q", g.logID, g.userID, relation, g.relation)
+		}
+	}
+
+	// 가져온 뒤에는 행을 기준으로 비교하므로 바꿀 것이 없음
+	report, err = reconciler.Reconcile(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Written != 0 || report.Deleted != 0 || report.Imported != 0 || len(report.Errors) != 0 {
+		t.Fatalf("second report = %+v", report)
+	}
+}
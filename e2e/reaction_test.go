@@ -0,0 +1,116 @@
+package e2e
+
+import (
+	"analog-be/dto"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestLogReactions(t *testing.T) {
+	requireDB(t)
+
+	author := signup(t, "반응받는이")
+	fan := signup(t, "반응하는이")
+	other := signup(t, "또다른팬")
+
+	created := createLog(t, author.SessionToken, dto.LogCreateRequest{Title: "반응 달릴 로그"})
+	reactionPath := func(kind string) string { return fmt.Sprintf("/logs/%d/reactions/%s", created.ID, kind) }
+
+	res := doRequest(t, http.MethodGet, "/reactions", nil, "")
+	expectStatus(t, res, http.StatusOK)
+	if kinds := decode[[]string](t, res); !slices.Contains(kinds, "heart") {
+		t.Fatalf("reaction kinds = %v", kinds)
+	}
+
+	expectStatus(t, doRequest(t, http.MethodPut, reactionPath("heart"), nil, ""), http.StatusUnauthorized)
+	expectStatus(t, doRequest(t, http.MethodPut, reactionPath("poop"), nil, fan.SessionToken), http.StatusBadRequest)
+	expectStatus(t, doRequest(t, http.MethodPut, fmt.Sprintf("/logs/%d/reactions/heart", created.ID+100000), nil, fan.SessionToken), http.StatusNotFound)
+
+	// 같은 반응을 두 번 달아도 한 번만 셈
+	expectStatus(t, doRequest(t, http.MethodPut, reactionPath("heart"), nil, fan.SessionToken), http.StatusOK)
+	res = doRequest(t, http.MethodPut, reactionPath("heart"), nil, fan.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if got := decode[dto.ReactionsResponse](t, res); got.Counts["heart"] != 1 || !slices.Equal(got.Mine, []string{"heart"}) {
+		t.Fatalf("reactions after duplicate = %+v", got)
+	}
+
+	expectStatus(t, doRequest(t, http.MethodPut, reactionPath("rocket"), nil, fan.SessionToken), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodPut, reactionPath("heart"), nil, other.SessionToken), http.StatusOK)
+
+	logPath := fmt.Sprintf("/logs/%d", created.ID)
+	res = doRequest(t, http.MethodGet, logPath, nil, fan.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	got := decode[dto.LogResponse](t, res)
+	if want := map[string]int{"heart": 2, "rocket": 1}; !maps.Equal(got.Reactions.Counts, want) {
+		t.Fatalf("counts = %v, want %v", got.Reactions.Counts, want)
+	}
+	if !slices.Equal(got.Reactions.Mine, []string{"heart", "rocket"}) {
+		t.Fatalf("mine = %v", got.Reactions.Mine)
+	}
+
+	res = doRequest(t, http.MethodGet, logPath, nil, "")
+	expectStatus(t, res, http.StatusOK)
+	if got := decode[dto.LogResponse](t, res); len(got.Reactions.Mine) != 0 || got.Reactions.Counts["heart"] != 2 {
+		t.Fatalf("anonymous reactions = %+v", got.Reactions)
+	}
+
+	// 마지막 반응을 떼면 종류가 목록에서 빠짐
+	res = doRequest(t, http.MethodDelete, reactionPath("rocket"), nil, fan.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if got := decode[dto.ReactionsResponse](t, res); !maps.Equal(got.Counts, map[string]int{"heart": 2}) {
+		t.Fatalf("counts after remove = %v", got.Counts)
+	}
+	expectStatus(t, doRequest(t, http.MethodDelete, reactionPath("rocket"), nil, fan.SessionToken), http.StatusOK)
+
+	// 탈퇴하면 그 사용자의 반응이 개수에서 빠짐
+	expectStatus(t, doRequest(t, http.MethodDelete, "/users", nil, other.SessionToken), http.StatusOK)
+	res = doRequest(t, http.MethodGet, logPath, nil, "")
+	expectStatus(t, res, http.StatusOK)
+	if got := decode[dto.LogResponse](t, res); !maps.Equal(got.Reactions.Counts, map[string]int{"heart": 1}) {
+		t.Fatalf("counts after user deletion = %v", got.Reactions.Counts)
+	}
+}
+
+func TestCommentReactions(t *testing.T) {
+	requireDB(t)
+
+	owner := signup(t, "댓글반응주인")
+	commenter := signup(t, "댓글반응작성자")
+	fan := signup(t, "댓글반응팬")
+
+	created := createLog(t, owner.SessionToken, dto.LogCreateRequest{Title: "댓글 반응 로그"})
+	commentsPath := fmt.Sprintf("/logs/%d/comments", created.ID)
+	quiet := postComment(t, created.ID, commenter.SessionToken, dto.CommentCreateRequest{Content: "조용한 댓글"})
+	popular := postComment(t, created.ID, commenter.SessionToken, dto.CommentCreateRequest{Content: "인기 댓글"})
+	reactionPath := func(commentID any, kind string) string {
+		return fmt.Sprintf("%s/%v/reactions/%s", commentsPath, commentID, kind)
+	}
+
+	expectStatus(t, doRequest(t, http.MethodPut, reactionPath(popular.ID, "eyes"), nil, fan.SessionToken), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodPut, reactionPath(popular.ID, "heart"), nil, owner.SessionToken), http.StatusOK)
+
+	// 반응 순 정렬은 종류를 가리지 않은 합계를 씀
+	res := doRequest(t, http.MethodGet, commentsPath+"?sort=reactions", nil, fan.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	list := decode[dto.PaginatedResult[dto.CommentResponse]](t, res)
+	if len(list.Items) != 2 || list.Items[0].ID != popular.ID {
+		t.Fatalf("reaction sort = %+v", list.Items)
+	}
+	if first := list.Items[0].Reactions; !maps.Equal(first.Counts, map[string]int{"eyes": 1, "heart": 1}) || !slices.Equal(first.Mine, []string{"eyes"}) {
+		t.Fatalf("comment reactions = %+v", first)
+	}
+
+	// 숨긴 댓글과 댓글을 끈 로그에는 새 반응을 달 수 없지만 뗄 수는 있음
+	expectStatus(t, doRequest(t, http.MethodPut, fmt.Sprintf("%s/%d/hidden", commentsPath, quiet.ID), nil, owner.SessionToken), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodPut, reactionPath(quiet.ID, "heart"), nil, fan.SessionToken), http.StatusBadRequest)
+
+	modePath := fmt.Sprintf("/logs/%d/comment-mode", created.ID)
+	expectStatus(t, doRequest(t, http.MethodPut, modePath, dto.CommentModeUpdateRequest{Mode: "off"}, owner.SessionToken), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodPut, reactionPath(popular.ID, "rocket"), nil, fan.SessionToken), http.StatusForbidden)
+	expectStatus(t, doRequest(t, http.MethodDelete, reactionPath(popular.ID, "eyes"), nil, fan.SessionToken), http.StatusOK)
+
+	expectStatus(t, doRequest(t, http.MethodPut, reactionPath(popular.ID+100000, "heart"), nil, fan.SessionToken), http.StatusNotFound)
+}
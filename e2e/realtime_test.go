@@ -0,0 +1,223 @@
+package e2e
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"analog-be/repository"
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type streamEvent struct {
+	ID   string
+	Type string
+	Data string
+}
+
+// eventStream 은 SSE 응답을 이벤트 단위로 읽습니다.
+type eventStream struct {
+	events chan streamEvent
+	cancel context.CancelFunc
+}
+
+func openEventStream(t *testing.T, path, token, lastEventID string) *eventStream {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		cancel()
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		resp.Body.Close()
+		cancel()
+		t.Fatalf("stream status = %d, content type = %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	s := &eventStream{events: make(chan streamEvent, 16), cancel: cancel}
+	go func() {
+		defer resp.Body.Close()
+		defer close(s.events)
+
+		var event streamEvent
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				if event.Type != "" || event.Data != "" {
+					s.events <- event
+				}
+				event = streamEvent{}
+			case strings.HasPrefix(line, "id: "):
+				event.ID = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				event.Type = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				event.Data += strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *eventStream) Close() {
+	s.cancel()
+}
+
+func (s *eventStream) next(t *testing.T) streamEvent {
+	t.Helper()
+
+	select {
+	case event, ok := <-s.events:
+		if !ok {
+			t.Fatal("stream closed")
+		}
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event within 5s")
+	}
+	return streamEvent{}
+}
+
+func TestRealtimeEvents(t *testing.T) {
+	requireDB(t)
+
+	author := signup(t, "실시간글쓴이")
+	commenter := signup(t, "실시간댓글러")
+
+	created := createLog(t, author.SessionToken, dto.LogCreateRequest{Title: "실시간 로그"})
+	eventsPath := fmt.Sprintf("/events?logs=%d", created.ID)
+
+	expectStatus(t, doRequest(t, http.MethodGet, "/events?logs=abc", nil, ""), http.StatusBadRequest)
+
+	stream := openEventStream(t, eventsPath, author.SessionToken, "")
+	ready := stream.next(t)
+	if ready.Type != "ready" || ready.ID == "" {
+		t.Fatalf("first event = %+v", ready)
+	}
+
+	res := doRequest(t, http.MethodPost, fmt.Sprintf("/logs/%d/comments", created.ID), dto.CommentCreateRequest{Content: "실시간 댓글"}, commenter.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	comment := decode[dto.CommentResponse](t, res)
+
+	// 댓글은 로그 채널로, 알림은 로그 작성자에게만 감
+	got := map[string]streamEvent{}
+	for range 2 {
+		event := stream.next(t)
+		got[event.Type] = event
+	}
+	var streamed dto.CommentResponse
+	if err := json.Unmarshal([]byte(got[entity.RealtimeComment].Data), &streamed); err != nil || streamed.ID != comment.ID {
+		t.Fatalf("comment event = %+v", got[entity.RealtimeComment])
+	}
+	var notification dto.NotificationResponse
+	if err := json.Unmarshal([]byte(got[entity.RealtimeNotification].Data), &notification); err != nil || notification.Type != entity.NotificationComment {
+		t.Fatalf("notification event = %+v", got[entity.RealtimeNotification])
+	}
+	stream.Close()
+
+	// 끊긴 동안 올라온 로그는 다시 연결할 때 받음
+	other := createLog(t, commenter.SessionToken, dto.LogCreateRequest{Title: "끊긴 동안 올라온 로그"})
+
+	resumed := openEventStream(t, eventsPath, commenter.SessionToken, ready.ID)
+	var replayed []string
+	for {
+		event := resumed.next(t)
+		if event.Type == "ready" {
+			break
+		}
+		replayed = append(replayed, event.Type)
+		if event.Type == entity.RealtimeLog && !strings.Contains(event.Data, fmt.Sprintf(`"id":%d`, other.ID)) {
+			t.Fatalf("log event = %+v", event)
+		}
+	}
+	// 다른 사람의 알림은 되돌려 받지 않음
+	if strings.Join(replayed, ",") != "comment,log" {
+		t.Fatalf("replayed = %v", replayed)
+	}
+
+	// 정리되어 이어 줄 수 없으면 reset을 보냄
+	execSQL(t, "DELETE FROM realtime_events WHERE id <= ?", ready.ID)
+	execSQL(t, "DELETE FROM realtime_events WHERE id = (SELECT MIN(id) FROM realtime_events)")
+	if event := openEventStream(t, eventsPath, "", ready.ID).next(t); event.Type != "reset" || event.ID == "" {
+		t.Fatalf("stale resume = %+v", event)
+	}
+}
+
+// 동시에 저장한 이벤트도 아이디 순서대로 보여야 Last-Event-ID 이후를 읽을 때 놓치지 않음
+func TestRealtimeEventsBecomeVisibleInIDOrder(t *testing.T) {
+	requireDB(t)
+
+	ctx := context.Background()
+	channel := fmt.Sprintf("test:%d", time.Now().UnixNano())
+	repo := repository.NewRealtimeEventRepository(testDB)
+
+	tx, err := testDB.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	first := &entity.RealtimeEvent{Channel: channel, Type: "first", Data: json.RawMessage(`{}`)}
+	if err := repository.NewRealtimeEventRepository(tx).Create(ctx, []*entity.RealtimeEvent{first}); err != nil {
+		t.Fatal(err)
+	}
+
+	second := &entity.RealtimeEvent{Channel: channel, Type: "second", Data: json.RawMessage(`{}`)}
+	done := make(chan error, 1)
+	go func() {
+		done <- repo.Create(ctx, []*entity.RealtimeEvent{second})
+	}()
+
+	// 먼저 시작한 트랜잭션이 커밋되기 전에는 나중 이벤트도 보이지 않음
+	time.Sleep(300 * time.Millisecond)
+	visible, err := repo.FindAfter(ctx, 0, []string{channel}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(visible) != 0 {
+		t.Fatalf("events visible before the first commit: %d", len(visible))
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := repo.FindAfter(ctx, 0, []string{channel}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].Type != "first" || events[1].Type != "second" {
+		t.Fatalf("events = %+v", events)
+	}
+
+	// 첫 이벤트 이후로 이어 받으면 나중 이벤트를 받음
+	after, err := repo.FindAfter(ctx, first.ID, []string{channel}, 10)
+	if err != nil || len(after) != 1 || after[0].ID != second.ID {
+		t.Fatalf("after first = %+v, %v", after, err)
+	}
+}
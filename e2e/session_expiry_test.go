@@ -0,0 +1,92 @@
+package e2e
+
+import (
+	"analog-be/dto"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func execSQL(t *testing.T, query string, args ...any) {
+	t.Helper()
+
+	if _, err := testDB.ExecContext(context.Background(), query, args...); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func sessionTimes(t *testing.T, userID int64) (createdAt, lastSeenAt, expiresAt time.Time) {
+	t.Helper()
+
+	err := testDB.QueryRowContext(context.Background(),
+		"SELECT created_at, last_seen_at, expires_at FROM sessions WHERE user_id = ?", userID,
+	).Scan(&createdAt, &lastSeenAt, &expiresAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return createdAt, lastSeenAt, expiresAt
+}
+
+func TestExpiredSessionIsRejected(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "만료")
+	execSQL(t, "UPDATE sessions SET expires_at = ? WHERE user_id = ?", time.Now().UTC().Add(-time.Minute), user.User.ID)
+
+	expectStatus(t, doRequest(t, http.MethodPost, "/logs", dto.LogCreateRequest{Title: "만료", Content: "본문"}, user.SessionToken), http.StatusUnauthorized)
+	expectStatus(t, doRequest(t, http.MethodGet, "/auth/me", nil, user.SessionToken), http.StatusUnauthorized)
+}
+
+func TestActivityExtendsIdleExpiry(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "활동")
+	now := time.Now().UTC()
+	execSQL(t, "UPDATE sessions SET last_seen_at = ?, expires_at = ? WHERE user_id = ?", now.Add(-time.Hour), now.Add(time.Hour), user.User.ID)
+
+	expectStatus(t, doRequest(t, http.MethodGet, "/auth/me", nil, user.SessionToken), http.StatusOK)
+
+	_, lastSeenAt, expiresAt := sessionTimes(t, user.User.ID)
+	if lastSeenAt.Before(now.Add(-time.Minute)) || expiresAt.Before(now.Add(24*time.Hour)) {
+		t.Fatalf("session not renewed: lastSeenAt=%s expiresAt=%s", lastSeenAt, expiresAt)
+	}
+}
+
+func TestRenewalStopsAtMaxLifetime(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "오래된 세션")
+	now := time.Now().UTC()
+	createdAt := now.Add(-30*24*time.Hour + time.Hour)
+	execSQL(t, "UPDATE sessions SET created_at = ?, last_seen_at = ?, expires_at = ? WHERE user_id = ?", createdAt, now.Add(-time.Hour), now.Add(time.Hour), user.User.ID)
+
+	expectStatus(t, doRequest(t, http.MethodGet, "/auth/me", nil, user.SessionToken), http.StatusOK)
+
+	_, _, expiresAt := sessionTimes(t, user.User.ID)
+	if expiresAt.After(now.Add(time.Hour + time.Minute)) {
+		t.Fatalf("renewed past max lifetime: %s", expiresAt)
+	}
+}
+
+func TestRefreshSessionRotatesToken(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "회전")
+
+	res := doRequest(t, http.MethodPost, "/auth/session/refresh", nil, user.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	refreshed := decode[dto.SessionRefreshResponse](t, res)
+	if refreshed.SessionToken == "" || refreshed.SessionToken == user.SessionToken {
+		t.Fatalf("token not rotated: %+v", refreshed)
+	}
+
+	expectStatus(t, doRequest(t, http.MethodGet, "/auth/me", nil, user.SessionToken), http.StatusUnauthorized)
+	expectStatus(t, doRequest(t, http.MethodPost, "/auth/session/refresh", nil, user.SessionToken), http.StatusUnauthorized)
+	expectStatus(t, doRequest(t, http.MethodGet, "/auth/me", nil, refreshed.SessionToken), http.StatusOK)
+
+	// 회전해도 같은 세션으로 남음
+	if sessions := listSessions(t, refreshed.SessionToken); len(sessions) != 1 || !sessions[0].Current {
+		t.Fatalf("unexpected sessions: %+v", sessions)
+	}
+}
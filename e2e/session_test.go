@@ -0,0 +1,100 @@
+package e2e
+
+import (
+	"analog-be/dto"
+	"analog-be/pkg"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func listSessions(t *testing.T, token string) []dto.SessionResponse {
+	t.Helper()
+
+	res := doRequest(t, http.MethodGet, "/auth/sessions", nil, token)
+	expectStatus(t, res, http.StatusOK)
+	return decode[[]dto.SessionResponse](t, res)
+}
+
+func TestListAndRevokeSessions(t *testing.T) {
+	requireDB(t)
+
+	accountID := newAccount("여러 기기")
+	first := decode[dto.AuthResponse](t, authorize(t, "signup", accountID))
+	second := decode[dto.AuthResponse](t, authorize(t, "login", accountID))
+	third := decode[dto.AuthResponse](t, authorize(t, "login", accountID))
+
+	sessions := listSessions(t, second.SessionToken)
+	if len(sessions) != 3 {
+		t.Fatalf("len = %d, want 3", len(sessions))
+	}
+
+	var current, other *dto.SessionResponse
+	for i := range sessions {
+		if sessions[i].IPAddress != "127.0.0.1" || sessions[i].UserAgent == "" {
+			t.Fatalf("client metadata not recorded: %+v", sessions[i])
+		}
+		if sessions[i].Current {
+			current = &sessions[i]
+		} else if other == nil {
+			other = &sessions[i]
+		}
+	}
+	if current == nil || other == nil {
+		t.Fatalf("current session not marked: %+v", sessions)
+	}
+
+	// 다른 사용자의 세션은 끊을 수 없음
+	stranger := signup(t, "남")
+	expectStatus(t, doRequest(t, http.MethodDelete, fmt.Sprintf("/auth/sessions/%d", other.ID), nil, stranger.SessionToken), http.StatusNotFound)
+
+	expectStatus(t, doRequest(t, http.MethodDelete, fmt.Sprintf("/auth/sessions/%d", other.ID), nil, second.SessionToken), http.StatusOK)
+	if remaining := listSessions(t, second.SessionToken); len(remaining) != 2 {
+		t.Fatalf("len = %d, want 2", len(remaining))
+	}
+
+	expectStatus(t, doRequest(t, http.MethodDelete, "/auth/sessions", nil, second.SessionToken), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodGet, "/auth/me", nil, first.SessionToken), http.StatusUnauthorized)
+	expectStatus(t, doRequest(t, http.MethodGet, "/auth/me", nil, third.SessionToken), http.StatusUnauthorized)
+	expectStatus(t, doRequest(t, http.MethodGet, "/auth/me", nil, second.SessionToken), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodGet, "/auth/me", nil, stranger.SessionToken), http.StatusOK)
+
+	if remaining := listSessions(t, second.SessionToken); len(remaining) != 1 || !remaining[0].Current {
+		t.Fatalf("unexpected sessions: %+v", remaining)
+	}
+}
+
+func TestSessionTokenIsStoredHashed(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "해시")
+
+	var raw, hashed int
+	err := testDB.QueryRowContext(context.Background(),
+		"SELECT count(*) FILTER (WHERE token_hash = ?), count(*) FILTER (WHERE token_hash = ?) FROM sessions WHERE user_id = ?",
+		user.SessionToken, pkg.HashToken(user.SessionToken), user.User.ID,
+	).Scan(&raw, &hashed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw != 0 || hashed != 1 {
+		t.Fatalf("raw = %d, hashed = %d", raw, hashed)
+	}
+}
+
+func TestCachedSessionSeesUserUpdates(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "캐시")
+	expectStatus(t, doRequest(t, http.MethodGet, "/auth/me", nil, user.SessionToken), http.StatusOK)
+
+	name := "캐시 갱신"
+	expectStatus(t, doRequest(t, http.MethodPut, "/users", dto.UserUpdateRequest{Name: &name}, user.SessionToken), http.StatusOK)
+
+	res := doRequest(t, http.MethodGet, "/auth/me", nil, user.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if got := decode[dto.UserDTO](t, res); got.Name != name {
+		t.Fatalf("name = %q, want %q", got.Name, name)
+	}
+}
@@ -0,0 +1,56 @@
+package e2e
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"net/http"
+	"testing"
+)
+
+func TestTopicCountsLogs(t *testing.T) {
+	requireDB(t)
+
+	expectStatus(t, doRequest(t, http.MethodPost, "/topic", dto.TopicCreateRequest{}, ""), http.StatusBadRequest)
+
+	res := doRequest(t, http.MethodPost, "/topic", dto.TopicCreateRequest{Name: "세는 토픽"}, "")
+	expectStatus(t, res, http.StatusCreated)
+	topic := decode[dto.TopicResponse](t, res)
+
+	user := signup(t, "토픽")
+	for i := 0; i < 2; i++ {
+		createLog(t, user.SessionToken, dto.LogCreateRequest{Title: "토픽 로그", TopicIDs: []entity.ID{topic.ID}})
+	}
+
+	res = doRequest(t, http.MethodGet, "/topic?size=100", nil, "")
+	expectStatus(t, res, http.StatusOK)
+
+	for _, item := range decode[[]dto.TopicResponse](t, res) {
+		if item.ID == topic.ID {
+			if item.Count != 2 {
+				t.Fatalf("count = %d, want 2", item.Count)
+			}
+			return
+		}
+	}
+	t.Fatalf("topic %d not listed", topic.ID)
+}
+
+func TestTopicPagination(t *testing.T) {
+	requireDB(t)
+
+	for _, name := range []string{"토픽 A", "토픽 B", "토픽 C"} {
+		expectStatus(t, doRequest(t, http.MethodPost, "/topic", dto.TopicCreateRequest{Name: name}, ""), http.StatusCreated)
+	}
+
+	res := doRequest(t, http.MethodGet, "/topic?page=1&size=2", nil, "")
+	expectStatus(t, res, http.StatusOK)
+	if topics := decode[[]dto.TopicResponse](t, res); len(topics) != 2 {
+		t.Fatalf("len = %d, want 2", len(topics))
+	}
+
+	res = doRequest(t, http.MethodGet, "/topic?page=100000", nil, "")
+	expectStatus(t, res, http.StatusOK)
+	if topics := decode[[]dto.TopicResponse](t, res); len(topics) != 0 {
+		t.Fatalf("len = %d, want 0", len(topics))
+	}
+}
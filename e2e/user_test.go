@@ -0,0 +1,56 @@
+package e2e
+
+import (
+	"analog-be/dto"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestUserProfile(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "프로필")
+	userPath := fmt.Sprintf("/users/%d", user.User.ID)
+
+	res := doRequest(t, http.MethodGet, userPath, nil, user.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if got := decode[dto.UserResponse](t, res); got.Name != "프로필" {
+		t.Fatalf("name = %q", got.Name)
+	}
+
+	name := "바뀐 프로필"
+	partOf := "ISDT"
+	res = doRequest(t, http.MethodPut, "/users", dto.UserUpdateRequest{Name: &name, PartOf: &partOf}, user.SessionToken)
+	expectStatus(t, res, http.StatusOK)
+	if got := decode[dto.UserResponse](t, res); got.Name != name || got.PartOf != partOf {
+		t.Fatalf("unexpected update: %+v", got)
+	}
+
+	expectStatus(t, doRequest(t, http.MethodGet, "/users/999999999", nil, user.SessionToken), http.StatusNotFound)
+}
+
+func TestUserSearch(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "찾기쉬운이름")
+
+	expectStatus(t, doRequest(t, http.MethodGet, "/users/search/list", nil, ""), http.StatusBadRequest)
+
+	res := doRequest(t, http.MethodGet, "/users/search/list?size=1&q="+url.QueryEscape("찾기쉬운"), nil, "")
+	expectStatus(t, res, http.StatusOK)
+	result := decode[dto.PaginatedResult[dto.UserResponse]](t, res)
+	if len(result.Items) != 1 || result.Items[0].ID != user.User.ID || result.Limit != 1 {
+		t.Fatalf("unexpected search result: %+v", result)
+	}
+}
+
+func TestDeleteUserEndsSessions(t *testing.T) {
+	requireDB(t)
+
+	user := signup(t, "탈퇴")
+
+	expectStatus(t, doRequest(t, http.MethodDelete, "/users", nil, user.SessionToken), http.StatusOK)
+	expectStatus(t, doRequest(t, http.MethodGet, "/auth/me", nil, user.SessionToken), http.StatusUnauthorized)
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"slices"
+	"time"
+)
+
+// AccessTokenPrefix 는 개인 액세스 토큰 앞에 붙어 세션 토큰과 구별하게 해 줍니다.
+const AccessTokenPrefix = "anpat_"
+
+// 개인 액세스 토큰에 줄 수 있는 권한입니다.
+const (
+	ScopeLogsWrite      = "logs:write"
+	ScopeCommentsWrite  = "comments:write"
+	ScopeReactionsWrite = "reactions:write"
+	ScopeUserRead       = "user:read"
+)
+
+var AccessTokenScopes = []string{ScopeLogsWrite, ScopeCommentsWrite, ScopeReactionsWrite, ScopeUserRead}
+
+// PersonalAccessToken 은 스크립트나 CI가 사용자 대신 API를 호출할 때 쓰는 토큰입니다.
+// 세션과 달리 Scopes에 있는 권한이 필요한 엔드포인트에서만 쓸 수 있습니다.
+type PersonalAccessToken struct {
+	ID          ID        `bun:"id,pk,autoincrement" json:"id"`
+	UserID      ID        `bun:"user_id,notnull" json:"userId"`
+	Name        string    `bun:"name,notnull" json:"name"`
+	TokenHash   string    `bun:"token_hash,unique,notnull" json:"-"`
+	TokenPrefix string    `bun:"token_prefix,notnull" json:"tokenPrefix"`
+	Scopes      []string  `bun:"scopes,array" json:"scopes"`
+	ExpiresAt   time.Time `bun:"expires_at,nullzero" json:"expiresAt"`
+	LastUsedAt  time.Time `bun:"last_used_at,nullzero" json:"lastUsedAt"`
+	CreatedAt   time.Time `bun:"created_at,notnull,default:current_timestamp" json:"createdAt"`
+
+	// 디비에는 해시만 저장하고, 원본은 발급할 때만 채워짐
+	Token string `bun:"-" json:"-"`
+}
+
+func (t *PersonalAccessToken) HasScope(scope string) bool {
+	return slices.Contains(t.Scopes, scope)
+}
+
+func (t *PersonalAccessToken) Expired(now time.Time) bool {
+	return !t.ExpiresAt.IsZero() && !now.Before(t.ExpiresAt)
+}
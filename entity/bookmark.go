@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Bookmark 는 사용자가 나중에 읽으려고 저장한 로그입니다.
+type Bookmark struct {
+	bun.BaseModel `bun:"table:bookmarks"`
+
+	UserID    ID        `bun:"user_id,pk"`
+	LogID     ID        `bun:"log_id,pk"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+
+	Log *Log `bun:"rel:belongs-to,join:log_id=id"`
+}
+
+// ReadingList 는 저장한 로그를 묶는 이름 붙은 목록입니다. 공개하지 않으면 만든 사람만 볼 수 있습니다.
+type ReadingList struct {
+	bun.BaseModel `bun:"table:reading_lists,alias:reading_list"`
+
+	ID          ID     `bun:"id,pk,autoincrement"`
+	UserID      ID     `bun:"user_id,notnull"`
+	Name        string `bun:"name,notnull"`
+	Description string `bun:"description,notnull,default:''"`
+	Public      bool   `bun:"is_public,notnull,default:false"`
+	// 만든 사람의 목록 중 순서. 0부터 시작
+	Position  int       `bun:"position,notnull,default:0"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	ItemCount int `bun:"item_count,scanonly"`
+}
+
+// ReadingListItem 은 목록에 담긴 로그입니다. 목록에 담으면 저장도 함께 됩니다.
+type ReadingListItem struct {
+	bun.BaseModel `bun:"table:reading_list_items,alias:item"`
+
+	ListID ID `bun:"list_id,pk"`
+	LogID  ID `bun:"log_id,pk"`
+	// 목록 안의 순서. 0부터 시작
+	Position  int       `bun:"position,notnull,default:0"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+
+	Log *Log `bun:"rel:belongs-to,join:log_id=id"`
+}
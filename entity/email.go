@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// 메일 종류이자 수신 거부할 수 있는 목록입니다.
+const (
+	// 앱 내 알림을 메일로도 받음
+	EmailNotifications = "notifications"
+	// 주간 새 로그 소식
+	EmailDigest = "digest"
+	// 수신 거부할 때 모든 목록
+	EmailAll = "all"
+)
+
+var EmailLists = []string{EmailNotifications, EmailDigest, EmailAll}
+
+const (
+	EmailOutboxPending = "pending"
+	EmailOutboxDone    = "done"
+	EmailOutboxFailed  = "failed"
+	// 보내기 전에 수신을 끄거나 주소를 바꿔 보내지 않음
+	EmailOutboxCancelled = "cancelled"
+)
+
+// EmailSettings 는 사용자의 메일 수신 설정입니다. 처음 켤 때 만들어지고, 행이 없으면 아무 메일도 받지 않습니다.
+type EmailSettings struct {
+	bun.BaseModel `bun:"table:email_settings"`
+
+	UserID        ID     `bun:"user_id,pk"`
+	Email         string `bun:"email,notnull"`
+	Notifications bool   `bun:"notifications,notnull"`
+	Digest        bool   `bun:"digest,notnull"`
+	// 둘 다 비어 있으면 모든 새 로그를 담음
+	DigestTopicIDs    []ID     `bun:"digest_topic_ids,array"`
+	DigestGenerations []uint16 `bun:"digest_generations,array"`
+
+	LastDigestAt time.Time `bun:"last_digest_at,notnull,default:current_timestamp"`
+	CreatedAt    time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt    time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+}
+
+// EmailUnsubscribeToken 은 메일 한 통의 수신 거부 링크에 담긴 토큰입니다. 해시만 저장합니다.
+// 보낸 주소(Email)가 지금 설정한 주소와 다르거나 만료되면 쓸 수 없습니다.
+type EmailUnsubscribeToken struct {
+	bun.BaseModel `bun:"table:email_unsubscribe_tokens"`
+
+	TokenHash string    `bun:"token_hash,pk"`
+	UserID    ID        `bun:"user_id,notnull"`
+	Email     string    `bun:"email,notnull"`
+	ExpiresAt time.Time `bun:"expires_at,notnull"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
+
+// EmailOutbox 는 보내야 할 메일 한 통입니다. 백그라운드 워커가 재시도하며 보냅니다.
+// 본문은 저장하지 않고, 워커가 보낼 때마다 새 수신 거부 링크를 넣어 Template과 Data로 렌더링합니다.
+type EmailOutbox struct {
+	bun.BaseModel `bun:"table:email_outbox"`
+
+	ID        ID     `bun:"id,pk,autoincrement"`
+	UserID    ID     `bun:"user_id,notnull"`
+	Kind      string `bun:"kind,notnull"`
+	ToAddress string `bun:"to_address,notnull"`
+	Subject   string `bun:"subject,notnull"`
+	// 메일 템플릿 이름과 템플릿에 넣을 내용(JSON)
+	Template string          `bun:"template,notnull"`
+	Data     json.RawMessage `bun:"data,type:jsonb,notnull"`
+
+	Status        string     `bun:"status,notnull,default:'pending'"`
+	Attempts      int        `bun:"attempts,notnull,default:0"`
+	LastError     string     `bun:"last_error,nullzero"`
+	NextAttemptAt time.Time  `bun:"next_attempt_at,notnull,default:current_timestamp"`
+	ProcessedAt   *time.Time `bun:"processed_at,nullzero"`
+	CreatedAt     time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// ProviderAnAccount 는 An-Account 신원 제공자의 이름입니다. An-Account로 가입한 사용자는 An-Account 아이디를 그대로 씁니다.
+const ProviderAnAccount = "an-account"
+
+// UserIdentity 는 Analog 사용자에 연결된 외부 계정입니다. 한 사용자는 제공자마다 계정 하나를 연결할 수 있습니다.
+type UserIdentity struct {
+	ID         ID        `bun:"id,pk,autoincrement" json:"id"`
+	UserID     ID        `bun:"user_id,notnull" json:"userId"`
+	Provider   string    `bun:"provider,notnull" json:"provider"`
+	Subject    string    `bun:"subject,notnull" json:"subject"`
+	Email      string    `bun:"email,nullzero" json:"email"`
+	CreatedAt  time.Time `bun:"created_at,notnull,default:current_timestamp" json:"createdAt"`
+	LastUsedAt time.Time `bun:"last_used_at,notnull,default:current_timestamp" json:"lastUsedAt"`
+}
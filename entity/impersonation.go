@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+const (
+	ImpersonationActionStart   = "start"
+	ImpersonationActionRequest = "request"
+	// 쓰기를 허용하지 않은 대리 세션의 변경 요청을 막았을 때
+	ImpersonationActionBlocked = "blocked"
+	ImpersonationActionEnd     = "end"
+)
+
+// ImpersonationAudit 는 대리 세션에서 일어난 일 한 건의 기록입니다.
+type ImpersonationAudit struct {
+	ID             ID        `bun:"id,pk,autoincrement" json:"id"`
+	SessionID      ID        `bun:"session_id,notnull" json:"sessionId"`
+	ImpersonatorID ID        `bun:"impersonator_id,notnull" json:"impersonatorId"`
+	TargetUserID   ID        `bun:"target_user_id,notnull" json:"targetUserId"`
+	Action         string    `bun:"action,notnull" json:"action"`
+	Method         string    `bun:"method,nullzero" json:"method,omitempty"`
+	Path           string    `bun:"path,nullzero" json:"path,omitempty"`
+	Reason         string    `bun:"reason,nullzero" json:"reason,omitempty"`
+	CreatedAt      time.Time `bun:"created_at,notnull,default:current_timestamp" json:"createdAt"`
+}
+
+func NewImpersonationAudit(session *Session, action string) *ImpersonationAudit {
+	return &ImpersonationAudit{
+		SessionID:      session.ID,
+		ImpersonatorID: session.ImpersonatorID,
+		TargetUserID:   session.UserID,
+		Action:         action,
+		CreatedAt:      time.Now().UTC(),
+	}
+}
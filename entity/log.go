@@ -19,8 +19,28 @@ type Log struct {
 	PreRendered string    `bun:"pre_rendered"`
 	CreatedAt   time.Time `bun:"created_at"`
 	LoggedBy    []*User   `bun:"m2m:log_to_users,join:Log=User"`
+
+	// 댓글을 달고 지울 때 함께 갱신. 자리만 남은 댓글은 세지 않음
+	CommentCount int    `bun:"comment_count,notnull,default:0"`
+	CommentMode  string `bun:"comment_mode,notnull,default:'open'"`
+
+	// 반응 종류별 개수. 반응을 달고 뗄 때 함께 갱신
+	ReactionCounts map[string]int `bun:"reaction_counts,type:jsonb,nullzero,notnull,default:'{}'"`
+	// 조회한 사용자가 단 반응. 저장하지 않음
+	MyReactions []string `bun:"-"`
 }
 
+// 로그 작성자가 정하는 댓글 상태입니다.
+const (
+	CommentModeOpen = "open"
+	// 로그 작성자만 새로 쓰거나 고칠 수 있음
+	CommentModeLocked = "locked"
+	// 로그 작성자 외에는 댓글이 보이지 않고 아무도 새로 쓸 수 없음
+	CommentModeOff = "off"
+)
+
+var CommentModes = []string{CommentModeOpen, CommentModeLocked, CommentModeOff}
+
 type Comment struct {
 	bun.BaseModel `bun:"table:comments"`
 
@@ -31,27 +51,117 @@ type Comment struct {
 	Author    *User     `bun:"rel:belongs-to,join:author_id=id"`
 	Content   string    `bun:"content"`
 	CreatedAt time.Time `bun:"created_at"`
+	// 마지막으로 고친 시각. 고친 적 없으면 0
+	UpdatedAt time.Time `bun:"updated_at,nullzero"`
+
+	// 허용한 마크다운만 렌더링하고 멘션을 프로필 링크로 바꾼 HTML
+	PreRendered string  `bun:"pre_rendered,notnull,default:''"`
+	Mentions    []*User `bun:"m2m:comment_mentions,join:Comment=User"`
+
+	// 답글이면 부모 댓글. 최상위 댓글은 0
+	ParentID ID `bun:"parent_id,nullzero"`
+	// 최상위 댓글은 0, 답글은 부모보다 1 큼
+	Depth int `bun:"depth,notnull,default:0"`
+	// 답글이 달린 댓글을 지우면 내용을 비우고 이 시각만 남김
+	DeletedAt time.Time `bun:"deleted_at,nullzero"`
+	// 로그 작성자가 숨긴 시각과 숨긴 사람
+	HiddenAt time.Time `bun:"hidden_at,nullzero"`
+	HiddenBy ID        `bun:"hidden_by,nullzero"`
+
+	// 반응을 달고 뗄 때 함께 갱신. ReactionCount는 종류를 가리지 않은 합계
+	ReactionCount  int            `bun:"reaction_count,notnull,default:0"`
+	ReactionCounts map[string]int `bun:"reaction_counts,type:jsonb,nullzero,notnull,default:'{}'"`
+	// 조회한 사용자가 단 반응. 저장하지 않음
+	MyReactions []string `bun:"-"`
+
+	ReplyCount int `bun:"reply_count,scanonly"`
+}
+
+func (c *Comment) Deleted() bool {
+	return !c.DeletedAt.IsZero()
+}
+
+func (c *Comment) Hidden() bool {
+	return !c.HiddenAt.IsZero()
+}
+
+func (c *Comment) Edited() bool {
+	return !c.UpdatedAt.IsZero()
 }
 
+// CommentRevision 은 댓글을 고치기 전의 내용입니다.
+type CommentRevision struct {
+	bun.BaseModel `bun:"table:comment_revisions"`
+
+	ID        ID     `bun:"id,pk,autoincrement"`
+	CommentID ID     `bun:"comment_id,notnull"`
+	Content   string `bun:"content,notnull"`
+	// 이 내용을 쓴 시각
+	CreatedAt time.Time `bun:"created_at,notnull"`
+	// 이 내용을 고친 시각
+	ReplacedAt time.Time `bun:"replaced_at,notnull,default:current_timestamp"`
+}
+
+// 최상위 댓글 목록의 정렬 순서입니다.
+const (
+	CommentSortOldest    = "oldest"
+	CommentSortNewest    = "newest"
+	CommentSortReactions = "reactions"
+)
+
+var CommentSorts = []string{CommentSortOldest, CommentSortNewest, CommentSortReactions}
+
 type Topic struct {
 	bun.BaseModel `bun:"table:topics"`
 
 	ID   ID     `bun:"id,pk,autoincrement"`
 	Name string `bun:"name,unique"`
 
-	Count int64 `bun:"-"`
+	Count int64 `bun:"count,scanonly"`
 }
 
 type LogToUser struct {
 	bun.BaseModel `bun:"table:log_to_users"`
 
-	UserID ID `bun:"user_id,pk"`
-	LogID  ID `bun:"log_id,pk"`
+	UserID   ID     `bun:"user_id,pk"`
+	LogID    ID     `bun:"log_id,pk"`
+	Relation string `bun:"relation,notnull,default:'editor'"` // owner | editor
 
 	Log  *Log  `bun:"rel:belongs-to,join:log_id=id"`
 	User *User `bun:"rel:belongs-to,join:user_id=id"`
 }
 
+type CommentMention struct {
+	bun.BaseModel `bun:"table:comment_mentions"`
+
+	CommentID ID        `bun:"comment_id,pk"`
+	UserID    ID        `bun:"user_id,pk"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+
+	Comment *Comment `bun:"rel:belongs-to,join:comment_id=id"`
+	User    *User    `bun:"rel:belongs-to,join:user_id=id"`
+}
+
+// LogReaction 은 사용자가 로그에 단 반응입니다. 사용자마다 종류별로 하나씩 달 수 있습니다.
+type LogReaction struct {
+	bun.BaseModel `bun:"table:log_reactions"`
+
+	LogID     ID        `bun:"log_id,pk"`
+	UserID    ID        `bun:"user_id,pk"`
+	Kind      string    `bun:"kind,pk"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
+
+// CommentReaction 은 사용자가 댓글에 단 반응입니다.
+type CommentReaction struct {
+	bun.BaseModel `bun:"table:comment_reactions"`
+
+	CommentID ID        `bun:"comment_id,pk"`
+	UserID    ID        `bun:"user_id,pk"`
+	Kind      string    `bun:"kind,pk"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
+
 type LogToTopic struct {
 	bun.BaseModel `bun:"table:log_to_topics"`
 
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// 알림 종류입니다.
+const (
+	// 내 로그에 댓글이 달림
+	NotificationComment = "comment"
+	// 내 댓글에 답글이 달림
+	NotificationReply = "reply"
+	// 댓글에서 나를 멘션함
+	NotificationMention = "mention"
+	// 로그의 공동 작성자로 추가됨
+	NotificationCoAuthor = "coauthor"
+	// 내 로그나 댓글에 반응이 달림
+	NotificationReaction = "reaction"
+)
+
+var NotificationTypes = []string{NotificationComment, NotificationReply, NotificationMention, NotificationCoAuthor, NotificationReaction}
+
+// Notification 은 사용자에게 보여 줄 알림입니다. 같은 GroupKey의 읽지 않은 알림은 하나로 묶여
+// 마지막으로 행동한 사람(ActorID)과 행동한 사람 수(ActorCount)만 바뀝니다.
+type Notification struct {
+	bun.BaseModel `bun:"table:notifications,alias:notification"`
+
+	ID        ID     `bun:"id,pk,autoincrement"`
+	UserID    ID     `bun:"user_id,notnull"`
+	Type      string `bun:"type,notnull"`
+	GroupKey  string `bun:"group_key,notnull"`
+	LogID     ID     `bun:"log_id,nullzero"`
+	CommentID ID     `bun:"comment_id,nullzero"`
+
+	ActorID    ID   `bun:"actor_id,nullzero"`
+	ActorIDs   []ID `bun:"actor_ids,array"`
+	ActorCount int  `bun:"actor_count,notnull,default:1"`
+
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	// 마지막으로 묶인 시각
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+	ReadAt    time.Time `bun:"read_at,nullzero"`
+
+	Actor *User `bun:"rel:belongs-to,join:actor_id=id"`
+	Log   *Log  `bun:"rel:belongs-to,join:log_id=id"`
+}
+
+func (n *Notification) Read() bool {
+	return !n.ReadAt.IsZero()
+}
+
+// NotificationPreference 는 알림 종류별 수신 설정입니다. 행이 없으면 받습니다.
+type NotificationPreference struct {
+	bun.BaseModel `bun:"table:notification_preferences"`
+
+	UserID ID     `bun:"user_id,pk"`
+	Type   string `bun:"type,pk"`
+	InApp  bool   `bun:"in_app,notnull"`
+	// 메일 알림을 켠 사용자에게 이 종류를 메일로도 보낼지
+	Email bool `bun:"email,notnull"`
+}
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// An-Americano에서 로그 권한을 표현할 때 사용하는 네임스페이스와 관계입니다.
+const (
+	LogPermissionNamespace = "analog_log"
+
+	RelationOwner  = "owner"
+	RelationEditor = "editor"
+)
+
+// An-Americano에서 Analog 관리자를 표현할 때 사용하는 네임스페이스, 객체, 관계입니다.
+const (
+	SystemPermissionNamespace = "analog_system"
+	SystemObjectID            = "analog"
+
+	RelationAdmin = "admin"
+)
+
+const (
+	PermissionOpWrite  = "write"
+	PermissionOpDelete = "delete"
+
+	PermissionOutboxPending = "pending"
+	PermissionOutboxDone    = "done"
+	PermissionOutboxFailed  = "failed"
+)
+
+// PermissionOutbox 는 An-Americano에 반영해야 할 권한 변경 한 건을 의미합니다.
+// 로그 변경과 같은 트랜잭션에서 기록되고, 백그라운드 워커가 재시도하며 반영합니다.
+type PermissionOutbox struct {
+	bun.BaseModel `bun:"table:permission_outbox"`
+
+	ID            ID         `bun:"id,pk,autoincrement"`
+	Operation     string     `bun:"operation,notnull"`
+	Namespace     string     `bun:"namespace,notnull"`
+	ObjectID      ID         `bun:"object_id,notnull"`
+	Relation      string     `bun:"relation,notnull"`
+	UserID        ID         `bun:"user_id,notnull"`
+	Status        string     `bun:"status,notnull,default:'pending'"`
+	Attempts      int        `bun:"attempts,notnull,default:0"`
+	LastError     string     `bun:"last_error,nullzero"`
+	NextAttemptAt time.Time  `bun:"next_attempt_at,notnull,default:current_timestamp"`
+	ProcessedAt   *time.Time `bun:"processed_at,nullzero"`
+	CreatedAt     time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+}
+
+func NewLogPermissionOutbox(op string, logID, userID ID, relation string) *PermissionOutbox {
+	now := time.Now().UTC()
+	return &PermissionOutbox{
+		Operation:     op,
+		Namespace:     LogPermissionNamespace,
+		ObjectID:      logID,
+		Relation:      relation,
+		UserID:        userID,
+		Status:        PermissionOutboxPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// 실시간 이벤트 종류입니다.
+const (
+	// 보고 있는 로그에 댓글이 달림
+	RealtimeComment = "comment"
+	// 나에게 알림이 옴
+	RealtimeNotification = "notification"
+	// 새 로그가 올라옴
+	RealtimeLog = "log"
+)
+
+// RealtimeLogsChannel 은 새 로그 이벤트를 받는 채널로, 모든 구독자가 받습니다.
+const RealtimeLogsChannel = "logs"
+
+// RealtimeEvent 는 실시간 스트림으로 보낼 이벤트입니다. Channel을 구독한 연결만 받습니다.
+type RealtimeEvent struct {
+	bun.BaseModel `bun:"table:realtime_events,alias:realtime_event"`
+
+	ID        ID              `bun:"id,pk,autoincrement"`
+	Channel   string          `bun:"channel,notnull"`
+	Type      string          `bun:"type,notnull"`
+	Data      json.RawMessage `bun:"data,type:jsonb,notnull"`
+	CreatedAt time.Time       `bun:"created_at,notnull,default:current_timestamp"`
+}
+
+// LogRealtimeChannel 은 로그를 보고 있는 사람이 구독하는 채널입니다.
+func LogRealtimeChannel(logID ID) string {
+	return fmt.Sprintf("log:%d", logID)
+}
+
+// UserRealtimeChannel 은 로그인한 사용자 본인만 구독하는 채널입니다.
+func UserRealtimeChannel(userID ID) string {
+	return fmt.Sprintf("user:%d", userID)
+}
@@ -3,21 +3,46 @@ package entity
 import "time"
 
 type OAuthState struct {
-	ID           ID        `bun:"id,pk,autoincrement" json:"id"`
-	State        string    `bun:"state,unique,notnull" json:"state"`
-	CodeVerifier string    `bun:"code_verifier,notnull" json:"codeVerifier"`
-	RedirectUri  string    `bun:"redirect_uri" json:"redirectUri"`
-	IsSignup     bool      `bun:"is_signup,notnull,default:false" json:"isSignup"`
-	ExpiresAt    time.Time `bun:"expires_at,notnull" json:"expiresAt"`
-	CreatedAt    time.Time `bun:"created_at,notnull,default:current_timestamp" json:"createdAt"`
+	ID        ID     `bun:"id,pk,autoincrement" json:"id"`
+	StateHash string `bun:"state_hash,unique,notnull" json:"-"`
+	// 해시 저장 이전에 만들어진 state에만 남아 있음. 이후에는 state에서 파생
+	CodeVerifier string `bun:"code_verifier,nullzero" json:"-"`
+	RedirectUri  string `bun:"redirect_uri" json:"redirectUri"`
+	IsSignup     bool   `bun:"is_signup,notnull,default:false" json:"isSignup"`
+	Provider     string `bun:"provider,notnull" json:"provider"`
+	// 로그인된 사용자가 외부 계정을 연결하는 중이면 그 사용자
+	LinkUserID ID `bun:"link_user_id,nullzero" json:"linkUserId"`
+	// 가입할 때 고른 핸들. 비어 있으면 아이디로 만든 기본 핸들을 사용
+	Handle    string    `bun:"handle,nullzero" json:"handle"`
+	ExpiresAt time.Time `bun:"expires_at,notnull" json:"expiresAt"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp" json:"createdAt"`
+
+	// 디비에는 해시만 저장하고, 원본은 만들 때만 채워짐
+	State string `bun:"-" json:"state"`
 }
 
 type Session struct {
-	ID           ID        `bun:"id,pk,autoincrement" json:"id"`
-	SessionToken string    `bun:"session_token,unique,notnull" json:"sessionToken"`
-	UserID       ID        `bun:"user_id,notnull" json:"userId"`
-	ExpiresAt    time.Time `bun:"expires_at,notnull" json:"expiresAt"`
-	CreatedAt    time.Time `bun:"created_at,notnull,default:current_timestamp" json:"createdAt"`
+	ID         ID        `bun:"id,pk,autoincrement" json:"id"`
+	TokenHash  string    `bun:"token_hash,unique,notnull" json:"-"`
+	UserID     ID        `bun:"user_id,notnull" json:"userId"`
+	ExpiresAt  time.Time `bun:"expires_at,notnull" json:"expiresAt"`
+	CreatedAt  time.Time `bun:"created_at,notnull,default:current_timestamp" json:"createdAt"`
+	LastSeenAt time.Time `bun:"last_seen_at,notnull,default:current_timestamp" json:"lastSeenAt"`
+	IPAddress  string    `bun:"ip_address,nullzero" json:"ipAddress"`
+	UserAgent  string    `bun:"user_agent,nullzero" json:"userAgent"`
+
+	// 관리자가 UserID 사용자로 보기 위해 만든 대리 세션이면 그 관리자
+	ImpersonatorID      ID     `bun:"impersonator_id,nullzero" json:"impersonatorId,omitempty"`
+	ImpersonationReason string `bun:"impersonation_reason,nullzero" json:"-"`
+	// 대리 세션에서 상태를 바꾸는 요청을 허용할지
+	AllowWrites bool `bun:"allow_writes,notnull,default:false" json:"allowWrites"`
+
+	// 디비에는 해시만 저장하고, 원본은 발급하거나 회전할 때만 채워짐
+	SessionToken string `bun:"-" json:"-"`
 
 	User *User `bun:"rel:belongs-to,join:user_id=id" json:"user,omitempty"`
 }
+
+func (s *Session) Impersonated() bool {
+	return s.ImpersonatorID != 0
+}
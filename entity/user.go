@@ -2,10 +2,12 @@ package entity
 
 import (
 	"time"
+
+	"github.com/uptrace/bun"
 )
 
 type User struct {
-	ID           ID        `bun:"id,pk"` // An-Account와 아이디를 공유합니다
+	ID           ID        `bun:"id,pk"` // An-Account로 가입하면 An-Account 아이디, 다른 제공자로 가입하면 음수
 	Name         string    `bun:"name"`
 	Handle       string    `bun:"handle,unique"` // @handle
 	ProfileImage string    `bun:"profile_image"`
@@ -16,3 +18,12 @@ type User struct {
 	CreatedAt    time.Time `bun:"created_at,notnull,default:current_timestamp"`
 	UpdatedAt    time.Time `bun:"updated_at,notnull,default:current_timestamp"`
 }
+
+// UserHandleHistory 는 사용자가 바꾸기 전에 쓰던 핸들입니다.
+type UserHandleHistory struct {
+	bun.BaseModel `bun:"table:user_handle_history"`
+
+	Handle    string    `bun:"handle,pk"`
+	UserID    ID        `bun:"user_id,notnull"`
+	ChangedAt time.Time `bun:"changed_at,notnull,default:current_timestamp"`
+}
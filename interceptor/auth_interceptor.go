@@ -1,75 +1,196 @@
 package interceptor
 
 import (
+	"analog-be/entity"
 	"analog-be/pkg"
 	"analog-be/repository"
+	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/NARUBROWN/spine/core"
 	"go.uber.org/zap"
 )
 
+var (
+	errSessionExpired     = errors.New("session expired")
+	errAccessTokenExpired = errors.New("access token expired")
+)
+
+// AuthInterceptor 는 Bearer 토큰으로 세션 토큰과 개인 액세스 토큰을 모두 받고, Authorization 헤더가 없으면 세션 쿠키를 봅니다.
+// 개인 액세스 토큰은 RequireScope로 필요한 권한이 표시된 라우트에서만 쓸 수 있습니다.
+// 세션 쿠키로 상태를 바꾸는 요청은 CSRF 토큰과 Origin을 확인합니다.
+// 관리자 대리 세션의 요청은 모두 기록하고, 허용하지 않은 변경 요청은 막습니다.
+// OptionalAuth로 표시된 라우트에서는 인증에 실패해도 익명 요청으로 처리합니다.
 type AuthInterceptor struct {
-	sessionRepo repository.SessionRepository
-	logger      *zap.Logger
+	sessionRepo     repository.SessionRepository
+	accessTokenRepo repository.AccessTokenRepository
+	auditRepo       repository.ImpersonationAuditRepository
+	sessionPolicy   *pkg.SessionPolicy
+	cookies         *pkg.SessionCookiePolicy
+	origins         *pkg.RedirectAllowlist
+	logger          *zap.Logger
 }
 
-func NewAuthInterceptor(sessionRepo repository.SessionRepository, logger *zap.Logger) *AuthInterceptor {
+func NewAuthInterceptor(sessionRepo repository.SessionRepository, accessTokenRepo repository.AccessTokenRepository, auditRepo repository.ImpersonationAuditRepository, sessionPolicy *pkg.SessionPolicy, cookies *pkg.SessionCookiePolicy, logger *zap.Logger) *AuthInterceptor {
 	return &AuthInterceptor{
-		sessionRepo: sessionRepo,
-		logger:      logger,
+		sessionRepo:     sessionRepo,
+		accessTokenRepo: accessTokenRepo,
+		auditRepo:       auditRepo,
+		sessionPolicy:   sessionPolicy,
+		cookies:         cookies,
+		origins:         newOriginAllowlist(logger),
+		logger:          logger,
 	}
 }
 
-func (i *AuthInterceptor) PreHandle(ctx core.ExecutionContext, _ core.HandlerMeta) error {
-	authHeader := ctx.Header("Authorization")
-	if authHeader == "" {
-		return pkg.NewUnauthorizedError("Missing authorization header")
+func (i *AuthInterceptor) PreHandle(ctx core.ExecutionContext, meta core.HandlerMeta) error {
+	if authOptional(meta) {
+		return i.OptionalPreHandle(ctx, meta)
 	}
 
-	parts := strings.SplitN(authHeader, " ", 2)
-	if len(parts) != 2 || parts[0] != "Bearer" {
-		return pkg.NewUnauthorizedError("Invalid authorization header format")
+	token, fromCookie, err := i.credentials(ctx)
+	if err != nil {
+		return err
 	}
 
-	sessionToken := parts[1]
+	return i.authenticate(ctx, meta, token, fromCookie)
+}
 
-	session, err := i.sessionRepo.FindByToken(ctx.Context(), sessionToken)
+// credentials 는 Authorization 헤더를 먼저 보고, 없으면 세션 쿠키에서 토큰을 꺼냅니다.
+func (i *AuthInterceptor) credentials(ctx core.ExecutionContext) (string, bool, error) {
+	if authHeader := ctx.Header("Authorization"); authHeader != "" {
+		parts := strings.SplitN(authHeader, " ", 2)
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			return "", false, pkg.NewUnauthorizedError("Invalid authorization header format")
+		}
+		return parts[1], false, nil
+	}
+
+	if token := i.cookies.SessionToken(ctx.Header("Cookie")); token != "" {
+		return token, true, nil
+	}
+
+	return "", false, pkg.NewUnauthorizedError("Missing authorization header")
+}
+
+func (i *AuthInterceptor) authenticate(ctx core.ExecutionContext, meta core.HandlerMeta, token string, fromCookie bool) error {
+	// 쿠키로는 세션 토큰만 받음
+	if !fromCookie && strings.HasPrefix(token, entity.AccessTokenPrefix) {
+		return i.authenticateAccessToken(ctx, meta, token)
+	}
+
+	session, err := i.findActiveSession(ctx, token)
 	if err != nil {
 		i.logger.Debug("Invalid session token", zap.Error(err))
 		return pkg.NewUnauthorizedError("Invalid or expired session")
 	}
 
+	if fromCookie {
+		if err := i.checkCSRF(ctx, token); err != nil {
+			return err
+		}
+	}
+
+	if session.Impersonated() {
+		if err := i.checkImpersonation(ctx, meta, session); err != nil {
+			return err
+		}
+	}
+
 	ctx.Set(string(pkg.UserIDKey), session.UserID)
-	ctx.Set(string(pkg.SessionTokenKey), sessionToken)
+	ctx.Set(string(pkg.SessionTokenKey), token)
+	ctx.Set(string(pkg.SessionIDKey), session.ID)
+	ctx.Set(string(pkg.SessionFromCookieKey), fromCookie)
 
 	return nil
 }
 
-func (i *AuthInterceptor) PostHandle(core.ExecutionContext, core.HandlerMeta) {}
+func (i *AuthInterceptor) authenticateAccessToken(ctx core.ExecutionContext, meta core.HandlerMeta, token string) error {
+	accessToken, err := i.findActiveAccessToken(ctx, token)
+	if err != nil {
+		i.logger.Debug("Invalid access token", zap.Error(err))
+		return pkg.NewUnauthorizedError("Invalid or expired access token")
+	}
 
-func (i *AuthInterceptor) AfterCompletion(core.ExecutionContext, core.HandlerMeta, error) {}
+	scope, ok := requiredScope(meta)
+	if !ok {
+		return pkg.NewForbiddenError("Personal access tokens cannot be used for this endpoint")
+	}
+	if !accessToken.HasScope(scope) {
+		return pkg.NewForbiddenError(fmt.Sprintf("Access token is missing the %s scope", scope))
+	}
 
-func (i *AuthInterceptor) OptionalPreHandle(ctx core.ExecutionContext, _ core.HandlerMeta) error {
-	authHeader := ctx.Header("Authorization")
-	if authHeader == "" {
-		return nil
+	ctx.Set(string(pkg.UserIDKey), accessToken.UserID)
+	ctx.Set(string(pkg.AccessTokenIDKey), accessToken.ID)
+
+	return nil
+}
+
+// findActiveSession 은 만료되지 않은 세션을 찾고, 사용할 때마다 유휴 만료 시각을 뒤로 미룹니다.
+// 매 요청마다 쓰지 않도록 마지막 갱신 후 RenewInterval이 지났을 때만 갱신합니다.
+func (i *AuthInterceptor) findActiveSession(ctx core.ExecutionContext, sessionToken string) (*entity.Session, error) {
+	session, err := i.sessionRepo.FindByToken(ctx.Context(), sessionToken)
+	if err != nil {
+		return nil, err
 	}
 
-	parts := strings.SplitN(authHeader, " ", 2)
-	if len(parts) != 2 || parts[0] != "Bearer" {
-		return nil
+	now := time.Now().UTC()
+	if !now.Before(session.ExpiresAt) {
+		if err := i.sessionRepo.DeleteByID(ctx.Context(), &session.ID); err != nil {
+			i.logger.Warn("Failed to delete expired session", zap.Int64("sessionID", session.ID), zap.Error(err))
+		}
+		return nil, errSessionExpired
 	}
 
-	sessionToken := parts[1]
+	if i.sessionPolicy.ShouldRenew(session.LastSeenAt, now) {
+		expiresAt := i.sessionPolicy.ExpiresAt(session.CreatedAt, now)
+		if session.Impersonated() {
+			// 대리 세션은 시작할 때 정한 시각에 끝남
+			expiresAt = session.ExpiresAt
+		}
+		if err := i.sessionRepo.Touch(ctx.Context(), &session.ID, now, expiresAt); err != nil {
+			i.logger.Warn("Failed to renew session", zap.Int64("sessionID", session.ID), zap.Error(err))
+		}
+	}
 
-	session, err := i.sessionRepo.FindByToken(ctx.Context(), sessionToken)
+	return session, nil
+}
+
+// findActiveAccessToken 은 만료되지 않은 개인 액세스 토큰을 찾고, 세션과 같은 간격으로 마지막 사용 시각을 기록합니다.
+func (i *AuthInterceptor) findActiveAccessToken(ctx core.ExecutionContext, token string) (*entity.PersonalAccessToken, error) {
+	accessToken, err := i.accessTokenRepo.FindByToken(ctx.Context(), token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if accessToken.Expired(now) {
+		return nil, errAccessTokenExpired
+	}
+
+	if i.sessionPolicy.ShouldRenew(accessToken.LastUsedAt, now) {
+		if err := i.accessTokenRepo.TouchLastUsed(ctx.Context(), &accessToken.ID, now); err != nil {
+			i.logger.Warn("Failed to record access token use", zap.Int64("accessTokenID", accessToken.ID), zap.Error(err))
+		}
+	}
+
+	return accessToken, nil
+}
+
+func (i *AuthInterceptor) PostHandle(core.ExecutionContext, core.HandlerMeta) {}
+
+func (i *AuthInterceptor) AfterCompletion(core.ExecutionContext, core.HandlerMeta, error) {}
+
+func (i *AuthInterceptor) OptionalPreHandle(ctx core.ExecutionContext, meta core.HandlerMeta) error {
+	token, fromCookie, err := i.credentials(ctx)
 	if err != nil {
 		return nil
 	}
 
-	ctx.Set(string(pkg.UserIDKey), session.UserID)
-	ctx.Set(string(pkg.SessionTokenKey), sessionToken)
+	// 인증에 실패하면 익명 요청으로 처리
+	_ = i.authenticate(ctx, meta, token, fromCookie)
 
 	return nil
 }
@@ -1,6 +1,7 @@
 package interceptor
 
 import (
+	"analog-be/pkg"
 	"os"
 	"strings"
 
@@ -14,7 +15,7 @@ func NewCORSInterceptor() core.Interceptor {
 	return cors.New(cors.Config{
 		AllowOrigins:     origins,
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Content-Type", "Authorization"},
+		AllowHeaders:     []string{"Content-Type", "Authorization", pkg.CSRFHeader},
 		AllowCredentials: true,
 	})
 }
@@ -0,0 +1,67 @@
+package interceptor
+
+import (
+	"analog-be/pkg"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/NARUBROWN/spine/core"
+	"go.uber.org/zap"
+)
+
+// checkCSRF 는 세션 쿠키로 인증한 요청이 다른 사이트에서 위조되지 않았는지 확인합니다.
+// 상태를 바꾸는 요청은 CORS에서 허용한 Origin에서 와야 하고, 세션에 묶인 CSRF 토큰을 헤더로 보내야 합니다.
+func (i *AuthInterceptor) checkCSRF(ctx core.ExecutionContext, sessionToken string) error {
+	if isSafeMethod(ctx.Method()) {
+		return nil
+	}
+
+	if origin := requestOrigin(ctx); origin != "" && !i.origins.Allowed(origin) {
+		return pkg.NewForbiddenError("Cross-site request is not allowed")
+	}
+
+	if !pkg.ValidCSRFToken(sessionToken, ctx.Header(pkg.CSRFHeader)) {
+		return pkg.NewForbiddenError("Missing or invalid CSRF token")
+	}
+
+	return nil
+}
+
+// isSafeMethod 는 상태를 바꾸지 않는 요청 메서드인지 확인합니다.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// requestOrigin 은 Origin 헤더를, 없으면 Referer의 origin을 반환합니다. 둘 다 없으면 빈 문자열입니다.
+func requestOrigin(ctx core.ExecutionContext) string {
+	if origin := ctx.Header("Origin"); origin != "" {
+		return origin
+	}
+
+	referer, err := url.Parse(ctx.Header("Referer"))
+	if err != nil || referer.Scheme == "" || referer.Host == "" {
+		return ""
+	}
+	return referer.Scheme + "://" + referer.Host
+}
+
+// newOriginAllowlist 는 CORS에서 허용한 origin 목록으로 Origin 검사 목록을 만듭니다.
+func newOriginAllowlist(logger *zap.Logger) *pkg.RedirectAllowlist {
+	var origins []string
+	for _, origin := range getAllowedOrigins() {
+		origin = strings.TrimSpace(origin)
+		if _, err := pkg.ParseRedirectAllowlist(origin); err != nil {
+			logger.Warn("Ignoring invalid CORS origin for CSRF checks", zap.String("origin", origin), zap.Error(err))
+			continue
+		}
+		origins = append(origins, origin)
+	}
+
+	allowlist, _ := pkg.ParseRedirectAllowlist(origins...)
+	return allowlist
+}
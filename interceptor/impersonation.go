@@ -0,0 +1,41 @@
+package interceptor
+
+import (
+	"analog-be/entity"
+	"analog-be/pkg"
+	"fmt"
+
+	"github.com/NARUBROWN/spine/core"
+)
+
+// checkImpersonation 은 대리 세션의 요청을 기록하고, 쓰기를 허용하지 않은 대리 세션의 변경 요청을 막습니다.
+// 쓰기를 허용해도 개인 액세스 토큰으로 쓸 수 없는 라우트(계정, 세션, 토큰 관리)의 변경 요청은 막습니다.
+func (i *AuthInterceptor) checkImpersonation(ctx core.ExecutionContext, meta core.HandlerMeta, session *entity.Session) error {
+	// 막힌 요청도 요청 로그에 대리 세션으로 표시되도록 먼저 설정
+	ctx.Set(string(pkg.ImpersonatorIDKey), session.ImpersonatorID)
+
+	blocked := false
+	if !isSafeMethod(ctx.Method()) {
+		_, scoped := requiredScope(meta)
+		blocked = !session.AllowWrites || !scoped
+	}
+
+	action := entity.ImpersonationActionRequest
+	if blocked {
+		action = entity.ImpersonationActionBlocked
+	}
+
+	audit := entity.NewImpersonationAudit(session, action)
+	audit.Method = ctx.Method()
+	audit.Path = ctx.Path()
+	if err := i.auditRepo.Create(ctx.Context(), audit); err != nil {
+		// 기록을 남기지 못한 대리 요청은 처리하지 않음
+		return fmt.Errorf("failed to write impersonation audit: %w", err)
+	}
+
+	if blocked {
+		return pkg.NewForbiddenError("This request is not allowed while impersonating")
+	}
+
+	return nil
+}
@@ -49,22 +49,24 @@ func (i *LoggingInterceptor) AfterCompletion(ctx core.ExecutionContext, meta cor
 
 	duration := time.Since(start)
 
+	fields := []zap.Field{
+		zap.String("method", ctx.Method()),
+		zap.String("path", ctx.Path()),
+		zap.String("controller", meta.ControllerType.Name()),
+		zap.String("handler", meta.Method.Name),
+		zap.Duration("duration", duration),
+	}
+	// 관리자 대리 세션의 요청은 로그에서 찾을 수 있도록 표시
+	if impersonatorID, ok := ctx.Get(string(pkg.ImpersonatorIDKey)); ok {
+		fields = append(fields, zap.Bool("impersonated", true), zap.Any("impersonatorID", impersonatorID))
+		if userID, ok := ctx.Get(string(pkg.UserIDKey)); ok {
+			fields = append(fields, zap.Any("userID", userID))
+		}
+	}
+
 	if err != nil {
-		i.logger.Error("Request failed",
-			zap.String("method", ctx.Method()),
-			zap.String("path", ctx.Path()),
-			zap.String("controller", meta.ControllerType.Name()),
-			zap.String("handler", meta.Method.Name),
-			zap.Duration("duration", duration),
-			zap.Error(err),
-		)
+		i.logger.Error("Request failed", append(fields, zap.Error(err))...)
 	} else {
-		i.logger.Info("Request completed",
-			zap.String("method", ctx.Method()),
-			zap.String("path", ctx.Path()),
-			zap.String("controller", meta.ControllerType.Name()),
-			zap.String("handler", meta.Method.Name),
-			zap.Duration("duration", duration),
-		)
+		i.logger.Info("Request completed", fields...)
 	}
 }
@@ -0,0 +1,30 @@
+package interceptor
+
+import (
+	"github.com/NARUBROWN/spine/core"
+)
+
+// OptionalAuthInterceptor 는 로그인하지 않아도 쓸 수 있지만 로그인하면 응답이 달라지는 라우트를 표시합니다.
+// AuthInterceptor는 이 표시가 있는 라우트에서 인증에 실패하면 막지 않고 익명 요청으로 넘깁니다.
+type OptionalAuthInterceptor struct{}
+
+func OptionalAuth() *OptionalAuthInterceptor {
+	return &OptionalAuthInterceptor{}
+}
+
+func (i *OptionalAuthInterceptor) PreHandle(core.ExecutionContext, core.HandlerMeta) error {
+	return nil
+}
+
+func (i *OptionalAuthInterceptor) PostHandle(core.ExecutionContext, core.HandlerMeta) {}
+
+func (i *OptionalAuthInterceptor) AfterCompletion(core.ExecutionContext, core.HandlerMeta, error) {}
+
+func authOptional(meta core.HandlerMeta) bool {
+	for _, it := range meta.Interceptors {
+		if _, ok := it.(*OptionalAuthInterceptor); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,8 @@ package interceptor
 
 import (
 	"analog-be/pkg"
+	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -18,8 +20,8 @@ type RateLimitInterceptor struct {
 }
 
 func NewRateLimitInterceptor() *RateLimitInterceptor {
-	rps := 10
-	burst := 20
+	rps := getEnvInt("RATE_LIMIT_RPS", 10)
+	burst := getEnvInt("RATE_LIMIT_BURST", 20)
 
 	rl := &RateLimitInterceptor{
 		visitors: make(map[string]*rate.Limiter),
@@ -99,3 +101,11 @@ func (rl *RateLimitInterceptor) PreHandle(ctx core.ExecutionContext, _ core.Hand
 func (rl *RateLimitInterceptor) PostHandle(core.ExecutionContext, core.HandlerMeta) {}
 
 func (rl *RateLimitInterceptor) AfterCompletion(core.ExecutionContext, core.HandlerMeta, error) {}
+
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
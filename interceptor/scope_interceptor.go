@@ -0,0 +1,31 @@
+package interceptor
+
+import (
+	"github.com/NARUBROWN/spine/core"
+)
+
+// ScopeInterceptor 는 개인 액세스 토큰으로 라우트를 호출할 때 필요한 권한을 표시합니다.
+// 검사는 AuthInterceptor가 하며, ScopeInterceptor가 없는 라우트는 개인 액세스 토큰을 받지 않습니다.
+type ScopeInterceptor struct {
+	scope string
+}
+
+func RequireScope(scope string) *ScopeInterceptor {
+	return &ScopeInterceptor{scope: scope}
+}
+
+func (i *ScopeInterceptor) PreHandle(core.ExecutionContext, core.HandlerMeta) error { return nil }
+
+func (i *ScopeInterceptor) PostHandle(core.ExecutionContext, core.HandlerMeta) {}
+
+func (i *ScopeInterceptor) AfterCompletion(core.ExecutionContext, core.HandlerMeta, error) {}
+
+// requiredScope 는 라우트에 붙은 ScopeInterceptor의 권한을 찾습니다.
+func requiredScope(meta core.HandlerMeta) (string, bool) {
+	for _, it := range meta.Interceptors {
+		if scope, ok := it.(*ScopeInterceptor); ok {
+			return scope.scope, true
+		}
+	}
+	return "", false
+}
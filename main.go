@@ -1,30 +1,19 @@
 package main
 
 import (
-	"analog-be/controller"
-	"analog-be/entity"
-	"analog-be/interceptor"
 	"analog-be/pkg"
+	"analog-be/pkg/mailer"
 	"analog-be/repository"
-	"analog-be/routes"
+	"analog-be/server"
 	"analog-be/service"
 	"context"
-	"crypto/tls"
-	"database/sql"
 	"os"
-	"time"
+	"sync"
 
-	"github.com/NARUBROWN/spine/pkg/boot"
 	"github.com/joho/godotenv"
-	"github.com/labstack/echo/v4"
-	httpSwagger "github.com/swaggo/http-swagger"
 
 	_ "analog-be/docs"
 
-	"github.com/NARUBROWN/spine"
-	"github.com/uptrace/bun"
-	"github.com/uptrace/bun/dialect/pgdialect"
-	"github.com/uptrace/bun/driver/pgdriver"
 	"go.uber.org/zap"
 )
 
@@ -50,156 +39,77 @@ func main() {
 
 	ValidateEnvVars(logger)
 
-	app := spine.New()
+	db := server.NewDB(server.DBConfigFromEnv("analog"))
 
-	db := newBunDB()
-
-	db.RegisterModel(
-		// relation
-		(*entity.LogToUser)(nil),
-		(*entity.LogToTopic)(nil),
-
-		(*entity.Log)(nil),
-		(*entity.Topic)(nil),
-		(*entity.User)(nil),
-		(*entity.Comment)(nil),
-		(*entity.OAuthState)(nil),
-		(*entity.Session)(nil),
-	)
-
-	app.Constructor(
-		// 디비
-		func() *bun.DB { return db },
-
-		// 기타
-		func() *zap.Logger { return logger },
-
-		// 레포지토리
-		repository.NewUserRepository,
-		repository.NewLogRepository,
-		repository.NewCommentRepository,
-		repository.NewOAuthStateRepository,
-		repository.NewSessionRepository,
-		repository.NewTopicRepository,
-
-		// 서비스
-		service.NewLogService,
-		service.NewUserService,
-		service.NewAnAccountOAuthService,
-		service.NewCommentService,
-		service.NewTopicService,
-		service.NewAnAmericanoService,
-		service.NewFeedService,
-
-		// 컨트롤러
-		controller.NewHealthController,
-		controller.NewLogController,
-		controller.NewUserController,
-		controller.NewAuthController,
-		controller.NewTopicController,
-		controller.NewFeedController,
-
-		// 인터셉터
-		interceptor.NewTxInterceptor,
-		interceptor.NewAuthInterceptor,
-	)
-
-	// 전역 인터셉터
-	app.Interceptor(
-		interceptor.NewCORSInterceptor(),
-		interceptor.NewRateLimitInterceptor(),
-		interceptor.NewLoggingInterceptor(),
-		interceptor.NewErrorInterceptor(),
-	)
-
-	routes.RegisterHealthRoutes(app)
-	routes.RegisterLogRoutes(app)
-	routes.RegisterUserRoutes(app)
-	routes.RegisterAuthRoutes(app)
-	routes.RegisterTopicRoutes(app)
-	routes.RegisterFeedRoutes(app)
-
-	app.Transport(func(t any) {
-		e := t.(*echo.Echo)
-		e.GET("/docs/*", echo.WrapHandler(httpSwagger.WrapHandler))
-	})
+	app := server.NewApp(db, logger)
 
 	port := os.Getenv("SERVER_PORT")
 	if port == "" {
 		port = "8080"
 	}
 
-	logger.Info("Server starting", zap.String("port", port))
-	app.Run(boot.Options{
-		Address:                ":" + port,
-		EnableGracefulShutdown: true,
-		HTTP: &boot.HTTPOptions{
-			GlobalPrefix: "/api",
-		},
-	})
-}
-
-func newBunDB() *bun.DB {
-	host := GetEnv("DB_HOST", "localhost")
-	port := GetEnv("DB_PORT", "5437")
-	user := GetEnv("DB_USER", "test")
-	password := GetEnv("DB_PASSWORD", "test")
-	database := GetEnv("DB_NAME", "test")
-
-	tlsConfig := &tls.Config{
-		InsecureSkipVerify: os.Getenv("DB_TLS_SKIP_VERIFY") == "true",
-	}
-
-	pgconn := pgdriver.NewConnector(
-		pgdriver.WithNetwork("tcp"),
-		pgdriver.WithAddr(host+":"+port),
-		pgdriver.WithTLSConfig(tlsConfig),
-		pgdriver.WithUser(user),
-		pgdriver.WithPassword(password),
-		pgdriver.WithDatabase(database),
-		pgdriver.WithApplicationName("analog"),
-		pgdriver.WithTimeout(10*time.Second),
-		pgdriver.WithDialTimeout(5*time.Second),
-		pgdriver.WithReadTimeout(10*time.Second),
-		pgdriver.WithWriteTimeout(10*time.Second),
-		pgdriver.WithInsecure(true),
+	// An-Americano 권한 outbox 워커
+	outboxWorker := service.NewPermissionOutboxWorker(
+		repository.NewPermissionOutboxRepository(db),
+		service.NewAnAmericanoService(),
+		logger,
 	)
 
-	sqldb := sql.OpenDB(pgconn)
+	// 만료된 세션 등을 정리하는 유지보수 작업 (정리만 하므로 세션 캐시는 쓰지 않음)
+	maintenanceRunner := service.NewMaintenanceRunner(
+		repository.NewLockRepository(db),
+		repository.NewSessionRepository(db, nil),
+		repository.NewOAuthStateRepository(db),
+		repository.NewPermissionOutboxRepository(db),
+		repository.NewAccessTokenRepository(db),
+		repository.NewRealtimeEventRepository(db),
+		repository.NewEmailOutboxRepository(db),
+		repository.NewEmailSettingsRepository(db),
+		logger,
+	)
 
-	sqldb.SetMaxOpenConns(25)
-	sqldb.SetMaxIdleConns(5)
-	sqldb.SetConnMaxLifetime(5 * time.Minute)
-	sqldb.SetConnMaxIdleTime(10 * time.Minute)
+	emailPolicy := pkg.NewEmailPolicy(logger)
 
-	db := bun.NewDB(sqldb, pgdialect.New())
+	// 메일 outbox 워커. SMTP_HOST가 없으면 보내지 않고 기록만 남김
+	emailWorker := service.NewEmailOutboxWorker(
+		repository.NewEmailOutboxRepository(db),
+		repository.NewEmailSettingsRepository(db),
+		mailer.NewSender(logger),
+		emailPolicy,
+		logger,
+	)
 
-	if os.Getenv("DEBUG") == "true" {
-		db.AddQueryHook(&debugHook{})
-	}
+	// 주간 소식을 메일 outbox에 쌓는 작업
+	digestRunner := service.NewEmailDigestRunner(
+		repository.NewLockRepository(db),
+		service.NewEmailService(
+			repository.NewEmailSettingsRepository(db),
+			repository.NewEmailOutboxRepository(db),
+			repository.NewNotificationRepository(db),
+			repository.NewLogRepository(db),
+			repository.NewUserIdentityRepository(db),
+			emailPolicy,
+			logger,
+		),
+		logger,
+	)
 
-	return db
-}
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	workers.Go(func() { outboxWorker.Run(workerCtx) })
+	workers.Go(func() { maintenanceRunner.Run(workerCtx) })
+	workers.Go(func() { emailWorker.Run(workerCtx) })
+	workers.Go(func() { digestRunner.Run(workerCtx) })
 
-func GetEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+	logger.Info("Server starting", zap.String("port", port))
+	err = app.Run(server.BootOptions(":"+port, true))
+	if err != nil {
+		logger.Error("Server stopped with error", zap.Error(err))
 	}
-	return defaultValue
-}
 
-type debugHook struct{}
-
-func (h *debugHook) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
-	return ctx
-}
-
-func (h *debugHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
-	logger := pkg.GetLogger()
-	logger.Debug("Database query",
-		zap.String("query", event.Query),
-		zap.Duration("duration", time.Since(event.StartTime)),
-	)
+	stopWorkers()
+	workers.Wait()
+	logger.Info("Background workers stopped")
 }
 
 func ValidateEnvVars(logger *zap.Logger) {
@@ -207,6 +117,7 @@ func ValidateEnvVars(logger *zap.Logger) {
 		"AN_ACCOUNT_BASE_URL",
 		"AN_ACCOUNT_CLIENT_ID",
 		"AN_ACCOUNT_CLIENT_SECRET",
+		"OAUTH_STATE_SECRET",
 	}
 
 	for _, key := range required {
@@ -216,6 +127,11 @@ func ValidateEnvVars(logger *zap.Logger) {
 		}
 	}
 
+	if err := service.ValidateOAuthStateSecret(); err != nil {
+		logger.Fatal("Invalid environment variable",
+			zap.String("variable", "OAUTH_STATE_SECRET"), zap.Error(err))
+	}
+
 	if os.Getenv("DB_TLS_SKIP_VERIFY") == "true" {
 		logger.Warn("Database TLS certificate validation is disabled - only use in development")
 	}
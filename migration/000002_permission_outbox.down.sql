@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS permission_outbox;
+
+ALTER TABLE logs DROP COLUMN IF EXISTS legacy_authors;
+ALTER TABLE log_to_users DROP COLUMN IF EXISTS relation;
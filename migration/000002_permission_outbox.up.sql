@@ -0,0 +1,26 @@
+-- 로그 작성자의 An-Americano 권한 관계 (owner / editor)
+ALTER TABLE log_to_users ADD COLUMN relation VARCHAR(32) NOT NULL DEFAULT 'editor';
+
+-- 예전 로그는 작성자 행이 빠져 있거나(작성자는 행 없이 권한만 기록됨) 수정하면서 모두 지워졌을 수 있어 행으로 관계를 알 수 없음
+-- 권한 재조정이 An-Americano의 권한으로 작성자를 한 번 가져온 뒤 false로 바꿈. 새 로그는 false
+ALTER TABLE logs ADD COLUMN legacy_authors BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE logs ALTER COLUMN legacy_authors SET DEFAULT FALSE;
+
+-- An-Americano 권한 변경 outbox
+CREATE TABLE permission_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    operation VARCHAR(16) NOT NULL,  -- write | delete
+    namespace VARCHAR(100) NOT NULL,
+    object_id BIGINT NOT NULL,
+    relation VARCHAR(32) NOT NULL,
+    user_id BIGINT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',  -- pending | done | failed
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    processed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+    );
+
+CREATE INDEX idx_permission_outbox_pending ON permission_outbox(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_permission_outbox_tuple ON permission_outbox(namespace, object_id, relation, user_id);
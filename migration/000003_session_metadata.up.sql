@@ -0,0 +1,4 @@
+-- 세션 관리 화면에 보여줄 접속 정보
+ALTER TABLE sessions ADD COLUMN last_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE sessions ADD COLUMN ip_address VARCHAR(64);
+ALTER TABLE sessions ADD COLUMN user_agent VARCHAR(512);
@@ -0,0 +1,8 @@
+-- 해시에서 원래 토큰을 되돌릴 수 없으므로 모든 세션과 진행 중인 로그인을 지움
+DELETE FROM sessions;
+DELETE FROM o_auth_states;
+
+ALTER TABLE o_auth_states ALTER COLUMN code_verifier SET NOT NULL;
+ALTER TABLE o_auth_states RENAME COLUMN state_hash TO state;
+
+ALTER TABLE sessions RENAME COLUMN token_hash TO session_token;
@@ -0,0 +1,10 @@
+-- 세션 토큰과 OAuth state는 원본 대신 SHA-256 해시(hex)만 저장
+-- 기존 값도 같은 방식으로 해시하므로 로그인된 사용자는 그대로 유지됨
+ALTER TABLE sessions RENAME COLUMN session_token TO token_hash;
+UPDATE sessions SET token_hash = encode(sha256(convert_to(token_hash, 'UTF8')), 'hex');
+
+ALTER TABLE o_auth_states RENAME COLUMN state TO state_hash;
+UPDATE o_auth_states SET state_hash = encode(sha256(convert_to(state_hash, 'UTF8')), 'hex');
+
+-- 새 code_verifier는 state에서 파생하므로 저장하지 않음 (진행 중이던 로그인만 기존 값 사용)
+ALTER TABLE o_auth_states ALTER COLUMN code_verifier DROP NOT NULL;
@@ -0,0 +1,14 @@
+-- 스크립트, CI에서 쓰는 개인 액세스 토큰 (세션 토큰처럼 SHA-256 해시만 저장)
+CREATE TABLE personal_access_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    token_prefix VARCHAR(16) NOT NULL,  -- 목록에서 토큰을 구별하기 위한 앞부분
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMP,  -- NULL이면 만료되지 않음
+    last_used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+    );
+
+CREATE INDEX idx_personal_access_tokens_user_id ON personal_access_tokens(user_id);
@@ -0,0 +1,6 @@
+ALTER TABLE o_auth_states DROP COLUMN IF EXISTS link_user_id;
+ALTER TABLE o_auth_states DROP COLUMN IF EXISTS provider;
+
+DROP SEQUENCE IF EXISTS users_external_id_seq;
+
+DROP TABLE IF EXISTS user_identities;
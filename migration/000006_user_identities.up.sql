@@ -0,0 +1,25 @@
+-- 한 사용자에 여러 외부 계정(An-Account, GitHub 등)을 연결
+CREATE TABLE user_identities (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(32) NOT NULL,
+    subject VARCHAR(255) NOT NULL,  -- 제공자 안에서의 사용자 아이디
+    email VARCHAR(320),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (provider, subject),
+    UNIQUE (user_id, provider)  -- 제공자마다 한 계정만 연결
+    );
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
+
+-- 기존 사용자는 모두 An-Account로 가입했고 아이디가 같음
+INSERT INTO user_identities (user_id, provider, subject, created_at, last_used_at)
+SELECT id, 'an-account', id::text, created_at, created_at FROM users;
+
+-- An-Account 외의 제공자로 가입한 사용자 아이디. An-Account 아이디(양수)와 겹치지 않도록 -1부터 내려감
+CREATE SEQUENCE users_external_id_seq INCREMENT BY -1 START WITH -1 MAXVALUE -1;
+
+-- 로그인을 시작한 제공자와, 계정 연결이면 연결할 사용자
+ALTER TABLE o_auth_states ADD COLUMN provider VARCHAR(32) NOT NULL DEFAULT 'an-account';
+ALTER TABLE o_auth_states ADD COLUMN link_user_id BIGINT REFERENCES users(id) ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+ALTER TABLE o_auth_states DROP COLUMN IF EXISTS handle;
+
+DROP TABLE IF EXISTS user_handle_history;
@@ -0,0 +1,15 @@
+-- 핸들을 바꾼 사용자의 이전 핸들. 이전 주소(log.ana.st/<핸들>/...)를 새 핸들로 넘겨주는 데 사용하고, 다른 사용자가 가져갈 수 없음
+CREATE TABLE user_handle_history (
+    handle VARCHAR(255) PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+    );
+
+CREATE INDEX idx_user_handle_history_user_id ON user_handle_history(user_id);
+
+-- 가입할 때 고른 핸들. 콜백에서 사용자를 만들 때 사용
+ALTER TABLE o_auth_states ADD COLUMN handle VARCHAR(255);
+
+-- 예전 가입 흐름은 핸들을 채우지 않아 빈 핸들로 남은 사용자에게 새로 가입할 때와 같은 기본 핸들(user<아이디>, guest<아이디>)을 줌
+UPDATE users SET handle = CASE WHEN id < 0 THEN 'guest' || -id ELSE 'user' || id END
+WHERE handle = '';
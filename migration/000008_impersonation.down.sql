@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS impersonation_audits;
+
+ALTER TABLE sessions DROP COLUMN IF EXISTS allow_writes;
+ALTER TABLE sessions DROP COLUMN IF EXISTS impersonation_reason;
+ALTER TABLE sessions DROP COLUMN IF EXISTS impersonator_id;
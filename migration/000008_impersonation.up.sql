@@ -0,0 +1,20 @@
+-- 관리자가 다른 사용자로 보기 위해 만든 대리 세션
+ALTER TABLE sessions ADD COLUMN impersonator_id BIGINT REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE sessions ADD COLUMN impersonation_reason TEXT;
+ALTER TABLE sessions ADD COLUMN allow_writes BOOLEAN NOT NULL DEFAULT false;
+
+-- 대리 세션의 시작, 요청, 종료 기록. 사용자나 세션이 지워져도 남도록 외래 키를 걸지 않음
+CREATE TABLE impersonation_audits (
+    id BIGSERIAL PRIMARY KEY,
+    session_id BIGINT NOT NULL,
+    impersonator_id BIGINT NOT NULL,
+    target_user_id BIGINT NOT NULL,
+    action VARCHAR(16) NOT NULL,  -- start, request, blocked, end
+    method VARCHAR(10),
+    path TEXT,
+    reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+    );
+
+CREATE INDEX idx_impersonation_audits_created_at ON impersonation_audits(created_at DESC);
+CREATE INDEX idx_impersonation_audits_target_user_id ON impersonation_audits(target_user_id, created_at DESC);
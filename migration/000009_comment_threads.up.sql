@@ -0,0 +1,6 @@
+-- 댓글의 답글. 부모가 지워져도 답글이 남도록 부모는 내용만 비운 채 deleted_at으로 표시함
+ALTER TABLE comments ADD COLUMN parent_id BIGINT REFERENCES comments(id) ON DELETE CASCADE;
+ALTER TABLE comments ADD COLUMN depth INT NOT NULL DEFAULT 0;
+ALTER TABLE comments ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX idx_comments_parent_id ON comments(parent_id, created_at);
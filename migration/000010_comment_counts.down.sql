@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_comments_log_id_root;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS reaction_count;
+ALTER TABLE logs DROP COLUMN IF EXISTS comment_count;
@@ -0,0 +1,11 @@
+-- 목록에서 추가 쿼리 없이 보여줄 로그별 댓글 수. 자리만 남은 댓글은 세지 않음
+ALTER TABLE logs ADD COLUMN comment_count INT NOT NULL DEFAULT 0;
+
+UPDATE logs SET comment_count = c.count
+FROM (SELECT log_id, COUNT(*) AS count FROM comments WHERE deleted_at IS NULL GROUP BY log_id) AS c
+WHERE c.log_id = logs.id;
+
+-- 반응 많은 순 정렬용
+ALTER TABLE comments ADD COLUMN reaction_count INT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_comments_log_id_root ON comments(log_id, created_at) WHERE parent_id IS NULL;
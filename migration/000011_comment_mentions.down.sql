@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS comment_mentions;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS pre_rendered;
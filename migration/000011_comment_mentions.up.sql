@@ -0,0 +1,16 @@
+-- 댓글의 마크다운을 렌더링한 HTML. 이전 댓글은 마크다운 없이 글자 그대로 채움
+ALTER TABLE comments ADD COLUMN pre_rendered TEXT NOT NULL DEFAULT '';
+
+UPDATE comments
+SET pre_rendered = '<p>' || replace(replace(replace(replace(replace(content, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), E'\n', E'<br>\n') || E'</p>\n'
+WHERE deleted_at IS NULL;
+
+-- 댓글에서 멘션한 사용자. 알림을 보낼 때 사용
+CREATE TABLE comment_mentions (
+    comment_id BIGINT NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (comment_id, user_id)
+    );
+
+CREATE INDEX idx_comment_mentions_user_id ON comment_mentions(user_id, created_at DESC);
@@ -0,0 +1,13 @@
+-- 댓글을 마지막으로 고친 시각. 고친 적 없으면 NULL
+ALTER TABLE comments ADD COLUMN updated_at TIMESTAMP;
+
+-- 고치기 전의 댓글 내용. 댓글을 지우면 함께 지움
+CREATE TABLE comment_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    comment_id BIGINT NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,  -- 이 내용을 쓴 시각
+    replaced_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP  -- 이 내용을 고친 시각
+    );
+
+CREATE INDEX idx_comment_revisions_comment_id ON comment_revisions(comment_id, id DESC);
@@ -0,0 +1,4 @@
+ALTER TABLE comments DROP COLUMN IF EXISTS hidden_by;
+ALTER TABLE comments DROP COLUMN IF EXISTS hidden_at;
+
+ALTER TABLE logs DROP COLUMN IF EXISTS comment_mode;
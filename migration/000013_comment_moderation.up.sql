@@ -0,0 +1,6 @@
+-- 로그 작성자가 정하는 댓글 상태. open: 누구나, locked: 로그 작성자만 새로 쓰거나 고칠 수 있음, off: 댓글을 보이지 않음
+ALTER TABLE logs ADD COLUMN comment_mode VARCHAR(16) NOT NULL DEFAULT 'open';
+
+-- 로그 작성자가 숨긴 댓글. 다른 사람에게는 작성자와 내용이 보이지 않음
+ALTER TABLE comments ADD COLUMN hidden_at TIMESTAMP;
+ALTER TABLE comments ADD COLUMN hidden_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
@@ -0,0 +1,7 @@
+ALTER TABLE comments DROP COLUMN IF EXISTS reaction_counts;
+ALTER TABLE logs DROP COLUMN IF EXISTS reaction_counts;
+
+UPDATE comments SET reaction_count = 0;
+
+DROP TABLE IF EXISTS comment_reactions;
+DROP TABLE IF EXISTS log_reactions;
@@ -0,0 +1,24 @@
+-- 로그와 댓글의 이모지 반응. 사용자마다 종류별로 하나씩
+CREATE TABLE log_reactions (
+    log_id BIGINT NOT NULL REFERENCES logs(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (log_id, user_id, kind)
+    );
+
+CREATE INDEX idx_log_reactions_user_id ON log_reactions(user_id);
+
+CREATE TABLE comment_reactions (
+    comment_id BIGINT NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (comment_id, user_id, kind)
+    );
+
+CREATE INDEX idx_comment_reactions_user_id ON comment_reactions(user_id);
+
+-- 목록에서 반응을 세지 않도록 종류별 개수를 저장. comments.reaction_count는 그 합계
+ALTER TABLE logs ADD COLUMN reaction_counts JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE comments ADD COLUMN reaction_counts JSONB NOT NULL DEFAULT '{}';
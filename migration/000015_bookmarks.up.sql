@@ -0,0 +1,35 @@
+-- 나중에 읽으려고 저장한 로그. 로그가 지워지면 함께 지워짐
+CREATE TABLE bookmarks (
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    log_id BIGINT NOT NULL REFERENCES logs(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, log_id)
+    );
+
+CREATE INDEX idx_bookmarks_user_id_created_at ON bookmarks(user_id, created_at DESC);
+CREATE INDEX idx_bookmarks_log_id ON bookmarks(log_id);
+
+-- 저장한 로그를 묶는 이름 붙은 목록. 공개하면 누구나 볼 수 있음
+CREATE TABLE reading_lists (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500) NOT NULL DEFAULT '',
+    is_public BOOLEAN NOT NULL DEFAULT FALSE,
+    position INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+    );
+
+-- 목록에 담긴 로그. 저장을 취소하거나 로그가 지워지면 함께 지워짐
+CREATE TABLE reading_list_items (
+    list_id BIGINT NOT NULL REFERENCES reading_lists(id) ON DELETE CASCADE,
+    log_id BIGINT NOT NULL REFERENCES logs(id) ON DELETE CASCADE,
+    position INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (list_id, log_id)
+    );
+
+CREATE INDEX idx_reading_list_items_list_id_position ON reading_list_items(list_id, position);
+CREATE INDEX idx_reading_list_items_log_id ON reading_list_items(log_id);
@@ -0,0 +1,27 @@
+-- 사용자에게 보여 줄 알림. 같은 대상(group_key)에 대한 읽지 않은 알림은 한 행으로 묶고 행동한 사람을 모음
+CREATE TABLE notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(32) NOT NULL,
+    group_key VARCHAR(128) NOT NULL,
+    log_id BIGINT REFERENCES logs(id) ON DELETE CASCADE,
+    comment_id BIGINT REFERENCES comments(id) ON DELETE CASCADE,
+    -- 마지막으로 행동한 사람
+    actor_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    actor_ids BIGINT[] NOT NULL DEFAULT '{}',
+    actor_count INT NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    read_at TIMESTAMP
+    );
+
+CREATE UNIQUE INDEX idx_notifications_unread_group ON notifications(user_id, group_key) WHERE read_at IS NULL;
+CREATE INDEX idx_notifications_user_id_updated_at ON notifications(user_id, updated_at DESC);
+
+-- 알림 종류별 수신 설정. 행이 없으면 받음
+CREATE TABLE notification_preferences (
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(32) NOT NULL,
+    in_app BOOLEAN NOT NULL DEFAULT TRUE,
+    PRIMARY KEY (user_id, type)
+    );
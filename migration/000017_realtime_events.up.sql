@@ -0,0 +1,12 @@
+-- 실시간 스트림으로 보낸 이벤트. 다시 연결한 클라이언트가 Last-Event-ID 이후를 받을 수 있도록 잠시 보관함
+CREATE TABLE realtime_events (
+    id BIGSERIAL PRIMARY KEY,
+    -- 받을 구독 채널 (logs, log:<로그 아이디>, user:<사용자 아이디>)
+    channel VARCHAR(64) NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    data JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+    );
+
+CREATE INDEX idx_realtime_events_channel_id ON realtime_events(channel, id);
+CREATE INDEX idx_realtime_events_created_at ON realtime_events(created_at);
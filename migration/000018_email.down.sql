@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS email_outbox;
+DROP TABLE IF EXISTS email_unsubscribe_tokens;
+ALTER TABLE notification_preferences DROP COLUMN IF EXISTS email;
+DROP TABLE IF EXISTS email_settings;
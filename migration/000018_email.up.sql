@@ -0,0 +1,50 @@
+-- 메일 수신 설정. 행이 없거나 꺼져 있으면 메일을 보내지 않음
+CREATE TABLE email_settings (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    -- 연결된 계정에 등록된 메일 주소 중 하나
+    email VARCHAR(320) NOT NULL,
+    notifications BOOLEAN NOT NULL DEFAULT FALSE,
+    digest BOOLEAN NOT NULL DEFAULT FALSE,
+    -- 주간 소식에 담을 주제와 기수. 둘 다 비어 있으면 모든 새 로그
+    digest_topic_ids BIGINT[] NOT NULL DEFAULT '{}',
+    digest_generations SMALLINT[] NOT NULL DEFAULT '{}',
+    last_digest_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+    );
+
+CREATE INDEX idx_email_settings_digest ON email_settings(last_digest_at) WHERE digest;
+
+-- 메일마다 보낼 때 만드는 수신 거부 토큰. 토큰은 SHA-256 해시만 저장하고, 보낸 주소가 지금 설정과 같고 만료 전일 때만 쓸 수 있음
+CREATE TABLE email_unsubscribe_tokens (
+    token_hash VARCHAR(64) PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(320) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+    );
+
+CREATE INDEX idx_email_unsubscribe_tokens_user_id ON email_unsubscribe_tokens(user_id);
+CREATE INDEX idx_email_unsubscribe_tokens_expires_at ON email_unsubscribe_tokens(expires_at);
+
+ALTER TABLE notification_preferences ADD COLUMN email BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- 보낼 메일. 요청과 같은 흐름에서 쌓고 백그라운드 워커가 재시도하며 보냄
+-- 본문은 수신 거부 링크를 채워 보낼 때 template과 data로 렌더링함
+CREATE TABLE email_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(32) NOT NULL,
+    to_address VARCHAR(320) NOT NULL,
+    subject TEXT NOT NULL,
+    template VARCHAR(32) NOT NULL,
+    data JSONB NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    processed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+    );
+
+CREATE INDEX idx_email_outbox_pending ON email_outbox(next_attempt_at) WHERE status = 'pending';
@@ -2,16 +2,12 @@ package main
 
 import (
 	migrations "analog-be/migration"
+	"analog-be/server"
 	"context"
-	"crypto/tls"
-	"database/sql"
+	"os"
+
 	"github.com/joho/godotenv"
-	"github.com/uptrace/bun"
-	"github.com/uptrace/bun/dialect/pgdialect"
-	"github.com/uptrace/bun/driver/pgdriver"
 	"github.com/uptrace/bun/migrate"
-	"os"
-	"time"
 )
 
 func main() {
@@ -19,39 +15,8 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	host := GetEnv("DB_HOST", "localhost")
-	port := GetEnv("DB_PORT", "5437")
-	user := GetEnv("DB_USER", "test")
-	password := GetEnv("DB_PASSWORD", "test")
-	database := GetEnv("DB_NAME", "test")
-
-	tlsConfig := &tls.Config{
-		InsecureSkipVerify: os.Getenv("DB_TLS_SKIP_VERIFY") == "true",
-	}
-
-	pgconn := pgdriver.NewConnector(
-		pgdriver.WithNetwork("tcp"),
-		pgdriver.WithAddr(host+":"+port),
-		pgdriver.WithTLSConfig(tlsConfig),
-		pgdriver.WithUser(user),
-		pgdriver.WithPassword(password),
-		pgdriver.WithDatabase(database),
-		pgdriver.WithApplicationName("analog"),
-		pgdriver.WithTimeout(10*time.Second),
-		pgdriver.WithDialTimeout(5*time.Second),
-		pgdriver.WithReadTimeout(10*time.Second),
-		pgdriver.WithWriteTimeout(10*time.Second),
-		pgdriver.WithInsecure(true),
-	)
 
-	sqldb := sql.OpenDB(pgconn)
-
-	sqldb.SetMaxOpenConns(25)
-	sqldb.SetMaxIdleConns(5)
-	sqldb.SetConnMaxLifetime(5 * time.Minute)
-	sqldb.SetConnMaxIdleTime(10 * time.Minute)
-
-	db := bun.NewDB(sqldb, pgdialect.New())
+	db := server.NewDB(server.DBConfigFromEnv("analog"))
 
 	migrator := migrate.NewMigrator(db, migrations.Migrations)
 
@@ -77,10 +42,3 @@ func main() {
 	}
 
 }
-
-func GetEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
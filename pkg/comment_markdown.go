@@ -0,0 +1,259 @@
+package pkg
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// MaxCommentMentions 는 댓글 하나에서 링크로 바꾸고 알림을 보낼 서로 다른 멘션 수입니다. 넘는 멘션은 글자로 남습니다.
+const MaxCommentMentions = 20
+
+// 댓글의 링크는 검색 엔진에 점수를 주지 않고, 새 창이 원래 창에 접근하지 못하게 함
+var commentLinkRel = []byte("nofollow ugc noopener noreferrer")
+
+// CommentMarkdown 은 댓글에 허용한 마크다운(코드, 링크, 강조)만 HTML로 바꾸고, @핸들 멘션을 프로필 링크로 바꿉니다.
+// 제목, 목록, 인용, 이미지, 원본 HTML은 지원하지 않아 글자 그대로 보입니다.
+type CommentMarkdown struct {
+	md               goldmark.Markdown
+	profileURLFormat string
+}
+
+func NewCommentMarkdown() *CommentMarkdown {
+	profileURLFormat := os.Getenv("PROFILE_URL_FORMAT")
+	if profileURLFormat == "" {
+		profileURLFormat = "https://log.ana.st/%s"
+	}
+
+	md := goldmark.New(
+		goldmark.WithParser(parser.NewParser(
+			parser.WithBlockParsers(
+				util.Prioritized(parser.NewFencedCodeBlockParser(), 700),
+				util.Prioritized(parser.NewParagraphParser(), 1000),
+			),
+			parser.WithInlineParsers(
+				util.Prioritized(parser.NewCodeSpanParser(), 100),
+				util.Prioritized(parser.NewLinkParser(), 200),
+				util.Prioritized(parser.NewAutoLinkParser(), 300),
+				util.Prioritized(parser.NewEmphasisParser(), 500),
+				util.Prioritized(&mentionParser{}, 600),
+				util.Prioritized(extension.NewLinkifyParser(), 999),
+			),
+			parser.WithParagraphTransformers(
+				util.Prioritized(parser.LinkReferenceParagraphTransformer, 100),
+			),
+		)),
+		goldmark.WithRendererOptions(
+			html.WithHardWraps(),
+			renderer.WithNodeRenderers(util.Prioritized(&commentNodeRenderer{}, 100)),
+		),
+	)
+
+	return &CommentMarkdown{md: md, profileURLFormat: profileURLFormat}
+}
+
+// CommentDocument 는 한 번 파싱한 댓글입니다. Handles로 멘션된 핸들을 모아 확인한 뒤 Render로 HTML을 만듭니다.
+type CommentDocument struct {
+	markdown *CommentMarkdown
+	source   []byte
+	root     ast.Node
+	mentions []*mentionNode
+	handles  []string
+}
+
+func (m *CommentMarkdown) Parse(content string) *CommentDocument {
+	source := []byte(content)
+	doc := &CommentDocument{markdown: m, source: source, root: m.md.Parser().Parse(text.NewReader(source))}
+
+	seen := map[string]bool{}
+	_ = ast.Walk(doc.root, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch n := n.(type) {
+		case *ast.Link, *ast.Image, *ast.AutoLink:
+			n.SetAttributeString("rel", commentLinkRel)
+		case *mentionNode:
+			// 링크 글자 안의 멘션은 링크를 겹치지 않도록 글자로 둠
+			if insideLink(n) {
+				return ast.WalkContinue, nil
+			}
+			if !seen[n.handle] {
+				if len(doc.handles) >= MaxCommentMentions {
+					return ast.WalkContinue, nil
+				}
+				seen[n.handle] = true
+				doc.handles = append(doc.handles, n.handle)
+			}
+			doc.mentions = append(doc.mentions, n)
+		}
+		return ast.WalkContinue, nil
+	})
+
+	return doc
+}
+
+// Handles 는 멘션된 핸들을 처음 나온 순서대로 중복 없이 돌려줍니다. 정규화되어 있고 최대 MaxCommentMentions개입니다.
+func (d *CommentDocument) Handles() []string {
+	return d.handles
+}
+
+// Render 는 exists가 true인 핸들의 멘션만 프로필 링크로 바꿔 HTML을 만듭니다.
+func (d *CommentDocument) Render(exists func(handle string) bool) (string, error) {
+	for _, mention := range d.mentions {
+		mention.url = ""
+		if exists(mention.handle) {
+			mention.url = fmt.Sprintf(d.markdown.profileURLFormat, mention.handle)
+		}
+	}
+
+	var rendered bytes.Buffer
+	if err := d.markdown.md.Renderer().Render(&rendered, d.source, d.root); err != nil {
+		return "", err
+	}
+	return rendered.String(), nil
+}
+
+func insideLink(n ast.Node) bool {
+	for p := n.Parent(); p != nil; p = p.Parent() {
+		if p.Kind() == ast.KindLink || p.Kind() == ast.KindImage || p.Kind() == ast.KindAutoLink {
+			return true
+		}
+	}
+	return false
+}
+
+var kindMention = ast.NewNodeKind("Mention")
+
+// mentionNode 는 @핸들 하나입니다. url이 비어 있으면 원래 글자를 그대로 씁니다.
+type mentionNode struct {
+	ast.BaseInline
+
+	segment text.Segment
+	handle  string
+	url     string
+}
+
+func (n *mentionNode) Kind() ast.NodeKind {
+	return kindMention
+}
+
+func (n *mentionNode) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Handle": n.handle}, nil)
+}
+
+type mentionParser struct{}
+
+func (p *mentionParser) Trigger() []byte {
+	return []byte{'@'}
+}
+
+// Parse 는 글자나 숫자 바로 뒤의 @(이메일 주소 등)는 멘션으로 보지 않습니다.
+func (p *mentionParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	if prev := block.PrecendingCharacter(); isHandleRune(prev) || prev == '@' || prev == '.' || prev == '/' {
+		return nil
+	}
+
+	line, segment := block.PeekLine()
+	end := 1
+	for end < len(line) && isHandleRune(rune(line[end])) {
+		end++
+	}
+	// 문장 끝의 밑줄, 하이픈은 핸들에 넣지 않음
+	for end > 1 && (line[end-1] == '_' || line[end-1] == '-') {
+		end--
+	}
+
+	handle := NormalizeHandle(string(line[1:end]))
+	if !handlePattern.MatchString(handle) {
+		return nil
+	}
+
+	block.Advance(end)
+	return &mentionNode{segment: segment.WithStop(segment.Start + end), handle: handle}
+}
+
+func isHandleRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-'
+}
+
+// commentNodeRenderer 는 멘션을 그리고, 이미지는 불러오지 않도록 대체 글자를 단 링크로 바꿉니다.
+type commentNodeRenderer struct{}
+
+func (r *commentNodeRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindMention, r.renderMention)
+	reg.Register(ast.KindImage, r.renderImage)
+	reg.Register(ast.KindAutoLink, r.renderAutoLink)
+}
+
+func (r *commentNodeRenderer) renderMention(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	n := node.(*mentionNode)
+	if n.url == "" {
+		_, _ = w.Write(util.EscapeHTML(n.segment.Value(source)))
+		return ast.WalkContinue, nil
+	}
+
+	_, _ = w.WriteString(`<a href="`)
+	_, _ = w.Write(util.EscapeHTML(util.URLEscape([]byte(n.url), true)))
+	_, _ = w.WriteString(`" class="mention">@`)
+	_, _ = w.Write(util.EscapeHTML([]byte(n.handle)))
+	_, _ = w.WriteString(`</a>`)
+	return ast.WalkContinue, nil
+}
+
+func (r *commentNodeRenderer) renderImage(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*ast.Image)
+	if !entering {
+		_, _ = w.WriteString("</a>")
+		return ast.WalkContinue, nil
+	}
+
+	_, _ = w.WriteString(`<a href="`)
+	if !html.IsDangerousURL(n.Destination) {
+		_, _ = w.Write(util.EscapeHTML(util.URLEscape(n.Destination, true)))
+	}
+	_, _ = w.WriteString(`"`)
+	html.RenderAttributes(w, n, html.LinkAttributeFilter)
+	_ = w.WriteByte('>')
+	return ast.WalkContinue, nil
+}
+
+// renderAutoLink 는 기본 렌더러와 달리 javascript: 같은 위험한 주소를 링크로 만들지 않습니다.
+func (r *commentNodeRenderer) renderAutoLink(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	n := node.(*ast.AutoLink)
+	url := n.URL(source)
+	if n.AutoLinkType == ast.AutoLinkEmail && !bytes.HasPrefix(bytes.ToLower(url), []byte("mailto:")) {
+		url = append([]byte("mailto:"), url...)
+	}
+	if html.IsDangerousURL(url) {
+		_, _ = w.Write(util.EscapeHTML(n.Label(source)))
+		return ast.WalkContinue, nil
+	}
+
+	_, _ = w.WriteString(`<a href="`)
+	_, _ = w.Write(util.EscapeHTML(util.URLEscape(url, false)))
+	_, _ = w.WriteString(`"`)
+	html.RenderAttributes(w, n, html.LinkAttributeFilter)
+	_ = w.WriteByte('>')
+	_, _ = w.Write(util.EscapeHTML(n.Label(source)))
+	_, _ = w.WriteString(`</a>`)
+	return ast.WalkContinue, nil
+}
@@ -0,0 +1,83 @@
+package pkg
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func renderComment(t *testing.T, content string, existing ...string) (string, []string) {
+	t.Helper()
+
+	doc := NewCommentMarkdown().Parse(content)
+	rendered, err := doc.Render(func(handle string) bool { return slices.Contains(existing, handle) })
+	if err != nil {
+		t.Fatalf("render %q: %v", content, err)
+	}
+	return rendered, doc.Handles()
+}
+
+func TestCommentMarkdownSubset(t *testing.T) {
+	cases := []struct {
+		content string
+		want    string
+	}{
+		{"**굵게** _기울임_ `코드`", "<p><strong>굵게</strong> <em>기울임</em> <code>코드</code></p>\n"},
+		{"[링크](https://ana.st)", `<p><a href="https://ana.st" rel="nofollow ugc noopener noreferrer">링크</a></p>` + "\n"},
+		{"첫 줄\n둘째 줄", "<p>첫 줄<br>\n둘째 줄</p>\n"},
+		{"# 제목", "<p># 제목</p>\n"},
+		{"<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n"},
+		{"[나쁜 링크](javascript:alert(1))", `<p><a href="" rel="nofollow ugc noopener noreferrer">나쁜 링크</a></p>` + "\n"},
+		{"<javascript:alert(1)>", "<p>javascript:alert(1)</p>\n"},
+		{"![그림](https://ana.st/a.png)", `<p><a href="https://ana.st/a.png" rel="nofollow ugc noopener noreferrer">그림</a></p>` + "\n"},
+	}
+
+	for _, c := range cases {
+		if got, _ := renderComment(t, c.content); got != c.want {
+			t.Errorf("render %q = %q, want %q", c.content, got, c.want)
+		}
+	}
+}
+
+func TestCommentMarkdownCodeBlock(t *testing.T) {
+	got, handles := renderComment(t, "```go\nfmt.Println(\"<@alice>\")\n```")
+	if !strings.Contains(got, "<pre><code class=\"language-go\">") || !strings.Contains(got, "&lt;@alice&gt;") {
+		t.Fatalf("unexpected code block: %q", got)
+	}
+	if len(handles) != 0 {
+		t.Fatalf("mentions in code should be ignored: %v", handles)
+	}
+}
+
+func TestCommentMarkdownMentions(t *testing.T) {
+	got, handles := renderComment(t, "@Alice, @bob 그리고 @alice 메일은 me@alice.dev `@carol`", "alice")
+	if want := []string{"alice", "bob"}; !slices.Equal(handles, want) {
+		t.Fatalf("handles = %v, want %v", handles, want)
+	}
+
+	link := `<a href="https://log.ana.st/alice" class="mention">@alice</a>`
+	if strings.Count(got, link) != 2 {
+		t.Fatalf("expected two links to alice: %q", got)
+	}
+	if !strings.Contains(got, "@bob") || strings.Contains(got, "log.ana.st/bob") {
+		t.Fatalf("unknown handle should stay text: %q", got)
+	}
+	if strings.Contains(got, `class="mention">@alice.dev`) {
+		t.Fatalf("email address became a mention: %q", got)
+	}
+}
+
+func TestCommentMarkdownMentionLimit(t *testing.T) {
+	var content strings.Builder
+	for i := range MaxCommentMentions + 5 {
+		content.WriteString("@user")
+		content.WriteString(strings.Repeat("x", 3))
+		content.WriteByte(byte('a' + i%26))
+		content.WriteByte(byte('a' + i/26))
+		content.WriteByte(' ')
+	}
+
+	if _, handles := renderComment(t, content.String()); len(handles) != MaxCommentMentions {
+		t.Fatalf("handles = %d, want %d", len(handles), MaxCommentMentions)
+	}
+}
@@ -0,0 +1,17 @@
+package pkg
+
+// CommentPolicy 는 댓글 스레드의 깊이 제한입니다. 최상위 댓글의 깊이는 0입니다.
+type CommentPolicy struct {
+	MaxDepth int
+}
+
+func NewCommentPolicy() *CommentPolicy {
+	return &CommentPolicy{
+		MaxDepth: getEnvInt("COMMENT_MAX_DEPTH", 3),
+	}
+}
+
+// CanReply 는 깊이가 depth인 댓글에 답글을 달 수 있는지 확인합니다.
+func (p *CommentPolicy) CanReply(depth int) bool {
+	return depth < p.MaxDepth
+}
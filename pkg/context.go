@@ -3,15 +3,31 @@ package pkg
 import (
 	"analog-be/entity"
 	"context"
+	"net/http"
 )
 
 type contextKey string
 
 const (
-	UserIDKey       contextKey = "userID"
-	SessionTokenKey contextKey = "sessionToken"
+	UserIDKey        contextKey = "userID"
+	SessionTokenKey  contextKey = "sessionToken"
+	SessionIDKey     contextKey = "sessionID"
+	AccessTokenIDKey contextKey = "accessTokenID"
+	// 세션 토큰을 Authorization 헤더가 아닌 세션 쿠키로 받았으면 true
+	SessionFromCookieKey contextKey = "sessionFromCookie"
+	// 관리자 대리 세션이면 그 관리자 아이디
+	ImpersonatorIDKey contextKey = "impersonatorID"
+	ClientInfoKey     contextKey = "clientInfo"
+	// 스트리밍 응답을 쓸 원본 http.ResponseWriter
+	ResponseWriterKey contextKey = "responseWriter"
 )
 
+// ClientInfo 는 요청을 보낸 클라이언트의 접속 정보입니다.
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
 func GetUserID(ctx context.Context) (entity.ID, bool) {
 	userID, ok := ctx.Value(UserIDKey).(entity.ID)
 	return userID, ok
@@ -21,3 +37,22 @@ func GetSessionToken(ctx context.Context) (string, bool) {
 	token, ok := ctx.Value(SessionTokenKey).(string)
 	return token, ok
 }
+
+func WithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, ClientInfoKey, info)
+}
+
+func GetClientInfo(ctx context.Context) (ClientInfo, bool) {
+	info, ok := ctx.Value(ClientInfoKey).(ClientInfo)
+	return info, ok
+}
+
+func WithResponseWriter(ctx context.Context, w http.ResponseWriter) context.Context {
+	return context.WithValue(ctx, ResponseWriterKey, w)
+}
+
+// GetResponseWriter 는 Spine의 응답 형식을 거치지 않고 직접 써야 하는 응답(SSE 등)에 쓸 원본 ResponseWriter를 꺼냅니다.
+func GetResponseWriter(ctx context.Context) (http.ResponseWriter, bool) {
+	w, ok := ctx.Value(ResponseWriterKey).(http.ResponseWriter)
+	return w, ok
+}
@@ -0,0 +1,50 @@
+package pkg
+
+import (
+	"net/url"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// EmailPolicy 는 메일 알림과 주간 소식을 보내는 규칙입니다.
+type EmailPolicy struct {
+	// 메일 앱의 원클릭 수신 거부(List-Unsubscribe)가 POST할 API 주소. token, list 쿼리가 붙음
+	// RFC 8058에 따라 https여야 하며, 설정하지 않으면 비어 있고 메일을 쌓지 않음
+	UnsubscribeURL string
+	// 본문의 수신 거부 링크가 가리킬 프론트엔드 주소. token, list 쿼리가 붙음
+	UnsubscribePageURL string
+	// 주간 소식을 보내는 간격
+	DigestInterval time.Duration
+	// 주간 소식 한 통에 담을 최대 로그 수
+	DigestMaxLogs int
+}
+
+func NewEmailPolicy(logger *zap.Logger) *EmailPolicy {
+	// 배포마다 API 주소가 다르므로 기본값을 두지 않음
+	unsubscribeURL := os.Getenv("EMAIL_UNSUBSCRIBE_URL")
+	if u, err := url.Parse(unsubscribeURL); err != nil || u.Scheme != "https" || u.Host == "" {
+		logger.Error("EMAIL_UNSUBSCRIBE_URL must be an https URL; emails will not be queued", zap.String("value", unsubscribeURL))
+		unsubscribeURL = ""
+	}
+
+	return &EmailPolicy{
+		UnsubscribeURL:     unsubscribeURL,
+		UnsubscribePageURL: getEnvString("EMAIL_UNSUBSCRIBE_PAGE_URL", "https://log.ana.st/email/unsubscribe"),
+		DigestInterval:     getEnvDuration("EMAIL_DIGEST_INTERVAL", 7*24*time.Hour),
+		DigestMaxLogs:      getEnvInt("EMAIL_DIGEST_MAX_LOGS", 20),
+	}
+}
+
+// Enabled 는 원클릭 수신 거부 주소가 있어 메일을 쌓을 수 있는지 확인합니다.
+func (p *EmailPolicy) Enabled() bool {
+	return p.UnsubscribeURL != ""
+}
+
+func getEnvString(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
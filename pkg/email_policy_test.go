@@ -0,0 +1,21 @@
+package pkg
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestEmailPolicyRequiresHTTPSUnsubscribeURL(t *testing.T) {
+	for value, enabled := range map[string]bool{
+		"": false,
+		"http://localhost:8080/api/email/unsubscribe": false,
+		"https://api.ana.st/api/email/unsubscribe":    true,
+	} {
+		t.Setenv("EMAIL_UNSUBSCRIBE_URL", value)
+
+		if got := NewEmailPolicy(zap.NewNop()).Enabled(); got != enabled {
+			t.Errorf("Enabled() with %q = %v, want %v", value, got, enabled)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package fakeana
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"time"
+)
+
+// idTokenLifetime 는 대역 서버가 발급하는 ID 토큰의 유효 기간입니다.
+const idTokenLifetime = 5 * time.Minute
+
+type signingKey struct {
+	id  string
+	key *rsa.PrivateKey
+}
+
+func newSigningKey() *signingKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	return &signingKey{id: randomToken()[:16], key: key}
+}
+
+// RotateKey 는 새 서명 키로 바꿉니다. 이전 키도 JWKS에 남겨 두어 이미 발급한 토큰은 계속 검증됩니다.
+func (s *Server) RotateKey() {
+	key := newSigningKey()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.signingKeys = append([]*signingKey{key}, s.signingKeys...)
+}
+
+// SetIDTokenHook 은 서명 직전의 ID 토큰 클레임을 바꿀 수 있게 합니다. nil이면 해제합니다.
+func (s *Server) SetIDTokenHook(hook func(claims map[string]any)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.idTokenHook = hook
+}
+
+// issuer 는 ID 토큰의 iss입니다. Start로 띄우지 않았으면 요청의 호스트로 정합니다.
+func (s *Server) issuer(r *http.Request) string {
+	if s.httpServer != nil {
+		return s.httpServer.URL
+	}
+	return "http://" + r.Host
+}
+
+func (s *Server) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	issuer := s.issuer(r)
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                issuer,
+		"authorization_endpoint":                issuer + "/oauth2/authorize",
+		"token_endpoint":                        issuer + "/oauth2/token",
+		"userinfo_endpoint":                     issuer + "/userinfo",
+		"jwks_uri":                              issuer + "/.well-known/jwks.json",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (s *Server) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	keys := make([]map[string]string, 0, len(s.signingKeys))
+	for _, k := range s.signingKeys {
+		keys = append(keys, map[string]string{
+			"kty": "RSA",
+			"kid": k.id,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(k.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.key.E)).Bytes()),
+		})
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]any{"keys": keys})
+}
+
+// signIDToken 은 현재 서명 키로 RS256 ID 토큰을 만듭니다. 사용자가 없으면 false를 반환합니다.
+func (s *Server) signIDToken(issuer string, userID int64, nonce string) (string, bool) {
+	s.mu.Lock()
+	user, ok := s.users[userID]
+	if !ok {
+		s.mu.Unlock()
+		return "", false
+	}
+
+	now := time.Now()
+	claims := map[string]any{
+		"iss":                issuer,
+		"sub":                user.subject(),
+		"aud":                s.config.ClientID,
+		"exp":                now.Add(idTokenLifetime).Unix(),
+		"iat":                now.Unix(),
+		"name":               user.Name,
+		"email":              user.Email,
+		"preferred_username": user.PreferredUsername,
+		"picture":            user.Picture,
+	}
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+	if s.idTokenHook != nil {
+		s.idTokenHook(claims)
+	}
+	key := s.signingKeys[0]
+	s.mu.Unlock()
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": key.id})
+	payload, _ := json.Marshal(claims)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", false
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), true
+}
@@ -0,0 +1,595 @@
+// Package fakeana 는 테스트와 로컬 개발을 위한 An-Account / An-Americano 대역 서버입니다.
+//
+// AnAccountServiceImpl이 사용하는 OAuth2 엔드포인트(/oauth2/authorize, /oauth2/token, /userinfo),
+// OIDC 디스커버리 문서와 JWKS, An-Americano 권한 API(/api/anamericano/...)를 메모리 상태로 흉내 냅니다.
+//
+//	srv := fakeana.NewTestServer(t) // AN_ACCOUNT_BASE_URL 등 환경 변수도 함께 설정
+//	srv.AddUser(fakeana.User{ID: 1, Name: "홍길동"})
+//	srv.InjectFailure(fakeana.EndpointWrite, fakeana.Failure{Status: 503, Times: 1})
+//
+// 로컬 개발용으로는 cmd/fakeana를 실행합니다.
+package fakeana
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sunrin-ana/anamericano-golang"
+)
+
+// Endpoint 는 실패 주입과 호출 횟수 집계에 쓰이는 엔드포인트 이름입니다.
+type Endpoint string
+
+const (
+	EndpointAuthorize Endpoint = "authorize"
+	EndpointToken     Endpoint = "token"
+	EndpointUserInfo  Endpoint = "userinfo"
+	EndpointDiscovery Endpoint = "discovery"
+	EndpointJWKS      Endpoint = "jwks"
+	EndpointCheck     Endpoint = "check"
+	EndpointWrite     Endpoint = "write"
+	EndpointDelete    Endpoint = "delete"
+	EndpointRead      Endpoint = "read"
+	EndpointList      Endpoint = "list"
+)
+
+// User 는 대역 서버에 등록된 An-Account 사용자입니다.
+type User struct {
+	ID                int64  `json:"id"`
+	Name              string `json:"name"`
+	Email             string `json:"email"`
+	PreferredUsername string `json:"preferredUsername"`
+	Picture           string `json:"picture"`
+	// 동아리 부원이면 userinfo에 학과와 기수를 담음
+	Department string `json:"department,omitempty"`
+	Generation uint16 `json:"generation,omitempty"`
+	// Subject 가 비어 있지 않으면 ID 토큰과 userinfo의 sub로 ID 대신 사용합니다. (잘못된 sub나 다른 제공자 테스트용)
+	Subject string `json:"subject,omitempty"`
+}
+
+func (u *User) subject() string {
+	if u.Subject != "" {
+		return u.Subject
+	}
+	return strconv.FormatInt(u.ID, 10)
+}
+
+// Failure 는 다음 Times번의 호출을 Status로 실패시킵니다. Times가 0 이하이면 해제될 때까지 계속 실패합니다.
+type Failure struct {
+	Status int
+	Body   string
+	Times  int
+	Delay  time.Duration
+}
+
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	// APIToken 이 비어 있지 않으면 권한 API는 이 Bearer 토큰만 허용합니다.
+	APIToken string
+}
+
+type authCode struct {
+	userID        int64
+	redirectURI   string
+	codeChallenge string
+	nonce         string
+	expiresAt     time.Time
+}
+
+type permissionKey struct {
+	namespace   string
+	objectID    string
+	relation    string
+	subjectType string
+	subjectID   string
+}
+
+type Server struct {
+	config Config
+
+	mu            sync.Mutex
+	users         map[int64]*User
+	loginAs       int64
+	codes         map[string]*authCode
+	accessTokens  map[string]int64
+	refreshTokens map[string]int64
+	permissions   map[permissionKey]*anamericano.Permission
+	nextPermID    int64
+	failures      map[Endpoint]*Failure
+	calls         map[Endpoint]int
+
+	signingKeys []*signingKey
+	idTokenHook func(claims map[string]any)
+
+	httpServer *httptest.Server
+	mux        *http.ServeMux
+}
+
+func New(config Config) *Server {
+	s := &Server{
+		config:        config,
+		users:         make(map[int64]*User),
+		codes:         make(map[string]*authCode),
+		accessTokens:  make(map[string]int64),
+		refreshTokens: make(map[string]int64),
+		permissions:   make(map[permissionKey]*anamericano.Permission),
+		failures:      make(map[Endpoint]*Failure),
+		calls:         make(map[Endpoint]int),
+		mux:           http.NewServeMux(),
+	}
+	s.signingKeys = []*signingKey{newSigningKey()}
+
+	s.mux.HandleFunc("GET /.well-known/openid-configuration", s.wrap(EndpointDiscovery, s.handleDiscovery))
+	s.mux.HandleFunc("GET /.well-known/jwks.json", s.wrap(EndpointJWKS, s.handleJWKS))
+	s.mux.HandleFunc("GET /oauth2/authorize", s.wrap(EndpointAuthorize, s.handleAuthorize))
+	s.mux.HandleFunc("POST /oauth2/token", s.wrap(EndpointToken, s.handleToken))
+	s.mux.HandleFunc("GET /userinfo", s.wrap(EndpointUserInfo, s.handleUserInfo))
+
+	s.mux.HandleFunc("POST /api/anamericano/check", s.wrap(EndpointCheck, s.requireAPIToken(s.handleCheck)))
+	s.mux.HandleFunc("POST /api/anamericano/write", s.wrap(EndpointWrite, s.requireAPIToken(s.handleWrite)))
+	s.mux.HandleFunc("DELETE /api/anamericano/delete", s.wrap(EndpointDelete, s.requireAPIToken(s.handleDelete)))
+	s.mux.HandleFunc("GET /api/anamericano/read/{ns}/{id}", s.wrap(EndpointRead, s.requireAPIToken(s.handleRead)))
+	s.mux.HandleFunc("GET /api/anamericano/list/{subjectType}/{subjectId}/{relation}/{ns}", s.wrap(EndpointList, s.requireAPIToken(s.handleList)))
+
+	return s
+}
+
+// Start 는 임의의 로컬 포트로 서버를 띄웁니다.
+func (s *Server) Start() {
+	s.httpServer = httptest.NewServer(s)
+}
+
+func (s *Server) Close() {
+	if s.httpServer != nil {
+		s.httpServer.Close()
+	}
+}
+
+// URL 은 Start로 띄운 서버의 주소입니다. AN_ACCOUNT_BASE_URL에 그대로 사용할 수 있습니다.
+func (s *Server) URL() string {
+	if s.httpServer == nil {
+		return ""
+	}
+	return s.httpServer.URL
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) AddUser(user User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := user
+	s.users[user.ID] = &u
+	if s.loginAs == 0 {
+		s.loginAs = user.ID
+	}
+}
+
+func (s *Server) RemoveUser(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.users, id)
+	if s.loginAs == id {
+		s.loginAs = 0
+	}
+}
+
+// LoginAs 는 /oauth2/authorize에 login_hint가 없을 때 로그인된 것으로 볼 사용자를 정합니다.
+func (s *Server) LoginAs(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.loginAs = id
+}
+
+func (s *Server) InjectFailure(endpoint Endpoint, failure Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f := failure
+	s.failures[endpoint] = &f
+}
+
+func (s *Server) ClearFailure(endpoint Endpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.failures, endpoint)
+}
+
+func (s *Server) Calls(endpoint Endpoint) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls[endpoint]
+}
+
+// GrantPermission 은 API를 거치지 않고 권한을 직접 추가합니다.
+func (s *Server) GrantPermission(perm anamericano.Permission) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.putPermission(perm)
+}
+
+func (s *Server) HasPermission(namespace, objectID, relation, subjectType, subjectID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.permissions[permissionKey{namespace, objectID, relation, subjectType, subjectID}]
+	return ok
+}
+
+// Permissions 는 저장된 모든 권한을 아이디 순으로 반환합니다.
+func (s *Server) Permissions() []anamericano.Permission {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	perms := make([]anamericano.Permission, 0, len(s.permissions))
+	for _, p := range s.permissions {
+		perms = append(perms, *p)
+	}
+	sort.Slice(perms, func(i, j int) bool { return perms[i].ID < perms[j].ID })
+	return perms
+}
+
+// IssueAccessToken 은 OAuth 흐름 없이 사용자의 액세스 토큰을 발급합니다.
+func (s *Server) IssueAccessToken(userID int64) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token := randomToken()
+	s.accessTokens[token] = userID
+	return token
+}
+
+func (s *Server) wrap(endpoint Endpoint, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.calls[endpoint]++
+		failure := s.failures[endpoint]
+		var injected Failure
+		if failure != nil {
+			injected = *failure
+			if failure.Times > 0 {
+				failure.Times--
+				if failure.Times == 0 {
+					delete(s.failures, endpoint)
+				}
+			}
+		}
+		s.mu.Unlock()
+
+		if failure != nil {
+			if injected.Delay > 0 {
+				time.Sleep(injected.Delay)
+			}
+			if injected.Status != 0 {
+				body := injected.Body
+				if body == "" {
+					body = http.StatusText(injected.Status)
+				}
+				writeAPIError(w, r, injected.Status, body)
+				return
+			}
+		}
+
+		next(w, r)
+	}
+}
+
+func (s *Server) requireAPIToken(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.config.APIToken != "" && r.Header.Get("Authorization") != "Bearer "+s.config.APIToken {
+			writeAPIError(w, r, http.StatusUnauthorized, "invalid api token")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	if q.Get("response_type") != "code" {
+		writeOAuthError(w, http.StatusBadRequest, "unsupported_response_type")
+		return
+	}
+	if s.config.ClientID != "" && q.Get("client_id") != s.config.ClientID {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_client")
+		return
+	}
+	redirectURI := q.Get("redirect_uri")
+	target, err := url.Parse(redirectURI)
+	if err != nil || redirectURI == "" {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	s.mu.Lock()
+	userID := s.loginAs
+	if hint := q.Get("login_hint"); hint != "" {
+		userID, _ = strconv.ParseInt(hint, 10, 64)
+	}
+	_, exists := s.users[userID]
+	code := randomToken()
+	if exists {
+		s.codes[code] = &authCode{
+			userID:        userID,
+			redirectURI:   redirectURI,
+			codeChallenge: q.Get("code_challenge"),
+			nonce:         q.Get("nonce"),
+			expiresAt:     time.Now().Add(time.Minute),
+		}
+	}
+	s.mu.Unlock()
+
+	params := target.Query()
+	if exists {
+		params.Set("code", code)
+	} else {
+		params.Set("error", "access_denied")
+	}
+	params.Set("state", q.Get("state"))
+	target.RawQuery = params.Encode()
+
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	if s.config.ClientID != "" && r.PostForm.Get("client_id") != s.config.ClientID {
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+	if s.config.ClientSecret != "" && r.PostForm.Get("client_secret") != s.config.ClientSecret {
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+
+	var userID int64
+	var nonce string
+	issueIDToken := false
+
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		s.mu.Lock()
+		code, ok := s.codes[r.PostForm.Get("code")]
+		delete(s.codes, r.PostForm.Get("code"))
+		s.mu.Unlock()
+
+		if !ok || time.Now().After(code.expiresAt) || code.redirectURI != r.PostForm.Get("redirect_uri") {
+			writeOAuthError(w, http.StatusBadRequest, "invalid_grant")
+			return
+		}
+		hash := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if base64.RawURLEncoding.EncodeToString(hash[:]) != code.codeChallenge {
+			writeOAuthError(w, http.StatusBadRequest, "invalid_grant")
+			return
+		}
+		userID = code.userID
+		nonce = code.nonce
+		issueIDToken = true
+	case "refresh_token":
+		s.mu.Lock()
+		id, ok := s.refreshTokens[r.PostForm.Get("refresh_token")]
+		delete(s.refreshTokens, r.PostForm.Get("refresh_token"))
+		s.mu.Unlock()
+
+		if !ok {
+			writeOAuthError(w, http.StatusBadRequest, "invalid_grant")
+			return
+		}
+		userID = id
+	default:
+		writeOAuthError(w, http.StatusBadRequest, "unsupported_grant_type")
+		return
+	}
+
+	accessToken := randomToken()
+	refreshToken := randomToken()
+
+	s.mu.Lock()
+	s.accessTokens[accessToken] = userID
+	s.refreshTokens[refreshToken] = userID
+	s.mu.Unlock()
+
+	resp := map[string]any{
+		"access_token":  accessToken,
+		"token_type":    "Bearer",
+		"expires_in":    3600,
+		"refresh_token": refreshToken,
+		"scope":         "openid profile email",
+	}
+	if issueIDToken {
+		idToken, ok := s.signIDToken(s.issuer(r), userID, nonce)
+		if !ok {
+			writeOAuthError(w, http.StatusBadRequest, "invalid_grant")
+			return
+		}
+		resp["id_token"] = idToken
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleUserInfo(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	s.mu.Lock()
+	userID, ok := s.accessTokens[token]
+	user := s.users[userID]
+	s.mu.Unlock()
+
+	if !ok || user == nil {
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_token")
+		return
+	}
+
+	info := map[string]any{
+		"sub":                user.subject(),
+		"email":              user.Email,
+		"email_verified":     user.Email != "",
+		"name":               user.Name,
+		"preferred_username": user.PreferredUsername,
+		"picture":            user.Picture,
+	}
+	if user.Department != "" {
+		info["department"] = user.Department
+		info["generation"] = user.Generation
+	}
+	writeJSON(w, http.StatusOK, info)
+}
+
+func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
+	var req anamericano.PermissionCheckRequest
+	if !decodeRequest(w, r, &req, req.Validate) {
+		return
+	}
+
+	allowed := s.HasPermission(req.ObjectNamespace, req.ObjectID, req.Relation, req.SubjectType, req.SubjectID)
+	writeJSON(w, http.StatusOK, anamericano.PermissionCheckResponse{Allowed: allowed})
+}
+
+func (s *Server) handleWrite(w http.ResponseWriter, r *http.Request) {
+	var req anamericano.PermissionWriteRequest
+	if !decodeRequest(w, r, &req, req.Validate) {
+		return
+	}
+
+	s.mu.Lock()
+	key := permissionKey{req.ObjectNamespace, req.ObjectID, req.Relation, req.SubjectType, req.SubjectID}
+	if _, exists := s.permissions[key]; exists {
+		s.mu.Unlock()
+		writeAPIError(w, r, http.StatusConflict, "permission already exists")
+		return
+	}
+	perm := s.putPermission(anamericano.Permission{
+		ObjectNamespace: req.ObjectNamespace,
+		ObjectID:        req.ObjectID,
+		Relation:        req.Relation,
+		SubjectType:     req.SubjectType,
+		SubjectID:       req.SubjectID,
+		SubjectRelation: req.SubjectRelation,
+	})
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, perm)
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request) {
+	var req anamericano.PermissionDeleteRequest
+	if !decodeRequest(w, r, &req, req.Validate) {
+		return
+	}
+
+	s.mu.Lock()
+	key := permissionKey{req.ObjectNamespace, req.ObjectID, req.Relation, req.SubjectType, req.SubjectID}
+	_, exists := s.permissions[key]
+	delete(s.permissions, key)
+	s.mu.Unlock()
+
+	if !exists {
+		writeAPIError(w, r, http.StatusNotFound, "permission not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleRead(w http.ResponseWriter, r *http.Request) {
+	ns, id := r.PathValue("ns"), r.PathValue("id")
+
+	perms := []anamericano.Permission{}
+	for _, p := range s.Permissions() {
+		if p.ObjectNamespace == ns && p.ObjectID == id {
+			perms = append(perms, p)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, perms)
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	objects := []string{}
+	for _, p := range s.Permissions() {
+		if p.SubjectType == r.PathValue("subjectType") && p.SubjectID == r.PathValue("subjectId") &&
+			p.Relation == r.PathValue("relation") && p.ObjectNamespace == r.PathValue("ns") {
+			objects = append(objects, p.ObjectID)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, objects)
+}
+
+// putPermission 은 s.mu를 잡은 상태에서 호출해야 합니다.
+func (s *Server) putPermission(perm anamericano.Permission) *anamericano.Permission {
+	s.nextPermID++
+	perm.ID = s.nextPermID
+	perm.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+
+	key := permissionKey{perm.ObjectNamespace, perm.ObjectID, perm.Relation, perm.SubjectType, perm.SubjectID}
+	s.permissions[key] = &perm
+	return &perm
+}
+
+func decodeRequest(w http.ResponseWriter, r *http.Request, out any, validate func() error) bool {
+	if err := json.NewDecoder(r.Body).Decode(out); err != nil {
+		writeAPIError(w, r, http.StatusBadRequest, err.Error())
+		return false
+	}
+	if err := validate(); err != nil {
+		writeAPIError(w, r, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeAPIError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	writeJSON(w, status, anamericano.APIError{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Status:    status,
+		ErrorType: http.StatusText(status),
+		Message:   message,
+		Path:      r.URL.Path,
+	})
+}
+
+func writeOAuthError(w http.ResponseWriter, status int, code string) {
+	writeJSON(w, status, map[string]string{"error": code})
+}
+
+func randomToken() string {
+	b := make([]byte, 24)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,169 @@
+package fakeana
+
+import (
+	"analog-be/pkg/oidc"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	srv := NewTestServer(t)
+	srv.AddUser(User{ID: 42, Name: "홍길동", Email: "hong@ana.st"})
+
+	codeVerifier := "test-verifier-0123456789-abcdefghijklmnopqrstuvwxyz"
+	hash := sha256.Sum256([]byte(codeVerifier))
+	challenge := base64.RawURLEncoding.EncodeToString(hash[:])
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {TestClientID},
+		"redirect_uri":          {"http://localhost/callback"},
+		"state":                 {"xyz"},
+		"nonce":                 {"n-0"},
+		"code_challenge":        {challenge},
+		"code_challenge_method": {"S256"},
+	}
+	resp, err := client.Get(srv.URL() + "/oauth2/authorize?" + params.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize status = %d", resp.StatusCode)
+	}
+	location, _ := url.Parse(resp.Header.Get("Location"))
+	if location.Query().Get("state") != "xyz" {
+		t.Fatalf("state not echoed: %s", location)
+	}
+	code := location.Query().Get("code")
+
+	exchange := func(verifier string) *http.Response {
+		form := url.Values{
+			"grant_type":    {"authorization_code"},
+			"code":          {code},
+			"redirect_uri":  {"http://localhost/callback"},
+			"client_id":     {TestClientID},
+			"client_secret": {TestClientSecret},
+			"code_verifier": {verifier},
+		}
+		resp, err := http.Post(srv.URL()+"/oauth2/token", "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	resp = exchange(codeVerifier)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("token status = %d", resp.StatusCode)
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+		IDToken     string `json:"id_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		t.Fatal(err)
+	}
+
+	verifier := oidc.NewVerifier(oidc.Config{Issuer: srv.URL(), ClientID: TestClientID})
+	claims, err := verifier.Verify(context.Background(), token.IDToken, "n-0")
+	if err != nil {
+		t.Fatalf("verify id token: %v", err)
+	}
+	if claims.Subject != "42" || claims.Email != "hong@ana.st" {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+
+	// 코드는 한 번만 쓸 수 있어야 함
+	if replay := exchange(codeVerifier); replay.StatusCode != http.StatusBadRequest {
+		t.Fatalf("replayed code status = %d", replay.StatusCode)
+	}
+
+	req, _ := http.NewRequest("GET", srv.URL()+"/userinfo", nil)
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var info map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		t.Fatal(err)
+	}
+	if info["sub"] != "42" || info["email"] != "hong@ana.st" {
+		t.Fatalf("unexpected userinfo: %v", info)
+	}
+}
+
+func TestPKCEMismatch(t *testing.T) {
+	srv := NewTestServer(t)
+	srv.AddUser(User{ID: 1})
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {TestClientID},
+		"redirect_uri":          {"http://localhost/callback"},
+		"code_challenge":        {"not-the-right-challenge"},
+		"code_challenge_method": {"S256"},
+	}
+	resp, err := client.Get(srv.URL() + "/oauth2/authorize?" + params.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize status = %d", resp.StatusCode)
+	}
+	location, _ := url.Parse(resp.Header.Get("Location"))
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {location.Query().Get("code")},
+		"redirect_uri":  {"http://localhost/callback"},
+		"client_id":     {TestClientID},
+		"client_secret": {TestClientSecret},
+		"code_verifier": {"whatever"},
+	}
+	resp, err = http.Post(srv.URL()+"/oauth2/token", "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("token status = %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestUnknownLoginHintIsDenied(t *testing.T) {
+	srv := NewTestServer(t)
+	srv.AddUser(User{ID: 1})
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {TestClientID},
+		"redirect_uri":          {"http://localhost/callback"},
+		"code_challenge":        {"c"},
+		"code_challenge_method": {"S256"},
+		"login_hint":            {"999"},
+	}
+	resp, err := client.Get(srv.URL() + "/oauth2/authorize?" + params.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	location, _ := url.Parse(resp.Header.Get("Location"))
+	if location.Query().Get("error") != "access_denied" {
+		t.Fatalf("expected access_denied, got %s", location)
+	}
+}
@@ -0,0 +1,32 @@
+package fakeana
+
+import "testing"
+
+// 테스트에서 사용하는 기본 클라이언트 설정
+const (
+	TestClientID     = "analog-test"
+	TestClientSecret = "analog-test-secret"
+	TestAPIToken     = "analog-test-token"
+)
+
+// NewTestServer 는 대역 서버를 띄우고, 테스트 동안 AnAccountService/AnAmericanoService가
+// 이 서버를 바라보도록 환경 변수를 설정합니다. 서버는 테스트가 끝나면 닫힙니다.
+func NewTestServer(t testing.TB) *Server {
+	t.Helper()
+
+	s := New(Config{
+		ClientID:     TestClientID,
+		ClientSecret: TestClientSecret,
+		APIToken:     TestAPIToken,
+	})
+	s.Start()
+	t.Cleanup(s.Close)
+
+	t.Setenv("AN_ACCOUNT_BASE_URL", s.URL())
+	t.Setenv("AN_AMERICANO_BASE_URL", s.URL())
+	t.Setenv("AN_ACCOUNT_CLIENT_ID", TestClientID)
+	t.Setenv("AN_ACCOUNT_CLIENT_SECRET", TestClientSecret)
+	t.Setenv("AN_ACCOUNT_API_TOKEN", TestAPIToken)
+
+	return s
+}
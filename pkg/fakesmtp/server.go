@@ -0,0 +1,188 @@
+// Package fakesmtp 는 테스트와 로컬 개발을 위한 SMTP 대역 서버입니다.
+//
+// 받은 메일을 보내지 않고 메모리에 모아 두며, AUTH PLAIN은 어떤 계정이든 받아들입니다. STARTTLS는 지원하지 않습니다.
+//
+//	srv := fakesmtp.NewTestServer(t) // SMTP_HOST 등 환경 변수도 함께 설정
+//	... 메일 보내기 ...
+//	msgs := srv.Messages()
+package fakesmtp
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Message 는 대역 서버가 받은 메일 한 통입니다.
+type Message struct {
+	From string
+	To   []string
+	// 헤더와 본문을 포함한 원문 (줄 끝은 CRLF)
+	Data string
+}
+
+type Server struct {
+	listener net.Listener
+
+	mu       sync.Mutex
+	messages []Message
+
+	wg sync.WaitGroup
+}
+
+// New 는 127.0.0.1의 임의 포트에서 연결을 받기 시작합니다.
+func New() (*Server, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{listener: ln}
+	s.wg.Go(s.serve)
+	return s, nil
+}
+
+// NewTestServer 는 대역 서버를 띄우고, 테스트 동안 SMTP 발송기가 이 서버를 바라보도록 환경 변수를 설정합니다.
+func NewTestServer(t testing.TB) *Server {
+	t.Helper()
+
+	s, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Close)
+
+	t.Setenv("SMTP_HOST", s.Host())
+	t.Setenv("SMTP_PORT", s.Port())
+	t.Setenv("SMTP_TLS", "none")
+
+	return s
+}
+
+func (s *Server) Host() string {
+	host, _, _ := net.SplitHostPort(s.listener.Addr().String())
+	return host
+}
+
+func (s *Server) Port() string {
+	_, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return port
+}
+
+func (s *Server) Close() {
+	s.listener.Close()
+	s.wg.Wait()
+}
+
+// Messages 는 지금까지 받은 메일을 받은 순서대로 돌려줍니다.
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Message(nil), s.messages...)
+}
+
+// Reset 은 받은 메일을 비웁니다.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = nil
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Go(func() { s.handle(conn) })
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(format string, args ...any) {
+		fmt.Fprintf(conn, format+"\r\n", args...)
+	}
+
+	reply("220 fakesmtp ready")
+
+	var msg Message
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			reply("250-fakesmtp")
+			reply("250-8BITMIME")
+			reply("250 AUTH PLAIN")
+		case "HELO":
+			reply("250 fakesmtp")
+		case "AUTH":
+			reply("235 2.7.0 Authentication successful")
+		case "MAIL":
+			msg = Message{From: addressArg(arg)}
+			reply("250 OK")
+		case "RCPT":
+			msg.To = append(msg.To, addressArg(arg))
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			data, err := readData(r)
+			if err != nil {
+				return
+			}
+			msg.Data = data
+
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+
+			msg = Message{}
+			reply("250 OK")
+		case "RSET":
+			msg = Message{}
+			reply("250 OK")
+		case "NOOP":
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+// addressArg 는 "FROM:<a@b.c> SIZE=1" 같은 인자에서 주소만 꺼냅니다.
+func addressArg(arg string) string {
+	_, addr, _ := strings.Cut(arg, ":")
+	addr, _, _ = strings.Cut(strings.TrimSpace(addr), " ")
+	return strings.Trim(addr, "<>")
+}
+
+// readData 는 "." 한 줄이 나올 때까지 읽고, 점으로 시작하는 줄의 앞 점을 되돌립니다.
+func readData(r *bufio.Reader) (string, error) {
+	var b strings.Builder
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		if line == ".\r\n" || line == ".\n" {
+			return b.String(), nil
+		}
+		b.WriteString(strings.TrimPrefix(line, "."))
+	}
+}
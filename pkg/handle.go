@@ -0,0 +1,45 @@
+package pkg
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// 핸들은 3~30자의 소문자, 숫자, 밑줄, 하이픈이고 소문자나 숫자로 시작하고 끝남
+var handlePattern = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9_-]{1,28})[a-z0-9]$`)
+
+// defaultHandlePattern 은 핸들을 고르지 않은 사용자에게 주는 user<아이디>, guest<아이디> 형식입니다.
+var defaultHandlePattern = regexp.MustCompile(`^(?:user|guest)[0-9]+$`)
+
+// reservedHandles 는 프론트엔드 경로(log.ana.st/<핸들>/...)와 겹치거나 운영자로 오해할 수 있는 핸들입니다.
+var reservedHandles = map[string]bool{
+	"about": true, "admin": true, "administrator": true, "ana": true, "analog": true, "api": true,
+	"assets": true, "auth": true, "bookmarks": true, "explore": true, "feed": true, "help": true,
+	"login": true, "logout": true, "logs": true, "me": true, "moderator": true, "new": true,
+	"notifications": true, "null": true, "privacy": true, "root": true, "rss": true, "search": true,
+	"settings": true, "signup": true, "sitemap": true, "sitemaps": true, "static": true, "staff": true,
+	"support": true, "system": true, "terms": true, "topics": true, "undefined": true, "user": true,
+	"users": true,
+}
+
+var (
+	ErrHandleFormat   = errors.New("handle must be 3 to 30 lowercase letters, digits, '_' or '-', starting and ending with a letter or digit")
+	ErrHandleReserved = errors.New("handle is reserved")
+)
+
+// NormalizeHandle 은 앞뒤 공백과 @를 떼고 소문자로 바꿉니다. 핸들은 대소문자를 구분하지 않습니다.
+func NormalizeHandle(handle string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(handle), "@"))
+}
+
+// ValidateHandle 은 정규화한 핸들의 형식과 예약어를 확인합니다. 다른 사용자가 쓰는지는 확인하지 않습니다.
+func ValidateHandle(handle string) error {
+	if !handlePattern.MatchString(handle) {
+		return ErrHandleFormat
+	}
+	if reservedHandles[handle] || defaultHandlePattern.MatchString(handle) {
+		return ErrHandleReserved
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package pkg
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateHandle(t *testing.T) {
+	tests := []struct {
+		handle string
+		want   error
+	}{
+		{"ana_dev", nil},
+		{"kim-1", nil},
+		{"abc", nil},
+		{"ab", ErrHandleFormat},
+		{"Kim", ErrHandleFormat},
+		{"-kim", ErrHandleFormat},
+		{"kim_", ErrHandleFormat},
+		{"김철수", ErrHandleFormat},
+		{"a.b.c", ErrHandleFormat},
+		{"abcdefghijabcdefghijabcdefghij1", ErrHandleFormat},
+		{"admin", ErrHandleReserved},
+		{"settings", ErrHandleReserved},
+		{"user42", ErrHandleReserved},
+		{"guest7", ErrHandleReserved},
+		{"user42a", nil},
+	}
+
+	for _, tt := range tests {
+		if err := ValidateHandle(tt.handle); !errors.Is(err, tt.want) {
+			t.Errorf("ValidateHandle(%q) = %v, want %v", tt.handle, err, tt.want)
+		}
+	}
+}
+
+func TestNormalizeHandle(t *testing.T) {
+	if got := NormalizeHandle("  @Ana_Dev "); got != "ana_dev" {
+		t.Fatalf("NormalizeHandle = %q", got)
+	}
+}
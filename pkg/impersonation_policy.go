@@ -0,0 +1,32 @@
+package pkg
+
+import "time"
+
+// ImpersonationPolicy 는 관리자 대리 세션의 길이 제한입니다. 대리 세션은 사용해도 만료 시각이 늘어나지 않습니다.
+type ImpersonationPolicy struct {
+	DefaultDuration time.Duration
+	MaxDuration     time.Duration
+}
+
+func NewImpersonationPolicy() *ImpersonationPolicy {
+	p := &ImpersonationPolicy{
+		DefaultDuration: getEnvDuration("IMPERSONATION_DEFAULT_DURATION", 15*time.Minute),
+		MaxDuration:     getEnvDuration("IMPERSONATION_MAX_DURATION", time.Hour),
+	}
+	if p.DefaultDuration > p.MaxDuration {
+		p.DefaultDuration = p.MaxDuration
+	}
+	return p
+}
+
+// Duration 은 요청한 길이(분)를 검사합니다. 0이면 기본 길이, 최대 길이를 넘으면 false를 반환합니다.
+func (p *ImpersonationPolicy) Duration(minutes int) (time.Duration, bool) {
+	if minutes == 0 {
+		return p.DefaultDuration, true
+	}
+	d := time.Duration(minutes) * time.Minute
+	if minutes < 0 || d > p.MaxDuration {
+		return 0, false
+	}
+	return d, true
+}
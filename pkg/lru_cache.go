@@ -0,0 +1,87 @@
+package pkg
+
+import (
+	"container/list"
+	"time"
+)
+
+// LRUCache 는 크기가 제한되고 항목마다 만료 시각이 있는 LRU 캐시입니다.
+// 동시성 제어는 하지 않으므로 여러 고루틴에서 쓸 때는 호출하는 쪽에서 잠가야 합니다.
+type LRUCache[K comparable, V any] struct {
+	capacity int
+	items    map[K]*list.Element
+	order    *list.List
+
+	// 항목이 빠질 때(교체, 삭제, 만료, 용량 초과) 호출됨
+	onRemove func(key K, value V)
+}
+
+type lruEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+func NewLRUCache[K comparable, V any](capacity int, onRemove func(key K, value V)) *LRUCache[K, V] {
+	return &LRUCache[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*list.Element),
+		order:    list.New(),
+		onRemove: onRemove,
+	}
+}
+
+// Get 은 만료되지 않은 항목을 찾아 가장 최근에 쓴 것으로 표시합니다.
+func (c *LRUCache[K, V]) Get(key K, now time.Time) (V, bool) {
+	elem, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	entry := elem.Value.(*lruEntry[K, V])
+	if !now.Before(entry.expiresAt) {
+		c.remove(elem)
+		var zero V
+		return zero, false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Set 은 expiresAt까지 유효한 항목을 넣고, 용량을 넘으면 가장 오래 쓰지 않은 항목을 뺍니다.
+func (c *LRUCache[K, V]) Set(key K, value V, expiresAt time.Time) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	if elem, ok := c.items[key]; ok {
+		c.remove(elem)
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value, expiresAt: expiresAt})
+
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *LRUCache[K, V]) Delete(key K) {
+	if elem, ok := c.items[key]; ok {
+		c.remove(elem)
+	}
+}
+
+func (c *LRUCache[K, V]) Len() int {
+	return c.order.Len()
+}
+
+func (c *LRUCache[K, V]) remove(elem *list.Element) {
+	entry := c.order.Remove(elem).(*lruEntry[K, V])
+	delete(c.items, entry.key)
+
+	if c.onRemove != nil {
+		c.onRemove(entry.key, entry.value)
+	}
+}
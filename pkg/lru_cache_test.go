@@ -0,0 +1,44 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRUCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	var removed []string
+	cache := NewLRUCache(2, func(key string, _ int) { removed = append(removed, key) })
+	now := time.Now()
+	later := now.Add(time.Minute)
+
+	cache.Set("a", 1, later)
+	cache.Set("b", 2, later)
+	cache.Get("a", now)
+	cache.Set("c", 3, later)
+
+	if _, ok := cache.Get("b", now); ok {
+		t.Fatal("least recently used entry was kept")
+	}
+	if v, ok := cache.Get("a", now); !ok || v != 1 {
+		t.Fatalf("a = %d, %v", v, ok)
+	}
+	if len(removed) != 1 || removed[0] != "b" {
+		t.Fatalf("removed = %v", removed)
+	}
+}
+
+func TestLRUCacheExpiresEntries(t *testing.T) {
+	cache := NewLRUCache[string, int](10, nil)
+	now := time.Now()
+
+	cache.Set("a", 1, now.Add(time.Second))
+	if _, ok := cache.Get("a", now); !ok {
+		t.Fatal("entry expired early")
+	}
+	if _, ok := cache.Get("a", now.Add(time.Second)); ok {
+		t.Fatal("expired entry returned")
+	}
+	if cache.Len() != 0 {
+		t.Fatalf("len = %d", cache.Len())
+	}
+}
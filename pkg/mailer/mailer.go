@@ -0,0 +1,155 @@
+// Package mailer 는 메일을 만들어 보내는 발송기입니다.
+//
+// SMTP_HOST가 있으면 SMTP로 보내고, 없으면 로컬 개발용으로 보내지 않고 기록만 남깁니다.
+// 테스트에서는 pkg/fakesmtp 대역 서버를 씁니다.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Message 는 보낼 메일 한 통입니다. 텍스트와 HTML 본문을 함께 보내 메일 앱이 고르게 합니다.
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+	// List-Unsubscribe 같은 추가 헤더
+	Headers map[string]string
+}
+
+// Sender 는 메일을 보내는 방법입니다.
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// NewSender 는 환경 변수에 맞는 발송기를 만듭니다. SMTP_HOST가 없으면 LogSender를 씁니다.
+func NewSender(logger *zap.Logger) Sender {
+	from, err := mail.ParseAddress(getEnv("EMAIL_FROM", "AnAlog <no-reply@ana.st>"))
+	if err != nil {
+		logger.Warn("Invalid EMAIL_FROM, using the default", zap.Error(err))
+		from = &mail.Address{Name: "AnAlog", Address: "no-reply@ana.st"}
+	}
+
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		logger.Warn("SMTP_HOST is not set; emails will be logged instead of sent")
+		return &LogSender{From: from, Logger: logger}
+	}
+
+	security := getEnv("SMTP_TLS", TLSStartTLS)
+	if security != TLSStartTLS && security != TLSImplicit && security != TLSNone {
+		logger.Warn("Invalid SMTP_TLS, using starttls", zap.String("value", security))
+		security = TLSStartTLS
+	}
+
+	return &SMTPSender{
+		Host:     host,
+		Port:     getEnv("SMTP_PORT", "587"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		TLS:      security,
+		From:     from,
+	}
+}
+
+// LogSender 는 메일을 보내지 않고 받는 사람과 제목만 기록합니다.
+type LogSender struct {
+	From   *mail.Address
+	Logger *zap.Logger
+}
+
+func (s *LogSender) Send(ctx context.Context, msg *Message) error {
+	s.Logger.Info("Email not sent (SMTP is not configured)",
+		zap.String("to", msg.To),
+		zap.String("subject", msg.Subject),
+	)
+	return nil
+}
+
+// Build 는 msg를 텍스트와 HTML 본문을 담은 multipart/alternative 원문으로 만듭니다.
+func Build(from *mail.Address, msg *Message, now time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=UTF-8", msg.Text},
+		{"text/html; charset=UTF-8", msg.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	header := func(key, value string) {
+		fmt.Fprintf(&b, "%s: %s\r\n", key, value)
+	}
+	header("From", from.String())
+	header("To", to.String())
+	header("Subject", mime.BEncoding.Encode("UTF-8", msg.Subject))
+	header("Date", now.Format(time.RFC1123Z))
+	header("Message-ID", messageID(from))
+	for _, key := range slices.Sorted(maps.Keys(msg.Headers)) {
+		header(key, strings.ReplaceAll(msg.Headers[key], "\n", " "))
+	}
+	header("MIME-Version", "1.0")
+	header("Content-Type", "multipart/alternative; boundary="+parts.Boundary())
+	b.WriteString("\r\n")
+	b.Write(body.Bytes())
+
+	return b.Bytes(), nil
+}
+
+func messageID(from *mail.Address) string {
+	domain := "localhost"
+	if _, d, ok := strings.Cut(from.Address, "@"); ok {
+		domain = d
+	}
+
+	random := make([]byte, 16)
+	_, _ = rand.Read(random)
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(random), domain)
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
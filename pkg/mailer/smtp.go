@@ -0,0 +1,109 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+)
+
+// SMTP_TLS 값입니다.
+const (
+	// 평문으로 연결한 뒤 STARTTLS로 암호화 (보통 587 포트). 서버가 지원하지 않으면 보내지 않음
+	TLSStartTLS = "starttls"
+	// 처음부터 TLS로 연결 (보통 465 포트)
+	TLSImplicit = "tls"
+	// 암호화하지 않음. 로컬 대역 서버용
+	TLSNone = "none"
+)
+
+// 연결부터 전송까지 기다리는 최대 시간 (ctx에 더 짧은 기한이 있으면 그것을 따름)
+const smtpTimeout = 30 * time.Second
+
+// SMTPSender 는 SMTP 서버로 메일을 보냅니다. 메일마다 새로 연결합니다.
+type SMTPSender struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	TLS      string
+	From     *mail.Address
+}
+
+func (s *SMTPSender) Send(ctx context.Context, msg *Message) error {
+	data, err := Build(s.From, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(smtpTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	addr := net.JoinHostPort(s.Host, s.Port)
+	dialer := &net.Dialer{Deadline: deadline}
+	var conn net.Conn
+	if s.TLS == TLSImplicit {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: s.Host}}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, s.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if s.TLS == TLSStartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return errors.New("smtp server does not support STARTTLS")
+		}
+		if err := c.StartTLS(&tls.Config{ServerName: s.Host}); err != nil {
+			return err
+		}
+	}
+
+	if s.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.Username, s.Password, s.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(s.From.Address); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to.Address); err != nil {
+		return err
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
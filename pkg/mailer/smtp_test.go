@@ -0,0 +1,95 @@
+package mailer
+
+import (
+	"analog-be/pkg/fakesmtp"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestSMTPSender(t *testing.T) {
+	srv := fakesmtp.NewTestServer(t)
+	t.Setenv("EMAIL_FROM", "AnAlog <no-reply@ana.st>")
+	t.Setenv("SMTP_USERNAME", "analog")
+	t.Setenv("SMTP_PASSWORD", "secret")
+
+	sender := NewSender(zap.NewNop())
+	if _, ok := sender.(*SMTPSender); !ok {
+		t.Fatalf("sender = %T", sender)
+	}
+
+	err := sender.Send(context.Background(), &Message{
+		To:      "홍길동 <hong@ana.st>",
+		Subject: "새 댓글이 달렸어요",
+		Text:    "안녕하세요\n.점으로 시작하는 줄",
+		HTML:    "<p>안녕하세요</p>",
+		Headers: map[string]string{"List-Unsubscribe": "<https://ana.st/unsubscribe?token=x>"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msgs := srv.Messages()
+	if len(msgs) != 1 || msgs[0].From != "no-reply@ana.st" || len(msgs[0].To) != 1 || msgs[0].To[0] != "hong@ana.st" {
+		t.Fatalf("messages = %+v", msgs)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(msgs[0].Data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject, _ := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject")); subject != "새 댓글이 달렸어요" {
+		t.Fatalf("subject = %q", subject)
+	}
+	if got := parsed.Header.Get("List-Unsubscribe"); got != "<https://ana.st/unsubscribe?token=x>" {
+		t.Fatalf("List-Unsubscribe = %q", got)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("content type = %q, %v", mediaType, err)
+	}
+	var bodies []string
+	parts := multipart.NewReader(parsed.Body, params["boundary"])
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(quotedprintable.NewReader(part))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 본문 줄바꿈은 전송 중에 CRLF로 바뀜
+		bodies = append(bodies, part.Header.Get("Content-Type")+"|"+strings.ReplaceAll(string(body), "\r\n", "\n"))
+	}
+	want := []string{
+		"text/plain; charset=UTF-8|안녕하세요\n.점으로 시작하는 줄",
+		"text/html; charset=UTF-8|<p>안녕하세요</p>",
+	}
+	if strings.Join(bodies, "\n---\n") != strings.Join(want, "\n---\n") {
+		t.Fatalf("bodies = %q", bodies)
+	}
+}
+
+func TestNewSenderWithoutSMTP(t *testing.T) {
+	t.Setenv("SMTP_HOST", "")
+
+	sender := NewSender(zap.NewNop())
+	if _, ok := sender.(*LogSender); !ok {
+		t.Fatalf("sender = %T", sender)
+	}
+	if err := sender.Send(context.Background(), &Message{To: "a@ana.st", Subject: "제목"}); err != nil {
+		t.Fatal(err)
+	}
+}
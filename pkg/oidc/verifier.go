@@ -0,0 +1,418 @@
+// Package oidc 는 OpenID Connect ID 토큰을 검증합니다.
+//
+// 발급자의 디스커버리 문서(/.well-known/openid-configuration)에서 jwks_uri를 찾아 공개키를 캐시하고,
+// 처음 보는 kid가 오면 키가 교체된 것으로 보고 JWKS를 다시 가져옵니다.
+// 서명(RS256, ES256)과 iss, aud, exp, iat, nonce를 확인합니다.
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// 디스커버리 문서와 JWKS를 다시 가져오는 주기
+	defaultCacheTTL = time.Hour
+	// 처음 보는 kid 때문에 JWKS를 다시 가져오는 최소 간격 (잘못된 토큰으로 발급자를 두드리지 않도록)
+	defaultMinRefreshInterval = 10 * time.Second
+	// 발급자와 서버의 시계 차이 허용 범위
+	clockSkew = time.Minute
+)
+
+var (
+	ErrMalformedToken   = errors.New("oidc: malformed id token")
+	ErrUnsupportedAlg   = errors.New("oidc: unsupported signing algorithm")
+	ErrUnknownKey       = errors.New("oidc: signing key not found")
+	ErrInvalidSignature = errors.New("oidc: invalid signature")
+	ErrInvalidIssuer    = errors.New("oidc: issuer mismatch")
+	ErrInvalidAudience  = errors.New("oidc: audience mismatch")
+	ErrExpired          = errors.New("oidc: id token expired")
+	ErrNotYetValid      = errors.New("oidc: id token issued in the future")
+	ErrInvalidNonce     = errors.New("oidc: nonce mismatch")
+
+	// ErrDiscovery 는 토큰이 아니라 발급자의 디스커버리 문서나 JWKS를 가져오지 못한 경우입니다.
+	ErrDiscovery = errors.New("oidc: failed to load issuer keys")
+)
+
+type Config struct {
+	// Issuer 는 토큰의 iss와 같아야 하며, 디스커버리 문서를 이 주소 아래에서 찾습니다.
+	Issuer   string
+	ClientID string
+
+	HTTPClient *http.Client
+	// 0이면 기본값 사용
+	CacheTTL           time.Duration
+	MinRefreshInterval time.Duration
+	// 테스트에서 시계를 바꿀 때 사용
+	Now func() time.Time
+}
+
+// Claims 는 ID 토큰에서 사용하는 클레임입니다.
+type Claims struct {
+	Issuer            string   `json:"iss"`
+	Subject           string   `json:"sub"`
+	Audience          audience `json:"aud"`
+	AuthorizedParty   string   `json:"azp"`
+	Expiry            int64    `json:"exp"`
+	IssuedAt          int64    `json:"iat"`
+	Nonce             string   `json:"nonce"`
+	Name              string   `json:"name"`
+	Email             string   `json:"email"`
+	PreferredUsername string   `json:"preferred_username"`
+	Picture           string   `json:"picture"`
+}
+
+// audience 는 aud가 문자열 하나이거나 배열인 경우를 모두 받습니다.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// Discovery 는 디스커버리 문서에서 사용하는 항목입니다.
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type Verifier struct {
+	config Config
+
+	mu          sync.Mutex
+	discovery   *Discovery
+	keys        map[string]crypto.PublicKey
+	fetchedAt   time.Time
+	refreshedAt time.Time
+}
+
+func NewVerifier(config Config) *Verifier {
+	config.Issuer = strings.TrimSuffix(config.Issuer, "/")
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	if config.CacheTTL == 0 {
+		config.CacheTTL = defaultCacheTTL
+	}
+	if config.MinRefreshInterval == 0 {
+		config.MinRefreshInterval = defaultMinRefreshInterval
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+
+	return &Verifier{config: config}
+}
+
+// Verify 는 ID 토큰의 서명과 클레임을 확인하고, nonce가 expectedNonce와 같은지 봅니다.
+func (v *Verifier) Verify(ctx context.Context, rawIDToken string, expectedNonce string) (*Claims, error) {
+	parts := strings.Split(rawIDToken, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	key, err := v.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	if err := v.validateClaims(&claims, expectedNonce); err != nil {
+		return nil, err
+	}
+
+	return &claims, nil
+}
+
+func (v *Verifier) validateClaims(claims *Claims, expectedNonce string) error {
+	if claims.Issuer != v.config.Issuer {
+		return ErrInvalidIssuer
+	}
+
+	found := false
+	for _, aud := range claims.Audience {
+		if aud == v.config.ClientID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ErrInvalidAudience
+	}
+	// 여러 대상에게 발급된 토큰이면 우리에게 발급된 것인지 azp로 확인
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != v.config.ClientID {
+		return ErrInvalidAudience
+	}
+
+	now := v.config.Now()
+	if claims.Expiry == 0 || !now.Before(time.Unix(claims.Expiry, 0).Add(clockSkew)) {
+		return ErrExpired
+	}
+	if claims.IssuedAt != 0 && now.Add(clockSkew).Before(time.Unix(claims.IssuedAt, 0)) {
+		return ErrNotYetValid
+	}
+
+	if claims.Nonce == "" || claims.Nonce != expectedNonce {
+		return ErrInvalidNonce
+	}
+
+	return nil
+}
+
+// Discovery 는 캐시된 디스커버리 문서를 반환하고, 없거나 오래되었으면 다시 가져옵니다.
+func (v *Verifier) Discovery(ctx context.Context) (Discovery, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := v.config.Now()
+	if v.discovery == nil || now.Sub(v.fetchedAt) >= v.config.CacheTTL {
+		if err := v.refresh(ctx, now); err != nil {
+			return Discovery{}, err
+		}
+	}
+	return *v.discovery, nil
+}
+
+// key 는 kid에 맞는 공개키를 찾습니다. 캐시에 없으면 키가 교체되었을 수 있으므로 JWKS를 다시 가져옵니다.
+func (v *Verifier) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := v.config.Now()
+	if v.keys == nil || now.Sub(v.fetchedAt) >= v.config.CacheTTL {
+		if err := v.refresh(ctx, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if key, ok := v.lookup(kid); ok {
+		return key, nil
+	}
+
+	if now.Sub(v.refreshedAt) < v.config.MinRefreshInterval {
+		return nil, ErrUnknownKey
+	}
+	if err := v.refresh(ctx, now); err != nil {
+		return nil, err
+	}
+
+	if key, ok := v.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+// lookup 은 kid가 없는 토큰이면 키가 하나뿐일 때만 그 키를 사용합니다.
+func (v *Verifier) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" {
+		if len(v.keys) != 1 {
+			return nil, false
+		}
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+
+	key, ok := v.keys[kid]
+	return key, ok
+}
+
+// refresh 는 v.mu를 잡은 상태에서 디스커버리 문서와 JWKS를 다시 가져옵니다.
+func (v *Verifier) refresh(ctx context.Context, now time.Time) error {
+	v.refreshedAt = now
+
+	var discovery Discovery
+	if err := v.getJSON(ctx, v.config.Issuer+"/.well-known/openid-configuration", &discovery); err != nil {
+		return fmt.Errorf("%w: discovery: %w", ErrDiscovery, err)
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != v.config.Issuer {
+		return fmt.Errorf("%w: discovery issuer %q does not match %q", ErrDiscovery, discovery.Issuer, v.config.Issuer)
+	}
+	if discovery.JWKSURI == "" {
+		return fmt.Errorf("%w: discovery document has no jwks_uri", ErrDiscovery)
+	}
+
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := v.getJSON(ctx, discovery.JWKSURI, &jwks); err != nil {
+		return fmt.Errorf("%w: jwks: %w", ErrDiscovery, err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// 모르는 형식의 키는 건너뛰고 나머지 키로 검증
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	v.discovery = &discovery
+	v.keys = keys
+	v.fetchedAt = now
+	return nil
+}
+
+func (v *Verifier) getJSON(ctx context.Context, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := v.config.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil || !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31 {
+			return nil, errors.New("invalid rsa exponent")
+		}
+		if n.BitLen() < 2048 {
+			return nil, errors.New("rsa key too small")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != 32 {
+			return nil, errors.New("invalid ec x")
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil || len(y) != 32 {
+			return nil, errors.New("invalid ec y")
+		}
+		// 비압축 점 형식(0x04 || X || Y)으로 파싱해 곡선 위의 점인지도 확인
+		return ecdsa.ParseUncompressedPublicKey(elliptic.P256(), append(append([]byte{4}, x...), y...))
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signingInput string, signature []byte) error {
+	digest := sha256.Sum256([]byte(signingInput))
+
+	switch alg {
+	case "RS256":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return ErrInvalidSignature
+		}
+		if rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest[:], signature) != nil {
+			return ErrInvalidSignature
+		}
+		return nil
+	case "ES256":
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok || len(signature) != 64 {
+			return ErrInvalidSignature
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(ecKey, digest[:], r, s) {
+			return ErrInvalidSignature
+		}
+		return nil
+	default:
+		// none, HS256 같은 알고리즘은 받지 않음
+		return ErrUnsupportedAlg
+	}
+}
+
+func decodeSegment(segment string, out any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("invalid base64url integer")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
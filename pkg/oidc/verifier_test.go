@@ -0,0 +1,244 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testClientID = "client"
+
+// testIssuer 는 디스커버리 문서와 JWKS를 내려주는 발급자입니다.
+type testIssuer struct {
+	srv       *httptest.Server
+	mu        sync.Mutex
+	jwks      []map[string]string
+	jwksCalls atomic.Int32
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	iss := &testIssuer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   iss.srv.URL,
+			"jwks_uri": iss.srv.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		iss.jwksCalls.Add(1)
+		iss.mu.Lock()
+		defer iss.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": iss.jwks})
+	})
+	iss.srv = httptest.NewServer(mux)
+	t.Cleanup(iss.srv.Close)
+	return iss
+}
+
+func (iss *testIssuer) publishRSA(kid string, key *rsa.PrivateKey) {
+	iss.mu.Lock()
+	defer iss.mu.Unlock()
+	iss.jwks = append(iss.jwks, map[string]string{
+		"kty": "RSA", "kid": kid,
+		"n": b64(key.N.Bytes()),
+		"e": b64(big.NewInt(int64(key.E)).Bytes()),
+	})
+}
+
+func (iss *testIssuer) publishEC(kid string, key *ecdsa.PrivateKey) {
+	iss.mu.Lock()
+	defer iss.mu.Unlock()
+	iss.jwks = append(iss.jwks, map[string]string{
+		"kty": "EC", "kid": kid, "crv": "P-256",
+		"x": b64(key.X.FillBytes(make([]byte, 32))),
+		"y": b64(key.Y.FillBytes(make([]byte, 32))),
+	})
+}
+
+func (iss *testIssuer) claims() map[string]any {
+	now := time.Now()
+	return map[string]any{
+		"iss":   iss.srv.URL,
+		"sub":   "42",
+		"aud":   testClientID,
+		"exp":   now.Add(time.Hour).Unix(),
+		"iat":   now.Unix(),
+		"nonce": "n-0",
+	}
+}
+
+func sign(t *testing.T, alg, kid string, key crypto.Signer, claims map[string]any) string {
+	t.Helper()
+
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid})
+	payload, _ := json.Marshal(claims)
+	input := b64(header) + "." + b64(payload)
+	digest := sha256.Sum256([]byte(input))
+
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		s, err := rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature = s
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return input + "." + b64(signature)
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func rsaKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestVerify(t *testing.T) {
+	iss := newTestIssuer(t)
+	key := rsaKey(t)
+	iss.publishRSA("k1", key)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	iss.publishEC("k2", ecKey)
+
+	v := NewVerifier(Config{Issuer: iss.srv.URL, ClientID: testClientID})
+
+	tests := []struct {
+		name   string
+		token  func() string
+		nonce  string
+		expect error
+	}{
+		{"valid rs256", func() string { return sign(t, "RS256", "k1", key, iss.claims()) }, "n-0", nil},
+		{"valid es256", func() string { return sign(t, "ES256", "k2", ecKey, iss.claims()) }, "n-0", nil},
+		{"audience array", func() string {
+			c := iss.claims()
+			c["aud"] = []string{testClientID, "other"}
+			c["azp"] = testClientID
+			return sign(t, "RS256", "k1", key, c)
+		}, "n-0", nil},
+		{"wrong issuer", func() string {
+			c := iss.claims()
+			c["iss"] = "https://evil.example"
+			return sign(t, "RS256", "k1", key, c)
+		}, "n-0", ErrInvalidIssuer},
+		{"wrong audience", func() string {
+			c := iss.claims()
+			c["aud"] = "other"
+			return sign(t, "RS256", "k1", key, c)
+		}, "n-0", ErrInvalidAudience},
+		{"audience array without azp", func() string {
+			c := iss.claims()
+			c["aud"] = []string{testClientID, "other"}
+			return sign(t, "RS256", "k1", key, c)
+		}, "n-0", ErrInvalidAudience},
+		{"expired", func() string {
+			c := iss.claims()
+			c["exp"] = time.Now().Add(-2 * clockSkew).Unix()
+			return sign(t, "RS256", "k1", key, c)
+		}, "n-0", ErrExpired},
+		{"issued in the future", func() string {
+			c := iss.claims()
+			c["iat"] = time.Now().Add(2 * clockSkew).Unix()
+			return sign(t, "RS256", "k1", key, c)
+		}, "n-0", ErrNotYetValid},
+		{"wrong nonce", func() string { return sign(t, "RS256", "k1", key, iss.claims()) }, "n-1", ErrInvalidNonce},
+		{"signed by another key", func() string { return sign(t, "RS256", "k1", rsaKey(t), iss.claims()) }, "n-0", ErrInvalidSignature},
+		{"alg none", func() string {
+			header, _ := json.Marshal(map[string]string{"alg": "none", "kid": "k1"})
+			payload, _ := json.Marshal(iss.claims())
+			return b64(header) + "." + b64(payload) + "."
+		}, "n-0", ErrUnsupportedAlg},
+		{"key type mismatch", func() string { return sign(t, "RS256", "k2", key, iss.claims()) }, "n-0", ErrInvalidSignature},
+		{"malformed", func() string { return "not-a-jwt" }, "n-0", ErrMalformedToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := v.Verify(context.Background(), tt.token(), tt.nonce)
+			if !errors.Is(err, tt.expect) {
+				t.Fatalf("err = %v, want %v", err, tt.expect)
+			}
+			if err == nil && claims.Subject != "42" {
+				t.Fatalf("subject = %q", claims.Subject)
+			}
+		})
+	}
+}
+
+func TestVerifyRefetchesKeysOnRotation(t *testing.T) {
+	iss := newTestIssuer(t)
+	oldKey := rsaKey(t)
+	iss.publishRSA("old", oldKey)
+
+	now := time.Now()
+	v := NewVerifier(Config{
+		Issuer:             iss.srv.URL,
+		ClientID:           testClientID,
+		MinRefreshInterval: time.Minute,
+		Now:                func() time.Time { return now },
+	})
+
+	if _, err := v.Verify(context.Background(), sign(t, "RS256", "old", oldKey, iss.claims()), "n-0"); err != nil {
+		t.Fatal(err)
+	}
+	// 캐시된 키로 검증
+	if _, err := v.Verify(context.Background(), sign(t, "RS256", "old", oldKey, iss.claims()), "n-0"); err != nil {
+		t.Fatal(err)
+	}
+	if calls := iss.jwksCalls.Load(); calls != 1 {
+		t.Fatalf("jwks calls = %d, want 1", calls)
+	}
+
+	newKey := rsaKey(t)
+	iss.publishRSA("new", newKey)
+
+	// 최소 간격 안에서는 처음 보는 kid 때문에 다시 가져오지 않음
+	if _, err := v.Verify(context.Background(), sign(t, "RS256", "new", newKey, iss.claims()), "n-0"); !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("err = %v, want %v", err, ErrUnknownKey)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := v.Verify(context.Background(), sign(t, "RS256", "new", newKey, iss.claims()), "n-0"); err != nil {
+		t.Fatal(err)
+	}
+	if calls := iss.jwksCalls.Load(); calls != 2 {
+		t.Fatalf("jwks calls = %d, want 2", calls)
+	}
+}
+
+func TestVerifyRejectsDiscoveryIssuerMismatch(t *testing.T) {
+	iss := newTestIssuer(t)
+	iss.publishRSA("k1", rsaKey(t))
+
+	v := NewVerifier(Config{Issuer: iss.srv.URL + "/other", ClientID: testClientID})
+	if _, err := v.Verify(context.Background(), sign(t, "RS256", "k1", rsaKey(t), iss.claims()), "n-0"); !errors.Is(err, ErrDiscovery) {
+		t.Fatalf("err = %v, want %v", err, ErrDiscovery)
+	}
+}
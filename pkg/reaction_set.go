@@ -0,0 +1,52 @@
+package pkg
+
+import (
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+var defaultReactionKinds = []string{"thumbsup", "heart", "laugh", "hooray", "rocket", "eyes"}
+
+// 반응 종류는 이모지 이름(소문자, 숫자, _, -)으로 저장함. 이모지로 그리는 것은 프론트엔드가 맡음
+var reactionKindPattern = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+// ReactionSet 은 로그와 댓글에 달 수 있는 반응 종류입니다.
+type ReactionSet struct {
+	kinds []string
+}
+
+// NewReactionSet 은 REACTIONS(,로 구분)로 목록을 만듭니다. 잘못된 항목은 경고를 남기고 건너뜁니다.
+func NewReactionSet(logger *zap.Logger) *ReactionSet {
+	kinds := defaultReactionKinds
+	if env := os.Getenv("REACTIONS"); env != "" {
+		kinds = strings.Split(env, ",")
+	}
+
+	set := &ReactionSet{}
+	for _, kind := range kinds {
+		kind = strings.ToLower(strings.TrimSpace(kind))
+		if kind == "" || slices.Contains(set.kinds, kind) {
+			continue
+		}
+		if !reactionKindPattern.MatchString(kind) {
+			logger.Warn("invalid reaction kind", zap.String("kind", kind))
+			continue
+		}
+		set.kinds = append(set.kinds, kind)
+	}
+
+	return set
+}
+
+// Kinds 는 설정한 순서대로 반응 종류를 돌려줍니다.
+func (s *ReactionSet) Kinds() []string {
+	return s.kinds
+}
+
+func (s *ReactionSet) Allowed(kind string) bool {
+	return slices.Contains(s.kinds, kind)
+}
@@ -0,0 +1,32 @@
+package pkg
+
+import (
+	"slices"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestReactionSet(t *testing.T) {
+	t.Setenv("REACTIONS", " Heart,thumbsup,,heart,bad kind,party_parrot ")
+	set := NewReactionSet(zap.NewNop())
+
+	if want := []string{"heart", "thumbsup", "party_parrot"}; !slices.Equal(set.Kinds(), want) {
+		t.Fatalf("kinds = %v, want %v", set.Kinds(), want)
+	}
+	if !set.Allowed("party_parrot") {
+		t.Fatal("configured kind should be allowed")
+	}
+	if set.Allowed("rocket") || set.Allowed("Heart") {
+		t.Fatal("only configured, normalized kinds should be allowed")
+	}
+}
+
+func TestReactionSetDefault(t *testing.T) {
+	t.Setenv("REACTIONS", "")
+	set := NewReactionSet(zap.NewNop())
+
+	if !slices.Equal(set.Kinds(), defaultReactionKinds) {
+		t.Fatalf("kinds = %v, want %v", set.Kinds(), defaultReactionKinds)
+	}
+}
@@ -0,0 +1,24 @@
+package pkg
+
+import "time"
+
+// RealtimePolicy 는 실시간 이벤트 스트림(SSE) 연결 규칙입니다.
+type RealtimePolicy struct {
+	// 프록시가 연결을 끊지 않도록 아무 이벤트가 없어도 주석을 보내는 간격
+	HeartbeatInterval time.Duration
+	// 끊긴 클라이언트가 다시 연결하기 전에 기다릴 시간 (SSE retry 필드)
+	RetryDelay time.Duration
+	// 로그아웃이나 세션 만료가 반영되도록 이 시간이 지나면 연결을 닫아 다시 인증하게 함
+	MaxStreamDuration time.Duration
+	// 다시 연결할 때 Last-Event-ID 이후로 보내 줄 최대 이벤트 수. 더 많이 놓쳤으면 reset 이벤트를 보냄
+	ReplayLimit int
+}
+
+func NewRealtimePolicy() *RealtimePolicy {
+	return &RealtimePolicy{
+		HeartbeatInterval: getEnvDuration("REALTIME_HEARTBEAT_INTERVAL", 15*time.Second),
+		RetryDelay:        getEnvDuration("REALTIME_RETRY_DELAY", 3*time.Second),
+		MaxStreamDuration: getEnvDuration("REALTIME_MAX_STREAM_DURATION", 30*time.Minute),
+		ReplayLimit:       getEnvInt("REALTIME_REPLAY_LIMIT", 500),
+	}
+}
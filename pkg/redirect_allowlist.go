@@ -0,0 +1,178 @@
+package pkg
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// RedirectAllowlist 는 OAuth 로그인, 가입이 끝난 뒤 돌아갈 수 있는 주소 목록입니다.
+//
+// 항목은 scheme://host[:port][/path] 형식입니다.
+//   - 경로가 있으면 scheme, 호스트, 포트, 경로가 모두 같아야 합니다.
+//   - 경로가 없으면 해당 origin의 모든 경로를 허용합니다.
+//   - 호스트를 *.ana.st처럼 쓰면 하위 도메인만 허용하고 ana.st 자체는 허용하지 않습니다.
+type RedirectAllowlist struct {
+	patterns []redirectPattern
+}
+
+type redirectPattern struct {
+	scheme string
+	// 와일드카드면 "."으로 시작하는 접미사 (예: ".ana.st")
+	host     string
+	wildcard bool
+	port     string
+	path     string
+}
+
+// NewRedirectAllowlist 는 OAUTH_REDIRECT_ALLOWLIST(,로 구분)로 목록을 만듭니다. 잘못된 항목은 경고를 남기고 건너뜁니다.
+// 설정하지 않으면 기본값 없이 모든 주소를 거부합니다.
+func NewRedirectAllowlist(logger *zap.Logger) *RedirectAllowlist {
+	allowlist := &RedirectAllowlist{}
+
+	env := os.Getenv("OAUTH_REDIRECT_ALLOWLIST")
+	if env == "" {
+		logger.Error("OAUTH_REDIRECT_ALLOWLIST is not set; every OAuth redirect will be rejected")
+		return allowlist
+	}
+
+	for _, p := range strings.Split(env, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+
+		pattern, err := parseRedirectPattern(p)
+		if err != nil {
+			logger.Warn("Ignoring invalid redirect allowlist entry", zap.String("entry", p), zap.Error(err))
+			continue
+		}
+		allowlist.patterns = append(allowlist.patterns, pattern)
+	}
+
+	return allowlist
+}
+
+// ParseRedirectAllowlist 는 항목 하나라도 잘못되면 에러를 반환합니다.
+func ParseRedirectAllowlist(patterns ...string) (*RedirectAllowlist, error) {
+	allowlist := &RedirectAllowlist{}
+	for _, p := range patterns {
+		pattern, err := parseRedirectPattern(p)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", p, err)
+		}
+		allowlist.patterns = append(allowlist.patterns, pattern)
+	}
+	return allowlist, nil
+}
+
+func parseRedirectPattern(p string) (redirectPattern, error) {
+	u, err := url.Parse(p)
+	if err != nil {
+		return redirectPattern{}, err
+	}
+	if u.Scheme == "" || u.Host == "" || u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return redirectPattern{}, fmt.Errorf("must be scheme://host[:port][/path]")
+	}
+
+	host := strings.ToLower(u.Hostname())
+	wildcard := strings.HasPrefix(host, "*.")
+	if wildcard {
+		host = host[1:]
+	}
+	if !validRedirectHost(strings.TrimPrefix(host, ".")) {
+		return redirectPattern{}, fmt.Errorf("invalid host")
+	}
+
+	return redirectPattern{
+		scheme:   u.Scheme,
+		host:     host,
+		wildcard: wildcard,
+		port:     u.Port(),
+		path:     u.EscapedPath(),
+	}, nil
+}
+
+// Allowed 는 redirectUri가 목록의 항목과 맞는지 확인합니다.
+// 브라우저와 url.Parse가 다르게 해석할 수 있는 주소(역슬래시, 인코딩된 호스트, userinfo 등)는 모두 거부합니다.
+func (a *RedirectAllowlist) Allowed(redirectUri string) bool {
+	if redirectUri == "" || len(redirectUri) > 2048 {
+		return false
+	}
+
+	for _, r := range redirectUri {
+		if r <= ' ' || r == 0x7f || r == '\\' {
+			return false
+		}
+	}
+
+	u, err := url.Parse(redirectUri)
+	if err != nil || u.Scheme == "" || u.Host == "" || u.Opaque != "" || u.User != nil {
+		return false
+	}
+	if strings.Contains(redirectUri, "#") || strings.Contains(rawAuthority(redirectUri), "%") {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if !validRedirectHost(host) {
+		return false
+	}
+
+	for _, p := range a.patterns {
+		if p.matches(u.Scheme, host, u.Port(), u.EscapedPath()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p redirectPattern) matches(scheme, host, port, path string) bool {
+	if scheme != p.scheme || port != p.port {
+		return false
+	}
+
+	if p.wildcard {
+		if !strings.HasSuffix(host, p.host) || len(host) == len(p.host) {
+			return false
+		}
+	} else if host != p.host {
+		return false
+	}
+
+	return p.path == "" || path == p.path
+}
+
+// rawAuthority 는 파싱 전 주소에서 scheme:// 뒤의 호스트 부분을 잘라냅니다.
+func rawAuthority(raw string) string {
+	_, rest, ok := strings.Cut(raw, "://")
+	if !ok {
+		return ""
+	}
+	if i := strings.IndexAny(rest, "/?#"); i >= 0 {
+		rest = rest[:i]
+	}
+	return rest
+}
+
+// validRedirectHost 는 ASCII 소문자, 숫자, 하이픈으로 된 라벨만 허용합니다. 끝의 점이나 빈 라벨은 거부합니다.
+func validRedirectHost(host string) bool {
+	if host == "" {
+		return false
+	}
+
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
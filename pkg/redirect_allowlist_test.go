@@ -0,0 +1,88 @@
+package pkg
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestRedirectAllowlist(t *testing.T) {
+	allowlist, err := ParseRedirectAllowlist(
+		"https://ana.st",
+		"https://*.ana.st",
+		"http://localhost:3000/auth/callback",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		uri  string
+		want bool
+	}{
+		{"https://ana.st/auth/callback", true},
+		{"https://ana.st", true},
+		{"https://log.ana.st/auth/callback?next=%2Flogs", true},
+		{"https://a.b.ana.st/", true},
+		{"HTTPS://Log.Ana.St/callback", true},
+		{"http://localhost:3000/auth/callback", true},
+
+		// 정확히 일치해야 하는 항목
+		{"http://localhost:3000/auth/callback/", false},
+		{"http://localhost:3000/auth/callback/../../evil", false},
+		{"http://localhost:3000/other", false},
+		{"http://localhost:3001/auth/callback", false},
+
+		// scheme 변경
+		{"http://ana.st/auth/callback", false},
+		{"javascript://ana.st/%0aalert(1)", false},
+		{"//ana.st/auth/callback", false},
+		{"/auth/callback", false},
+
+		// 와일드카드
+		{"https://evilana.st/", false},
+		{"https://ana.st.evil.com/", false},
+		{"https://.ana.st/", false},
+		{"https://log..ana.st/", false},
+		{"https://ana.st./", false},
+
+		// userinfo, 인코딩, 파서 차이
+		{"https://ana.st@evil.com/", false},
+		{"https://evil.com@ana.st/", false},
+		{"https://evil.com%2eana.st/", false},
+		{"https://evil%2Ecom.ana.st/", false},
+		{"https://evil.com\\@ana.st/", false},
+		{"https://evil.com\\.ana.st/", false},
+		{"https://evil.com#.ana.st/", false},
+		{"https://ana.st/callback#fragment", false},
+		{"https://log.ana.st\t/", false},
+		{"https://xn--n3h.ana.st/", true},
+		{"https://ànà.ana.st/", false},
+		{"", false},
+	}
+
+	for _, c := range cases {
+		if got := allowlist.Allowed(c.uri); got != c.want {
+			t.Errorf("Allowed(%q) = %v, want %v", c.uri, got, c.want)
+		}
+	}
+}
+
+func TestParseRedirectAllowlistRejectsInvalidEntries(t *testing.T) {
+	for _, entry := range []string{"ana.st", "https://", "https://user@ana.st", "https://ana.st/?q=1", "https://*ana.st"} {
+		if _, err := ParseRedirectAllowlist(entry); err == nil {
+			t.Errorf("ParseRedirectAllowlist(%q) succeeded", entry)
+		}
+	}
+}
+
+func TestRedirectAllowlistWithoutConfigRejectsEverything(t *testing.T) {
+	t.Setenv("OAUTH_REDIRECT_ALLOWLIST", "")
+
+	allowlist := NewRedirectAllowlist(zap.NewNop())
+	for _, uri := range []string{"http://localhost:3000/auth/callback", "https://ana.st/auth/callback"} {
+		if allowlist.Allowed(uri) {
+			t.Errorf("Allowed(%q) without OAUTH_REDIRECT_ALLOWLIST", uri)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package pkg
+
+import (
+	"analog-be/entity"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SessionCache 는 인증 인터셉터의 세션 조회 결과를 토큰 해시 단위로 잠시 보관합니다.
+// 없는 토큰도 NegativeTTL 동안 기억해 잘못된 토큰으로 디비를 두드리지 못하게 합니다.
+//
+// 이 프로세스에서 일어난 로그아웃, 세션 폐기, 사용자 수정은 바로 반영되고,
+// 다른 인스턴스나 디비에서 직접 바꾼 내용은 늦어도 TTL 뒤에 반영됩니다.
+type SessionCache struct {
+	mu          sync.Mutex
+	entries     *LRUCache[string, *entity.Session]
+	ttl         time.Duration
+	negativeTTL time.Duration
+
+	// 무효화할 때 쓰는 색인 (세션 ID -> 토큰 해시, 사용자 ID -> 토큰 해시들)
+	bySession map[entity.ID]string
+	byUser    map[entity.ID]map[string]struct{}
+
+	hits         atomic.Uint64
+	negativeHits atomic.Uint64
+	misses       atomic.Uint64
+}
+
+type SessionCacheStats struct {
+	Enabled      bool   `json:"enabled"`
+	Size         int    `json:"size"`
+	Capacity     int    `json:"capacity"`
+	Hits         uint64 `json:"hits"`
+	NegativeHits uint64 `json:"negativeHits"`
+	Misses       uint64 `json:"misses"`
+}
+
+// NewSessionCache 는 환경 변수로 설정된 세션 캐시를 만듭니다. SESSION_CACHE_SIZE=0이면 캐시하지 않습니다.
+func NewSessionCache() *SessionCache {
+	return NewSessionCacheWithConfig(
+		getEnvInt("SESSION_CACHE_SIZE", 10000),
+		getEnvDuration("SESSION_CACHE_TTL", 30*time.Second),
+		getEnvDuration("SESSION_CACHE_NEGATIVE_TTL", 5*time.Second),
+	)
+}
+
+func NewSessionCacheWithConfig(capacity int, ttl, negativeTTL time.Duration) *SessionCache {
+	c := &SessionCache{
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		bySession:   make(map[entity.ID]string),
+		byUser:      make(map[entity.ID]map[string]struct{}),
+	}
+	c.entries = NewLRUCache(capacity, c.unindex)
+	return c
+}
+
+// Enabled 는 캐시를 쓰는지 여부입니다. nil 캐시는 캐시하지 않는 것으로 봅니다.
+func (c *SessionCache) Enabled() bool {
+	return c != nil && c.entries.capacity > 0
+}
+
+// Get 은 캐시된 세션의 복사본을 반환합니다.
+// found가 true이고 session이 nil이면 없는 토큰으로 기억된 것입니다.
+func (c *SessionCache) Get(tokenHash string) (session *entity.Session, found bool) {
+	if !c.Enabled() {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.entries.Get(tokenHash, time.Now())
+	switch {
+	case !ok:
+		c.misses.Add(1)
+		return nil, false
+	case cached == nil:
+		c.negativeHits.Add(1)
+		return nil, true
+	}
+
+	c.hits.Add(1)
+	return copySession(cached), true
+}
+
+// Set 은 세션을 캐시합니다. 만료된 세션을 캐시에서 내주지 않도록 세션 만료 시각을 넘겨 보관하지 않습니다.
+func (c *SessionCache) Set(tokenHash string, session *entity.Session) {
+	if !c.Enabled() {
+		return
+	}
+
+	expiresAt := time.Now().Add(c.ttl)
+	if session.ExpiresAt.Before(expiresAt) {
+		expiresAt = session.ExpiresAt
+	}
+
+	copied := copySession(session)
+	copied.SessionToken = ""
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries.Set(tokenHash, copied, expiresAt)
+
+	c.bySession[copied.ID] = tokenHash
+	if c.byUser[copied.UserID] == nil {
+		c.byUser[copied.UserID] = make(map[string]struct{})
+	}
+	c.byUser[copied.UserID][tokenHash] = struct{}{}
+}
+
+// SetMissing 은 디비에 없는 토큰을 기억합니다.
+func (c *SessionCache) SetMissing(tokenHash string) {
+	if !c.Enabled() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries.Set(tokenHash, nil, time.Now().Add(c.negativeTTL))
+}
+
+// Touch 는 디비에서 갱신한 사용 시각과 만료 시각을 캐시된 세션에도 반영합니다.
+func (c *SessionCache) Touch(sessionID entity.ID, lastSeenAt, expiresAt time.Time) {
+	if !c.Enabled() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tokenHash, ok := c.bySession[sessionID]
+	if !ok {
+		return
+	}
+
+	if cached, ok := c.entries.Get(tokenHash, time.Now()); ok && cached != nil {
+		cached.LastSeenAt = lastSeenAt
+		cached.ExpiresAt = expiresAt
+	}
+}
+
+func (c *SessionCache) Invalidate(tokenHash string) {
+	if !c.Enabled() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries.Delete(tokenHash)
+}
+
+func (c *SessionCache) InvalidateSession(sessionID entity.ID) {
+	if !c.Enabled() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if tokenHash, ok := c.bySession[sessionID]; ok {
+		c.entries.Delete(tokenHash)
+	}
+}
+
+// InvalidateUser 는 사용자의 세션을 모두 캐시에서 뺍니다. 캐시된 세션에 사용자 정보도 들어 있으므로 사용자를 고칠 때도 호출합니다.
+func (c *SessionCache) InvalidateUser(userID entity.ID) {
+	if !c.Enabled() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for tokenHash := range c.byUser[userID] {
+		c.entries.Delete(tokenHash)
+	}
+}
+
+func (c *SessionCache) Stats() SessionCacheStats {
+	c.mu.Lock()
+	size := c.entries.Len()
+	c.mu.Unlock()
+
+	return SessionCacheStats{
+		Enabled:      c.Enabled(),
+		Size:         size,
+		Capacity:     c.entries.capacity,
+		Hits:         c.hits.Load(),
+		NegativeHits: c.negativeHits.Load(),
+		Misses:       c.misses.Load(),
+	}
+}
+
+// unindex 는 LRU에서 항목이 빠질 때 호출되며, c.mu를 잡은 상태에서 실행됩니다.
+func (c *SessionCache) unindex(tokenHash string, session *entity.Session) {
+	if session == nil {
+		return
+	}
+
+	if c.bySession[session.ID] == tokenHash {
+		delete(c.bySession, session.ID)
+	}
+
+	if hashes, ok := c.byUser[session.UserID]; ok {
+		delete(hashes, tokenHash)
+		if len(hashes) == 0 {
+			delete(c.byUser, session.UserID)
+		}
+	}
+}
+
+// copySession 은 캐시 안의 세션과 사용자를 호출하는 쪽에서 고쳐도 영향이 없도록 복사합니다.
+func copySession(session *entity.Session) *entity.Session {
+	copied := *session
+	if session.User != nil {
+		user := *session.User
+		copied.User = &user
+	}
+	return &copied
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value < 0 {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,76 @@
+package pkg
+
+import (
+	"analog-be/entity"
+	"testing"
+	"time"
+)
+
+func TestSessionCacheInvalidation(t *testing.T) {
+	cache := NewSessionCacheWithConfig(10, time.Minute, time.Minute)
+	expiresAt := time.Now().Add(time.Hour)
+
+	cache.Set("a", &entity.Session{ID: 1, UserID: 100, ExpiresAt: expiresAt})
+	cache.Set("b", &entity.Session{ID: 2, UserID: 100, ExpiresAt: expiresAt})
+	cache.Set("c", &entity.Session{ID: 3, UserID: 200, ExpiresAt: expiresAt})
+
+	cache.InvalidateSession(1)
+	if _, found := cache.Get("a"); found {
+		t.Fatal("session 1 still cached")
+	}
+
+	cache.InvalidateUser(100)
+	if _, found := cache.Get("b"); found {
+		t.Fatal("session of user 100 still cached")
+	}
+	if session, found := cache.Get("c"); !found || session.ID != 3 {
+		t.Fatalf("session 3 = %+v, %v", session, found)
+	}
+
+	stats := cache.Stats()
+	if stats.Size != 1 || stats.Hits != 1 || stats.Misses != 2 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestSessionCacheRemembersMissingTokens(t *testing.T) {
+	cache := NewSessionCacheWithConfig(10, time.Minute, time.Minute)
+
+	cache.SetMissing("nope")
+	if session, found := cache.Get("nope"); !found || session != nil {
+		t.Fatalf("missing token = %+v, %v", session, found)
+	}
+	if stats := cache.Stats(); stats.NegativeHits != 1 {
+		t.Fatalf("negative hits = %d", stats.NegativeHits)
+	}
+}
+
+func TestSessionCacheDoesNotOutliveSession(t *testing.T) {
+	cache := NewSessionCacheWithConfig(10, time.Minute, time.Minute)
+
+	cache.Set("a", &entity.Session{ID: 1, UserID: 100, ExpiresAt: time.Now().Add(-time.Second)})
+	if _, found := cache.Get("a"); found {
+		t.Fatal("expired session served from cache")
+	}
+}
+
+func TestSessionCacheReturnsCopies(t *testing.T) {
+	cache := NewSessionCacheWithConfig(10, time.Minute, time.Minute)
+	cache.Set("a", &entity.Session{ID: 1, UserID: 100, ExpiresAt: time.Now().Add(time.Hour), User: &entity.User{Name: "원래"}})
+
+	session, _ := cache.Get("a")
+	session.User.Name = "바뀜"
+
+	if session, _ := cache.Get("a"); session.User.Name != "원래" {
+		t.Fatalf("cached user modified: %q", session.User.Name)
+	}
+}
+
+func TestDisabledSessionCache(t *testing.T) {
+	cache := NewSessionCacheWithConfig(0, time.Minute, time.Minute)
+	cache.Set("a", &entity.Session{ID: 1, ExpiresAt: time.Now().Add(time.Hour)})
+
+	if _, found := cache.Get("a"); found || cache.Enabled() {
+		t.Fatal("disabled cache returned an entry")
+	}
+}
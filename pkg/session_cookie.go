@@ -0,0 +1,110 @@
+package pkg
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/NARUBROWN/spine/pkg/httpx"
+)
+
+// CSRFHeader 는 쿠키 세션으로 상태를 바꾸는 요청에 CSRF 토큰을 담는 헤더입니다.
+const CSRFHeader = "X-CSRF-Token"
+
+// SessionCookiePolicy 는 쿠키 세션 모드의 쿠키 설정입니다.
+//
+// 세션 쿠키는 HttpOnly, Secure로 내려 스크립트에서 읽을 수 없고,
+// CSRF 쿠키는 같은 사이트의 프론트엔드가 읽어 CSRFHeader로 다시 보낼 수 있도록 HttpOnly가 아닙니다. (double-submit)
+type SessionCookiePolicy struct {
+	Name     string
+	CSRFName string
+	Domain   string
+	SameSite httpx.SameSite
+	// 쿠키 유효 기간. 세션의 최대 수명과 같고, 실제 만료는 서버의 세션 만료 규칙을 따릅니다.
+	MaxAge int
+}
+
+// NewSessionCookiePolicy 는 SESSION_COOKIE_NAME, SESSION_COOKIE_DOMAIN, SESSION_COOKIE_SAMESITE(Lax, Strict, None)로 설정을 만듭니다.
+func NewSessionCookiePolicy(sessionPolicy *SessionPolicy) *SessionCookiePolicy {
+	name := os.Getenv("SESSION_COOKIE_NAME")
+	if name == "" {
+		name = "analog_session"
+	}
+
+	sameSite := httpx.SameSiteLax
+	switch strings.ToLower(os.Getenv("SESSION_COOKIE_SAMESITE")) {
+	case "strict":
+		sameSite = httpx.SameSiteStrict
+	case "none":
+		sameSite = httpx.SameSiteNone
+	}
+
+	return &SessionCookiePolicy{
+		Name:     name,
+		CSRFName: name + "_csrf",
+		Domain:   os.Getenv("SESSION_COOKIE_DOMAIN"),
+		SameSite: sameSite,
+		MaxAge:   int(sessionPolicy.MaxLifetime.Seconds()),
+	}
+}
+
+// Cookies 는 세션 쿠키와 CSRF 쿠키를 만듭니다.
+func (p *SessionCookiePolicy) Cookies(sessionToken string) []httpx.Cookie {
+	return []httpx.Cookie{
+		p.cookie(p.Name, sessionToken, p.MaxAge, true),
+		p.cookie(p.CSRFName, CSRFToken(sessionToken), p.MaxAge, false),
+	}
+}
+
+// ClearCookies 는 세션 쿠키와 CSRF 쿠키를 지우는 쿠키를 만듭니다.
+func (p *SessionCookiePolicy) ClearCookies() []httpx.Cookie {
+	return []httpx.Cookie{
+		p.cookie(p.Name, "", -1, true),
+		p.cookie(p.CSRFName, "", -1, false),
+	}
+}
+
+// SessionToken 은 Cookie 헤더에서 세션 토큰을 찾습니다. 없으면 빈 문자열을 반환합니다.
+func (p *SessionCookiePolicy) SessionToken(cookieHeader string) string {
+	if cookieHeader == "" {
+		return ""
+	}
+
+	cookies, err := http.ParseCookie(cookieHeader)
+	if err != nil {
+		return ""
+	}
+	for _, c := range cookies {
+		if c.Name == p.Name {
+			return c.Value
+		}
+	}
+	return ""
+}
+
+func (p *SessionCookiePolicy) cookie(name, value string, maxAge int, httpOnly bool) httpx.Cookie {
+	return httpx.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Domain:   p.Domain,
+		MaxAge:   maxAge,
+		HttpOnly: httpOnly,
+		Secure:   true,
+		SameSite: p.SameSite,
+	}
+}
+
+// CSRFToken 은 세션 토큰에 묶인 CSRF 토큰입니다. 세션 토큰을 모르면 만들 수 없고, CSRF 토큰으로 세션 토큰을 알아낼 수도 없습니다.
+// 디비에 저장된 세션 토큰 해시와도 다른 값입니다.
+func CSRFToken(sessionToken string) string {
+	return HashToken("csrf:" + sessionToken)
+}
+
+func ValidCSRFToken(sessionToken, token string) bool {
+	if token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(CSRFToken(sessionToken)), []byte(token)) == 1
+}
@@ -0,0 +1,50 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NARUBROWN/spine/pkg/httpx"
+)
+
+func TestSessionCookiePolicy(t *testing.T) {
+	t.Setenv("SESSION_COOKIE_NAME", "")
+	t.Setenv("SESSION_COOKIE_SAMESITE", "none")
+
+	p := NewSessionCookiePolicy(&SessionPolicy{MaxLifetime: time.Hour})
+
+	cookies := p.Cookies("token")
+	session, csrf := cookies[0], cookies[1]
+	if session.Name != "analog_session" || session.Value != "token" || !session.HttpOnly || !session.Secure || session.SameSite != httpx.SameSiteNone || session.MaxAge != 3600 {
+		t.Fatalf("unexpected session cookie: %+v", session)
+	}
+	if csrf.Name != "analog_session_csrf" || csrf.HttpOnly || csrf.Value != CSRFToken("token") {
+		t.Fatalf("unexpected csrf cookie: %+v", csrf)
+	}
+
+	if got := p.SessionToken("a=1; analog_session=token; analog_session_csrf=x"); got != "token" {
+		t.Fatalf("SessionToken = %q", got)
+	}
+	if got := p.SessionToken("a=1"); got != "" {
+		t.Fatalf("SessionToken = %q", got)
+	}
+
+	for _, c := range p.ClearCookies() {
+		if c.MaxAge >= 0 || c.Value != "" {
+			t.Fatalf("unexpected clear cookie: %+v", c)
+		}
+	}
+}
+
+func TestValidCSRFToken(t *testing.T) {
+	if !ValidCSRFToken("token", CSRFToken("token")) {
+		t.Fatal("expected valid token")
+	}
+	if ValidCSRFToken("token", CSRFToken("other")) || ValidCSRFToken("token", "") {
+		t.Fatal("expected invalid token")
+	}
+	// 디비에 저장된 세션 토큰 해시로는 CSRF 토큰을 만들 수 없음
+	if ValidCSRFToken("token", HashToken("token")) {
+		t.Fatal("token hash must not be a valid csrf token")
+	}
+}
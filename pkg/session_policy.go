@@ -0,0 +1,46 @@
+package pkg
+
+import (
+	"os"
+	"time"
+)
+
+// SessionPolicy 는 세션 만료 규칙입니다.
+// 세션은 마지막 사용 후 IdleTimeout 동안 쓰지 않거나, 생성 후 MaxLifetime이 지나면 만료됩니다.
+type SessionPolicy struct {
+	IdleTimeout time.Duration
+	MaxLifetime time.Duration
+
+	// 요청마다 디비에 쓰지 않도록, 마지막 갱신 후 이 간격이 지났을 때만 만료 시각을 늘림
+	RenewInterval time.Duration
+}
+
+func NewSessionPolicy() *SessionPolicy {
+	return &SessionPolicy{
+		IdleTimeout:   getEnvDuration("SESSION_IDLE_TIMEOUT", 7*24*time.Hour),
+		MaxLifetime:   getEnvDuration("SESSION_MAX_LIFETIME", 30*24*time.Hour),
+		RenewInterval: getEnvDuration("SESSION_RENEW_INTERVAL", time.Minute),
+	}
+}
+
+// ExpiresAt 은 lastSeenAt에 사용된 세션의 만료 시각을 계산합니다.
+func (p *SessionPolicy) ExpiresAt(createdAt, lastSeenAt time.Time) time.Time {
+	idle := lastSeenAt.Add(p.IdleTimeout)
+	absolute := createdAt.Add(p.MaxLifetime)
+	if idle.Before(absolute) {
+		return idle
+	}
+	return absolute
+}
+
+func (p *SessionPolicy) ShouldRenew(lastSeenAt, now time.Time) bool {
+	return now.Sub(lastSeenAt) >= p.RenewInterval
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
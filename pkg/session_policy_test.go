@@ -0,0 +1,51 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionPolicyExpiresAt(t *testing.T) {
+	policy := &SessionPolicy{IdleTimeout: 7 * 24 * time.Hour, MaxLifetime: 30 * 24 * time.Hour, RenewInterval: time.Minute}
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if got, want := policy.ExpiresAt(created, created), created.Add(7*24*time.Hour); !got.Equal(want) {
+		t.Fatalf("fresh session expires at %s, want %s", got, want)
+	}
+
+	lastSeen := created.Add(20 * 24 * time.Hour)
+	if got, want := policy.ExpiresAt(created, lastSeen), lastSeen.Add(7*24*time.Hour); !got.Equal(want) {
+		t.Fatalf("active session expires at %s, want %s", got, want)
+	}
+
+	// 최대 수명을 넘겨 연장되지 않음
+	lastSeen = created.Add(28 * 24 * time.Hour)
+	if got, want := policy.ExpiresAt(created, lastSeen), created.Add(30*24*time.Hour); !got.Equal(want) {
+		t.Fatalf("old session expires at %s, want %s", got, want)
+	}
+}
+
+func TestSessionPolicyShouldRenew(t *testing.T) {
+	policy := &SessionPolicy{RenewInterval: time.Minute}
+	now := time.Now()
+
+	if policy.ShouldRenew(now.Add(-30*time.Second), now) {
+		t.Fatal("renewed within interval")
+	}
+	if !policy.ShouldRenew(now.Add(-2*time.Minute), now) {
+		t.Fatal("not renewed after interval")
+	}
+}
+
+func TestNewSessionPolicyFromEnv(t *testing.T) {
+	t.Setenv("SESSION_IDLE_TIMEOUT", "2h")
+	t.Setenv("SESSION_MAX_LIFETIME", "invalid")
+
+	policy := NewSessionPolicy()
+	if policy.IdleTimeout != 2*time.Hour {
+		t.Fatalf("idle timeout = %s", policy.IdleTimeout)
+	}
+	if policy.MaxLifetime != 30*24*time.Hour {
+		t.Fatalf("max lifetime = %s, want default", policy.MaxLifetime)
+	}
+}
@@ -0,0 +1,68 @@
+package pkg
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SSEWriter 는 Server-Sent Events 형식으로 쓰고, 쓸 때마다 바로 내보냅니다.
+type SSEWriter struct {
+	w       io.Writer
+	flusher http.Flusher
+}
+
+// NewSSEWriter 는 이벤트 스트림 헤더를 보내고 SSEWriter를 만듭니다. w가 http.Flusher가 아니면 false를 반환합니다.
+func NewSSEWriter(w http.ResponseWriter) (*SSEWriter, bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, false
+	}
+
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	// nginx가 응답을 모아 두지 않도록 함
+	h.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	return &SSEWriter{w: w, flusher: flusher}, true
+}
+
+// Retry 는 연결이 끊겼을 때 브라우저가 다시 연결하기 전에 기다릴 시간을 알려 줍니다.
+func (s *SSEWriter) Retry(d time.Duration) error {
+	return s.write(fmt.Sprintf("retry: %d\n\n", d.Milliseconds()))
+}
+
+// Event 는 이벤트 하나를 씁니다. id가 비어 있으면 브라우저의 Last-Event-ID를 바꾸지 않습니다.
+func (s *SSEWriter) Event(id, event string, data []byte) error {
+	var b strings.Builder
+	if id != "" {
+		fmt.Fprintf(&b, "id: %s\n", id)
+	}
+	if event != "" {
+		fmt.Fprintf(&b, "event: %s\n", event)
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+	return s.write(b.String())
+}
+
+// Comment 는 클라이언트가 무시하는 주석 줄을 씁니다. 연결 유지용입니다.
+func (s *SSEWriter) Comment(text string) error {
+	return s.write(": " + strings.ReplaceAll(text, "\n", " ") + "\n\n")
+}
+
+func (s *SSEWriter) write(chunk string) error {
+	if _, err := io.WriteString(s.w, chunk); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
@@ -0,0 +1,35 @@
+package pkg
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSSEWriter(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	w, ok := NewSSEWriter(rec)
+	if !ok {
+		t.Fatal("recorder should be a flusher")
+	}
+	if got := rec.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", got)
+	}
+
+	w.Retry(3 * time.Second)
+	w.Event("7", "comment", []byte("{\"a\":1}"))
+	w.Event("", "reset", []byte("first\r\nsecond"))
+	w.Comment("heartbeat\nline")
+
+	want := "retry: 3000\n\n" +
+		"id: 7\nevent: comment\ndata: {\"a\":1}\n\n" +
+		"event: reset\ndata: first\ndata: second\n\n" +
+		": heartbeat line\n\n"
+	if got := rec.Body.String(); got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+	if !rec.Flushed {
+		t.Fatal("writer should flush")
+	}
+}
@@ -0,0 +1,13 @@
+package pkg
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// HashToken 은 디비에 저장할 토큰의 SHA-256 해시(hex)를 만듭니다.
+// 토큰은 충분히 긴 난수이므로 솔트 없이 해시해도 역산할 수 없고, 같은 토큰은 항상 같은 해시가 되어 조회에 쓸 수 있습니다.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"analog-be/entity"
+	"analog-be/pkg"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// AccessTokenRepository 의 토큰 인자는 원본 토큰이며, 디비에는 pkg.HashToken으로 해시한 값만 저장합니다.
+type AccessTokenRepository interface {
+	// Create 는 토큰을 저장합니다. 사용자에게 이미 maxTokens개의 토큰이 있으면 저장하지 않고 ErrAccessTokenLimit을 반환합니다.
+	Create(ctx context.Context, token *entity.PersonalAccessToken, maxTokens int) error
+	FindByToken(ctx context.Context, token string) (*entity.PersonalAccessToken, error)
+	FindAllByUserID(ctx context.Context, userID *entity.ID) ([]*entity.PersonalAccessToken, error)
+	TouchLastUsed(ctx context.Context, id *entity.ID, lastUsedAt time.Time) error
+	DeleteByID(ctx context.Context, userID *entity.ID, id *entity.ID) error
+	DeleteExpiredBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type AccessTokenRepositoryImpl struct {
+	db bun.IDB
+}
+
+func NewAccessTokenRepository(db bun.IDB) AccessTokenRepository {
+	return &AccessTokenRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *AccessTokenRepositoryImpl) Create(ctx context.Context, token *entity.PersonalAccessToken, maxTokens int) error {
+	token.TokenHash = pkg.HashToken(token.Token)
+
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockUser(ctx, tx, token.UserID); err != nil {
+			return err
+		}
+
+		// 잠근 뒤에 센 개수로 확인해야 동시에 만들어도 한도를 넘지 않음
+		count, err := tx.NewSelect().
+			Model((*entity.PersonalAccessToken)(nil)).
+			Where("user_id = ?", token.UserID).
+			Count(ctx)
+		if err != nil {
+			return err
+		}
+		if count >= maxTokens {
+			return ErrAccessTokenLimit
+		}
+
+		_, err = tx.NewInsert().
+			Model(token).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (r *AccessTokenRepositoryImpl) FindByToken(ctx context.Context, token string) (*entity.PersonalAccessToken, error) {
+	accessToken := new(entity.PersonalAccessToken)
+	err := r.db.NewSelect().
+		Model(accessToken).
+		Where("token_hash = ?", pkg.HashToken(token)).
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return accessToken, nil
+}
+
+// FindAllByUserID 는 만료된 토큰도 포함해 최근에 만든 순서로 가져옵니다.
+func (r *AccessTokenRepositoryImpl) FindAllByUserID(ctx context.Context, userID *entity.ID) ([]*entity.PersonalAccessToken, error) {
+	var tokens []*entity.PersonalAccessToken
+	err := r.db.NewSelect().
+		Model(&tokens).
+		Where("user_id = ?", userID).
+		Order("created_at DESC", "id DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (r *AccessTokenRepositoryImpl) TouchLastUsed(ctx context.Context, id *entity.ID, lastUsedAt time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*entity.PersonalAccessToken)(nil)).
+		Set("last_used_at = ?", lastUsedAt).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+// DeleteByID 는 사용자의 토큰을 지웁니다. 다른 사용자의 토큰이거나 없으면 sql.ErrNoRows를 반환합니다.
+func (r *AccessTokenRepositoryImpl) DeleteByID(ctx context.Context, userID *entity.ID, id *entity.ID) error {
+	res, err := r.db.NewDelete().
+		Model((*entity.PersonalAccessToken)(nil)).
+		Where("id = ?", id).
+		Where("user_id = ?", userID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteExpiredBefore 는 before 이전에 만료된 토큰을 지우고 지운 개수를 반환합니다.
+func (r *AccessTokenRepositoryImpl) DeleteExpiredBefore(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.db.NewDelete().
+		Model((*entity.PersonalAccessToken)(nil)).
+		Where("expires_at < ?", before).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
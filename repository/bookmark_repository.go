@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"analog-be/entity"
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+type BookmarkRepository interface {
+	Find(ctx context.Context, userID *entity.ID, logID *entity.ID) (*entity.Bookmark, error)
+	FindByUserID(ctx context.Context, userID *entity.ID, limit int, offset int) ([]*entity.Bookmark, int, error)
+	// Create 는 로그를 저장합니다. 이미 저장한 로그면 그대로 둡니다.
+	Create(ctx context.Context, bookmark *entity.Bookmark) error
+	// Delete 는 저장을 취소하고, 사용자의 목록에서도 그 로그를 뺍니다.
+	Delete(ctx context.Context, userID *entity.ID, logID *entity.ID) error
+}
+
+type BookmarkRepositoryImpl struct {
+	db bun.IDB
+}
+
+func NewBookmarkRepository(db bun.IDB) BookmarkRepository {
+	return &BookmarkRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *BookmarkRepositoryImpl) Find(ctx context.Context, userID *entity.ID, logID *entity.ID) (*entity.Bookmark, error) {
+	bookmark := new(entity.Bookmark)
+
+	err := r.db.NewSelect().
+		Model(bookmark).
+		Where("user_id = ?", userID).
+		Where("log_id = ?", logID).
+		Limit(1).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bookmark, nil
+}
+
+// FindByUserID 는 최근에 저장한 것부터 찾습니다.
+func (r *BookmarkRepositoryImpl) FindByUserID(ctx context.Context, userID *entity.ID, limit int, offset int) ([]*entity.Bookmark, int, error) {
+	var bookmarks []*entity.Bookmark
+
+	count, err := r.db.NewSelect().
+		Model(&bookmarks).
+		Where("user_id = ?", userID).
+		Order("created_at DESC", "log_id DESC").
+		Limit(limit).
+		Offset(offset).
+		ScanAndCount(ctx)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return bookmarks, count, nil
+}
+
+func (r *BookmarkRepositoryImpl) Create(ctx context.Context, bookmark *entity.Bookmark) error {
+	_, err := r.db.NewInsert().
+		Model(bookmark).
+		On("CONFLICT DO NOTHING").
+		Exec(ctx)
+	return err
+}
+
+func (r *BookmarkRepositoryImpl) Delete(ctx context.Context, userID *entity.ID, logID *entity.ID) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewDelete().
+			Model((*entity.ReadingListItem)(nil)).
+			Where("log_id = ?", logID).
+			Where("list_id IN (SELECT id FROM reading_lists WHERE user_id = ?)", userID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.NewDelete().
+			Model((*entity.Bookmark)(nil)).
+			Where("user_id = ?", userID).
+			Where("log_id = ?", logID).
+			Exec(ctx)
+		return err
+	})
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"analog-be/entity"
+	"analog-be/pkg"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// cachedSessionRepository 는 FindByToken 결과를 pkg.SessionCache에 보관하고, 세션을 바꾸거나 지우면 캐시에서도 뺍니다.
+type cachedSessionRepository struct {
+	SessionRepository
+	cache *pkg.SessionCache
+}
+
+func (r *cachedSessionRepository) Create(ctx context.Context, session *entity.Session) error {
+	if err := r.SessionRepository.Create(ctx, session); err != nil {
+		return err
+	}
+
+	r.cache.Invalidate(session.TokenHash)
+	return nil
+}
+
+func (r *cachedSessionRepository) FindByToken(ctx context.Context, token string) (*entity.Session, error) {
+	tokenHash := pkg.HashToken(token)
+	if session, found := r.cache.Get(tokenHash); found {
+		if session == nil {
+			return nil, sql.ErrNoRows
+		}
+		return session, nil
+	}
+
+	session, err := r.SessionRepository.FindByToken(ctx, token)
+	if errors.Is(err, sql.ErrNoRows) {
+		r.cache.SetMissing(tokenHash)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Set(tokenHash, session)
+	return session, nil
+}
+
+func (r *cachedSessionRepository) Touch(ctx context.Context, id *entity.ID, lastSeenAt time.Time, expiresAt time.Time) error {
+	if err := r.SessionRepository.Touch(ctx, id, lastSeenAt, expiresAt); err != nil {
+		return err
+	}
+
+	r.cache.Touch(*id, lastSeenAt, expiresAt)
+	return nil
+}
+
+func (r *cachedSessionRepository) Rotate(ctx context.Context, id *entity.ID, oldToken string, newToken string, lastSeenAt time.Time, expiresAt time.Time) error {
+	defer r.cache.Invalidate(pkg.HashToken(newToken))
+	defer r.cache.InvalidateSession(*id)
+
+	return r.SessionRepository.Rotate(ctx, id, oldToken, newToken, lastSeenAt, expiresAt)
+}
+
+func (r *cachedSessionRepository) Delete(ctx context.Context, token string) error {
+	defer r.cache.Invalidate(pkg.HashToken(token))
+
+	return r.SessionRepository.Delete(ctx, token)
+}
+
+func (r *cachedSessionRepository) DeleteByID(ctx context.Context, id *entity.ID) error {
+	defer r.cache.InvalidateSession(*id)
+
+	return r.SessionRepository.DeleteByID(ctx, id)
+}
+
+func (r *cachedSessionRepository) DeleteByUserID(ctx context.Context, userID int64, exceptTokens ...string) error {
+	defer r.cache.InvalidateUser(userID)
+
+	return r.SessionRepository.DeleteByUserID(ctx, userID, exceptTokens...)
+}
@@ -3,15 +3,19 @@ package repository
 import (
 	"analog-be/entity"
 	"context"
+	"time"
 
 	"github.com/uptrace/bun"
 )
 
 type CommentRepository interface {
 	FindByID(ctx context.Context, id *entity.ID) (*entity.Comment, error)
-	FindByLogID(ctx context.Context, logID *entity.ID) ([]*entity.Comment, *int, error)
+	FindByLogID(ctx context.Context, logID *entity.ID, sort string, limit int, offset int) ([]*entity.Comment, *int, error)
+	FindReplies(ctx context.Context, parentID *entity.ID, limit int, offset int) ([]*entity.Comment, int, error)
+	FindRevisions(ctx context.Context, commentID *entity.ID) ([]*entity.CommentRevision, error)
 	Create(ctx context.Context, comment *entity.Comment) (*entity.Comment, error)
 	Update(ctx context.Context, comment *entity.Comment) error
+	UpdateHidden(ctx context.Context, comment *entity.Comment) error
 	Delete(ctx context.Context, id *entity.ID) error
 	DeleteByLogID(ctx context.Context, logID *entity.ID) error
 }
@@ -31,7 +35,9 @@ func (r *CommentRepositoryImpl) FindByID(ctx context.Context, id *entity.ID) (*e
 
 	err := r.db.NewSelect().
 		Model(comment).
-		Where("id = ?", id).
+		Relation("Author").
+		Relation("Mentions").
+		Where("comment.id = ?", id).
 		Limit(1).
 		Scan(ctx)
 
@@ -42,13 +48,36 @@ func (r *CommentRepositoryImpl) FindByID(ctx context.Context, id *entity.ID) (*e
 	return comment, nil
 }
 
-func (r *CommentRepositoryImpl) FindByLogID(ctx context.Context, logID *entity.ID) ([]*entity.Comment, *int, error) {
+// replyCountExpr 는 바로 아래 답글 수를 reply_count로 함께 읽습니다.
+const replyCountExpr = "(SELECT COUNT(*) FROM comments AS reply WHERE reply.parent_id = comment.id) AS reply_count"
+
+// commentOrders 는 정렬 이름별 ORDER BY 절입니다. 같은 값이면 먼저 단 댓글이 앞에 옵니다.
+var commentOrders = map[string][]string{
+	entity.CommentSortOldest:    {"comment.created_at ASC", "comment.id ASC"},
+	entity.CommentSortNewest:    {"comment.created_at DESC", "comment.id DESC"},
+	entity.CommentSortReactions: {"comment.reaction_count DESC", "comment.created_at ASC", "comment.id ASC"},
+}
+
+// FindByLogID 는 로그의 최상위 댓글을 작성자, 답글 수와 함께 찾습니다. 답글은 FindReplies로 불러옵니다.
+func (r *CommentRepositoryImpl) FindByLogID(ctx context.Context, logID *entity.ID, sort string, limit int, offset int) ([]*entity.Comment, *int, error) {
 	var comments []*entity.Comment
 
+	order, ok := commentOrders[sort]
+	if !ok {
+		order = commentOrders[entity.CommentSortOldest]
+	}
+
 	count, err := r.db.NewSelect().
 		Model(&comments).
-		Where("log_id = ?", logID).
-		Order("created_at ASC").
+		ColumnExpr("comment.*").
+		ColumnExpr(replyCountExpr).
+		Relation("Author").
+		Relation("Mentions").
+		Where("comment.log_id = ?", logID).
+		Where("comment.parent_id IS NULL").
+		Order(order...).
+		Limit(limit).
+		Offset(offset).
 		ScanAndCount(ctx)
 
 	if err != nil {
@@ -58,29 +87,222 @@ func (r *CommentRepositoryImpl) FindByLogID(ctx context.Context, logID *entity.I
 	return comments, &count, nil
 }
 
+func (r *CommentRepositoryImpl) FindReplies(ctx context.Context, parentID *entity.ID, limit int, offset int) ([]*entity.Comment, int, error) {
+	var comments []*entity.Comment
+
+	count, err := r.db.NewSelect().
+		Model(&comments).
+		ColumnExpr("comment.*").
+		ColumnExpr(replyCountExpr).
+		Relation("Author").
+		Relation("Mentions").
+		Where("comment.parent_id = ?", parentID).
+		Order("comment.created_at ASC", "comment.id ASC").
+		Limit(limit).
+		Offset(offset).
+		ScanAndCount(ctx)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return comments, count, nil
+}
+
+// FindRevisions 는 고치기 전 내용을 최근 것부터 찾습니다.
+func (r *CommentRepositoryImpl) FindRevisions(ctx context.Context, commentID *entity.ID) ([]*entity.CommentRevision, error) {
+	var revisions []*entity.CommentRevision
+
+	err := r.db.NewSelect().
+		Model(&revisions).
+		Where("comment_id = ?", commentID).
+		Order("id DESC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// Create 는 댓글과 멘션을 저장하고 로그의 댓글 수를 늘립니다.
 func (r *CommentRepositoryImpl) Create(ctx context.Context, comment *entity.Comment) (*entity.Comment, error) {
-	_, err := r.db.NewInsert().
-		Model(comment).
-		Exec(ctx)
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(comment).Exec(ctx); err != nil {
+			return err
+		}
+
+		if err := insertCommentMentions(ctx, tx, comment); err != nil {
+			return err
+		}
+
+		return addCommentCount(ctx, tx, comment.LogID, 1)
+	})
 	return comment, err
 }
 
+// Update 는 고치기 전 내용을 기록한 뒤 내용과 렌더링 결과를 바꾸고, 멘션 목록을 comment.Mentions로 바꿉니다.
 func (r *CommentRepositoryImpl) Update(ctx context.Context, comment *entity.Comment) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		previous, err := findCommentForUpdate(ctx, tx, &comment.ID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		revision := &entity.CommentRevision{
+			CommentID:  previous.ID,
+			Content:    previous.Content,
+			CreatedAt:  previous.CreatedAt,
+			ReplacedAt: now,
+		}
+		if previous.Edited() {
+			revision.CreatedAt = previous.UpdatedAt
+		}
+		if _, err := tx.NewInsert().Model(revision).Exec(ctx); err != nil {
+			return err
+		}
+
+		comment.UpdatedAt = now
+		_, err = tx.NewUpdate().
+			Model(comment).
+			Column("content", "pre_rendered", "updated_at").
+			Where("id = ?", comment.ID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.NewDelete().Model((*entity.CommentMention)(nil)).Where("comment_id = ?", comment.ID).Exec(ctx); err != nil {
+			return err
+		}
+
+		return insertCommentMentions(ctx, tx, comment)
+	})
+}
+
+// UpdateHidden 은 comment의 숨긴 시각과 숨긴 사람만 저장합니다.
+func (r *CommentRepositoryImpl) UpdateHidden(ctx context.Context, comment *entity.Comment) error {
 	_, err := r.db.NewUpdate().
 		Model(comment).
+		Column("hidden_at", "hidden_by").
 		Where("id = ?", comment.ID).
 		Exec(ctx)
 	return err
 }
 
+func insertCommentMentions(ctx context.Context, tx bun.Tx, comment *entity.Comment) error {
+	if len(comment.Mentions) == 0 {
+		return nil
+	}
+
+	mentions := make([]*entity.CommentMention, len(comment.Mentions))
+	for i, user := range comment.Mentions {
+		mentions[i] = &entity.CommentMention{CommentID: comment.ID, UserID: user.ID}
+	}
+
+	_, err := tx.NewInsert().
+		Model(&mentions).
+		On("CONFLICT DO NOTHING").
+		Exec(ctx)
+	return err
+}
+
+// Delete 는 답글이 없는 댓글은 지우고, 답글이 있으면 내용을 비운 자리만 남깁니다.
+// 지운 댓글이 지워진 부모의 마지막 답글이었으면 부모 자리도 함께 정리합니다.
 func (r *CommentRepositoryImpl) Delete(ctx context.Context, id *entity.ID) error {
-	_, err := r.db.NewDelete().
-		Model((*entity.Comment)(nil)).
-		Where("id = ?", id).
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		comment, err := findCommentForUpdate(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if !comment.Deleted() {
+			if err := addCommentCount(ctx, tx, comment.LogID, -1); err != nil {
+				return err
+			}
+		}
+
+		for {
+			replies, err := tx.NewSelect().
+				Model((*entity.Comment)(nil)).
+				Where("parent_id = ?", comment.ID).
+				Count(ctx)
+			if err != nil {
+				return err
+			}
+
+			if replies > 0 {
+				if comment.Deleted() {
+					return nil
+				}
+				_, err := tx.NewUpdate().
+					Model((*entity.Comment)(nil)).
+					Set("content = ''").
+					Set("pre_rendered = ''").
+					Set("deleted_at = ?", time.Now().UTC()).
+					Where("id = ?", comment.ID).
+					Exec(ctx)
+				if err != nil {
+					return err
+				}
+
+				if _, err := tx.NewDelete().Model((*entity.CommentMention)(nil)).Where("comment_id = ?", comment.ID).Exec(ctx); err != nil {
+					return err
+				}
+
+				// 지운 내용이 고친 기록으로 남지 않도록 함
+				_, err = tx.NewDelete().Model((*entity.CommentRevision)(nil)).Where("comment_id = ?", comment.ID).Exec(ctx)
+				return err
+			}
+
+			if _, err := tx.NewDelete().Model((*entity.Comment)(nil)).Where("id = ?", comment.ID).Exec(ctx); err != nil {
+				return err
+			}
+
+			if comment.ParentID == 0 {
+				return nil
+			}
+
+			parent, err := findCommentForUpdate(ctx, tx, &comment.ParentID)
+			if err != nil {
+				return err
+			}
+			if !parent.Deleted() {
+				return nil
+			}
+			comment = parent
+		}
+	})
+}
+
+func addCommentCount(ctx context.Context, tx bun.Tx, logID entity.ID, delta int) error {
+	_, err := tx.NewUpdate().
+		Model((*entity.Log)(nil)).
+		Set("comment_count = comment_count + ?", delta).
+		Where("id = ?", logID).
 		Exec(ctx)
 	return err
 }
 
+// findCommentForUpdate 는 지우는 동안 새 답글이 달리지 않도록 댓글 행을 잠급니다.
+func findCommentForUpdate(ctx context.Context, tx bun.Tx, id *entity.ID) (*entity.Comment, error) {
+	comment := new(entity.Comment)
+
+	err := tx.NewSelect().
+		Model(comment).
+		Where("id = ?", id).
+		For("UPDATE").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
 func (r *CommentRepositoryImpl) DeleteByLogID(ctx context.Context, logID *entity.ID) error {
 	_, err := r.db.NewDelete().
 		Model((*entity.Comment)(nil)).
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"analog-be/entity"
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type EmailOutboxRepository interface {
+	Enqueue(ctx context.Context, entries []*entity.EmailOutbox) error
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*entity.EmailOutbox, error)
+	MarkDone(ctx context.Context, id entity.ID) error
+	MarkRetry(ctx context.Context, id entity.ID, lastError string, nextAttemptAt time.Time) error
+	MarkFailed(ctx context.Context, id entity.ID, lastError string) error
+	// MarkCancelled 는 보내지 않기로 한 메일을 reason과 함께 끝냅니다.
+	MarkCancelled(ctx context.Context, id entity.ID, reason string) error
+	DeleteDoneBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type EmailOutboxRepositoryImpl struct {
+	db bun.IDB
+}
+
+func NewEmailOutboxRepository(db bun.IDB) EmailOutboxRepository {
+	return &EmailOutboxRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *EmailOutboxRepositoryImpl) Enqueue(ctx context.Context, entries []*entity.EmailOutbox) error {
+	return enqueueEmails(ctx, r.db, entries)
+}
+
+// Claim 은 보낼 차례가 된 메일을 lease 동안 선점합니다.
+func (r *EmailOutboxRepositoryImpl) Claim(ctx context.Context, limit int, lease time.Duration) ([]*entity.EmailOutbox, error) {
+	now := time.Now().UTC()
+
+	candidates := r.db.NewSelect().
+		Model((*entity.EmailOutbox)(nil)).
+		Column("id").
+		Where("status = ?", entity.EmailOutboxPending).
+		Where("next_attempt_at <= ?", now).
+		Order("id ASC").
+		Limit(limit).
+		For("UPDATE SKIP LOCKED")
+
+	var entries []*entity.EmailOutbox
+	_, err := r.db.NewUpdate().
+		Model((*entity.EmailOutbox)(nil)).
+		Set("next_attempt_at = ?", now.Add(lease)).
+		Set("attempts = attempts + 1").
+		Where("id IN (?)", candidates).
+		Returning("*").
+		Exec(ctx, &entries)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (r *EmailOutboxRepositoryImpl) MarkDone(ctx context.Context, id entity.ID) error {
+	_, err := r.db.NewUpdate().
+		Model((*entity.EmailOutbox)(nil)).
+		Set("status = ?", entity.EmailOutboxDone).
+		Set("processed_at = ?", time.Now().UTC()).
+		Set("last_error = NULL").
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+func (r *EmailOutboxRepositoryImpl) MarkRetry(ctx context.Context, id entity.ID, lastError string, nextAttemptAt time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*entity.EmailOutbox)(nil)).
+		Set("last_error = ?", lastError).
+		Set("next_attempt_at = ?", nextAttemptAt).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+func (r *EmailOutboxRepositoryImpl) MarkFailed(ctx context.Context, id entity.ID, lastError string) error {
+	_, err := r.db.NewUpdate().
+		Model((*entity.EmailOutbox)(nil)).
+		Set("status = ?", entity.EmailOutboxFailed).
+		Set("last_error = ?", lastError).
+		Set("processed_at = ?", time.Now().UTC()).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+func (r *EmailOutboxRepositoryImpl) MarkCancelled(ctx context.Context, id entity.ID, reason string) error {
+	_, err := r.db.NewUpdate().
+		Model((*entity.EmailOutbox)(nil)).
+		Set("status = ?", entity.EmailOutboxCancelled).
+		Set("last_error = ?", reason).
+		Set("processed_at = ?", time.Now().UTC()).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+// DeleteDoneBefore 는 before 이전에 보냈거나 취소한 메일을 지웁니다. 실패한 메일은 확인할 수 있도록 남겨 둡니다.
+func (r *EmailOutboxRepositoryImpl) DeleteDoneBefore(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.db.NewDelete().
+		Model((*entity.EmailOutbox)(nil)).
+		Where("status IN (?)", bun.In([]string{entity.EmailOutboxDone, entity.EmailOutboxCancelled})).
+		Where("processed_at < ?", before).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// enqueueEmails 는 다른 레포지토리의 트랜잭션 안에서도 메일을 쌓을 수 있도록 bun.IDB를 받습니다.
+func enqueueEmails(ctx context.Context, db bun.IDB, entries []*entity.EmailOutbox) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	_, err := db.NewInsert().
+		Model(&entries).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"analog-be/entity"
+	"analog-be/pkg"
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type EmailSettingsRepository interface {
+	FindByUserID(ctx context.Context, userID *entity.ID) (*entity.EmailSettings, error)
+	// FindByUnsubscribeToken 은 토큰을 보낸 주소가 지금 설정한 주소와 같은 설정을 찾습니다.
+	FindByUnsubscribeToken(ctx context.Context, token string) (*entity.EmailSettings, error)
+	// CreateUnsubscribeToken 은 email로 보내는 메일의 수신 거부 토큰을 expiresAt까지 쓸 수 있도록 해시로 저장합니다.
+	CreateUnsubscribeToken(ctx context.Context, userID entity.ID, email, token string, expiresAt time.Time) error
+	// DeleteExpiredUnsubscribeTokens 는 만료된 수신 거부 토큰을 지우고 지운 개수를 반환합니다.
+	DeleteExpiredUnsubscribeTokens(ctx context.Context) (int64, error)
+	// FindNotificationRecipients 는 userIDs 중 메일 알림을 켠 사용자의 설정을 찾습니다.
+	FindNotificationRecipients(ctx context.Context, userIDs []entity.ID) ([]*entity.EmailSettings, error)
+	// FindDigestDue 는 주간 소식을 켰고 마지막으로 보낸 지 before가 지난 사용자의 설정을 찾습니다.
+	FindDigestDue(ctx context.Context, before time.Time, limit int) ([]*entity.EmailSettings, error)
+	Save(ctx context.Context, settings *entity.EmailSettings) error
+	// CompleteDigest 는 주간 소식 메일을 쌓고 보낸 시각을 같은 트랜잭션에서 기록합니다. 보낼 로그가 없었으면 entry가 nil입니다.
+	CompleteDigest(ctx context.Context, userID entity.ID, sentAt time.Time, entry *entity.EmailOutbox) error
+}
+
+type EmailSettingsRepositoryImpl struct {
+	db bun.IDB
+}
+
+func NewEmailSettingsRepository(db bun.IDB) EmailSettingsRepository {
+	return &EmailSettingsRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *EmailSettingsRepositoryImpl) FindByUserID(ctx context.Context, userID *entity.ID) (*entity.EmailSettings, error) {
+	settings := new(entity.EmailSettings)
+
+	err := r.db.NewSelect().
+		Model(settings).
+		Where("user_id = ?", userID).
+		Limit(1).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func (r *EmailSettingsRepositoryImpl) FindByUnsubscribeToken(ctx context.Context, token string) (*entity.EmailSettings, error) {
+	settings := new(entity.EmailSettings)
+
+	err := r.db.NewSelect().
+		Model(settings).
+		Where(`EXISTS (
+			SELECT 1 FROM email_unsubscribe_tokens AS t
+			WHERE t.token_hash = ? AND t.user_id = email_settings.user_id AND lower(t.email) = lower(email_settings.email)
+				AND t.expires_at > ?
+		)`, pkg.HashToken(token), time.Now().UTC()).
+		Limit(1).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func (r *EmailSettingsRepositoryImpl) CreateUnsubscribeToken(ctx context.Context, userID entity.ID, email, token string, expiresAt time.Time) error {
+	_, err := r.db.NewInsert().
+		Model(&entity.EmailUnsubscribeToken{
+			TokenHash: pkg.HashToken(token),
+			UserID:    userID,
+			Email:     email,
+			ExpiresAt: expiresAt,
+			CreatedAt: time.Now().UTC(),
+		}).
+		Exec(ctx)
+	return err
+}
+
+func (r *EmailSettingsRepositoryImpl) DeleteExpiredUnsubscribeTokens(ctx context.Context) (int64, error) {
+	res, err := r.db.NewDelete().
+		Model((*entity.EmailUnsubscribeToken)(nil)).
+		Where("expires_at < ?", time.Now().UTC()).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *EmailSettingsRepositoryImpl) FindNotificationRecipients(ctx context.Context, userIDs []entity.ID) ([]*entity.EmailSettings, error) {
+	var settings []*entity.EmailSettings
+	if len(userIDs) == 0 {
+		return settings, nil
+	}
+
+	err := r.db.NewSelect().
+		Model(&settings).
+		Where("user_id IN (?)", bun.In(userIDs)).
+		Where("notifications").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func (r *EmailSettingsRepositoryImpl) FindDigestDue(ctx context.Context, before time.Time, limit int) ([]*entity.EmailSettings, error) {
+	var settings []*entity.EmailSettings
+
+	err := r.db.NewSelect().
+		Model(&settings).
+		Where("digest").
+		Where("last_digest_at <= ?", before).
+		Order("last_digest_at ASC").
+		Limit(limit).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func (r *EmailSettingsRepositoryImpl) Save(ctx context.Context, settings *entity.EmailSettings) error {
+	_, err := r.db.NewInsert().
+		Model(settings).
+		On("CONFLICT (user_id) DO UPDATE").
+		Set("email = EXCLUDED.email").
+		Set("notifications = EXCLUDED.notifications").
+		Set("digest = EXCLUDED.digest").
+		Set("digest_topic_ids = EXCLUDED.digest_topic_ids").
+		Set("digest_generations = EXCLUDED.digest_generations").
+		Set("last_digest_at = EXCLUDED.last_digest_at").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	return err
+}
+
+func (r *EmailSettingsRepositoryImpl) CompleteDigest(ctx context.Context, userID entity.ID, sentAt time.Time, entry *entity.EmailOutbox) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if entry != nil {
+			if err := enqueueEmails(ctx, tx, []*entity.EmailOutbox{entry}); err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.NewUpdate().
+			Model((*entity.EmailSettings)(nil)).
+			Set("last_digest_at = ?", sentAt).
+			Where("user_id = ?", userID).
+			Exec(ctx)
+		return err
+	})
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+// ErrHandleTaken 은 다른 사용자가 이미 쓰는 핸들로 사용자를 만들거나 바꾸려 할 때 반환됩니다.
+var ErrHandleTaken = errors.New("handle is already taken")
+
+// ErrReadingListNameTaken 은 같은 사용자의 다른 목록이 이미 쓰는 이름으로 목록을 만들거나 바꾸려 할 때 반환됩니다.
+var ErrReadingListNameTaken = errors.New("reading list name is already taken")
+
+// ErrReadingListFull 은 이미 가득 찬 목록에 새 로그를 담으려 할 때 반환됩니다.
+var ErrReadingListFull = errors.New("reading list is full")
+
+// ErrReadingListLimit 은 사용자가 만들 수 있는 목록 수를 넘겨 목록을 만들려 할 때 반환됩니다.
+var ErrReadingListLimit = errors.New("reading list limit reached")
+
+// ErrAccessTokenLimit 은 사용자가 만들 수 있는 개인 액세스 토큰 수를 넘겨 토큰을 만들려 할 때 반환됩니다.
+var ErrAccessTokenLimit = errors.New("access token limit reached")
+
+// usersHandleKey 는 users.handle의 UNIQUE 제약 이름입니다.
+const usersHandleKey = "users_handle_key"
+
+// readingListsNameKey 는 reading_lists(user_id, name)의 UNIQUE 제약 이름입니다.
+const readingListsNameKey = "reading_lists_user_id_name_key"
+
+// isUniqueViolation 은 err가 constraint 제약을 어긴 unique_violation(23505)인지 확인합니다.
+func isUniqueViolation(err error, constraint string) bool {
+	var pgErr pgdriver.Error
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Field('C') == "23505" && pgErr.Field('n') == constraint
+}
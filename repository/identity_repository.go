@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"analog-be/entity"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// ErrLastIdentity 는 사용자에게 남은 마지막 외부 계정을 지우려 할 때 반환됩니다. 지우면 다시 로그인할 수 없습니다.
+var ErrLastIdentity = errors.New("cannot remove the last identity of a user")
+
+type UserIdentityRepository interface {
+	FindByProviderSubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error)
+	FindAllByUserID(ctx context.Context, userID *entity.ID) ([]*entity.UserIdentity, error)
+	Create(ctx context.Context, identity *entity.UserIdentity) error
+	CreateWithUser(ctx context.Context, user *entity.User, identity *entity.UserIdentity) error
+	NextExternalUserID(ctx context.Context) (entity.ID, error)
+	TouchLastUsed(ctx context.Context, id *entity.ID, lastUsedAt time.Time) error
+	DeleteByID(ctx context.Context, userID *entity.ID, id *entity.ID) error
+}
+
+type UserIdentityRepositoryImpl struct {
+	db bun.IDB
+}
+
+func NewUserIdentityRepository(db bun.IDB) UserIdentityRepository {
+	return &UserIdentityRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *UserIdentityRepositoryImpl) FindByProviderSubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error) {
+	identity := new(entity.UserIdentity)
+	err := r.db.NewSelect().
+		Model(identity).
+		Where("provider = ?", provider).
+		Where("subject = ?", subject).
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+// FindAllByUserID 는 연결한 순서로 가져옵니다.
+func (r *UserIdentityRepositoryImpl) FindAllByUserID(ctx context.Context, userID *entity.ID) ([]*entity.UserIdentity, error) {
+	var identities []*entity.UserIdentity
+	err := r.db.NewSelect().
+		Model(&identities).
+		Where("user_id = ?", userID).
+		Order("created_at ASC", "id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return identities, nil
+}
+
+func (r *UserIdentityRepositoryImpl) Create(ctx context.Context, identity *entity.UserIdentity) error {
+	_, err := r.db.NewInsert().
+		Model(identity).
+		Exec(ctx)
+	return err
+}
+
+// CreateWithUser 는 가입할 때 사용자와 첫 외부 계정을 한 트랜잭션에서 만듭니다.
+// 다른 사용자가 먼저 핸들을 가져갔으면 ErrHandleTaken을 반환합니다.
+func (r *UserIdentityRepositoryImpl) CreateWithUser(ctx context.Context, user *entity.User, identity *entity.UserIdentity) error {
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(user).Exec(ctx); err != nil {
+			return err
+		}
+
+		identity.UserID = user.ID
+		_, err := tx.NewInsert().Model(identity).Exec(ctx)
+		return err
+	})
+	if isUniqueViolation(err, usersHandleKey) {
+		return ErrHandleTaken
+	}
+	return err
+}
+
+// NextExternalUserID 는 An-Account 외의 제공자로 가입하는 사용자의 아이디를 발급합니다.
+// An-Account 아이디와 겹치지 않도록 음수입니다.
+func (r *UserIdentityRepositoryImpl) NextExternalUserID(ctx context.Context) (entity.ID, error) {
+	var id entity.ID
+	err := r.db.NewRaw("SELECT nextval('users_external_id_seq')").Scan(ctx, &id)
+	return id, err
+}
+
+func (r *UserIdentityRepositoryImpl) TouchLastUsed(ctx context.Context, id *entity.ID, lastUsedAt time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*entity.UserIdentity)(nil)).
+		Set("last_used_at = ?", lastUsedAt).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+// DeleteByID 는 사용자의 외부 계정 연결을 끊습니다. 없으면 sql.ErrNoRows, 마지막 계정이면 ErrLastIdentity를 반환합니다.
+// 두 계정을 동시에 끊어 계정이 하나도 남지 않는 일이 없도록 사용자의 계정 행을 잠그고 확인합니다.
+func (r *UserIdentityRepositoryImpl) DeleteByID(ctx context.Context, userID *entity.ID, id *entity.ID) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var ids []entity.ID
+		err := tx.NewSelect().
+			Model((*entity.UserIdentity)(nil)).
+			Column("id").
+			Where("user_id = ?", userID).
+			For("UPDATE").
+			Scan(ctx, &ids)
+		if err != nil {
+			return err
+		}
+
+		found := false
+		for _, identityID := range ids {
+			if identityID == *id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return sql.ErrNoRows
+		}
+		if len(ids) == 1 {
+			return ErrLastIdentity
+		}
+
+		_, err = tx.NewDelete().
+			Model((*entity.UserIdentity)(nil)).
+			Where("id = ?", id).
+			Exec(ctx)
+		return err
+	})
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"analog-be/entity"
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+type ImpersonationAuditRepository interface {
+	Create(ctx context.Context, audit *entity.ImpersonationAudit) error
+	FindAll(ctx context.Context, targetUserID *entity.ID, limit, offset int) ([]*entity.ImpersonationAudit, *int, error)
+}
+
+type ImpersonationAuditRepositoryImpl struct {
+	db bun.IDB
+}
+
+func NewImpersonationAuditRepository(db bun.IDB) ImpersonationAuditRepository {
+	return &ImpersonationAuditRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *ImpersonationAuditRepositoryImpl) Create(ctx context.Context, audit *entity.ImpersonationAudit) error {
+	_, err := r.db.NewInsert().
+		Model(audit).
+		Exec(ctx)
+	return err
+}
+
+// FindAll 은 최신 기록부터 가져옵니다. targetUserID가 nil이 아니면 그 사용자로 본 기록만 가져옵니다.
+func (r *ImpersonationAuditRepositoryImpl) FindAll(ctx context.Context, targetUserID *entity.ID, limit, offset int) ([]*entity.ImpersonationAudit, *int, error) {
+	var audits []*entity.ImpersonationAudit
+
+	q := r.db.NewSelect().
+		Model(&audits).
+		Order("created_at DESC", "id DESC").
+		Limit(limit).
+		Offset(offset)
+	if targetUserID != nil {
+		q = q.Where("target_user_id = ?", targetUserID)
+	}
+
+	count, err := q.ScanAndCount(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return audits, &count, nil
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+// LockRepository 는 여러 인스턴스 중 한 곳에서만 작업하도록 PostgreSQL advisory lock을 잡습니다.
+type LockRepository interface {
+	// TryLock 은 잠금을 잡으면 풀 때 호출할 함수를, 다른 곳에서 이미 잡고 있으면 nil을 반환합니다.
+	TryLock(ctx context.Context, key int64) (func(), error)
+}
+
+type LockRepositoryImpl struct {
+	db *bun.DB
+}
+
+func NewLockRepository(db *bun.DB) LockRepository {
+	return &LockRepositoryImpl{
+		db: db,
+	}
+}
+
+// TryLock 은 트랜잭션 단위 잠금을 사용하므로, 프로세스가 죽거나 연결이 끊겨도 잠금이 남지 않습니다.
+func (r *LockRepositoryImpl) TryLock(ctx context.Context, key int64) (func(), error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var locked bool
+	err = tx.NewSelect().
+		ColumnExpr("pg_try_advisory_xact_lock(?)", key).
+		Scan(ctx, &locked)
+	if err != nil || !locked {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	return func() { _ = tx.Rollback() }, nil
+}
@@ -3,19 +3,32 @@ package repository
 import (
 	"analog-be/entity"
 	"context"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
 )
 
 type LogRepository interface {
 	FindByID(ctx context.Context, id *entity.ID) (*entity.Log, error)
+	FindAllByIDs(ctx context.Context, ids []entity.ID) ([]*entity.Log, error)
 	FindAll(ctx context.Context, limit int, offset int) ([]*entity.Log, *int, error)
 	FindAllByTopicID(ctx context.Context, topicID *entity.ID, limit int, offset int) ([]*entity.Log, *int, error)
 	FindAllByGeneration(ctx context.Context, generation uint16, limit, offset int) ([]*entity.Log, *int, error)
 	Search(ctx context.Context, query string, limit int, offset int) ([]*entity.Log, *int, error)
+	FindAllForDigest(ctx context.Context, since time.Time, topicIDs []entity.ID, generations []uint16, excludeAuthorID entity.ID, limit int) ([]*entity.Log, error)
 	Create(ctx context.Context, log *entity.Log, topicIDs, authorIDs *[]entity.ID) (*entity.Log, error)
 	Update(ctx context.Context, log *entity.Log, topicIDs, authorIDs *[]entity.ID) (*entity.Log, error)
 	Delete(ctx context.Context, id *entity.ID) error
+	FindAuthorRelations(ctx context.Context, id *entity.ID) ([]*entity.LogToUser, error)
+	// FindLegacyAuthorLogIDs 는 작성자 관계를 아직 An-Americano에서 가져오지 않은 예전 로그의 아이디를 찾습니다.
+	FindLegacyAuthorLogIDs(ctx context.Context) ([]entity.ID, error)
+	// ImportAuthorRelations 는 예전 로그의 작성자 관계를 authors로 채우고 가져왔다고 표시합니다.
+	// 권한은 이미 An-Americano에 있으므로 outbox에 기록하지 않고, 없는 사용자는 건너뜁니다.
+	ImportAuthorRelations(ctx context.Context, id *entity.ID, authors []*entity.LogToUser) error
+	UpdateCommentMode(ctx context.Context, id *entity.ID, mode string) error
 }
 
 type LogRepositoryImpl struct {
@@ -46,12 +59,35 @@ func (r *LogRepositoryImpl) FindByID(ctx context.Context, id *entity.ID) (*entit
 	return log, nil
 }
 
+// FindAllByIDs 는 ids의 로그를 주제, 작성자와 함께 찾습니다. 순서는 정해져 있지 않고 없는 아이디는 건너뜁니다.
+func (r *LogRepositoryImpl) FindAllByIDs(ctx context.Context, ids []entity.ID) ([]*entity.Log, error) {
+	var logs []*entity.Log
+	if len(ids) == 0 {
+		return logs, nil
+	}
+
+	err := r.db.NewSelect().
+		Model(&logs).
+		Relation("Topics").
+		Relation("LoggedBy").
+		Where("log.id IN (?)", bun.In(ids)).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
 func (r *LogRepositoryImpl) FindAll(ctx context.Context, limit int, offset int) ([]*entity.Log, *int, error) {
 	var logs []*entity.Log
 
 	count, err := r.db.NewSelect().
 		Model(&logs).
-		Order("created_at DESC").
+		Relation("Topics").
+		Relation("LoggedBy").
+		Order("log.created_at DESC").
 		Limit(limit).
 		Offset(offset).
 		ScanAndCount(ctx)
@@ -68,9 +104,11 @@ func (r *LogRepositoryImpl) FindAllByTopicID(ctx context.Context, topicID *entit
 
 	count, err := r.db.NewSelect().
 		Model(&logs).
+		Relation("Topics").
+		Relation("LoggedBy").
 		Join("JOIN log_to_topics ltt ON ltt.log_id = log.id").
 		Where("ltt.topic_id = ?", topicID).
-		Order("created_at DESC").
+		Order("log.created_at DESC").
 		Limit(limit).
 		Offset(offset).
 		ScanAndCount(ctx)
@@ -87,8 +125,10 @@ func (r *LogRepositoryImpl) FindAllByGeneration(ctx context.Context, generation
 
 	count, err := r.db.NewSelect().
 		Model(&logs).
-		Where("? = ANY(generations)", generation).
-		Order("created_at DESC").
+		Relation("Topics").
+		Relation("LoggedBy").
+		Where("? = ANY(log.generations)", generation).
+		Order("log.created_at DESC").
 		Limit(limit).
 		Offset(offset).
 		ScanAndCount(ctx)
@@ -100,13 +140,51 @@ func (r *LogRepositoryImpl) FindAllByGeneration(ctx context.Context, generation
 	return logs, &count, nil
 }
 
+// FindAllForDigest 는 since 이후에 올라온 로그 중 topicIDs의 주제나 generations의 기수가 하나라도 있는 로그를
+// 최신순으로 찾습니다. 둘 다 비어 있으면 모든 로그를 찾고, excludeAuthorID가 쓴 로그는 뺍니다.
+func (r *LogRepositoryImpl) FindAllForDigest(ctx context.Context, since time.Time, topicIDs []entity.ID, generations []uint16, excludeAuthorID entity.ID, limit int) ([]*entity.Log, error) {
+	var logs []*entity.Log
+
+	q := r.db.NewSelect().
+		Model(&logs).
+		Relation("Topics").
+		Relation("LoggedBy").
+		Where("log.created_at > ?", since).
+		Where("NOT EXISTS (SELECT 1 FROM log_to_users ltu WHERE ltu.log_id = log.id AND ltu.user_id = ?)", excludeAuthorID)
+
+	if len(topicIDs) > 0 || len(generations) > 0 {
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			if len(topicIDs) > 0 {
+				q = q.WhereOr("EXISTS (SELECT 1 FROM log_to_topics ltt WHERE ltt.log_id = log.id AND ltt.topic_id IN (?))", bun.In(topicIDs))
+			}
+			if len(generations) > 0 {
+				q = q.WhereOr("log.generations && ?::smallint[]", pgdialect.Array(generations))
+			}
+			return q
+		})
+	}
+
+	err := q.
+		Order("log.created_at DESC").
+		Limit(limit).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
 func (r *LogRepositoryImpl) Search(ctx context.Context, query string, limit int, offset int) ([]*entity.Log, *int, error) {
 	var logs []*entity.Log
 
 	count, err := r.db.NewSelect().
 		Model(&logs).
-		Where("title ILIKE ? OR content ILIKE ?", "%"+query+"%", "%"+query+"%").
-		Order("created_at DESC").
+		Relation("Topics").
+		Relation("LoggedBy").
+		Where("log.title ILIKE ? OR log.content ILIKE ?", "%"+query+"%", "%"+query+"%").
+		Order("log.created_at DESC").
 		Limit(limit).
 		Offset(offset).
 		ScanAndCount(ctx)
@@ -118,13 +196,15 @@ func (r *LogRepositoryImpl) Search(ctx context.Context, query string, limit int,
 	return logs, &count, nil
 }
 
+// Create 는 로그를 저장합니다. authorIDs의 첫 번째 사용자가 owner, 나머지는 editor가 되며
+// An-Americano 권한 반영은 같은 트랜잭션에서 outbox에 기록됩니다.
 func (r *LogRepositoryImpl) Create(ctx context.Context, log *entity.Log, topicIDs, authorIDs *[]entity.ID) (*entity.Log, error) {
 	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
 		if _, err := tx.NewInsert().Model(log).Exec(ctx); err != nil {
 			return err
 		}
 
-		if len(*topicIDs) > 0 {
+		if topicIDs != nil && len(*topicIDs) > 0 {
 			log2topic := make([]entity.LogToTopic, 0, len(*topicIDs))
 			for _, tid := range *topicIDs {
 				log2topic = append(log2topic, entity.LogToTopic{
@@ -137,17 +217,32 @@ func (r *LogRepositoryImpl) Create(ctx context.Context, log *entity.Log, topicID
 			}
 		}
 
-		if len(*authorIDs) > 0 {
-			log2user := make([]entity.LogToUser, 0, len(*authorIDs))
-			for _, uid := range *authorIDs {
-				log2user = append(log2user, entity.LogToUser{
-					LogID:  log.ID,
-					UserID: uid,
+		if authorIDs != nil && len(*authorIDs) > 0 {
+			log2user := make([]*entity.LogToUser, 0, len(*authorIDs))
+			seen := make(map[entity.ID]bool, len(*authorIDs))
+			for i, uid := range *authorIDs {
+				if seen[uid] {
+					continue
+				}
+				seen[uid] = true
+
+				relation := entity.RelationEditor
+				if i == 0 {
+					relation = entity.RelationOwner
+				}
+				log2user = append(log2user, &entity.LogToUser{
+					LogID:    log.ID,
+					UserID:   uid,
+					Relation: relation,
 				})
 			}
 			if _, err := tx.NewInsert().Model(&log2user).Exec(ctx); err != nil {
 				return err
 			}
+
+			if err := enqueuePermissions(ctx, tx, permissionChanges(entity.PermissionOpWrite, log2user)); err != nil {
+				return err
+			}
 		}
 
 		return nil
@@ -160,19 +255,22 @@ func (r *LogRepositoryImpl) Create(ctx context.Context, log *entity.Log, topicID
 	return log, err
 }
 
+// Update 는 nil이 아닌 연관 관계만 교체합니다. authorIDs가 주어지면 기존 owner는 유지되고
+// 나머지는 editor로 저장되며, 바뀐 권한만 outbox에 기록됩니다.
 func (r *LogRepositoryImpl) Update(ctx context.Context, log *entity.Log, topicIDs, authorIDs *[]entity.ID) (*entity.Log, error) {
 	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
-		if _, err := tx.NewUpdate().Model(log).WherePK().Exec(ctx); err != nil {
+		// 댓글 수는 댓글 저장소가, 댓글 상태는 UpdateCommentMode가 따로 갱신함
+		if _, err := tx.NewUpdate().Model(log).ExcludeColumn("comment_count", "comment_mode", "reaction_counts").WherePK().Exec(ctx); err != nil {
 			return err
 		}
 
 		logID := log.ID
 
-		if _, err := tx.NewDelete().Model((*entity.LogToTopic)(nil)).Where("log_id = ?", logID).Exec(ctx); err != nil {
-			return err
-		}
-
 		if topicIDs != nil {
+			if _, err := tx.NewDelete().Model((*entity.LogToTopic)(nil)).Where("log_id = ?", logID).Exec(ctx); err != nil {
+				return err
+			}
+
 			log2topic := make([]entity.LogToTopic, 0, len(*topicIDs))
 			for _, tid := range *topicIDs {
 				log2topic = append(log2topic, entity.LogToTopic{
@@ -180,24 +278,52 @@ func (r *LogRepositoryImpl) Update(ctx context.Context, log *entity.Log, topicID
 					TopicID: tid,
 				})
 			}
-			if _, err := tx.NewInsert().Model(&log2topic).Exec(ctx); err != nil {
-				return err
+			if len(log2topic) > 0 {
+				if _, err := tx.NewInsert().Model(&log2topic).Exec(ctx); err != nil {
+					return err
+				}
 			}
 		}
 
-		if _, err := tx.NewDelete().Model((*entity.LogToUser)(nil)).Where("log_id = ?", logID).Exec(ctx); err != nil {
-			return err
-		}
-
 		if authorIDs != nil {
-			log2user := make([]entity.LogToUser, 0, len(*authorIDs))
+			var current []*entity.LogToUser
+			if err := tx.NewSelect().Model(&current).Where("log_id = ?", logID).For("UPDATE").Scan(ctx); err != nil {
+				return err
+			}
+
+			next := make([]*entity.LogToUser, 0, len(current)+len(*authorIDs))
+			seen := make(map[entity.ID]bool, len(current)+len(*authorIDs))
+			for _, rel := range current {
+				if rel.Relation == entity.RelationOwner {
+					next = append(next, rel)
+					seen[rel.UserID] = true
+				}
+			}
 			for _, uid := range *authorIDs {
-				log2user = append(log2user, entity.LogToUser{
-					LogID:  logID,
-					UserID: uid,
+				if seen[uid] {
+					continue
+				}
+				seen[uid] = true
+				next = append(next, &entity.LogToUser{
+					LogID:    logID,
+					UserID:   uid,
+					Relation: entity.RelationEditor,
 				})
 			}
-			if _, err := tx.NewInsert().Model(&log2user).Exec(ctx); err != nil {
+
+			removed, added := diffLogToUsers(current, next)
+
+			if _, err := tx.NewDelete().Model((*entity.LogToUser)(nil)).Where("log_id = ?", logID).Exec(ctx); err != nil {
+				return err
+			}
+			if len(next) > 0 {
+				if _, err := tx.NewInsert().Model(&next).Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			changes := append(permissionChanges(entity.PermissionOpDelete, removed), permissionChanges(entity.PermissionOpWrite, added)...)
+			if err := enqueuePermissions(ctx, tx, changes); err != nil {
 				return err
 			}
 		}
@@ -212,9 +338,136 @@ func (r *LogRepositoryImpl) Update(ctx context.Context, log *entity.Log, topicID
 	return log, err
 }
 
+// Delete 는 로그를 삭제하고, 작성자들에게 부여된 An-Americano 권한 회수를 outbox에 기록합니다.
 func (r *LogRepositoryImpl) Delete(ctx context.Context, id *entity.ID) error {
-	_, err := r.db.NewDelete().
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var authors []*entity.LogToUser
+		if err := tx.NewSelect().Model(&authors).Where("log_id = ?", id).Scan(ctx); err != nil {
+			return err
+		}
+
+		if _, err := tx.NewDelete().Model((*entity.Log)(nil)).Where("id = ?", id).Exec(ctx); err != nil {
+			return err
+		}
+
+		return enqueuePermissions(ctx, tx, permissionChanges(entity.PermissionOpDelete, authors))
+	})
+}
+
+func (r *LogRepositoryImpl) FindAuthorRelations(ctx context.Context, id *entity.ID) ([]*entity.LogToUser, error) {
+	var authors []*entity.LogToUser
+
+	err := r.db.NewSelect().
+		Model(&authors).
+		Where("log_id = ?", id).
+		Order("user_id ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return authors, nil
+}
+
+func (r *LogRepositoryImpl) FindLegacyAuthorLogIDs(ctx context.Context) ([]entity.ID, error) {
+	var ids []entity.ID
+
+	err := r.db.NewSelect().
+		Model((*entity.Log)(nil)).
+		Column("id").
+		Where("legacy_authors").
+		Scan(ctx, &ids)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (r *LogRepositoryImpl) ImportAuthorRelations(ctx context.Context, id *entity.ID, authors []*entity.LogToUser) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		userIDs := make([]entity.ID, 0, len(authors))
+		for _, author := range authors {
+			userIDs = append(userIDs, author.UserID)
+		}
+
+		var existing []entity.ID
+		if len(userIDs) > 0 {
+			err := tx.NewSelect().
+				Model((*entity.User)(nil)).
+				Column("id").
+				Where("id IN (?)", bun.In(userIDs)).
+				Scan(ctx, &existing)
+			if err != nil {
+				return err
+			}
+		}
+
+		rels := make([]*entity.LogToUser, 0, len(authors))
+		for _, author := range authors {
+			if slices.Contains(existing, author.UserID) {
+				rels = append(rels, &entity.LogToUser{LogID: *id, UserID: author.UserID, Relation: author.Relation})
+			}
+		}
+		if len(rels) > 0 {
+			_, err := tx.NewInsert().
+				Model(&rels).
+				On("CONFLICT (user_id, log_id) DO UPDATE").
+				Set("relation = EXCLUDED.relation").
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.NewUpdate().
+			Model((*entity.Log)(nil)).
+			Set("legacy_authors = FALSE").
+			Where("id = ?", id).
+			Exec(ctx)
+		return err
+	})
+}
+
+func permissionChanges(op string, rels []*entity.LogToUser) []*entity.PermissionOutbox {
+	entries := make([]*entity.PermissionOutbox, 0, len(rels))
+	for _, rel := range rels {
+		entries = append(entries, entity.NewLogPermissionOutbox(op, rel.LogID, rel.UserID, rel.Relation))
+	}
+	return entries
+}
+
+func diffLogToUsers(current, next []*entity.LogToUser) (removed, added []*entity.LogToUser) {
+	key := func(rel *entity.LogToUser) string {
+		return fmt.Sprintf("%d:%s", rel.UserID, rel.Relation)
+	}
+
+	nextSet := make(map[string]bool, len(next))
+	for _, rel := range next {
+		nextSet[key(rel)] = true
+	}
+	currentSet := make(map[string]bool, len(current))
+	for _, rel := range current {
+		currentSet[key(rel)] = true
+		if !nextSet[key(rel)] {
+			removed = append(removed, rel)
+		}
+	}
+	for _, rel := range next {
+		if !currentSet[key(rel)] {
+			added = append(added, rel)
+		}
+	}
+
+	return removed, added
+}
+
+func (r *LogRepositoryImpl) UpdateCommentMode(ctx context.Context, id *entity.ID, mode string) error {
+	_, err := r.db.NewUpdate().
 		Model((*entity.Log)(nil)).
+		Set("comment_mode = ?", mode).
 		Where("id = ?", id).
 		Exec(ctx)
 	return err
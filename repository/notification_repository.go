@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"analog-be/entity"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type NotificationRepository interface {
+	// Create 는 알림을 저장합니다. 받는 사람에게 같은 GroupKey의 읽지 않은 알림이 있으면 새로 만들지 않고 그 알림에 묶습니다.
+	Create(ctx context.Context, notifications []*entity.Notification) error
+	// FindAllByIDs 는 ids의 알림을 마지막으로 행동한 사람, 로그 제목과 함께 찾습니다.
+	FindAllByIDs(ctx context.Context, ids []entity.ID) ([]*entity.Notification, error)
+	// FindByUserID 는 최근에 묶인 알림부터 마지막으로 행동한 사람, 로그 제목과 함께 찾습니다.
+	FindByUserID(ctx context.Context, userID *entity.ID, unreadOnly bool, limit int, offset int) ([]*entity.Notification, int, error)
+	CountUnread(ctx context.Context, userID *entity.ID) (int, error)
+	// MarkRead 는 userID의 알림 하나를 읽음으로 표시합니다. 없거나 남의 알림이면 sql.ErrNoRows를 반환합니다.
+	MarkRead(ctx context.Context, userID *entity.ID, id *entity.ID) error
+	MarkAllRead(ctx context.Context, userID *entity.ID) (int, error)
+
+	FindPreferences(ctx context.Context, userID *entity.ID) ([]*entity.NotificationPreference, error)
+	FindPreferencesByUserIDs(ctx context.Context, userIDs []entity.ID) ([]*entity.NotificationPreference, error)
+	SavePreferences(ctx context.Context, preferences []*entity.NotificationPreference) error
+	// FindMutedUserIDs 는 userIDs 중 kind 알림을 끈 사용자를 찾습니다.
+	FindMutedUserIDs(ctx context.Context, userIDs []entity.ID, kind string) ([]entity.ID, error)
+}
+
+type NotificationRepositoryImpl struct {
+	db bun.IDB
+}
+
+func NewNotificationRepository(db bun.IDB) NotificationRepository {
+	return &NotificationRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *NotificationRepositoryImpl) Create(ctx context.Context, notifications []*entity.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	_, err := r.db.NewInsert().
+		Model(&notifications).
+		On("CONFLICT (user_id, group_key) WHERE read_at IS NULL DO UPDATE").
+		Set("log_id = EXCLUDED.log_id").
+		Set("comment_id = EXCLUDED.comment_id").
+		Set("actor_id = EXCLUDED.actor_id").
+		Set("actor_count = notification.actor_count + CASE WHEN notification.actor_ids @> EXCLUDED.actor_ids THEN 0 ELSE 1 END").
+		Set("actor_ids = CASE WHEN notification.actor_ids @> EXCLUDED.actor_ids THEN notification.actor_ids ELSE notification.actor_ids || EXCLUDED.actor_ids END").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+	return err
+}
+
+func (r *NotificationRepositoryImpl) FindAllByIDs(ctx context.Context, ids []entity.ID) ([]*entity.Notification, error) {
+	var notifications []*entity.Notification
+	if len(ids) == 0 {
+		return notifications, nil
+	}
+
+	err := r.db.NewSelect().
+		Model(&notifications).
+		Relation("Actor").
+		Relation("Log", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Column("id", "title")
+		}).
+		Where("notification.id IN (?)", bun.In(ids)).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationRepositoryImpl) FindByUserID(ctx context.Context, userID *entity.ID, unreadOnly bool, limit int, offset int) ([]*entity.Notification, int, error) {
+	var notifications []*entity.Notification
+
+	q := r.db.NewSelect().
+		Model(&notifications).
+		Relation("Actor").
+		Relation("Log", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Column("id", "title")
+		}).
+		Where("notification.user_id = ?", userID)
+	if unreadOnly {
+		q = q.Where("notification.read_at IS NULL")
+	}
+
+	count, err := q.
+		Order("notification.updated_at DESC", "notification.id DESC").
+		Limit(limit).
+		Offset(offset).
+		ScanAndCount(ctx)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return notifications, count, nil
+}
+
+func (r *NotificationRepositoryImpl) CountUnread(ctx context.Context, userID *entity.ID) (int, error) {
+	return r.db.NewSelect().
+		Model((*entity.Notification)(nil)).
+		Where("user_id = ?", userID).
+		Where("read_at IS NULL").
+		Count(ctx)
+}
+
+func (r *NotificationRepositoryImpl) MarkRead(ctx context.Context, userID *entity.ID, id *entity.ID) error {
+	res, err := r.db.NewUpdate().
+		Model((*entity.Notification)(nil)).
+		Set("read_at = ?", time.Now().UTC()).
+		Where("id = ?", id).
+		Where("user_id = ?", userID).
+		Where("read_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil || n > 0 {
+		return err
+	}
+
+	// 이미 읽은 알림은 오류가 아님
+	exists, err := r.db.NewSelect().
+		Model((*entity.Notification)(nil)).
+		Where("id = ?", id).
+		Where("user_id = ?", userID).
+		Exists(ctx)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *NotificationRepositoryImpl) MarkAllRead(ctx context.Context, userID *entity.ID) (int, error) {
+	res, err := r.db.NewUpdate().
+		Model((*entity.Notification)(nil)).
+		Set("read_at = ?", time.Now().UTC()).
+		Where("user_id = ?", userID).
+		Where("read_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+func (r *NotificationRepositoryImpl) FindPreferences(ctx context.Context, userID *entity.ID) ([]*entity.NotificationPreference, error) {
+	var preferences []*entity.NotificationPreference
+
+	err := r.db.NewSelect().
+		Model(&preferences).
+		Where("user_id = ?", userID).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+func (r *NotificationRepositoryImpl) FindPreferencesByUserIDs(ctx context.Context, userIDs []entity.ID) ([]*entity.NotificationPreference, error) {
+	var preferences []*entity.NotificationPreference
+	if len(userIDs) == 0 {
+		return preferences, nil
+	}
+
+	err := r.db.NewSelect().
+		Model(&preferences).
+		Where("user_id IN (?)", bun.In(userIDs)).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+func (r *NotificationRepositoryImpl) SavePreferences(ctx context.Context, preferences []*entity.NotificationPreference) error {
+	if len(preferences) == 0 {
+		return nil
+	}
+
+	_, err := r.db.NewInsert().
+		Model(&preferences).
+		On("CONFLICT (user_id, type) DO UPDATE").
+		Set("in_app = EXCLUDED.in_app").
+		Set("email = EXCLUDED.email").
+		Exec(ctx)
+	return err
+}
+
+func (r *NotificationRepositoryImpl) FindMutedUserIDs(ctx context.Context, userIDs []entity.ID, kind string) ([]entity.ID, error) {
+	var muted []entity.ID
+	if len(userIDs) == 0 {
+		return muted, nil
+	}
+
+	err := r.db.NewSelect().
+		Model((*entity.NotificationPreference)(nil)).
+		Column("user_id").
+		Where("user_id IN (?)", bun.In(userIDs)).
+		Where("type = ?", kind).
+		Where("NOT in_app").
+		Scan(ctx, &muted)
+
+	return muted, err
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"analog-be/entity"
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type PermissionOutboxRepository interface {
+	Enqueue(ctx context.Context, entries []*entity.PermissionOutbox) error
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*entity.PermissionOutbox, error)
+	MarkDone(ctx context.Context, id entity.ID) error
+	MarkRetry(ctx context.Context, id entity.ID, lastError string, nextAttemptAt time.Time) error
+	MarkFailed(ctx context.Context, id entity.ID, lastError string) error
+	DeleteDoneBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type PermissionOutboxRepositoryImpl struct {
+	db bun.IDB
+}
+
+func NewPermissionOutboxRepository(db bun.IDB) PermissionOutboxRepository {
+	return &PermissionOutboxRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *PermissionOutboxRepositoryImpl) Enqueue(ctx context.Context, entries []*entity.PermissionOutbox) error {
+	return enqueuePermissions(ctx, r.db, entries)
+}
+
+// Claim 은 처리할 차례가 된 outbox 항목을 lease 동안 선점합니다.
+// 같은 권한 튜플에 대해 먼저 쌓인 항목이 남아 있으면 건너뛰어, 쓰기/삭제 순서가 뒤바뀌지 않도록 합니다.
+func (r *PermissionOutboxRepositoryImpl) Claim(ctx context.Context, limit int, lease time.Duration) ([]*entity.PermissionOutbox, error) {
+	now := time.Now().UTC()
+
+	candidates := r.db.NewSelect().
+		Model((*entity.PermissionOutbox)(nil)).
+		Column("id").
+		Where("status = ?", entity.PermissionOutboxPending).
+		Where("next_attempt_at <= ?", now).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM permission_outbox AS prev
+			WHERE prev.status = ?
+			  AND prev.id < permission_outbox.id
+			  AND prev.namespace = permission_outbox.namespace
+			  AND prev.object_id = permission_outbox.object_id
+			  AND prev.relation = permission_outbox.relation
+			  AND prev.user_id = permission_outbox.user_id
+		)`, entity.PermissionOutboxPending).
+		Order("id ASC").
+		Limit(limit).
+		For("UPDATE SKIP LOCKED")
+
+	var entries []*entity.PermissionOutbox
+	_, err := r.db.NewUpdate().
+		Model((*entity.PermissionOutbox)(nil)).
+		Set("next_attempt_at = ?", now.Add(lease)).
+		Set("attempts = attempts + 1").
+		Where("id IN (?)", candidates).
+		Returning("*").
+		Exec(ctx, &entries)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (r *PermissionOutboxRepositoryImpl) MarkDone(ctx context.Context, id entity.ID) error {
+	_, err := r.db.NewUpdate().
+		Model((*entity.PermissionOutbox)(nil)).
+		Set("status = ?", entity.PermissionOutboxDone).
+		Set("processed_at = ?", time.Now().UTC()).
+		Set("last_error = NULL").
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+func (r *PermissionOutboxRepositoryImpl) MarkRetry(ctx context.Context, id entity.ID, lastError string, nextAttemptAt time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*entity.PermissionOutbox)(nil)).
+		Set("last_error = ?", lastError).
+		Set("next_attempt_at = ?", nextAttemptAt).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+func (r *PermissionOutboxRepositoryImpl) MarkFailed(ctx context.Context, id entity.ID, lastError string) error {
+	_, err := r.db.NewUpdate().
+		Model((*entity.PermissionOutbox)(nil)).
+		Set("status = ?", entity.PermissionOutboxFailed).
+		Set("last_error = ?", lastError).
+		Set("processed_at = ?", time.Now().UTC()).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+// DeleteDoneBefore 는 before 이전에 반영이 끝난 항목을 지웁니다. 실패한 항목은 확인할 수 있도록 남겨 둡니다.
+func (r *PermissionOutboxRepositoryImpl) DeleteDoneBefore(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.db.NewDelete().
+		Model((*entity.PermissionOutbox)(nil)).
+		Where("status = ?", entity.PermissionOutboxDone).
+		Where("processed_at < ?", before).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// enqueuePermissions 는 다른 레포지토리의 트랜잭션 안에서도 outbox를 기록할 수 있도록 bun.IDB를 받습니다.
+func enqueuePermissions(ctx context.Context, db bun.IDB, entries []*entity.PermissionOutbox) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	_, err := db.NewInsert().
+		Model(&entries).
+		Exec(ctx)
+	return err
+}
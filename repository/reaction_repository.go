@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"analog-be/entity"
+	"context"
+	"database/sql"
+
+	"github.com/uptrace/bun"
+)
+
+type ReactionRepository interface {
+	// AddLogReaction 은 반응을 달고 로그의 종류별 개수와 새로 달았는지를 돌려줍니다. 이미 단 반응이면 그대로 둡니다.
+	AddLogReaction(ctx context.Context, reaction *entity.LogReaction) (map[string]int, bool, error)
+	RemoveLogReaction(ctx context.Context, reaction *entity.LogReaction) (map[string]int, error)
+	AddCommentReaction(ctx context.Context, reaction *entity.CommentReaction) (map[string]int, bool, error)
+	RemoveCommentReaction(ctx context.Context, reaction *entity.CommentReaction) (map[string]int, error)
+	// FindLogReactionsByUser 는 logIDs 중 userID가 단 반응을 로그별로 찾습니다.
+	FindLogReactionsByUser(ctx context.Context, userID *entity.ID, logIDs []entity.ID) (map[entity.ID][]string, error)
+	FindCommentReactionsByUser(ctx context.Context, userID *entity.ID, commentIDs []entity.ID) (map[entity.ID][]string, error)
+}
+
+type ReactionRepositoryImpl struct {
+	db bun.IDB
+}
+
+func NewReactionRepository(db bun.IDB) ReactionRepository {
+	return &ReactionRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *ReactionRepositoryImpl) AddLogReaction(ctx context.Context, reaction *entity.LogReaction) (map[string]int, bool, error) {
+	log := &entity.Log{ID: reaction.LogID}
+
+	var added bool
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewInsert().Model(reaction).On("CONFLICT DO NOTHING").Exec(ctx)
+		if err != nil {
+			return err
+		}
+		added, err = updateReactionCounts(ctx, tx, log, res, reaction.Kind, 1)
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return log.ReactionCounts, added, nil
+}
+
+func (r *ReactionRepositoryImpl) RemoveLogReaction(ctx context.Context, reaction *entity.LogReaction) (map[string]int, error) {
+	log := &entity.Log{ID: reaction.LogID}
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewDelete().Model(reaction).WherePK().Exec(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = updateReactionCounts(ctx, tx, log, res, reaction.Kind, -1)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return log.ReactionCounts, nil
+}
+
+func (r *ReactionRepositoryImpl) AddCommentReaction(ctx context.Context, reaction *entity.CommentReaction) (map[string]int, bool, error) {
+	comment := &entity.Comment{ID: reaction.CommentID}
+
+	var added bool
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewInsert().Model(reaction).On("CONFLICT DO NOTHING").Exec(ctx)
+		if err != nil {
+			return err
+		}
+		added, err = updateReactionCounts(ctx, tx, comment, res, reaction.Kind, 1)
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return comment.ReactionCounts, added, nil
+}
+
+func (r *ReactionRepositoryImpl) RemoveCommentReaction(ctx context.Context, reaction *entity.CommentReaction) (map[string]int, error) {
+	comment := &entity.Comment{ID: reaction.CommentID}
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewDelete().Model(reaction).WherePK().Exec(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = updateReactionCounts(ctx, tx, comment, res, reaction.Kind, -1)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return comment.ReactionCounts, nil
+}
+
+// reactionCountExpr 는 reaction_counts의 kind 개수에 delta를 더합니다. 0이 되면 키를 뺍니다.
+const reactionCountExpr = `CASE WHEN COALESCE((reaction_counts->>?0)::int, 0) + ?1 > 0
+	THEN jsonb_set(reaction_counts, ARRAY[?0], to_jsonb(COALESCE((reaction_counts->>?0)::int, 0) + ?1))
+	ELSE reaction_counts - ?0 END`
+
+// updateReactionCounts 는 반응 행이 실제로 추가되거나 지워졌을 때만 model(로그나 댓글)의 개수를 고치고,
+// 바뀐 개수를 model에 읽어 옵니다. 반응 행이 바뀌었는지를 돌려줍니다.
+func updateReactionCounts(ctx context.Context, tx bun.Tx, model any, res sql.Result, kind string, delta int) (bool, error) {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 {
+		return false, tx.NewSelect().Model(model).Column("reaction_counts").WherePK().Scan(ctx)
+	}
+
+	q := tx.NewUpdate().
+		Model(model).
+		Set("reaction_counts = "+reactionCountExpr, kind, delta)
+	if _, ok := model.(*entity.Comment); ok {
+		q = q.Set("reaction_count = reaction_count + ?", delta)
+	}
+
+	return true, q.WherePK().Returning("reaction_counts").Scan(ctx)
+}
+
+func (r *ReactionRepositoryImpl) FindLogReactionsByUser(ctx context.Context, userID *entity.ID, logIDs []entity.ID) (map[entity.ID][]string, error) {
+	mine := map[entity.ID][]string{}
+	if len(logIDs) == 0 {
+		return mine, nil
+	}
+
+	var reactions []*entity.LogReaction
+	err := r.db.NewSelect().
+		Model(&reactions).
+		Where("user_id = ?", userID).
+		Where("log_id IN (?)", bun.In(logIDs)).
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, reaction := range reactions {
+		mine[reaction.LogID] = append(mine[reaction.LogID], reaction.Kind)
+	}
+	return mine, nil
+}
+
+func (r *ReactionRepositoryImpl) FindCommentReactionsByUser(ctx context.Context, userID *entity.ID, commentIDs []entity.ID) (map[entity.ID][]string, error) {
+	mine := map[entity.ID][]string{}
+	if len(commentIDs) == 0 {
+		return mine, nil
+	}
+
+	var reactions []*entity.CommentReaction
+	err := r.db.NewSelect().
+		Model(&reactions).
+		Where("user_id = ?", userID).
+		Where("comment_id IN (?)", bun.In(commentIDs)).
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, reaction := range reactions {
+		mine[reaction.CommentID] = append(mine[reaction.CommentID], reaction.Kind)
+	}
+	return mine, nil
+}
+
+// recountReactions 는 반응이 함께 지워진 로그와 댓글(예: 사용자 탈퇴)의 개수를 반응 행으로 다시 셉니다.
+func recountReactions(ctx context.Context, tx bun.Tx, logIDs []entity.ID, commentIDs []entity.ID) error {
+	if len(logIDs) > 0 {
+		_, err := tx.NewUpdate().
+			Model((*entity.Log)(nil)).
+			Set(`reaction_counts = COALESCE((SELECT jsonb_object_agg(r.kind, r.n) FROM (
+				SELECT kind, COUNT(*) AS n FROM log_reactions WHERE log_id = log.id GROUP BY kind) AS r), '{}')`).
+			Where("id IN (?)", bun.In(logIDs)).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(commentIDs) > 0 {
+		_, err := tx.NewUpdate().
+			Model((*entity.Comment)(nil)).
+			Set(`reaction_counts = COALESCE((SELECT jsonb_object_agg(r.kind, r.n) FROM (
+				SELECT kind, COUNT(*) AS n FROM comment_reactions WHERE comment_id = comment.id GROUP BY kind) AS r), '{}')`).
+			Set("reaction_count = (SELECT COUNT(*) FROM comment_reactions WHERE comment_id = comment.id)").
+			Where("id IN (?)", bun.In(commentIDs)).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
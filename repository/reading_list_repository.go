@@ -0,0 +1,313 @@
+package repository
+
+import (
+	"analog-be/entity"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+)
+
+type ReadingListRepository interface {
+	FindByID(ctx context.Context, id *entity.ID) (*entity.ReadingList, error)
+	// FindByUserID 는 사용자의 목록을 순서대로 찾습니다. publicOnly면 공개한 목록만 찾습니다.
+	FindByUserID(ctx context.Context, userID *entity.ID, publicOnly bool) ([]*entity.ReadingList, error)
+	// Create 는 목록을 사용자의 마지막 목록 뒤에 만듭니다.
+	// 사용자에게 이미 maxLists개의 목록이 있으면 만들지 않고 ErrReadingListLimit을 반환합니다.
+	Create(ctx context.Context, list *entity.ReadingList, maxLists int) error
+	Update(ctx context.Context, list *entity.ReadingList) error
+	// Move 는 목록을 사용자의 목록 중 position번째로 옮깁니다. 범위를 벗어나면 맨 앞이나 맨 뒤로 옮깁니다.
+	Move(ctx context.Context, list *entity.ReadingList, position int) error
+	Delete(ctx context.Context, list *entity.ReadingList) error
+
+	FindItems(ctx context.Context, listID *entity.ID, limit int, offset int) ([]*entity.ReadingListItem, int, error)
+	FindItem(ctx context.Context, listID *entity.ID, logID *entity.ID) (*entity.ReadingListItem, error)
+	// PutItem 은 로그를 목록 주인의 저장 목록에 넣고 목록에 담습니다. 이미 담긴 로그면 옮기기만 합니다.
+	// position이 nil이면 새 항목은 맨 뒤에 두고 이미 담긴 항목은 그대로 둡니다.
+	// 목록에 이미 maxItems개가 담겨 있으면 새 로그는 담지 않고 ErrReadingListFull을 반환합니다.
+	PutItem(ctx context.Context, list *entity.ReadingList, logID *entity.ID, position *int, maxItems int) (*entity.ReadingListItem, error)
+	RemoveItem(ctx context.Context, listID *entity.ID, logID *entity.ID) error
+}
+
+type ReadingListRepositoryImpl struct {
+	db bun.IDB
+}
+
+func NewReadingListRepository(db bun.IDB) ReadingListRepository {
+	return &ReadingListRepositoryImpl{
+		db: db,
+	}
+}
+
+// itemCountExpr 는 목록에 담긴 로그 수를 item_count로 함께 읽습니다.
+const itemCountExpr = "(SELECT COUNT(*) FROM reading_list_items AS item WHERE item.list_id = reading_list.id) AS item_count"
+
+func (r *ReadingListRepositoryImpl) FindByID(ctx context.Context, id *entity.ID) (*entity.ReadingList, error) {
+	list := new(entity.ReadingList)
+
+	err := r.db.NewSelect().
+		Model(list).
+		ColumnExpr("reading_list.*").
+		ColumnExpr(itemCountExpr).
+		Where("reading_list.id = ?", id).
+		Limit(1).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (r *ReadingListRepositoryImpl) FindByUserID(ctx context.Context, userID *entity.ID, publicOnly bool) ([]*entity.ReadingList, error) {
+	var lists []*entity.ReadingList
+
+	q := r.db.NewSelect().
+		Model(&lists).
+		ColumnExpr("reading_list.*").
+		ColumnExpr(itemCountExpr).
+		Where("reading_list.user_id = ?", userID)
+	if publicOnly {
+		q = q.Where("reading_list.is_public")
+	}
+
+	err := q.Order("reading_list.position ASC", "reading_list.id ASC").Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return lists, nil
+}
+
+func (r *ReadingListRepositoryImpl) Create(ctx context.Context, list *entity.ReadingList, maxLists int) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		ids, err := lockListOrder(ctx, tx, list.UserID)
+		if err != nil {
+			return err
+		}
+
+		// 잠근 뒤에 센 개수로 확인해야 동시에 만들어도 한도를 넘지 않음
+		if len(ids) >= maxLists {
+			return ErrReadingListLimit
+		}
+
+		list.Position = len(ids)
+		_, err = tx.NewInsert().Model(list).Exec(ctx)
+		if isUniqueViolation(err, readingListsNameKey) {
+			return ErrReadingListNameTaken
+		}
+		return err
+	})
+}
+
+// Update 는 이름, 설명, 공개 여부를 저장합니다. 순서는 Move로 바꿉니다.
+func (r *ReadingListRepositoryImpl) Update(ctx context.Context, list *entity.ReadingList) error {
+	list.UpdatedAt = time.Now().UTC()
+
+	_, err := r.db.NewUpdate().
+		Model(list).
+		Column("name", "description", "is_public", "updated_at").
+		WherePK().
+		Exec(ctx)
+	if isUniqueViolation(err, readingListsNameKey) {
+		return ErrReadingListNameTaken
+	}
+	return err
+}
+
+func (r *ReadingListRepositoryImpl) Move(ctx context.Context, list *entity.ReadingList, position int) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		ids, err := lockListOrder(ctx, tx, list.UserID)
+		if err != nil {
+			return err
+		}
+
+		ids, list.Position = moveID(ids, list.ID, position)
+		return renumberLists(ctx, tx, ids)
+	})
+}
+
+// renumberLists 는 ids 순서대로 목록의 순서를 0부터 다시 매깁니다.
+func renumberLists(ctx context.Context, tx bun.Tx, ids []entity.ID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := tx.NewUpdate().
+		Model((*entity.ReadingList)(nil)).
+		TableExpr("unnest(?::bigint[]) WITH ORDINALITY AS v(id, ord)", pgdialect.Array(ids)).
+		Set("position = v.ord - 1").
+		Where("reading_list.id = v.id").
+		Exec(ctx)
+	return err
+}
+
+// lockListOrder 는 목록을 만들거나 옮기는 동안 사용자의 목록을 잠그고 순서대로 아이디를 돌려줍니다.
+// 목록 행만 잠그면 동시에 만드는 목록은 보이지 않으므로 사용자 행을 먼저 잠급니다.
+func lockListOrder(ctx context.Context, tx bun.Tx, userID entity.ID) ([]entity.ID, error) {
+	if err := lockUser(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	var ids []entity.ID
+
+	err := tx.NewSelect().
+		Model((*entity.ReadingList)(nil)).
+		Column("id").
+		Where("user_id = ?", userID).
+		Order("position ASC", "id ASC").
+		For("UPDATE").
+		Scan(ctx, &ids)
+
+	return ids, err
+}
+
+// Delete 는 목록을 지우고 남은 목록의 순서를 다시 매깁니다.
+func (r *ReadingListRepositoryImpl) Delete(ctx context.Context, list *entity.ReadingList) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		ids, err := lockListOrder(ctx, tx, list.UserID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.NewDelete().Model((*entity.ReadingList)(nil)).Where("id = ?", list.ID).Exec(ctx); err != nil {
+			return err
+		}
+
+		ids = slices.DeleteFunc(ids, func(id entity.ID) bool { return id == list.ID })
+		return renumberLists(ctx, tx, ids)
+	})
+}
+
+func (r *ReadingListRepositoryImpl) FindItems(ctx context.Context, listID *entity.ID, limit int, offset int) ([]*entity.ReadingListItem, int, error) {
+	var items []*entity.ReadingListItem
+
+	count, err := r.db.NewSelect().
+		Model(&items).
+		Where("list_id = ?", listID).
+		Order("position ASC", "created_at ASC").
+		Limit(limit).
+		Offset(offset).
+		ScanAndCount(ctx)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 로그가 지워져 생긴 틈이 보이지 않도록 실제 위치를 돌려줌
+	for i, item := range items {
+		item.Position = offset + i
+	}
+
+	return items, count, nil
+}
+
+func (r *ReadingListRepositoryImpl) FindItem(ctx context.Context, listID *entity.ID, logID *entity.ID) (*entity.ReadingListItem, error) {
+	item := new(entity.ReadingListItem)
+
+	err := r.db.NewSelect().
+		Model(item).
+		Where("list_id = ?", listID).
+		Where("log_id = ?", logID).
+		Limit(1).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (r *ReadingListRepositoryImpl) PutItem(ctx context.Context, list *entity.ReadingList, logID *entity.ID, position *int, maxItems int) (*entity.ReadingListItem, error) {
+	item := &entity.ReadingListItem{ListID: list.ID, LogID: *logID}
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// 같은 목록을 동시에 고치지 않도록 목록 행을 잠금
+		_, err := tx.NewSelect().
+			Model((*entity.ReadingList)(nil)).
+			Column("id").
+			Where("id = ?", list.ID).
+			For("UPDATE").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		var ids []entity.ID
+		err = tx.NewSelect().
+			Model((*entity.ReadingListItem)(nil)).
+			Column("log_id").
+			Where("list_id = ?", list.ID).
+			Order("position ASC", "created_at ASC").
+			Scan(ctx, &ids)
+		if err != nil {
+			return err
+		}
+
+		// 잠근 뒤에 센 개수로 확인해야 동시에 담아도 한도를 넘지 않음
+		exists := slices.Contains(ids, *logID)
+		if !exists && len(ids) >= maxItems {
+			return ErrReadingListFull
+		}
+
+		bookmark := &entity.Bookmark{UserID: list.UserID, LogID: *logID}
+		if _, err := tx.NewInsert().Model(bookmark).On("CONFLICT DO NOTHING").Exec(ctx); err != nil {
+			return err
+		}
+
+		if !exists {
+			if _, err := tx.NewInsert().Model(item).Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case position != nil:
+			ids, item.Position = moveID(ids, *logID, *position)
+		case !exists:
+			ids, item.Position = moveID(ids, *logID, len(ids))
+		default:
+			item.Position = slices.Index(ids, *logID)
+			return tx.NewSelect().Model(item).Column("created_at").WherePK().Scan(ctx)
+		}
+
+		_, err = tx.NewUpdate().
+			Model((*entity.ReadingListItem)(nil)).
+			TableExpr("unnest(?::bigint[]) WITH ORDINALITY AS v(log_id, ord)", pgdialect.Array(ids)).
+			Set("position = v.ord - 1").
+			Where("item.list_id = ?", list.ID).
+			Where("item.log_id = v.log_id").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		return tx.NewSelect().Model(item).Column("created_at").WherePK().Scan(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (r *ReadingListRepositoryImpl) RemoveItem(ctx context.Context, listID *entity.ID, logID *entity.ID) error {
+	_, err := r.db.NewDelete().
+		Model((*entity.ReadingListItem)(nil)).
+		Where("list_id = ?", listID).
+		Where("log_id = ?", logID).
+		Exec(ctx)
+	return err
+}
+
+// moveID 는 ids에서 id를 빼고 position번째(범위를 벗어나면 맨 앞이나 맨 뒤)에 넣은 순서와 실제 위치를 돌려줍니다.
+// 순서를 새로 매기므로 지워진 항목 때문에 생긴 틈은 다음에 옮길 때 메워집니다.
+func moveID(ids []entity.ID, id entity.ID, position int) ([]entity.ID, int) {
+	ids = slices.DeleteFunc(slices.Clone(ids), func(v entity.ID) bool { return v == id })
+	position = max(0, min(position, len(ids)))
+	return slices.Insert(ids, position, id), position
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"analog-be/entity"
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// RealtimeNotifyChannel 은 새 실시간 이벤트의 아이디를 알리는 Postgres NOTIFY 채널입니다.
+const RealtimeNotifyChannel = "realtime_events"
+
+// realtimeEventLockKey 는 이벤트를 저장하는 동안 잡는 advisory lock 키입니다. ("realtime"의 ASCII)
+const realtimeEventLockKey int64 = 0x7265616c74696d65
+
+type RealtimeEventRepository interface {
+	// Create 는 이벤트를 저장하고, 커밋되면 모든 인스턴스에 NOTIFY로 아이디를 알립니다.
+	// 아이디 순서와 커밋 순서가 같으므로 어떤 아이디가 보이면 그보다 작은 아이디도 모두 보입니다.
+	Create(ctx context.Context, events []*entity.RealtimeEvent) error
+	FindAllByIDs(ctx context.Context, ids []entity.ID) ([]*entity.RealtimeEvent, error)
+	// FindAfter 는 afterID 이후의 이벤트를 아이디 순으로 limit개까지 찾습니다. channels가 nil이면 모든 채널에서 찾습니다.
+	FindAfter(ctx context.Context, afterID entity.ID, channels []string, limit int) ([]*entity.RealtimeEvent, error)
+	LastID(ctx context.Context) (entity.ID, error)
+	Exists(ctx context.Context, id entity.ID) (bool, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type RealtimeEventRepositoryImpl struct {
+	db bun.IDB
+}
+
+func NewRealtimeEventRepository(db bun.IDB) RealtimeEventRepository {
+	return &RealtimeEventRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *RealtimeEventRepositoryImpl) Create(ctx context.Context, events []*entity.RealtimeEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// 아이디는 넣을 때 정해지지만 보이는 건 커밋할 때라서, 동시에 저장하면 큰 아이디가 먼저 보일 수 있음
+		// 그러면 그 아이디 이후를 읽는 Last-Event-ID 재연결과 리스너 따라잡기가 작은 아이디를 놓치므로 한 번에 하나씩 저장함
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(?)", realtimeEventLockKey); err != nil {
+			return err
+		}
+
+		if _, err := tx.NewInsert().Model(&events).Returning("id, created_at").Exec(ctx); err != nil {
+			return err
+		}
+
+		// NOTIFY는 커밋될 때 전달되므로 리스너가 아이디를 받으면 이벤트를 읽을 수 있음
+		for _, event := range events {
+			if _, err := tx.ExecContext(ctx, "SELECT pg_notify(?, ?)", RealtimeNotifyChannel, strconv.FormatInt(event.ID, 10)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *RealtimeEventRepositoryImpl) FindAllByIDs(ctx context.Context, ids []entity.ID) ([]*entity.RealtimeEvent, error) {
+	var events []*entity.RealtimeEvent
+	if len(ids) == 0 {
+		return events, nil
+	}
+
+	err := r.db.NewSelect().
+		Model(&events).
+		Where("id IN (?)", bun.In(ids)).
+		Order("id ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *RealtimeEventRepositoryImpl) FindAfter(ctx context.Context, afterID entity.ID, channels []string, limit int) ([]*entity.RealtimeEvent, error) {
+	var events []*entity.RealtimeEvent
+
+	q := r.db.NewSelect().
+		Model(&events).
+		Where("id > ?", afterID)
+	if channels != nil {
+		if len(channels) == 0 {
+			return events, nil
+		}
+		q = q.Where("channel IN (?)", bun.In(channels))
+	}
+
+	err := q.
+		Order("id ASC").
+		Limit(limit).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *RealtimeEventRepositoryImpl) LastID(ctx context.Context) (entity.ID, error) {
+	var id entity.ID
+
+	err := r.db.NewSelect().
+		Model((*entity.RealtimeEvent)(nil)).
+		ColumnExpr("COALESCE(MAX(id), 0)").
+		Scan(ctx, &id)
+
+	return id, err
+}
+
+func (r *RealtimeEventRepositoryImpl) Exists(ctx context.Context, id entity.ID) (bool, error) {
+	return r.db.NewSelect().
+		Model((*entity.RealtimeEvent)(nil)).
+		Where("id = ?", id).
+		Exists(ctx)
+}
+
+// DeleteBefore 는 before 이전에 만든 이벤트를 지웁니다. 그보다 오래 끊겼던 클라이언트는 처음부터 다시 불러와야 합니다.
+func (r *RealtimeEventRepositoryImpl) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.db.NewDelete().
+		Model((*entity.RealtimeEvent)(nil)).
+		Where("created_at < ?", before).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -2,7 +2,9 @@ package repository
 
 import (
 	"analog-be/entity"
+	"analog-be/pkg"
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/uptrace/bun"
@@ -10,9 +12,9 @@ import (
 
 type OAuthStateRepository interface {
 	Create(ctx context.Context, state *entity.OAuthState) error
-	FindByState(ctx context.Context, state string) (*entity.OAuthState, error)
-	Delete(ctx context.Context, state string) error
-	DeleteExpired(ctx context.Context) error
+	// Consume 은 state를 지우고 지운 항목을 반환합니다. 없거나 이미 쓴 state면 sql.ErrNoRows를 반환합니다.
+	Consume(ctx context.Context, state string) (*entity.OAuthState, error)
+	DeleteExpired(ctx context.Context) (int64, error)
 }
 
 type OAuthStateRepositoryImpl struct {
@@ -26,18 +28,21 @@ func NewOAuthStateRepository(db bun.IDB) OAuthStateRepository {
 }
 
 func (r *OAuthStateRepositoryImpl) Create(ctx context.Context, state *entity.OAuthState) error {
+	state.StateHash = pkg.HashToken(state.State)
+
 	_, err := r.db.NewInsert().
 		Model(state).
 		Exec(ctx)
 	return err
 }
 
-func (r *OAuthStateRepositoryImpl) FindByState(ctx context.Context, state string) (*entity.OAuthState, error) {
+// Consume 은 조회와 삭제를 한 문장으로 처리하므로, 같은 state로 동시에 콜백이 와도 한 요청만 state를 얻습니다.
+func (r *OAuthStateRepositoryImpl) Consume(ctx context.Context, state string) (*entity.OAuthState, error) {
 	oauthState := new(entity.OAuthState)
-	err := r.db.NewSelect().
+	err := r.db.NewDelete().
 		Model(oauthState).
-		Where("state = ?", state).
-		Limit(1).
+		Where("state_hash = ?", pkg.HashToken(state)).
+		Returning("*").
 		Scan(ctx)
 	if err != nil {
 		return nil, err
@@ -45,41 +50,50 @@ func (r *OAuthStateRepositoryImpl) FindByState(ctx context.Context, state string
 	return oauthState, nil
 }
 
-func (r *OAuthStateRepositoryImpl) Delete(ctx context.Context, state string) error {
-	_, err := r.db.NewDelete().
+// DeleteExpired 는 만료된 항목을 지우고 지운 개수를 반환합니다.
+func (r *OAuthStateRepositoryImpl) DeleteExpired(ctx context.Context) (int64, error) {
+	res, err := r.db.NewDelete().
 		Model((*entity.OAuthState)(nil)).
-		Where("state = ?", state).
-		Exec(ctx)
-	return err
-}
-
-func (r *OAuthStateRepositoryImpl) DeleteExpired(ctx context.Context) error {
-	_, err := r.db.NewDelete().
-		Model((*entity.OAuthState)(nil)).
-		Where("expires_at < ?", time.Now()).
+		Where("expires_at < ?", time.Now().UTC()).
 		Exec(ctx)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
 }
 
+// SessionRepository 의 토큰 인자는 모두 원본 토큰이며, 디비에는 pkg.HashToken으로 해시한 값만 저장하고 조회합니다.
 type SessionRepository interface {
 	Create(ctx context.Context, session *entity.Session) error
 	FindByToken(ctx context.Context, token string) (*entity.Session, error)
+	FindByID(ctx context.Context, id *entity.ID) (*entity.Session, error)
+	FindAllByUserID(ctx context.Context, userID *entity.ID) ([]*entity.Session, error)
+	Touch(ctx context.Context, id *entity.ID, lastSeenAt time.Time, expiresAt time.Time) error
+	Rotate(ctx context.Context, id *entity.ID, oldToken string, newToken string, lastSeenAt time.Time, expiresAt time.Time) error
 	Delete(ctx context.Context, token string) error
-	DeleteByUserID(ctx context.Context, userID int64) error
-	DeleteExpired(ctx context.Context) error
+	DeleteByID(ctx context.Context, id *entity.ID) error
+	DeleteByUserID(ctx context.Context, userID int64, exceptTokens ...string) error
+	DeleteExpired(ctx context.Context) (int64, error)
 }
 
 type SessionRepositoryImpl struct {
 	db bun.IDB
 }
 
-func NewSessionRepository(db bun.IDB) SessionRepository {
-	return &SessionRepositoryImpl{
+func NewSessionRepository(db bun.IDB, cache *pkg.SessionCache) SessionRepository {
+	repo := &SessionRepositoryImpl{
 		db: db,
 	}
+
+	if !cache.Enabled() {
+		return repo
+	}
+	return &cachedSessionRepository{SessionRepository: repo, cache: cache}
 }
 
 func (r *SessionRepositoryImpl) Create(ctx context.Context, session *entity.Session) error {
+	session.TokenHash = pkg.HashToken(session.SessionToken)
+
 	_, err := r.db.NewInsert().
 		Model(session).
 		Exec(ctx)
@@ -90,7 +104,7 @@ func (r *SessionRepositoryImpl) FindByToken(ctx context.Context, token string) (
 	session := new(entity.Session)
 	err := r.db.NewSelect().
 		Model(session).
-		Where("session_token = ?", token).
+		Where("token_hash = ?", pkg.HashToken(token)).
 		Relation("User").
 		Limit(1).
 		Scan(ctx)
@@ -100,26 +114,107 @@ func (r *SessionRepositoryImpl) FindByToken(ctx context.Context, token string) (
 	return session, nil
 }
 
+func (r *SessionRepositoryImpl) FindByID(ctx context.Context, id *entity.ID) (*entity.Session, error) {
+	session := new(entity.Session)
+	err := r.db.NewSelect().
+		Model(session).
+		Where("id = ?", id).
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// FindAllByUserID 는 만료되지 않은 세션을 최근에 사용한 순서로 가져옵니다.
+func (r *SessionRepositoryImpl) FindAllByUserID(ctx context.Context, userID *entity.ID) ([]*entity.Session, error) {
+	var sessions []*entity.Session
+	err := r.db.NewSelect().
+		Model(&sessions).
+		Where("user_id = ?", userID).
+		Where("expires_at > ?", time.Now().UTC()).
+		Order("last_seen_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// Touch 는 세션의 마지막 사용 시각과 만료 시각을 함께 갱신합니다.
+func (r *SessionRepositoryImpl) Touch(ctx context.Context, id *entity.ID, lastSeenAt time.Time, expiresAt time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*entity.Session)(nil)).
+		Set("last_seen_at = ?", lastSeenAt).
+		Set("expires_at = ?", expiresAt).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+// Rotate 는 세션 토큰을 새 토큰으로 바꿉니다. 그 사이 다른 요청이 먼저 바꿨으면 sql.ErrNoRows를 반환합니다.
+func (r *SessionRepositoryImpl) Rotate(ctx context.Context, id *entity.ID, oldToken string, newToken string, lastSeenAt time.Time, expiresAt time.Time) error {
+	res, err := r.db.NewUpdate().
+		Model((*entity.Session)(nil)).
+		Set("token_hash = ?", pkg.HashToken(newToken)).
+		Set("last_seen_at = ?", lastSeenAt).
+		Set("expires_at = ?", expiresAt).
+		Where("id = ?", id).
+		Where("token_hash = ?", pkg.HashToken(oldToken)).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
 func (r *SessionRepositoryImpl) Delete(ctx context.Context, token string) error {
 	_, err := r.db.NewDelete().
 		Model((*entity.Session)(nil)).
-		Where("session_token = ?", token).
+		Where("token_hash = ?", pkg.HashToken(token)).
 		Exec(ctx)
 	return err
 }
 
-func (r *SessionRepositoryImpl) DeleteByUserID(ctx context.Context, userID int64) error {
+func (r *SessionRepositoryImpl) DeleteByID(ctx context.Context, id *entity.ID) error {
 	_, err := r.db.NewDelete().
 		Model((*entity.Session)(nil)).
-		Where("user_id = ?", userID).
+		Where("id = ?", id).
 		Exec(ctx)
 	return err
 }
 
-func (r *SessionRepositoryImpl) DeleteExpired(ctx context.Context) error {
-	_, err := r.db.NewDelete().
+// DeleteByUserID 는 사용자의 세션을 모두 지웁니다. exceptTokens에 있는 세션은 남깁니다.
+func (r *SessionRepositoryImpl) DeleteByUserID(ctx context.Context, userID int64, exceptTokens ...string) error {
+	q := r.db.NewDelete().
+		Model((*entity.Session)(nil)).
+		Where("user_id = ?", userID)
+
+	if len(exceptTokens) > 0 {
+		hashes := make([]string, len(exceptTokens))
+		for i, token := range exceptTokens {
+			hashes[i] = pkg.HashToken(token)
+		}
+		q = q.Where("token_hash NOT IN (?)", bun.In(hashes))
+	}
+
+	_, err := q.Exec(ctx)
+	return err
+}
+
+// DeleteExpired 는 만료된 항목을 지우고 지운 개수를 반환합니다.
+func (r *SessionRepositoryImpl) DeleteExpired(ctx context.Context) (int64, error) {
+	res, err := r.db.NewDelete().
 		Model((*entity.Session)(nil)).
 		Where("expires_at < ?", time.Now().UTC()).
 		Exec(ctx)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
 }
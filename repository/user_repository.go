@@ -3,6 +3,7 @@ package repository
 import (
 	"analog-be/entity"
 	"context"
+	"time"
 
 	"github.com/uptrace/bun"
 )
@@ -14,6 +15,11 @@ type UserRepository interface {
 	Delete(ctx context.Context, id *entity.ID) error
 	FindAll(ctx context.Context, limit, offset int) ([]*entity.User, *int, error)
 	Search(ctx context.Context, query string, limit, offset int) ([]*entity.User, *int, error)
+	FindByHandle(ctx context.Context, handle string) (*entity.User, error)
+	FindAllByHandles(ctx context.Context, handles []string) ([]*entity.User, error)
+	FindHandleHistory(ctx context.Context, handle string) (*entity.UserHandleHistory, error)
+	IsHandleTaken(ctx context.Context, handle string, userID *entity.ID) (bool, error)
+	ChangeHandle(ctx context.Context, user *entity.User, handle string) error
 }
 
 type UserRepositoryImpl struct {
@@ -57,12 +63,40 @@ func (r *UserRepositoryImpl) Update(ctx context.Context, user *entity.User) (*en
 	return user, err
 }
 
+// Delete 는 사용자를 지우고, 함께 지워지는 반응이 달려 있던 로그와 댓글의 반응 수를 다시 셉니다.
 func (r *UserRepositoryImpl) Delete(ctx context.Context, id *entity.ID) error {
-	_, err := r.db.NewDelete().
-		Model((*entity.User)(nil)).
-		Where("id = ?", id).
-		Exec(ctx)
-	return err
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var logIDs, commentIDs []entity.ID
+		err := tx.NewSelect().
+			Model((*entity.LogReaction)(nil)).
+			Column("log_id").
+			Distinct().
+			Where("user_id = ?", id).
+			Scan(ctx, &logIDs)
+		if err != nil {
+			return err
+		}
+
+		err = tx.NewSelect().
+			Model((*entity.CommentReaction)(nil)).
+			Column("comment_id").
+			Distinct().
+			Where("user_id = ?", id).
+			Scan(ctx, &commentIDs)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.NewDelete().
+			Model((*entity.User)(nil)).
+			Where("id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		return recountReactions(ctx, tx, logIDs, commentIDs)
+	})
 }
 
 func (r *UserRepositoryImpl) FindAll(ctx context.Context, limit, offset int) ([]*entity.User, *int, error) {
@@ -98,3 +132,133 @@ func (r *UserRepositoryImpl) Search(ctx context.Context, query string, limit, of
 
 	return users, &count, nil
 }
+
+func (r *UserRepositoryImpl) FindByHandle(ctx context.Context, handle string) (*entity.User, error) {
+	user := new(entity.User)
+
+	err := r.db.NewSelect().
+		Model(user).
+		Where("handle = ?", handle).
+		Limit(1).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// FindAllByHandles 는 지금 핸들이 handles 중 하나인 사용자를 찾습니다. 이전 핸들은 보지 않습니다.
+func (r *UserRepositoryImpl) FindAllByHandles(ctx context.Context, handles []string) ([]*entity.User, error) {
+	var users []*entity.User
+	if len(handles) == 0 {
+		return users, nil
+	}
+
+	err := r.db.NewSelect().
+		Model(&users).
+		Where("handle IN (?)", bun.In(handles)).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *UserRepositoryImpl) FindHandleHistory(ctx context.Context, handle string) (*entity.UserHandleHistory, error) {
+	history := new(entity.UserHandleHistory)
+
+	err := r.db.NewSelect().
+		Model(history).
+		Where("handle = ?", handle).
+		Limit(1).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// IsHandleTaken 은 다른 사용자가 핸들을 쓰고 있거나 예전에 썼는지 확인합니다. userID가 nil이 아니면 그 사용자는 제외합니다.
+func (r *UserRepositoryImpl) IsHandleTaken(ctx context.Context, handle string, userID *entity.ID) (bool, error) {
+	current := r.db.NewSelect().
+		Model((*entity.User)(nil)).
+		Where("handle = ?", handle)
+	previous := r.db.NewSelect().
+		Model((*entity.UserHandleHistory)(nil)).
+		Where("handle = ?", handle)
+	if userID != nil {
+		current = current.Where("id != ?", userID)
+		previous = previous.Where("user_id != ?", userID)
+	}
+
+	taken, err := current.Exists(ctx)
+	if err != nil || taken {
+		return taken, err
+	}
+	return previous.Exists(ctx)
+}
+
+// ChangeHandle 은 사용자의 핸들을 바꾸고 이전 핸들을 기록합니다. 예전에 쓰던 핸들로 돌아가면 그 기록은 지웁니다.
+// 다른 사용자가 먼저 가져간 핸들이면 ErrHandleTaken을 반환합니다.
+func (r *UserRepositoryImpl) ChangeHandle(ctx context.Context, user *entity.User, handle string) error {
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewDelete().
+			Model((*entity.UserHandleHistory)(nil)).
+			Where("handle = ?", handle).
+			Where("user_id = ?", user.ID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		history := &entity.UserHandleHistory{
+			Handle:    user.Handle,
+			UserID:    user.ID,
+			ChangedAt: time.Now().UTC(),
+		}
+		_, err = tx.NewInsert().
+			Model(history).
+			On("CONFLICT (handle) DO UPDATE").
+			Set("user_id = EXCLUDED.user_id").
+			Set("changed_at = EXCLUDED.changed_at").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.NewUpdate().
+			Model((*entity.User)(nil)).
+			Set("handle = ?", handle).
+			Set("updated_at = ?", time.Now().UTC()).
+			Where("id = ?", user.ID).
+			Exec(ctx)
+		return err
+	})
+	if isUniqueViolation(err, usersHandleKey) {
+		return ErrHandleTaken
+	}
+	if err != nil {
+		return err
+	}
+
+	user.Handle = handle
+	return nil
+}
+
+// lockUser 는 트랜잭션이 끝날 때까지 사용자 행을 잠급니다.
+// 기존 행만 잠그면 동시에 새로 넣는 행은 막지 못하므로, 사용자마다 개수 한도가 있는 행을 세고 넣기 전에 잡습니다.
+func lockUser(ctx context.Context, tx bun.Tx, userID entity.ID) error {
+	_, err := tx.NewSelect().
+		Model((*entity.User)(nil)).
+		Column("id").
+		Where("id = ?", userID).
+		For("NO KEY UPDATE").
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"analog-be/controller"
+	"analog-be/interceptor"
+
+	"github.com/NARUBROWN/spine"
+	"github.com/NARUBROWN/spine/pkg/route"
+)
+
+// RegisterAdminRoutes 는 관리자 기능을 등록합니다. 관리자 확인은 서비스에서 An-Americano로 합니다.
+func RegisterAdminRoutes(app spine.App) {
+	app.Route("POST", "/admin/impersonations", (*controller.AdminController).StartImpersonation, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("GET", "/admin/impersonations/audits", (*controller.AdminController).ListImpersonationAudits, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("DELETE", "/admin/impersonations/:id", (*controller.AdminController).EndImpersonation, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+}
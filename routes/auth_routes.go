@@ -2,8 +2,11 @@ package routes
 
 import (
 	"analog-be/controller"
+	"analog-be/entity"
+	"analog-be/interceptor"
 
 	"github.com/NARUBROWN/spine"
+	"github.com/NARUBROWN/spine/pkg/route"
 )
 
 func RegisterAuthRoutes(app spine.App) {
@@ -14,6 +17,23 @@ func RegisterAuthRoutes(app spine.App) {
 
 	app.Route("POST", "/auth/refresh", (*controller.AuthController).RefreshToken)
 
-	app.Route("POST", "/auth/logout", (*controller.AuthController).Logout)
-	app.Route("GET", "/auth/me", (*controller.AuthController).GetCurrentUser)
+	app.Route("POST", "/auth/logout", (*controller.AuthController).Logout, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("GET", "/auth/me", (*controller.AuthController).GetCurrentUser, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.RequireScope(entity.ScopeUserRead)))
+
+	app.Route("POST", "/auth/session/refresh", (*controller.AuthController).RefreshSession, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("GET", "/auth/sessions", (*controller.AuthController).ListSessions, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("DELETE", "/auth/sessions", (*controller.AuthController).RevokeOtherSessions, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("DELETE", "/auth/sessions/:id", (*controller.AuthController).RevokeSession, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+
+	// 개인 액세스 토큰 관리는 세션으로만 가능
+	app.Route("GET", "/auth/tokens", (*controller.AccessTokenController).ListAccessTokens, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("POST", "/auth/tokens", (*controller.AccessTokenController).CreateAccessToken, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("DELETE", "/auth/tokens/:id", (*controller.AccessTokenController).RevokeAccessToken, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+
+	// 외부 계정 연결
+	app.Route("GET", "/auth/providers", (*controller.IdentityController).ListProviders)
+	app.Route("GET", "/auth/identities", (*controller.IdentityController).ListIdentities, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("POST", "/auth/identities/link/init", (*controller.IdentityController).InitiateLink, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("GET", "/auth/identities/link/callback", (*controller.IdentityController).HandleLinkCallback, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("DELETE", "/auth/identities/:id", (*controller.IdentityController).UnlinkIdentity, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
 }
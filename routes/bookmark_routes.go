@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"analog-be/controller"
+	"analog-be/interceptor"
+
+	"github.com/NARUBROWN/spine"
+	"github.com/NARUBROWN/spine/pkg/route"
+)
+
+// 나중에 읽을 로그 저장과 읽기 목록. 개인 액세스 토큰으로는 쓸 수 없습니다.
+func RegisterBookmarkRoutes(app spine.App) {
+	app.Route("GET", "/bookmarks", (*controller.BookmarkController).ListBookmarks, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("PUT", "/logs/:id/bookmark", (*controller.BookmarkController).AddBookmark, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("DELETE", "/logs/:id/bookmark", (*controller.BookmarkController).RemoveBookmark, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+
+	app.Route("GET", "/reading-lists", (*controller.BookmarkController).ListReadingLists, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.OptionalAuth()))
+	app.Route("POST", "/reading-lists", (*controller.BookmarkController).CreateReadingList, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("GET", "/reading-lists/:id", (*controller.BookmarkController).GetReadingList, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.OptionalAuth()))
+	app.Route("PUT", "/reading-lists/:id", (*controller.BookmarkController).UpdateReadingList, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("DELETE", "/reading-lists/:id", (*controller.BookmarkController).DeleteReadingList, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("GET", "/reading-lists/:id/items", (*controller.BookmarkController).ListReadingListItems, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.OptionalAuth()))
+	app.Route("PUT", "/reading-lists/:id/items/:logId", (*controller.BookmarkController).PutReadingListItem, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("DELETE", "/reading-lists/:id/items/:logId", (*controller.BookmarkController).RemoveReadingListItem, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+}
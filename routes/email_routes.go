@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"analog-be/controller"
+	"analog-be/interceptor"
+
+	"github.com/NARUBROWN/spine"
+	"github.com/NARUBROWN/spine/pkg/route"
+)
+
+// 메일 알림과 주간 소식. 수신 거부는 메일의 토큰으로 로그인 없이 할 수 있습니다.
+func RegisterEmailRoutes(app spine.App) {
+	app.Route("GET", "/notifications/email", (*controller.EmailController).GetEmailSettings, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("PUT", "/notifications/email", (*controller.EmailController).UpdateEmailSettings, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+
+	app.Route("GET", "/email/unsubscribe", (*controller.EmailController).GetEmailSubscription)
+	app.Route("POST", "/email/unsubscribe", (*controller.EmailController).Unsubscribe)
+}
@@ -12,4 +12,6 @@ func RegisterHealthRoutes(app spine.App) {
 	app.Route("GET", "/health/ready", (*controller.HealthController).Ready)
 
 	app.Route("GET", "/health/live", (*controller.HealthController).Live)
+
+	app.Route("GET", "/health/metrics", (*controller.HealthController).Metrics)
 }
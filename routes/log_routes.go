@@ -2,6 +2,7 @@ package routes
 
 import (
 	"analog-be/controller"
+	"analog-be/entity"
 	"analog-be/interceptor"
 
 	"github.com/NARUBROWN/spine"
@@ -10,18 +11,24 @@ import (
 
 // 여기서 'log' 란 article을 의미합니다.
 func RegisterLogRoutes(app spine.App) {
-	app.Route("GET", "/logs", (*controller.LogController).GetListOfLog)
-	app.Route("GET", "/logs/:id", (*controller.LogController).GetLog)
-	app.Route("GET", "/logs/topic/list/:topicId", (*controller.LogController).GetListOfTopicLog)
-	app.Route("GET", "/logs/generation/list/:generation", (*controller.LogController).GetListOfGenerationLog)
-	app.Route("GET", "/logs/search/list", (*controller.LogController).SearchLogs)
+	app.Route("GET", "/logs", (*controller.LogController).GetListOfLog, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.OptionalAuth()))
+	app.Route("GET", "/logs/:id", (*controller.LogController).GetLog, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.OptionalAuth()))
+	app.Route("GET", "/logs/topic/list/:topicId", (*controller.LogController).GetListOfTopicLog, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.OptionalAuth()))
+	app.Route("GET", "/logs/generation/list/:generation", (*controller.LogController).GetListOfGenerationLog, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.OptionalAuth()))
+	app.Route("GET", "/logs/search/list", (*controller.LogController).SearchLogs, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.OptionalAuth()))
 
-	app.Route("POST", "/logs", (*controller.LogController).CreateLog, route.WithInterceptors(&interceptor.AuthInterceptor{}))
-	app.Route("PUT", "/logs/:id", (*controller.LogController).UpdateLog, route.WithInterceptors(&interceptor.AuthInterceptor{}))
-	app.Route("DELETE", "/logs/:id", (*controller.LogController).DeleteLog, route.WithInterceptors(&interceptor.AuthInterceptor{}))
+	app.Route("POST", "/logs", (*controller.LogController).CreateLog, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.RequireScope(entity.ScopeLogsWrite)))
+	app.Route("PUT", "/logs/:id", (*controller.LogController).UpdateLog, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.RequireScope(entity.ScopeLogsWrite)))
+	app.Route("DELETE", "/logs/:id", (*controller.LogController).DeleteLog, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.RequireScope(entity.ScopeLogsWrite)))
 
-	app.Route("GET", "/logs/:id/comments", (*controller.LogController).FindAllCommentByLogID)
-	app.Route("POST", "/logs/:id/comments", (*controller.LogController).CreateComment, route.WithInterceptors(&interceptor.AuthInterceptor{}))
-	app.Route("PUT", "/logs/:id/comments/:commentId", (*controller.LogController).UpdateComment, route.WithInterceptors(&interceptor.AuthInterceptor{}))
-	app.Route("DELETE", "/logs/:id/comments/:commentId", (*controller.LogController).DeleteComment, route.WithInterceptors(&interceptor.AuthInterceptor{}))
+	app.Route("GET", "/logs/:id/comments", (*controller.LogController).FindAllCommentByLogID, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.OptionalAuth()))
+	app.Route("GET", "/logs/:id/comments/:commentId/replies", (*controller.LogController).FindRepliesByCommentID, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.OptionalAuth()))
+	app.Route("GET", "/logs/:id/comments/:commentId/revisions", (*controller.LogController).GetCommentRevisions, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("POST", "/logs/:id/comments", (*controller.LogController).CreateComment, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.RequireScope(entity.ScopeCommentsWrite)))
+	app.Route("PUT", "/logs/:id/comments/:commentId", (*controller.LogController).UpdateComment, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.RequireScope(entity.ScopeCommentsWrite)))
+	app.Route("DELETE", "/logs/:id/comments/:commentId", (*controller.LogController).DeleteComment, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.RequireScope(entity.ScopeCommentsWrite)))
+
+	app.Route("PUT", "/logs/:id/comments/:commentId/hidden", (*controller.LogController).HideComment, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.RequireScope(entity.ScopeCommentsWrite)))
+	app.Route("DELETE", "/logs/:id/comments/:commentId/hidden", (*controller.LogController).UnhideComment, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.RequireScope(entity.ScopeCommentsWrite)))
+	app.Route("PUT", "/logs/:id/comment-mode", (*controller.LogController).UpdateCommentMode, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.RequireScope(entity.ScopeLogsWrite)))
 }
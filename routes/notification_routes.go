@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"analog-be/controller"
+	"analog-be/interceptor"
+
+	"github.com/NARUBROWN/spine"
+	"github.com/NARUBROWN/spine/pkg/route"
+)
+
+// 앱 내 알림. 개인 액세스 토큰으로는 쓸 수 없습니다.
+func RegisterNotificationRoutes(app spine.App) {
+	app.Route("GET", "/notifications", (*controller.NotificationController).ListNotifications, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("GET", "/notifications/unread-count", (*controller.NotificationController).GetUnreadCount, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("PUT", "/notifications/:id/read", (*controller.NotificationController).MarkNotificationRead, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("PUT", "/notifications/read-all", (*controller.NotificationController).MarkAllNotificationsRead, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("GET", "/notifications/preferences", (*controller.NotificationController).GetNotificationPreferences, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("PUT", "/notifications/preferences", (*controller.NotificationController).UpdateNotificationPreferences, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+}
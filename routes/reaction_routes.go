@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"analog-be/controller"
+	"analog-be/entity"
+	"analog-be/interceptor"
+
+	"github.com/NARUBROWN/spine"
+	"github.com/NARUBROWN/spine/pkg/route"
+)
+
+// 로그와 댓글의 이모지 반응. 핸들러는 LogController에 있습니다.
+func RegisterReactionRoutes(app spine.App) {
+	app.Route("GET", "/reactions", (*controller.LogController).GetReactionKinds)
+
+	app.Route("PUT", "/logs/:id/reactions/:kind", (*controller.LogController).AddLogReaction, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.RequireScope(entity.ScopeReactionsWrite)))
+	app.Route("DELETE", "/logs/:id/reactions/:kind", (*controller.LogController).RemoveLogReaction, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.RequireScope(entity.ScopeReactionsWrite)))
+	app.Route("PUT", "/logs/:id/comments/:commentId/reactions/:kind", (*controller.LogController).AddCommentReaction, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.RequireScope(entity.ScopeReactionsWrite)))
+	app.Route("DELETE", "/logs/:id/comments/:commentId/reactions/:kind", (*controller.LogController).RemoveCommentReaction, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.RequireScope(entity.ScopeReactionsWrite)))
+}
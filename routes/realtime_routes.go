@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"analog-be/controller"
+	"analog-be/interceptor"
+
+	"github.com/NARUBROWN/spine"
+	"github.com/NARUBROWN/spine/pkg/route"
+)
+
+// 실시간 이벤트 스트림. 브라우저의 EventSource는 헤더를 보낼 수 없으므로 세션 쿠키로 인증합니다.
+func RegisterRealtimeRoutes(app spine.App) {
+	app.Route("GET", "/events", (*controller.RealtimeController).StreamEvents, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.OptionalAuth()))
+}
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"analog-be/controller"
+	"analog-be/entity"
 	"analog-be/interceptor"
 
 	"github.com/NARUBROWN/spine"
@@ -10,9 +11,11 @@ import (
 
 func RegisterUserRoutes(app spine.App) {
 	app.Route("GET", "/users/search/list", (*controller.UserController).Search)
-	app.Route("GET", "/users/:id", (*controller.UserController).Get, route.WithInterceptors(&interceptor.AuthInterceptor{}))
+	app.Route("GET", "/users/handles/:handle", (*controller.UserController).GetByHandle)
+	app.Route("GET", "/users/handles/:handle/availability", (*controller.UserController).CheckHandle)
+	app.Route("GET", "/users/:id", (*controller.UserController).Get, route.WithInterceptors((*interceptor.AuthInterceptor)(nil), interceptor.RequireScope(entity.ScopeUserRead)))
 
 	app.Route("POST", "/users", (*controller.UserController).Create)
-	app.Route("PUT", "/users", (*controller.UserController).Update, route.WithInterceptors(&interceptor.AuthInterceptor{}))
-	app.Route("DELETE", "/users", (*controller.UserController).Delete, route.WithInterceptors(&interceptor.AuthInterceptor{}))
+	app.Route("PUT", "/users", (*controller.UserController).Update, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
+	app.Route("DELETE", "/users", (*controller.UserController).Delete, route.WithInterceptors((*interceptor.AuthInterceptor)(nil)))
 }
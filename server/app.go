@@ -0,0 +1,146 @@
+// Package server 는 Analog API 서버의 의존성 구성과 라우트 등록을 담당합니다.
+// main과 e2e 테스트가 같은 구성으로 서버를 띄울 수 있도록 main.go에서 분리되었습니다.
+package server
+
+import (
+	"analog-be/controller"
+	"analog-be/interceptor"
+	"analog-be/pkg"
+	"analog-be/repository"
+	"analog-be/routes"
+	"analog-be/service"
+
+	"github.com/NARUBROWN/spine"
+	"github.com/NARUBROWN/spine/pkg/boot"
+	"github.com/labstack/echo/v4"
+	httpSwagger "github.com/swaggo/http-swagger"
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+const GlobalPrefix = "/api"
+
+// NewApp 은 생성자, 인터셉터, 라우트가 모두 등록된 Spine 앱을 만듭니다.
+func NewApp(db *bun.DB, logger *zap.Logger) spine.App {
+	app := spine.New()
+
+	// 종료할 때 열린 스트림을 닫아야 하므로 직접 만들어 둠
+	realtimeService := service.NewRealtimeService(db, repository.NewRealtimeEventRepository(db), logger)
+
+	app.Constructor(
+		// 디비
+		func() *bun.DB { return db },
+
+		// 기타
+		func() *zap.Logger { return logger },
+		pkg.NewSessionPolicy,
+		pkg.NewSessionCookiePolicy,
+		pkg.NewSessionCache,
+		pkg.NewRedirectAllowlist,
+		pkg.NewImpersonationPolicy,
+		pkg.NewCommentPolicy,
+		pkg.NewCommentMarkdown,
+		pkg.NewReactionSet,
+		pkg.NewRealtimePolicy,
+		pkg.NewEmailPolicy,
+
+		// 레포지토리
+		repository.NewUserRepository,
+		repository.NewLogRepository,
+		repository.NewCommentRepository,
+		repository.NewReactionRepository,
+		repository.NewBookmarkRepository,
+		repository.NewReadingListRepository,
+		repository.NewNotificationRepository,
+		repository.NewEmailSettingsRepository,
+		repository.NewEmailOutboxRepository,
+		repository.NewOAuthStateRepository,
+		repository.NewSessionRepository,
+		repository.NewTopicRepository,
+		repository.NewPermissionOutboxRepository,
+		repository.NewAccessTokenRepository,
+		repository.NewUserIdentityRepository,
+		repository.NewImpersonationAuditRepository,
+
+		// 서비스
+		service.NewLogService,
+		service.NewUserService,
+		service.NewAnAccountOAuthService,
+		service.NewCommentService,
+		service.NewReactionService,
+		service.NewBookmarkService,
+		service.NewNotificationService,
+		service.NewEmailService,
+		func() service.RealtimeService { return realtimeService },
+		service.NewTopicService,
+		service.NewAnAmericanoService,
+		service.NewFeedService,
+		service.NewSessionService,
+		service.NewAccessTokenService,
+		service.NewIdentityProviders,
+		service.NewIdentityService,
+		service.NewImpersonationService,
+
+		// 컨트롤러
+		controller.NewHealthController,
+		controller.NewLogController,
+		controller.NewBookmarkController,
+		controller.NewNotificationController,
+		controller.NewEmailController,
+		controller.NewRealtimeController,
+		controller.NewUserController,
+		controller.NewAuthController,
+		controller.NewTopicController,
+		controller.NewFeedController,
+		controller.NewAccessTokenController,
+		controller.NewIdentityController,
+		controller.NewAdminController,
+
+		// 인터셉터
+		interceptor.NewTxInterceptor,
+		interceptor.NewAuthInterceptor,
+	)
+
+	// 전역 인터셉터
+	app.Interceptor(
+		interceptor.NewCORSInterceptor(),
+		interceptor.NewRateLimitInterceptor(),
+		interceptor.NewLoggingInterceptor(),
+		interceptor.NewErrorInterceptor(),
+	)
+
+	routes.RegisterHealthRoutes(app)
+	routes.RegisterLogRoutes(app)
+	routes.RegisterReactionRoutes(app)
+	routes.RegisterBookmarkRoutes(app)
+	routes.RegisterNotificationRoutes(app)
+	routes.RegisterEmailRoutes(app)
+	routes.RegisterRealtimeRoutes(app)
+	routes.RegisterUserRoutes(app)
+	routes.RegisterAuthRoutes(app)
+	routes.RegisterTopicRoutes(app)
+	routes.RegisterFeedRoutes(app)
+	routes.RegisterAdminRoutes(app)
+
+	app.Transport(func(t any) {
+		e := t.(*echo.Echo)
+		e.HTTPErrorHandler = newHTTPErrorHandler(logger)
+		e.IPExtractor = newIPExtractor(logger)
+		e.Use(clientInfoMiddleware)
+		e.Use(responseWriterMiddleware)
+		e.Server.RegisterOnShutdown(realtimeService.Close)
+		e.GET("/docs/*", echo.WrapHandler(httpSwagger.WrapHandler))
+	})
+
+	return app
+}
+
+func BootOptions(address string, gracefulShutdown bool) boot.Options {
+	return boot.Options{
+		Address:                address,
+		EnableGracefulShutdown: gracefulShutdown,
+		HTTP: &boot.HTTPOptions{
+			GlobalPrefix: GlobalPrefix,
+		},
+	}
+}
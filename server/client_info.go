@@ -0,0 +1,69 @@
+package server
+
+import (
+	"analog-be/pkg"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// User-Agent는 길이 제한 없이 들어올 수 있으므로 세션 컬럼 크기에 맞춰 자름
+const maxUserAgentLength = 512
+
+// newIPExtractor 는 TRUSTED_PROXIES(쉼표로 구분한 CIDR)에서 온 요청만 X-Forwarded-For를 믿고,
+// 그 외에는 연결한 주소를 클라이언트 IP로 씁니다. 클라이언트가 보낸 헤더로 IP를 속일 수 없도록 합니다.
+func newIPExtractor(logger *zap.Logger) echo.IPExtractor {
+	env := os.Getenv("TRUSTED_PROXIES")
+	if env == "" {
+		return echo.ExtractIPDirect()
+	}
+
+	// 사설망, 루프백도 기본으로 믿지 않고 설정한 범위만 믿음
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, cidr := range strings.Split(env, ",") {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			logger.Warn("Ignoring invalid TRUSTED_PROXIES entry", zap.String("value", cidr), zap.Error(err))
+			continue
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+// truncateUserAgent 는 User-Agent를 최대 maxUserAgentLength 글자로 자릅니다.
+// 컬럼 길이는 바이트가 아닌 글자 수이고, 바이트로 자르면 멀티바이트 문자가 깨져 Postgres가 INSERT를 거부합니다.
+func truncateUserAgent(userAgent string) string {
+	count := 0
+	for i := range userAgent {
+		if count == maxUserAgentLength {
+			return userAgent[:i]
+		}
+		count++
+	}
+	return userAgent
+}
+
+// clientInfoMiddleware 는 요청 컨텍스트에 클라이언트 IP와 User-Agent를 담습니다.
+// Spine은 핸들러에 요청 컨텍스트를 넘기므로 서비스에서 pkg.GetClientInfo로 꺼낼 수 있습니다.
+func clientInfoMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userAgent := truncateUserAgent(c.Request().UserAgent())
+
+		req := c.Request()
+		c.SetRequest(req.WithContext(pkg.WithClientInfo(req.Context(), pkg.ClientInfo{
+			IP:        c.RealIP(),
+			UserAgent: userAgent,
+		})))
+
+		return next(c)
+	}
+}
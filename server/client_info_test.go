@@ -0,0 +1,32 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncateUserAgent(t *testing.T) {
+	short := "Mozilla/5.0 (Macintosh)"
+	if got := truncateUserAgent(short); got != short {
+		t.Errorf("truncateUserAgent(%q) = %q, want unchanged", short, got)
+	}
+
+	ascii := strings.Repeat("a", maxUserAgentLength+10)
+	if got := truncateUserAgent(ascii); len(got) != maxUserAgentLength {
+		t.Errorf("ascii user agent truncated to %d bytes, want %d", len(got), maxUserAgentLength)
+	}
+
+	// 바이트 기준 512번째 위치가 한글 글자 중간에 걸리도록 ASCII 한 글자를 앞에 붙임
+	multibyte := "a" + strings.Repeat("안", maxUserAgentLength)
+	got := truncateUserAgent(multibyte)
+	if !utf8.ValidString(got) {
+		t.Fatalf("truncated user agent is not valid UTF-8: %q", got)
+	}
+	if n := utf8.RuneCountInString(got); n != maxUserAgentLength {
+		t.Errorf("multibyte user agent truncated to %d characters, want %d", n, maxUserAgentLength)
+	}
+	if !strings.HasPrefix(multibyte, got) {
+		t.Errorf("truncated user agent is not a prefix of the original")
+	}
+}
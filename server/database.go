@@ -0,0 +1,118 @@
+package server
+
+import (
+	"analog-be/entity"
+	"analog-be/pkg"
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"os"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+	"github.com/uptrace/bun/driver/pgdriver"
+	"go.uber.org/zap"
+)
+
+type DBConfig struct {
+	Host            string
+	Port            string
+	User            string
+	Password        string
+	Database        string
+	ApplicationName string
+	TLSSkipVerify   bool
+	Debug           bool
+}
+
+// DBConfigFromEnv 는 DB_* 환경 변수로 접속 정보를 만듭니다.
+func DBConfigFromEnv(applicationName string) DBConfig {
+	return DBConfig{
+		Host:            GetEnv("DB_HOST", "localhost"),
+		Port:            GetEnv("DB_PORT", "5437"),
+		User:            GetEnv("DB_USER", "test"),
+		Password:        GetEnv("DB_PASSWORD", "test"),
+		Database:        GetEnv("DB_NAME", "test"),
+		ApplicationName: applicationName,
+		TLSSkipVerify:   os.Getenv("DB_TLS_SKIP_VERIFY") == "true",
+		Debug:           os.Getenv("DEBUG") == "true",
+	}
+}
+
+func NewDB(config DBConfig) *bun.DB {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.TLSSkipVerify,
+	}
+
+	pgconn := pgdriver.NewConnector(
+		pgdriver.WithNetwork("tcp"),
+		pgdriver.WithAddr(config.Host+":"+config.Port),
+		pgdriver.WithTLSConfig(tlsConfig),
+		pgdriver.WithUser(config.User),
+		pgdriver.WithPassword(config.Password),
+		pgdriver.WithDatabase(config.Database),
+		pgdriver.WithApplicationName(config.ApplicationName),
+		pgdriver.WithTimeout(10*time.Second),
+		pgdriver.WithDialTimeout(5*time.Second),
+		pgdriver.WithReadTimeout(10*time.Second),
+		pgdriver.WithWriteTimeout(10*time.Second),
+		pgdriver.WithInsecure(true),
+	)
+
+	sqldb := sql.OpenDB(pgconn)
+
+	sqldb.SetMaxOpenConns(25)
+	sqldb.SetMaxIdleConns(5)
+	sqldb.SetConnMaxLifetime(5 * time.Minute)
+	sqldb.SetConnMaxIdleTime(10 * time.Minute)
+
+	db := bun.NewDB(sqldb, pgdialect.New())
+
+	if config.Debug {
+		db.AddQueryHook(&debugHook{})
+	}
+
+	RegisterModels(db)
+
+	return db
+}
+
+// RegisterModels 는 m2m 관계에 필요한 모델을 등록합니다.
+func RegisterModels(db *bun.DB) {
+	db.RegisterModel(
+		// relation
+		(*entity.LogToUser)(nil),
+		(*entity.LogToTopic)(nil),
+		(*entity.CommentMention)(nil),
+
+		(*entity.Log)(nil),
+		(*entity.Topic)(nil),
+		(*entity.User)(nil),
+		(*entity.Comment)(nil),
+		(*entity.OAuthState)(nil),
+		(*entity.Session)(nil),
+		(*entity.PermissionOutbox)(nil),
+	)
+}
+
+func GetEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+type debugHook struct{}
+
+func (h *debugHook) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+	return ctx
+}
+
+func (h *debugHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	logger := pkg.GetLogger()
+	logger.Debug("Database query",
+		zap.String("query", event.Query),
+		zap.Duration("duration", time.Since(event.StartTime)),
+	)
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"analog-be/pkg"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/NARUBROWN/spine/pkg/httperr"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+const (
+	spineNoHandlerMessage       = "핸들러가 없습니다."
+	spineInvalidPathParamPrefix = "유효하지 않은 Path param"
+)
+
+// newHTTPErrorHandler 는 인터셉터나 인자 바인딩에서 올라온 에러를 상태 코드에 맞게 응답합니다.
+// Echo 기본 핸들러는 알 수 없는 에러를 모두 500으로 처리해, 인증 실패도 500으로 나가는 문제가 있었습니다.
+func newHTTPErrorHandler(logger *zap.Logger) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		status, body := errorResponse(err)
+		if status >= http.StatusInternalServerError {
+			logger.Error("Request failed",
+				zap.Error(err),
+				zap.String("method", c.Request().Method),
+				zap.String("path", c.Request().URL.Path),
+			)
+		}
+
+		if c.Request().Method == http.MethodHead {
+			err = c.NoContent(status)
+		} else {
+			err = c.JSON(status, body)
+		}
+		if err != nil {
+			logger.Error("Failed to write error response", zap.Error(err))
+		}
+	}
+}
+
+func errorResponse(err error) (int, *pkg.AppError) {
+	var appErr *pkg.AppError
+	if errors.As(err, &appErr) {
+		return appErr.StatusCode, appErr
+	}
+
+	var httpErr *httperr.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Status, pkg.NewAppError(httpErr.Status, codeForStatus(httpErr.Status), httpErr.Message)
+	}
+
+	// 라우트가 없거나 바디 바인딩에 실패한 경우
+	var echoErr *echo.HTTPError
+	if errors.As(err, &echoErr) {
+		message := http.StatusText(echoErr.Code)
+		if m, ok := echoErr.Message.(string); ok {
+			message = m
+		}
+		return echoErr.Code, pkg.NewAppError(echoErr.Code, codeForStatus(echoErr.Code), message)
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return http.StatusNotFound, pkg.NewNotFoundError("Resource")
+	}
+
+	// Spine은 라우팅/경로 파라미터 실패를 타입 없는 에러로 반환하므로 메시지로 구분합니다.
+	switch msg := err.Error(); {
+	case msg == spineNoHandlerMessage:
+		return http.StatusNotFound, pkg.NewNotFoundError("Route")
+	case strings.HasPrefix(msg, spineInvalidPathParamPrefix):
+		return http.StatusBadRequest, pkg.NewBadRequestError("Invalid path parameter", nil)
+	}
+
+	return http.StatusInternalServerError, pkg.NewInternalError("Internal Server Error")
+}
+
+func codeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "BAD_REQUEST"
+	case http.StatusUnauthorized:
+		return "UNAUTHORIZED"
+	case http.StatusForbidden:
+		return "FORBIDDEN"
+	case http.StatusNotFound:
+		return "NOT_FOUND"
+	case http.StatusMethodNotAllowed:
+		return "METHOD_NOT_ALLOWED"
+	case http.StatusConflict:
+		return "CONFLICT"
+	case http.StatusTooManyRequests:
+		return "RATE_LIMIT_EXCEEDED"
+	default:
+		if status >= http.StatusInternalServerError {
+			return "INTERNAL_ERROR"
+		}
+		return "ERROR"
+	}
+}
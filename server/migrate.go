@@ -0,0 +1,20 @@
+package server
+
+import (
+	migrations "analog-be/migration"
+	"context"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+// Migrate 는 마이그레이션 테이블을 준비하고 적용되지 않은 마이그레이션을 모두 실행합니다.
+func Migrate(ctx context.Context, db *bun.DB) (*migrate.MigrationGroup, error) {
+	migrator := migrate.NewMigrator(db, migrations.Migrations)
+
+	if err := migrator.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	return migrator.Migrate(ctx)
+}
@@ -0,0 +1,18 @@
+package server
+
+import (
+	"analog-be/pkg"
+
+	"github.com/labstack/echo/v4"
+)
+
+// responseWriterMiddleware 는 요청 컨텍스트에 원본 응답을 담습니다.
+// Spine 핸들러는 JSON과 문자열만 돌려줄 수 있으므로 SSE처럼 나눠서 써야 하는 응답은 pkg.GetResponseWriter로 꺼내 씁니다.
+func responseWriterMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		c.SetRequest(req.WithContext(pkg.WithResponseWriter(req.Context(), c.Response())))
+
+		return next(c)
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"analog-be/pkg"
+	"analog-be/repository"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// 사용자마다 만들 수 있는 개인 액세스 토큰 수
+const maxAccessTokensPerUser = 20
+
+type AccessTokenService interface {
+	Create(ctx context.Context, userID *entity.ID, req *dto.AccessTokenCreateRequest) (*entity.PersonalAccessToken, error)
+	List(ctx context.Context, userID *entity.ID) ([]*entity.PersonalAccessToken, error)
+	Revoke(ctx context.Context, userID *entity.ID, tokenID *entity.ID) error
+}
+
+type AccessTokenServiceImpl struct {
+	tokenRepo repository.AccessTokenRepository
+}
+
+func NewAccessTokenService(tokenRepo repository.AccessTokenRepository) AccessTokenService {
+	return &AccessTokenServiceImpl{tokenRepo: tokenRepo}
+}
+
+// Create 는 새 토큰을 발급합니다. 원본 토큰은 반환된 엔티티의 Token에만 담기고 다시 조회할 수 없습니다.
+func (s *AccessTokenServiceImpl) Create(ctx context.Context, userID *entity.ID, req *dto.AccessTokenCreateRequest) (*entity.PersonalAccessToken, error) {
+	secret, err := generateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+	raw := entity.AccessTokenPrefix + secret
+
+	now := time.Now().UTC()
+	token := &entity.PersonalAccessToken{
+		UserID:      *userID,
+		Name:        req.Name,
+		Token:       raw,
+		TokenPrefix: raw[:len(entity.AccessTokenPrefix)+4],
+		Scopes:      uniqueScopes(req.Scopes),
+		CreatedAt:   now,
+	}
+	if req.ExpiresInDays > 0 {
+		token.ExpiresAt = now.AddDate(0, 0, req.ExpiresInDays)
+	}
+
+	err = s.tokenRepo.Create(ctx, token, maxAccessTokensPerUser)
+	if errors.Is(err, repository.ErrAccessTokenLimit) {
+		return nil, pkg.NewConflictError(fmt.Sprintf("A user can have at most %d access tokens", maxAccessTokensPerUser))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create access token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (s *AccessTokenServiceImpl) List(ctx context.Context, userID *entity.ID) ([]*entity.PersonalAccessToken, error) {
+	return s.tokenRepo.FindAllByUserID(ctx, userID)
+}
+
+// Revoke 는 사용자의 토큰 하나를 지웁니다. 다른 사용자의 토큰이면 sql.ErrNoRows를 반환합니다.
+func (s *AccessTokenServiceImpl) Revoke(ctx context.Context, userID *entity.ID, tokenID *entity.ID) error {
+	return s.tokenRepo.DeleteByID(ctx, userID, tokenID)
+}
+
+func uniqueScopes(scopes []string) []string {
+	seen := make(map[string]bool, len(scopes))
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !seen[scope] {
+			seen[scope] = true
+			result = append(result, scope)
+		}
+	}
+	return result
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"analog-be/pkg"
+	"analog-be/pkg/oidc"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// AnAccountProvider 는 An-Account OIDC 제공자입니다. sub는 Analog 사용자 아이디와 같은 양의 정수입니다.
+type AnAccountProvider struct {
+	httpClient *http.Client
+	verifier   *oidc.Verifier
+}
+
+func NewAnAccountProvider() *AnAccountProvider {
+	httpClient := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+
+	return &AnAccountProvider{
+		httpClient: httpClient,
+		verifier: oidc.NewVerifier(oidc.Config{
+			Issuer:     getAnAccountIssuer(),
+			ClientID:   getAnAccountClientID(),
+			HTTPClient: httpClient,
+		}),
+	}
+}
+
+func (p *AnAccountProvider) Name() string {
+	return entity.ProviderAnAccount
+}
+
+func (p *AnAccountProvider) AuthorizationURL(_ context.Context, state, codeChallenge, nonce, redirectUri string) (string, error) {
+	baseURL := getAnAccountBaseURL()
+	clientID := getAnAccountClientID()
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", clientID)
+	params.Set("redirect_uri", redirectUri)
+	params.Set("scope", "openid profile email")
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", codeChallenge)
+	params.Set("code_challenge_method", "S256")
+
+	return fmt.Sprintf("%s/oauth2/authorize?%s", baseURL, params.Encode()), nil
+}
+
+func (p *AnAccountProvider) Exchange(ctx context.Context, code, codeVerifier, nonce, redirectUri string) (*ExternalIdentity, error) {
+	tokenResp, err := p.exchangeCodeForToken(code, codeVerifier, redirectUri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+
+	// 사용자 식별은 서명을 확인한 ID 토큰의 sub로만 하고, userinfo는 프로필을 채우는 데만 사용
+	if tokenResp.IDToken == "" {
+		return nil, pkg.NewUnauthorizedError("ID token is missing from the token response")
+	}
+	claims, err := p.verifier.Verify(ctx, tokenResp.IDToken, nonce)
+	if errors.Is(err, oidc.ErrDiscovery) {
+		return nil, fmt.Errorf("failed to verify ID token: %w", err)
+	}
+	if err != nil {
+		return nil, pkg.NewUnauthorizedError(fmt.Sprintf("Invalid ID token: %v", err))
+	}
+
+	if _, err := parseSubject(claims.Subject); err != nil {
+		return nil, pkg.NewUnauthorizedError(err.Error())
+	}
+
+	userInfo, err := p.getUserInfo(tokenResp.AccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user info: %w", err)
+	}
+	if userInfo.Sub != claims.Subject {
+		return nil, pkg.NewUnauthorizedError("userinfo subject does not match the ID token")
+	}
+
+	return &ExternalIdentity{
+		Provider: entity.ProviderAnAccount,
+		Subject:  claims.Subject,
+		Name:     userInfo.Name,
+		Email:    userInfo.Email,
+		Picture:  userInfo.Picture,
+		// An-Account가 아는 동아리 부원이면 학과와 기수를 가져옴
+		PartOf:     userInfo.Department,
+		Generation: userInfo.Generation,
+	}, nil
+}
+
+func (p *AnAccountProvider) exchangeCodeForToken(code, codeVerifier, redirectUri string) (*dto.TokenResponse, error) {
+	baseURL := getAnAccountBaseURL()
+	clientID := getAnAccountClientID()
+	clientSecret := getAnAccountClientSecret()
+
+	data := url.Values{}
+	data.Set("grant_type", "authorization_code")
+	data.Set("code", code)
+	data.Set("redirect_uri", redirectUri)
+	data.Set("client_id", clientID)
+	data.Set("client_secret", clientSecret)
+	data.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequest("POST", baseURL+"/oauth2/token", strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("token request failed: %s", string(body))
+	}
+
+	var tokenResp dto.TokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return nil, err
+	}
+
+	return &tokenResp, nil
+}
+
+func (p *AnAccountProvider) getUserInfo(accessToken string) (*dto.UserInfoResponse, error) {
+	baseURL := getAnAccountBaseURL()
+
+	req, err := http.NewRequest("GET", baseURL+"/userinfo", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("userinfo request failed: %s", string(body))
+	}
+
+	var userInfo dto.UserInfoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&userInfo); err != nil {
+		return nil, err
+	}
+
+	return &userInfo, nil
+}
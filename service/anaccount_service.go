@@ -3,17 +3,21 @@ package service
 import (
 	"analog-be/dto"
 	"analog-be/entity"
+	"analog-be/pkg"
 	"analog-be/repository"
 	"context"
-	"crypto/rand"
+	"crypto/hmac"
 	"crypto/sha256"
+	"database/sql"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -21,42 +25,68 @@ import (
 )
 
 type AnAccountService interface {
-	InitiateLogin(ctx context.Context, redirectUri string) (*dto.LoginInitResponse, error)
-	InitiateSignup(ctx context.Context, redirectUri string) (*dto.SignupInitResponse, error)
+	InitiateLogin(ctx context.Context, provider, redirectUri string) (*dto.LoginInitResponse, error)
+	InitiateSignup(ctx context.Context, provider, handle, redirectUri string) (*dto.SignupInitResponse, error)
 	HandleCallback(ctx context.Context, code, state string) (*dto.AuthResponse, error)
+	InitiateLink(ctx context.Context, userID entity.ID, provider, redirectUri string) (*dto.LoginInitResponse, error)
+	HandleLinkCallback(ctx context.Context, userID entity.ID, code, state string) (*entity.UserIdentity, error)
 	Logout(ctx context.Context, sessionToken string) error
 	ValidateSession(ctx context.Context, sessionToken string) (*entity.User, error)
 	RefreshAccessToken(refreshToken string) (*dto.TokenResponse, error)
 }
 
+// AnAccountServiceImpl 은 신원 제공자를 통한 로그인, 가입, 외부 계정 연결 흐름을 처리합니다.
+// provider를 비우면 An-Account를 사용합니다.
 type AnAccountServiceImpl struct {
-	stateRepo   repository.OAuthStateRepository
-	sessionRepo repository.SessionRepository
-	userRepo    repository.UserRepository
-	httpClient  *http.Client
+	stateRepo     repository.OAuthStateRepository
+	sessionRepo   repository.SessionRepository
+	userRepo      repository.UserRepository
+	identityRepo  repository.UserIdentityRepository
+	providers     *IdentityProviders
+	sessionPolicy *pkg.SessionPolicy
+	redirects     *pkg.RedirectAllowlist
+	stateSecret   []byte
+	httpClient    *http.Client
 }
 
 func NewAnAccountOAuthService(
 	stateRepo repository.OAuthStateRepository,
 	sessionRepo repository.SessionRepository,
 	userRepo repository.UserRepository,
+	identityRepo repository.UserIdentityRepository,
+	providers *IdentityProviders,
+	sessionPolicy *pkg.SessionPolicy,
+	redirects *pkg.RedirectAllowlist,
 ) AnAccountService {
 	return &AnAccountServiceImpl{
-		stateRepo:   stateRepo,
-		sessionRepo: sessionRepo,
-		userRepo:    userRepo,
+		stateRepo:     stateRepo,
+		sessionRepo:   sessionRepo,
+		userRepo:      userRepo,
+		identityRepo:  identityRepo,
+		providers:     providers,
+		sessionPolicy: sessionPolicy,
+		redirects:     redirects,
+		stateSecret:   []byte(getOAuthStateSecret()),
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
 	}
 }
 
-func (s *AnAccountServiceImpl) InitiateLogin(ctx context.Context, redirectUri string) (*dto.LoginInitResponse, error) {
-	return s.initiateOAuth(ctx, redirectUri, false)
+func (s *AnAccountServiceImpl) InitiateLogin(ctx context.Context, provider, redirectUri string) (*dto.LoginInitResponse, error) {
+	return s.initiateOAuth(ctx, &entity.OAuthState{Provider: provider, RedirectUri: redirectUri})
 }
 
-func (s *AnAccountServiceImpl) InitiateSignup(ctx context.Context, redirectUri string) (*dto.SignupInitResponse, error) {
-	resp, err := s.initiateOAuth(ctx, redirectUri, true)
+// InitiateSignup 은 handle을 미리 확인해 두고, 콜백에서 사용자를 만들 때 다시 확인합니다.
+func (s *AnAccountServiceImpl) InitiateSignup(ctx context.Context, provider, handle, redirectUri string) (*dto.SignupInitResponse, error) {
+	handle = pkg.NormalizeHandle(handle)
+	if handle != "" {
+		if err := ensureHandleAvailable(ctx, s.userRepo, handle, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := s.initiateOAuth(ctx, &entity.OAuthState{Provider: provider, RedirectUri: redirectUri, IsSignup: true, Handle: handle})
 	if err != nil {
 		return nil, err
 	}
@@ -66,61 +96,92 @@ func (s *AnAccountServiceImpl) InitiateSignup(ctx context.Context, redirectUri s
 	}, nil
 }
 
-func (s *AnAccountServiceImpl) initiateOAuth(ctx context.Context, redirectUri string, isSignup bool) (*dto.LoginInitResponse, error) {
-	state := uuid.New().String()
+// InitiateLink 는 로그인된 사용자에게 다른 제공자의 계정을 연결하는 흐름을 시작합니다.
+func (s *AnAccountServiceImpl) InitiateLink(ctx context.Context, userID entity.ID, provider, redirectUri string) (*dto.LoginInitResponse, error) {
+	return s.initiateOAuth(ctx, &entity.OAuthState{Provider: provider, RedirectUri: redirectUri, LinkUserID: userID})
+}
 
-	codeVerifier, err := generateCodeVerifier()
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate code verifier: %w", err)
+func (s *AnAccountServiceImpl) initiateOAuth(ctx context.Context, oauthState *entity.OAuthState) (*dto.LoginInitResponse, error) {
+	// 허용하지 않은 주소로 인가 코드가 넘어가지 않도록 흐름을 시작하기 전에 확인
+	if !s.redirects.Allowed(oauthState.RedirectUri) {
+		return nil, pkg.NewBadRequestError("redirectUri is not in the allowlist", map[string]string{"redirectUri": oauthState.RedirectUri})
+	}
+
+	if oauthState.Provider == "" {
+		oauthState.Provider = entity.ProviderAnAccount
 	}
+	provider, ok := s.providers.Get(oauthState.Provider)
+	if !ok {
+		return nil, pkg.NewBadRequestError("Unknown identity provider", map[string]string{"provider": oauthState.Provider})
+	}
+
+	state := uuid.New().String()
 
-	codeChallenge := generateCodeChallenge(codeVerifier)
+	codeChallenge := generateCodeChallenge(s.deriveCodeVerifier(state))
 
-	oauthState := &entity.OAuthState{
-		State:        state,
-		CodeVerifier: codeVerifier,
-		RedirectUri:  redirectUri,
-		IsSignup:     isSignup,
-		ExpiresAt:    time.Now().UTC().Add(10 * time.Minute),
-		CreatedAt:    time.Now().UTC(),
+	authUrl, err := provider.AuthorizationURL(ctx, state, codeChallenge, s.deriveNonce(state), oauthState.RedirectUri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build authorization url: %w", err)
 	}
 
+	oauthState.State = state
+	oauthState.ExpiresAt = time.Now().UTC().Add(10 * time.Minute)
+	oauthState.CreatedAt = time.Now().UTC()
+
 	err = s.stateRepo.Create(ctx, oauthState)
 	if err != nil {
 		return nil, fmt.Errorf("failed to save OAuth state: %w", err)
 	}
 
-	authUrl := s.buildAuthorizationURL(state, codeChallenge, redirectUri)
-
 	return &dto.LoginInitResponse{
 		AuthorizationUrl: authUrl,
 		State:            state,
 	}, nil
 }
 
-func (s *AnAccountServiceImpl) HandleCallback(ctx context.Context, code, state string) (*dto.AuthResponse, error) {
-	oauthState, err := s.stateRepo.FindByState(ctx, state)
+// completeOAuth 는 state를 소모하고 인가 코드를 교환해 제공자가 확인한 사용자를 반환합니다.
+func (s *AnAccountServiceImpl) completeOAuth(ctx context.Context, code, state string) (*entity.OAuthState, *ExternalIdentity, error) {
+	// 같은 state와 코드를 다시 보내도 한 번만 쓰이도록 먼저 지우면서 읽음
+	oauthState, err := s.stateRepo.Consume(ctx, state)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, pkg.NewBadRequestError("Invalid state", nil)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("invalid state: %w", err)
+		return nil, nil, fmt.Errorf("failed to consume OAuth state: %w", err)
 	}
 
 	if time.Now().UTC().After(oauthState.ExpiresAt) {
-		return nil, fmt.Errorf("state expired")
+		return nil, nil, pkg.NewBadRequestError("State expired", nil)
 	}
 
-	defer s.stateRepo.Delete(ctx, state)
+	provider, ok := s.providers.Get(oauthState.Provider)
+	if !ok {
+		return nil, nil, pkg.NewBadRequestError("Identity provider is no longer available", map[string]string{"provider": oauthState.Provider})
+	}
+
+	codeVerifier := oauthState.CodeVerifier
+	if codeVerifier == "" {
+		codeVerifier = s.deriveCodeVerifier(state)
+	}
 
-	tokenResp, err := s.exchangeCodeForToken(code, oauthState.CodeVerifier, oauthState.RedirectUri)
+	identity, err := provider.Exchange(ctx, code, codeVerifier, s.deriveNonce(state), oauthState.RedirectUri)
 	if err != nil {
-		return nil, fmt.Errorf("failed to exchange code: %w", err)
+		return nil, nil, err
 	}
 
-	userInfo, err := s.getUserInfo(tokenResp.AccessToken)
+	return oauthState, identity, nil
+}
+
+func (s *AnAccountServiceImpl) HandleCallback(ctx context.Context, code, state string) (*dto.AuthResponse, error) {
+	oauthState, identity, err := s.completeOAuth(ctx, code, state)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user info: %w", err)
+		return nil, err
+	}
+	if oauthState.LinkUserID != 0 {
+		return nil, pkg.NewBadRequestError("State was issued for linking an account", nil)
 	}
 
-	user, err := s.findOrCreateUser(ctx, userInfo, oauthState.IsSignup)
+	user, err := s.findOrCreateUser(ctx, identity, oauthState)
 	if err != nil {
 		return nil, err
 	}
@@ -135,6 +196,7 @@ func (s *AnAccountServiceImpl) HandleCallback(ctx context.Context, code, state s
 		User: &dto.UserDTO{
 			ID:           user.ID,
 			Name:         user.Name,
+			Handle:       user.Handle,
 			ProfileImage: user.ProfileImage,
 			PartOf:       user.PartOf,
 			Generation:   user.Generation,
@@ -144,6 +206,56 @@ func (s *AnAccountServiceImpl) HandleCallback(ctx context.Context, code, state s
 	}, nil
 }
 
+// HandleLinkCallback 은 외부 계정을 userID에 연결합니다. state를 시작한 사용자만 완료할 수 있습니다.
+func (s *AnAccountServiceImpl) HandleLinkCallback(ctx context.Context, userID entity.ID, code, state string) (*entity.UserIdentity, error) {
+	oauthState, identity, err := s.completeOAuth(ctx, code, state)
+	if err != nil {
+		return nil, err
+	}
+	if oauthState.LinkUserID == 0 {
+		return nil, pkg.NewBadRequestError("State was not issued for linking an account", nil)
+	}
+	if oauthState.LinkUserID != userID {
+		return nil, pkg.NewForbiddenError("State was issued for another user")
+	}
+
+	existing, err := s.identityRepo.FindByProviderSubject(ctx, identity.Provider, identity.Subject)
+	if err == nil {
+		if existing.UserID != userID {
+			return nil, pkg.NewConflictError("This account is already linked to another user")
+		}
+		return existing, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	linked, err := s.identityRepo.FindAllByUserID(ctx, &userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range linked {
+		if l.Provider == identity.Provider {
+			return nil, pkg.NewConflictError(fmt.Sprintf("Another %s account is already linked; unlink it first", identity.Provider))
+		}
+	}
+
+	now := time.Now().UTC()
+	userIdentity := &entity.UserIdentity{
+		UserID:     userID,
+		Provider:   identity.Provider,
+		Subject:    identity.Subject,
+		Email:      identity.Email,
+		CreatedAt:  now,
+		LastUsedAt: now,
+	}
+	if err := s.identityRepo.Create(ctx, userIdentity); err != nil {
+		return nil, fmt.Errorf("failed to link identity: %w", err)
+	}
+
+	return userIdentity, nil
+}
+
 func (s *AnAccountServiceImpl) Logout(ctx context.Context, sessionToken string) error {
 	return s.sessionRepo.Delete(ctx, sessionToken)
 }
@@ -202,116 +314,78 @@ func (s *AnAccountServiceImpl) RefreshAccessToken(refreshToken string) (*dto.Tok
 	return &tokenResp, nil
 }
 
-func (s *AnAccountServiceImpl) buildAuthorizationURL(state, codeChallenge, redirectUri string) string {
-	baseURL := getAnAccountBaseURL()
-	clientID := getAnAccountClientID()
-
-	params := url.Values{}
-	params.Set("response_type", "code")
-	params.Set("client_id", clientID)
-	params.Set("redirect_uri", redirectUri)
-	params.Set("scope", "openid profile email")
-	params.Set("state", state)
-	params.Set("code_challenge", codeChallenge)
-	params.Set("code_challenge_method", "S256")
-
-	return fmt.Sprintf("%s/oauth2/authorize?%s", baseURL, params.Encode())
-}
-
-func (s *AnAccountServiceImpl) exchangeCodeForToken(code, codeVerifier, redirectUri string) (*dto.TokenResponse, error) {
-	baseURL := getAnAccountBaseURL()
-	clientID := getAnAccountClientID()
-	clientSecret := getAnAccountClientSecret()
-
-	data := url.Values{}
-	data.Set("grant_type", "authorization_code")
-	data.Set("code", code)
-	data.Set("redirect_uri", redirectUri)
-	data.Set("client_id", clientID)
-	data.Set("client_secret", clientSecret)
-	data.Set("code_verifier", codeVerifier)
-
-	req, err := http.NewRequest("POST", baseURL+"/oauth2/token", strings.NewReader(data.Encode()))
-	if err != nil {
+// findOrCreateUser 는 외부 계정에 연결된 사용자를 찾고, 가입 흐름이면 사용자를 만들어 계정을 연결합니다.
+// An-Account로 가입하면 An-Account 아이디를, 다른 제공자로 가입하면 음수 아이디를 씁니다.
+func (s *AnAccountServiceImpl) findOrCreateUser(ctx context.Context, identity *ExternalIdentity, oauthState *entity.OAuthState) (*entity.User, error) {
+	linked, err := s.identityRepo.FindByProviderSubject(ctx, identity.Provider, identity.Subject)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("token request failed: %s", string(body))
-	}
-
-	var tokenResp dto.TokenResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
-		return nil, err
-	}
-
-	return &tokenResp, nil
-}
-
-func (s *AnAccountServiceImpl) getUserInfo(accessToken string) (*dto.UserInfoResponse, error) {
-	baseURL := getAnAccountBaseURL()
-
-	req, err := http.NewRequest("GET", baseURL+"/userinfo", nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Authorization", "Bearer "+accessToken)
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("userinfo request failed: %s", string(body))
+	if linked != nil {
+		if oauthState.IsSignup {
+			return nil, pkg.NewConflictError("User already exists, please login instead")
+		}
+		if err := s.identityRepo.TouchLastUsed(ctx, &linked.ID, time.Now().UTC()); err != nil {
+			return nil, err
+		}
+		return s.userRepo.FindByID(ctx, &linked.UserID)
 	}
 
-	var userInfo dto.UserInfoResponse
-	if err := json.NewDecoder(resp.Body).Decode(&userInfo); err != nil {
-		return nil, err
+	if !oauthState.IsSignup {
+		return nil, pkg.NewNotFoundError("User")
 	}
 
-	return &userInfo, nil
-}
-
-func (s *AnAccountServiceImpl) findOrCreateUser(ctx context.Context, userInfo *dto.UserInfoResponse, isSignup bool) (*entity.User, error) {
 	var userID entity.ID
-	fmt.Sscanf(userInfo.Sub, "%d", &userID)
-
-	user, err := s.userRepo.FindByID(ctx, &userID)
-	if err == nil {
-		if isSignup {
-			return nil, fmt.Errorf("user already exists, please login instead")
+	if identity.Provider == entity.ProviderAnAccount {
+		userID, err = parseSubject(identity.Subject)
+		if err != nil {
+			return nil, err
+		}
+		// An-Account 연결을 끊은 사용자는 같은 아이디로 다시 가입할 수 없으므로 연결하도록 안내
+		if _, err := s.userRepo.FindByID(ctx, &userID); err == nil {
+			return nil, pkg.NewConflictError("User already exists; log in with a linked provider and link this account instead")
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+	} else {
+		userID, err = s.identityRepo.NextExternalUserID(ctx)
+		if err != nil {
+			return nil, err
 		}
-		return user, nil
 	}
 
-	if !isSignup {
-		return nil, fmt.Errorf("user not found, please sign up first")
+	handle := oauthState.Handle
+	if handle == "" {
+		handle = defaultHandle(userID)
+	} else if err := ensureHandleAvailable(ctx, s.userRepo, handle, nil); err != nil {
+		// 가입을 시작한 뒤 다른 사용자가 먼저 가져갔을 수 있음
+		return nil, err
 	}
 
 	now := time.Now()
 	newUser := &entity.User{
 		ID:           userID,
-		Name:         userInfo.Name,
-		ProfileImage: userInfo.Picture,
+		Name:         identity.Name,
+		Handle:       handle,
+		ProfileImage: identity.Picture,
 		JoinedAt:     now,
-		PartOf:       "", // TODO: impl
-		Generation:   0,  // TODO: impl
+		PartOf:       identity.PartOf,
+		Generation:   identity.Generation,
 		Connections:  []string{},
 	}
+	userIdentity := &entity.UserIdentity{
+		Provider:   identity.Provider,
+		Subject:    identity.Subject,
+		Email:      identity.Email,
+		CreatedAt:  now.UTC(),
+		LastUsedAt: now.UTC(),
+	}
 
-	newUser, err = s.userRepo.Create(ctx, newUser)
+	err = s.identityRepo.CreateWithUser(ctx, newUser, userIdentity)
+	if errors.Is(err, repository.ErrHandleTaken) {
+		return nil, pkg.NewConflictError("Handle is already taken")
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
@@ -325,11 +399,17 @@ func (s *AnAccountServiceImpl) createSession(ctx context.Context, userID *entity
 		return nil, err
 	}
 
+	now := time.Now().UTC()
+	client, _ := pkg.GetClientInfo(ctx)
+
 	session := &entity.Session{
 		SessionToken: sessionToken,
 		UserID:       *userID,
-		ExpiresAt:    time.Now().UTC().Add(7 * 24 * time.Hour), // 7 days
-		CreatedAt:    time.Now().UTC(),
+		ExpiresAt:    s.sessionPolicy.ExpiresAt(now, now),
+		CreatedAt:    now,
+		LastSeenAt:   now,
+		IPAddress:    client.IP,
+		UserAgent:    client.UserAgent,
 	}
 
 	err = s.sessionRepo.Create(ctx, session)
@@ -340,13 +420,28 @@ func (s *AnAccountServiceImpl) createSession(ctx context.Context, userID *entity
 	return session, nil
 }
 
-func generateCodeVerifier() (string, error) {
-	b := make([]byte, 32)
-	_, err := rand.Read(b)
-	if err != nil {
-		return "", err
+// deriveCodeVerifier 는 state와 서버 비밀값으로 PKCE code_verifier를 만듭니다.
+// 디비에는 state의 해시만 남으므로 디비를 읽을 수 있어도 비밀값 없이는 verifier를 알 수 없습니다.
+func (s *AnAccountServiceImpl) deriveCodeVerifier(state string) string {
+	mac := hmac.New(sha256.New, s.stateSecret)
+	mac.Write([]byte("pkce:" + state))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// deriveNonce 는 ID 토큰이 이 로그인 요청에 대해 발급되었는지 확인하는 nonce를 state로부터 만듭니다.
+func (s *AnAccountServiceImpl) deriveNonce(state string) string {
+	mac := hmac.New(sha256.New, s.stateSecret)
+	mac.Write([]byte("nonce:" + state))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// parseSubject 는 An-Account의 sub(양의 정수 문자열)를 사용자 ID로 바꿉니다.
+func parseSubject(sub string) (entity.ID, error) {
+	id, err := strconv.ParseInt(sub, 10, 64)
+	if err != nil || id <= 0 || strconv.FormatInt(id, 10) != sub {
+		return 0, fmt.Errorf("invalid subject %q", sub)
 	}
-	return base64.RawURLEncoding.EncodeToString(b), nil
+	return id, nil
 }
 
 func generateCodeChallenge(verifier string) string {
@@ -362,12 +457,45 @@ func getAnAccountBaseURL() string {
 	return baseURL
 }
 
+// AN_ACCOUNT_ISSUER가 없으면 AN_ACCOUNT_BASE_URL을 발급자로 사용
+func getAnAccountIssuer() string {
+	if issuer := os.Getenv("AN_ACCOUNT_ISSUER"); issuer != "" {
+		return issuer
+	}
+	return getAnAccountBaseURL()
+}
+
 func getAnAccountClientID() string {
 	clientID := os.Getenv("AN_ACCOUNT_CLIENT_ID")
 
 	return clientID
 }
 
+// minOAuthStateSecretLength 는 OAUTH_STATE_SECRET의 최소 길이(바이트)입니다.
+const minOAuthStateSecretLength = 32
+
+// OAUTH_STATE_SECRET은 PKCE code_verifier와 nonce를 만드는 HMAC 키로만 씀
+// 다른 용도의 비밀값을 키로 다시 쓰지 않도록 클라이언트 시크릿과 같으면 거부
+// ValidateOAuthStateSecret 는 OAUTH_STATE_SECRET이 충분히 길고 클라이언트 시크릿과 다른지 확인합니다.
+// 서버를 띄우기 전에 확인해 설정이 잘못되었으면 어떤 변수인지 알리고 종료할 수 있도록 합니다.
+func ValidateOAuthStateSecret() error {
+	secret := os.Getenv("OAUTH_STATE_SECRET")
+	if len(secret) < minOAuthStateSecretLength {
+		return fmt.Errorf("OAUTH_STATE_SECRET must be set to at least %d bytes", minOAuthStateSecretLength)
+	}
+	if secret == os.Getenv("AN_ACCOUNT_CLIENT_SECRET") {
+		return errors.New("OAUTH_STATE_SECRET must not be the same as AN_ACCOUNT_CLIENT_SECRET")
+	}
+	return nil
+}
+
+func getOAuthStateSecret() string {
+	if err := ValidateOAuthStateSecret(); err != nil {
+		panic(err.Error())
+	}
+	return os.Getenv("OAUTH_STATE_SECRET")
+}
+
 func getAnAccountClientSecret() string {
 	clientSecret := os.Getenv("AN_ACCOUNT_CLIENT_SECRET")
 	if clientSecret == "" {
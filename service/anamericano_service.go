@@ -1,9 +1,15 @@
 package service
 
 import (
-	"context"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/sunrin-ana/anamericano-golang"
 )
@@ -19,15 +25,32 @@ type AnAmericanoService interface {
 		relation string,
 		ns string,
 		targetId int64) (*anamericano.Permission, error)
+	Delete(
+		userID int64,
+		relation string,
+		ns string,
+		targetId int64) error
+	Read(
+		ns string,
+		targetId int64) ([]anamericano.Permission, error)
+	ListObjects(
+		userID int64,
+		relation string,
+		ns string) ([]string, error)
 }
 
+// AnAmericanoServiceImpl 은 An-Americano 권한 API를 호출합니다.
+// anamericano-golang 클라이언트는 호스트가 고정되어 있어, 요청/응답 타입만 사용하고
+// 전송은 AN_AMERICANO_BASE_URL(기본값: AN_ACCOUNT_BASE_URL)로 직접 보냅니다.
 type AnAmericanoServiceImpl struct {
-	client *anamericano.Client
+	httpClient *http.Client
 }
 
 func NewAnAmericanoService() AnAmericanoService {
 	return &AnAmericanoServiceImpl{
-		client: anamericano.NewClient(&anamericano.ContextTokenAuth{}, nil),
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
 	}
 }
 
@@ -37,17 +60,14 @@ func (s *AnAmericanoServiceImpl) Check(
 	ns string,
 	targetId string) (bool, error) {
 
-	token := os.Getenv("AN_ACCOUNT_API_TOKEN")
-
-	ctx := anamericano.WithToken(context.Background(), token)
-
-	resp, err := s.client.CheckPermission(ctx, &anamericano.PermissionCheckRequest{
+	var resp anamericano.PermissionCheckResponse
+	err := s.do("POST", "/api/anamericano/check", &anamericano.PermissionCheckRequest{
 		SubjectType:     "user",
 		SubjectID:       strconv.FormatInt(userID, 10),
 		Relation:        relation,
 		ObjectNamespace: ns,
 		ObjectID:        targetId,
-	})
+	}, &resp)
 
 	if err != nil {
 		return false, err
@@ -61,22 +81,112 @@ func (s *AnAmericanoServiceImpl) Write(
 	relation string,
 	ns string,
 	targetId int64) (*anamericano.Permission, error) {
-	// TODO: 맞나?
-	token := os.Getenv("AN_ACCOUNT_API_TOKEN")
-
-	ctx := anamericano.WithToken(context.Background(), token)
 
-	resp, err := s.client.WritePermission(ctx, &anamericano.PermissionWriteRequest{
+	var resp anamericano.Permission
+	err := s.do("POST", "/api/anamericano/write", &anamericano.PermissionWriteRequest{
 		ObjectNamespace: ns,
 		ObjectID:        strconv.FormatInt(targetId, 10),
 		Relation:        relation,
 		SubjectType:     "user",
 		SubjectID:       strconv.FormatInt(userID, 10),
-	})
+	}, &resp)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return resp, nil
+	return &resp, nil
+}
+
+func (s *AnAmericanoServiceImpl) Delete(
+	userID int64,
+	relation string,
+	ns string,
+	targetId int64) error {
+
+	return s.do("DELETE", "/api/anamericano/delete", &anamericano.PermissionDeleteRequest{
+		ObjectNamespace: ns,
+		ObjectID:        strconv.FormatInt(targetId, 10),
+		Relation:        relation,
+		SubjectType:     "user",
+		SubjectID:       strconv.FormatInt(userID, 10),
+	}, nil)
+}
+
+func (s *AnAmericanoServiceImpl) Read(
+	ns string,
+	targetId int64) ([]anamericano.Permission, error) {
+
+	var perms []anamericano.Permission
+	path := fmt.Sprintf("/api/anamericano/read/%s/%d", url.PathEscape(ns), targetId)
+
+	return perms, s.do("GET", path, nil, &perms)
+}
+
+func (s *AnAmericanoServiceImpl) ListObjects(
+	userID int64,
+	relation string,
+	ns string) ([]string, error) {
+
+	var objects []string
+	path := fmt.Sprintf("/api/anamericano/list/user/%d/%s/%s", userID, url.PathEscape(relation), url.PathEscape(ns))
+
+	return objects, s.do("GET", path, nil, &objects)
+}
+
+func (s *AnAmericanoServiceImpl) do(method, path string, body any, result any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, getAnAmericanoBaseURL()+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	// An-Americano는 An-Account의 서비스 API 토큰으로 인증함 (OAuth 클라이언트 시크릿이 아님)
+	req.Header.Set("Authorization", "Bearer "+os.Getenv("AN_ACCOUNT_API_TOKEN"))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &anamericano.APIError{}
+		if err := json.Unmarshal(data, apiErr); err != nil || apiErr.Status == 0 {
+			apiErr.Status = resp.StatusCode
+			apiErr.Message = string(data)
+			apiErr.Path = path
+		}
+		return apiErr
+	}
+
+	if result != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, result); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func getAnAmericanoBaseURL() string {
+	if baseURL := os.Getenv("AN_AMERICANO_BASE_URL"); baseURL != "" {
+		return baseURL
+	}
+	return getAnAccountBaseURL()
 }
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"analog-be/pkg/fakeana"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/sunrin-ana/anamericano-golang"
+)
+
+func TestAnAmericanoServiceAgainstFake(t *testing.T) {
+	srv := fakeana.NewTestServer(t)
+	s := NewAnAmericanoService()
+
+	if _, err := s.Write(7, "owner", "analog_log", 100); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !srv.HasPermission("analog_log", "100", "owner", "user", "7") {
+		t.Fatal("permission was not stored")
+	}
+
+	allowed, err := s.Check(7, "owner", "analog_log", "100")
+	if err != nil || !allowed {
+		t.Fatalf("check = %v, %v", allowed, err)
+	}
+
+	perms, err := s.Read("analog_log", 100)
+	if err != nil || len(perms) != 1 || perms[0].SubjectID != "7" {
+		t.Fatalf("read = %v, %v", perms, err)
+	}
+
+	objects, err := s.ListObjects(7, "owner", "analog_log")
+	if err != nil || len(objects) != 1 || objects[0] != "100" {
+		t.Fatalf("list = %v, %v", objects, err)
+	}
+
+	if _, err := s.Write(7, "owner", "analog_log", 100); !isAnAmericanoStatus(err, http.StatusConflict) {
+		t.Fatalf("duplicate write err = %v, want 409", err)
+	}
+
+	if err := s.Delete(7, "owner", "analog_log", 100); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := s.Delete(7, "owner", "analog_log", 100); !isAnAmericanoStatus(err, http.StatusNotFound) {
+		t.Fatalf("second delete err = %v, want 404", err)
+	}
+}
+
+func TestAnAmericanoServiceInjectedFailure(t *testing.T) {
+	srv := fakeana.NewTestServer(t)
+	s := NewAnAmericanoService()
+
+	srv.InjectFailure(fakeana.EndpointWrite, fakeana.Failure{Status: http.StatusServiceUnavailable, Times: 1})
+
+	_, err := s.Write(1, "editor", "analog_log", 5)
+	var apiErr *anamericano.APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusServiceUnavailable {
+		t.Fatalf("err = %v, want 503 APIError", err)
+	}
+
+	if _, err := s.Write(1, "editor", "analog_log", 5); err != nil {
+		t.Fatalf("write after failure cleared: %v", err)
+	}
+	if got := srv.Calls(fakeana.EndpointWrite); got != 2 {
+		t.Fatalf("write calls = %d, want 2", got)
+	}
+}
+
+func TestAnAmericanoServiceRejectsBadToken(t *testing.T) {
+	fakeana.NewTestServer(t)
+	t.Setenv("AN_ACCOUNT_API_TOKEN", "wrong")
+
+	_, err := NewAnAmericanoService().Check(1, "owner", "analog_log", "1")
+	if !isAnAmericanoStatus(err, http.StatusUnauthorized) {
+		t.Fatalf("err = %v, want 401", err)
+	}
+}
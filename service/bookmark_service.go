@@ -0,0 +1,286 @@
+package service
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"analog-be/pkg"
+	"analog-be/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// 사용자마다 만들 수 있는 목록 수와 목록 하나에 담을 수 있는 로그 수
+const (
+	maxReadingListsPerUser = 50
+	maxReadingListItems    = 500
+)
+
+type BookmarkService interface {
+	ListBookmarks(ctx context.Context, userID *entity.ID, limit, offset int) (*dto.PaginatedResult[*entity.Bookmark], error)
+	AddBookmark(ctx context.Context, userID *entity.ID, logID *entity.ID) (*entity.Bookmark, error)
+	// RemoveBookmark 는 저장을 취소하고 내 목록에서도 그 로그를 뺍니다. 저장하지 않은 로그여도 오류가 아닙니다.
+	RemoveBookmark(ctx context.Context, userID *entity.ID, logID *entity.ID) error
+
+	// ListReadingLists 는 ownerID의 목록을 순서대로 돌려줍니다. viewerID가 주인이 아니면 공개한 목록만 돌려줍니다.
+	ListReadingLists(ctx context.Context, ownerID *entity.ID, viewerID *entity.ID) ([]*entity.ReadingList, error)
+	// GetReadingList 는 공개하지 않은 남의 목록을 없는 목록으로 취급합니다.
+	GetReadingList(ctx context.Context, listID *entity.ID, viewerID *entity.ID) (*entity.ReadingList, error)
+	CreateReadingList(ctx context.Context, userID *entity.ID, req *dto.ReadingListCreateRequest) (*entity.ReadingList, error)
+	UpdateReadingList(ctx context.Context, listID *entity.ID, userID *entity.ID, req *dto.ReadingListUpdateRequest) (*entity.ReadingList, error)
+	DeleteReadingList(ctx context.Context, listID *entity.ID, userID *entity.ID) error
+
+	ListItems(ctx context.Context, listID *entity.ID, viewerID *entity.ID, limit, offset int) (*dto.PaginatedResult[*entity.ReadingListItem], error)
+	PutItem(ctx context.Context, listID *entity.ID, logID *entity.ID, userID *entity.ID, req *dto.ReadingListItemRequest) (*entity.ReadingListItem, error)
+	RemoveItem(ctx context.Context, listID *entity.ID, logID *entity.ID, userID *entity.ID) error
+}
+
+type BookmarkServiceImpl struct {
+	bookmarkRepository    repository.BookmarkRepository
+	readingListRepository repository.ReadingListRepository
+	logRepository         repository.LogRepository
+}
+
+func NewBookmarkService(bookmarkRepository repository.BookmarkRepository, readingListRepository repository.ReadingListRepository, logRepository repository.LogRepository) BookmarkService {
+	return &BookmarkServiceImpl{
+		bookmarkRepository:    bookmarkRepository,
+		readingListRepository: readingListRepository,
+		logRepository:         logRepository,
+	}
+}
+
+func (s *BookmarkServiceImpl) ListBookmarks(ctx context.Context, userID *entity.ID, limit, offset int) (*dto.PaginatedResult[*entity.Bookmark], error) {
+	bookmarks, total, err := s.bookmarkRepository.FindByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	logIDs := make([]entity.ID, len(bookmarks))
+	for i, bookmark := range bookmarks {
+		logIDs[i] = bookmark.LogID
+	}
+	logs, err := s.findLogs(ctx, logIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, bookmark := range bookmarks {
+		bookmark.Log = logs[bookmark.LogID]
+	}
+	// 읽는 사이에 지워진 로그는 뺌
+	bookmarks = slices.DeleteFunc(bookmarks, func(b *entity.Bookmark) bool { return b.Log == nil })
+
+	return &dto.PaginatedResult[*entity.Bookmark]{
+		Items:  bookmarks,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
+
+// findLogs 는 응답에 담을 로그를 주제, 작성자와 함께 아이디별로 찾습니다.
+func (s *BookmarkServiceImpl) findLogs(ctx context.Context, ids []entity.ID) (map[entity.ID]*entity.Log, error) {
+	logs, err := s.logRepository.FindAllByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[entity.ID]*entity.Log, len(logs))
+	for _, log := range logs {
+		byID[log.ID] = log
+	}
+	return byID, nil
+}
+
+func (s *BookmarkServiceImpl) AddBookmark(ctx context.Context, userID *entity.ID, logID *entity.ID) (*entity.Bookmark, error) {
+	log, err := s.logRepository.FindByID(ctx, logID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmark := &entity.Bookmark{UserID: *userID, LogID: log.ID, CreatedAt: time.Now().UTC()}
+	if err := s.bookmarkRepository.Create(ctx, bookmark); err != nil {
+		return nil, err
+	}
+
+	// 이미 저장한 로그면 처음 저장한 시각을 돌려줌
+	bookmark, err = s.bookmarkRepository.Find(ctx, userID, logID)
+	if err != nil {
+		return nil, err
+	}
+	bookmark.Log = log
+
+	return bookmark, nil
+}
+
+func (s *BookmarkServiceImpl) RemoveBookmark(ctx context.Context, userID *entity.ID, logID *entity.ID) error {
+	return s.bookmarkRepository.Delete(ctx, userID, logID)
+}
+
+func (s *BookmarkServiceImpl) ListReadingLists(ctx context.Context, ownerID *entity.ID, viewerID *entity.ID) ([]*entity.ReadingList, error) {
+	publicOnly := viewerID == nil || *viewerID != *ownerID
+	return s.readingListRepository.FindByUserID(ctx, ownerID, publicOnly)
+}
+
+func (s *BookmarkServiceImpl) GetReadingList(ctx context.Context, listID *entity.ID, viewerID *entity.ID) (*entity.ReadingList, error) {
+	list, err := s.readingListRepository.FindByID(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !list.Public && (viewerID == nil || *viewerID != list.UserID) {
+		return nil, sql.ErrNoRows
+	}
+
+	return list, nil
+}
+
+// ownList 는 userID가 만든 목록만 돌려줍니다. 남의 목록은 공개 여부와 관계없이 고칠 수 없습니다.
+func (s *BookmarkServiceImpl) ownList(ctx context.Context, listID *entity.ID, userID *entity.ID) (*entity.ReadingList, error) {
+	list, err := s.GetReadingList(ctx, listID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if list.UserID != *userID {
+		return nil, pkg.NewForbiddenError("Only the owner can change this reading list")
+	}
+
+	return list, nil
+}
+
+func (s *BookmarkServiceImpl) CreateReadingList(ctx context.Context, userID *entity.ID, req *dto.ReadingListCreateRequest) (*entity.ReadingList, error) {
+	now := time.Now().UTC()
+	list := &entity.ReadingList{
+		UserID:      *userID,
+		Name:        req.Name,
+		Description: req.Description,
+		Public:      req.Public,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	err := s.readingListRepository.Create(ctx, list, maxReadingListsPerUser)
+	if errors.Is(err, repository.ErrReadingListLimit) {
+		return nil, pkg.NewConflictError(fmt.Sprintf("A user can have at most %d reading lists", maxReadingListsPerUser))
+	}
+	if errors.Is(err, repository.ErrReadingListNameTaken) {
+		return nil, pkg.NewConflictError("You already have a reading list with this name")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (s *BookmarkServiceImpl) UpdateReadingList(ctx context.Context, listID *entity.ID, userID *entity.ID, req *dto.ReadingListUpdateRequest) (*entity.ReadingList, error) {
+	list, err := s.ownList(ctx, listID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil || req.Description != nil || req.Public != nil {
+		if req.Name != nil {
+			list.Name = *req.Name
+		}
+		if req.Description != nil {
+			list.Description = *req.Description
+		}
+		if req.Public != nil {
+			list.Public = *req.Public
+		}
+
+		err := s.readingListRepository.Update(ctx, list)
+		if errors.Is(err, repository.ErrReadingListNameTaken) {
+			return nil, pkg.NewConflictError("You already have a reading list with this name")
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Position != nil {
+		if err := s.readingListRepository.Move(ctx, list, *req.Position); err != nil {
+			return nil, err
+		}
+	}
+
+	return list, nil
+}
+
+func (s *BookmarkServiceImpl) DeleteReadingList(ctx context.Context, listID *entity.ID, userID *entity.ID) error {
+	list, err := s.ownList(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.readingListRepository.Delete(ctx, list)
+}
+
+func (s *BookmarkServiceImpl) ListItems(ctx context.Context, listID *entity.ID, viewerID *entity.ID, limit, offset int) (*dto.PaginatedResult[*entity.ReadingListItem], error) {
+	if _, err := s.GetReadingList(ctx, listID, viewerID); err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.readingListRepository.FindItems(ctx, listID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	logIDs := make([]entity.ID, len(items))
+	for i, item := range items {
+		logIDs[i] = item.LogID
+	}
+	logs, err := s.findLogs(ctx, logIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		item.Log = logs[item.LogID]
+	}
+	items = slices.DeleteFunc(items, func(i *entity.ReadingListItem) bool { return i.Log == nil })
+
+	return &dto.PaginatedResult[*entity.ReadingListItem]{
+		Items:  items,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
+
+// PutItem 은 로그를 목록에 담거나 목록 안에서 옮깁니다. 목록에 담은 로그는 저장 목록에도 들어갑니다.
+func (s *BookmarkServiceImpl) PutItem(ctx context.Context, listID *entity.ID, logID *entity.ID, userID *entity.ID, req *dto.ReadingListItemRequest) (*entity.ReadingListItem, error) {
+	list, err := s.ownList(ctx, listID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	log, err := s.logRepository.FindByID(ctx, logID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, pkg.NewNotFoundError("Log")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.readingListRepository.PutItem(ctx, list, logID, req.Position, maxReadingListItems)
+	if errors.Is(err, repository.ErrReadingListFull) {
+		return nil, pkg.NewConflictError(fmt.Sprintf("A reading list can hold at most %d logs", maxReadingListItems))
+	}
+	if err != nil {
+		return nil, err
+	}
+	item.Log = log
+
+	return item, nil
+}
+
+func (s *BookmarkServiceImpl) RemoveItem(ctx context.Context, listID *entity.ID, logID *entity.ID, userID *entity.ID) error {
+	if _, err := s.ownList(ctx, listID, userID); err != nil {
+		return err
+	}
+
+	return s.readingListRepository.RemoveItem(ctx, listID, logID)
+}
@@ -3,37 +3,81 @@ package service
 import (
 	"analog-be/dto"
 	"analog-be/entity"
+	"analog-be/pkg"
 	"analog-be/repository"
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
 )
 
 type CommentService interface {
 	Create(ctx context.Context, req *dto.CommentCreateRequest, logID *entity.ID, authorID *entity.ID) (*entity.Comment, error)
-	Update(ctx context.Context, commentID *entity.ID, req *dto.CommentUpdateRequest) (*entity.Comment, error)
+	Update(ctx context.Context, commentID *entity.ID, userID *entity.ID, req *dto.CommentUpdateRequest) (*entity.Comment, error)
 	Delete(ctx context.Context, commentID *entity.ID) error
-	FindByLogID(ctx context.Context, logID *entity.ID, limit, offset int) (*dto.PaginatedResult[*entity.Comment], error)
+	FindByLogID(ctx context.Context, logID *entity.ID, viewerID *entity.ID, sort string, limit, offset int) (*dto.PaginatedResult[*entity.Comment], error)
+	FindReplies(ctx context.Context, logID *entity.ID, commentID *entity.ID, viewerID *entity.ID, limit, offset int) (*dto.PaginatedResult[*entity.Comment], error)
+	FindRevisions(ctx context.Context, logID *entity.ID, commentID *entity.ID, userID *entity.ID) ([]*entity.CommentRevision, error)
 	GetById(ctx context.Context, commentID *entity.ID) (*entity.Comment, error)
+	CanModerate(ctx context.Context, logID *entity.ID, userID *entity.ID) (bool, error)
+	SetHidden(ctx context.Context, logID *entity.ID, commentID *entity.ID, moderatorID *entity.ID, hidden bool) (*entity.Comment, error)
+	SetCommentMode(ctx context.Context, logID *entity.ID, userID *entity.ID, mode string) (*entity.Log, error)
 }
 
 type CommentServiceImpl struct {
 	commentRepository repository.CommentRepository
 	logRepository     repository.LogRepository
+	userRepository    repository.UserRepository
+	commentPolicy     *pkg.CommentPolicy
+	markdown          *pkg.CommentMarkdown
+
+	notificationService NotificationService
+	realtimeService     RealtimeService
 }
 
-func NewCommentService(commentRepository repository.CommentRepository, logRepository repository.LogRepository) CommentService {
-	return &CommentServiceImpl{commentRepository: commentRepository, logRepository: logRepository}
+func NewCommentService(commentRepository repository.CommentRepository, logRepository repository.LogRepository, userRepository repository.UserRepository, commentPolicy *pkg.CommentPolicy, markdown *pkg.CommentMarkdown, notificationService NotificationService, realtimeService RealtimeService) CommentService {
+	return &CommentServiceImpl{
+		commentRepository:   commentRepository,
+		logRepository:       logRepository,
+		userRepository:      userRepository,
+		commentPolicy:       commentPolicy,
+		markdown:            markdown,
+		notificationService: notificationService,
+		realtimeService:     realtimeService,
+	}
 }
 
 func (s *CommentServiceImpl) Create(ctx context.Context, req *dto.CommentCreateRequest, logID *entity.ID, authorID *entity.ID) (*entity.Comment, error) {
-	_, err := s.logRepository.FindByID(ctx, logID)
+	log, err := s.logRepository.FindByID(ctx, logID)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkCommentMode(log, authorID); err != nil {
+		return nil, err
+	}
 
 	comment := &entity.Comment{
-		LogID:    *logID,
-		AuthorID: *authorID,
-		Content:  req.Content,
+		LogID:     *logID,
+		AuthorID:  *authorID,
+		Content:   req.Content,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	var parent *entity.Comment
+	if req.ParentID != nil {
+		parent, err = s.replyParent(ctx, logID, req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		comment.ParentID = parent.ID
+		comment.Depth = parent.Depth + 1
+	}
+
+	comment.PreRendered, comment.Mentions, err = s.render(ctx, req.Content)
+	if err != nil {
+		return nil, err
 	}
 
 	comment, err = s.commentRepository.Create(ctx, comment)
@@ -41,22 +85,145 @@ func (s *CommentServiceImpl) Create(ctx context.Context, req *dto.CommentCreateR
 		return nil, err
 	}
 
+	// 멘션, 답글, 로그 댓글 순으로 한 사람에게 하나만 보냄
+	events := []NotificationEvent{s.mentionEvent(comment, comment.Mentions)}
+	if parent != nil {
+		events = append(events, NotificationEvent{
+			Type:         entity.NotificationReply,
+			ActorID:      *authorID,
+			RecipientIDs: []entity.ID{parent.AuthorID},
+			LogID:        log.ID,
+			CommentID:    comment.ID,
+			GroupKey:     fmt.Sprintf("reply:comment:%d", parent.ID),
+		})
+	}
+	events = append(events, NotificationEvent{
+		Type:         entity.NotificationComment,
+		ActorID:      *authorID,
+		RecipientIDs: userIDs(log.LoggedBy),
+		LogID:        log.ID,
+		CommentID:    comment.ID,
+		GroupKey:     fmt.Sprintf("comment:log:%d", log.ID),
+	})
+	s.notificationService.Notify(ctx, events...)
+
+	// 응답에 작성자를 담기 위해 다시 읽음
+	comment, err = s.commentRepository.FindByID(ctx, &comment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.realtimeService.Publish(ctx, RealtimeMessage{
+		Channel: entity.LogRealtimeChannel(log.ID),
+		Type:    entity.RealtimeComment,
+		Data:    dto.NewCommentResponse(comment),
+	})
 	return comment, nil
 }
 
-func (s *CommentServiceImpl) Update(ctx context.Context, commentID *entity.ID, req *dto.CommentUpdateRequest) (*entity.Comment, error) {
+// render 는 내용을 HTML로 바꾸고, 멘션 중 지금 그 핸들을 쓰는 사용자가 있는 것만 프로필 링크로 만듭니다.
+func (s *CommentServiceImpl) render(ctx context.Context, content string) (string, []*entity.User, error) {
+	doc := s.markdown.Parse(content)
+
+	mentioned, err := s.userRepository.FindAllByHandles(ctx, doc.Handles())
+	if err != nil {
+		return "", nil, err
+	}
+
+	handles := make(map[string]bool, len(mentioned))
+	for _, user := range mentioned {
+		handles[user.Handle] = true
+	}
+
+	rendered, err := doc.Render(func(handle string) bool { return handles[handle] })
+	if err != nil {
+		return "", nil, err
+	}
+
+	return rendered, mentioned, nil
+}
+
+func (s *CommentServiceImpl) mentionEvent(comment *entity.Comment, mentioned []*entity.User) NotificationEvent {
+	return NotificationEvent{
+		Type:         entity.NotificationMention,
+		ActorID:      comment.AuthorID,
+		RecipientIDs: userIDs(mentioned),
+		LogID:        comment.LogID,
+		CommentID:    comment.ID,
+		GroupKey:     fmt.Sprintf("mention:comment:%d", comment.ID),
+	}
+}
+
+// replyParent 는 답글을 달 부모 댓글이 같은 로그에 있고, 지워지거나 숨겨지지 않았고, 깊이 제한 안에 있는지 확인합니다.
+func (s *CommentServiceImpl) replyParent(ctx context.Context, logID *entity.ID, parentID *entity.ID) (*entity.Comment, error) {
+	parent, err := s.commentRepository.FindByID(ctx, parentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, pkg.NewNotFoundError("Parent comment")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if parent.LogID != *logID {
+		return nil, pkg.NewBadRequestError("Parent comment belongs to another log", nil)
+	}
+	if parent.Deleted() {
+		return nil, pkg.NewBadRequestError("Cannot reply to a deleted comment", nil)
+	}
+	if parent.Hidden() {
+		return nil, pkg.NewBadRequestError("Cannot reply to a hidden comment", nil)
+	}
+	if !s.commentPolicy.CanReply(parent.Depth) {
+		return nil, pkg.NewBadRequestError(fmt.Sprintf("Replies can be nested at most %d levels deep", s.commentPolicy.MaxDepth), nil)
+	}
+
+	return parent, nil
+}
+
+func (s *CommentServiceImpl) Update(ctx context.Context, commentID *entity.ID, userID *entity.ID, req *dto.CommentUpdateRequest) (*entity.Comment, error) {
 	comment, err := s.commentRepository.FindByID(ctx, commentID)
 	if err != nil {
 		return nil, err
 	}
 
+	log, err := s.logRepository.FindByID(ctx, &comment.LogID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCommentMode(log, userID); err != nil {
+		return nil, err
+	}
+
+	// 내용이 같으면 고친 기록을 남기지 않음
+	if comment.Content == req.Content {
+		return comment, nil
+	}
+
+	mentioned := make(map[entity.ID]bool, len(comment.Mentions))
+	for _, user := range comment.Mentions {
+		mentioned[user.ID] = true
+	}
+
 	comment.Content = req.Content
+	comment.PreRendered, comment.Mentions, err = s.render(ctx, req.Content)
+	if err != nil {
+		return nil, err
+	}
 
 	err = s.commentRepository.Update(ctx, comment)
 	if err != nil {
 		return nil, err
 	}
 
+	// 고치면서 새로 멘션한 사람에게만 알림
+	var added []*entity.User
+	for _, user := range comment.Mentions {
+		if !mentioned[user.ID] {
+			added = append(added, user)
+		}
+	}
+	s.notificationService.Notify(ctx, s.mentionEvent(comment, added))
+
 	return comment, nil
 }
 
@@ -64,16 +231,23 @@ func (s *CommentServiceImpl) Delete(ctx context.Context, commentID *entity.ID) e
 	return s.commentRepository.Delete(ctx, commentID)
 }
 
-func (s *CommentServiceImpl) FindByLogID(ctx context.Context, logID *entity.ID, limit, offset int) (*dto.PaginatedResult[*entity.Comment], error) {
-	_, err := s.logRepository.FindByID(ctx, logID)
+// FindByLogID 는 viewerID가 로그 작성자가 아니면 숨긴 댓글의 작성자와 내용을 비우고, 댓글을 끈 로그에서는 빈 목록을 돌려줍니다.
+func (s *CommentServiceImpl) FindByLogID(ctx context.Context, logID *entity.ID, viewerID *entity.ID, sort string, limit, offset int) (*dto.PaginatedResult[*entity.Comment], error) {
+	log, err := s.logRepository.FindByID(ctx, logID)
 	if err != nil {
 		return nil, err
 	}
 
-	comment, total, err := s.commentRepository.FindByLogID(ctx, logID)
+	moderator := isModerator(log, viewerID)
+	if log.CommentMode == entity.CommentModeOff && !moderator {
+		return &dto.PaginatedResult[*entity.Comment]{Items: []*entity.Comment{}, Limit: limit, Offset: offset}, nil
+	}
+
+	comment, total, err := s.commentRepository.FindByLogID(ctx, logID, sort, limit, offset)
 	if err != nil {
 		return nil, err
 	}
+	concealHidden(comment, viewerID, moderator)
 
 	return &dto.PaginatedResult[*entity.Comment]{
 		Items:  comment,
@@ -83,11 +257,171 @@ func (s *CommentServiceImpl) FindByLogID(ctx context.Context, logID *entity.ID,
 	}, nil
 }
 
+func (s *CommentServiceImpl) FindReplies(ctx context.Context, logID *entity.ID, commentID *entity.ID, viewerID *entity.ID, limit, offset int) (*dto.PaginatedResult[*entity.Comment], error) {
+	log, err := s.logRepository.FindByID(ctx, logID)
+	if err != nil {
+		return nil, err
+	}
+
+	parent, err := s.commentRepository.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent.LogID != *logID {
+		return nil, pkg.NewBadRequestError("Invalid Log ID", nil)
+	}
+
+	moderator := isModerator(log, viewerID)
+	if log.CommentMode == entity.CommentModeOff && !moderator {
+		return &dto.PaginatedResult[*entity.Comment]{Items: []*entity.Comment{}, Limit: limit, Offset: offset}, nil
+	}
+
+	replies, total, err := s.commentRepository.FindReplies(ctx, commentID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	concealHidden(replies, viewerID, moderator)
+
+	return &dto.PaginatedResult[*entity.Comment]{
+		Items:  replies,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
+
+// FindRevisions 는 댓글의 고친 기록을 최근 것부터 돌려줍니다. 댓글 작성자와 로그 작성자만 볼 수 있습니다.
+func (s *CommentServiceImpl) FindRevisions(ctx context.Context, logID *entity.ID, commentID *entity.ID, userID *entity.ID) ([]*entity.CommentRevision, error) {
+	comment, err := s.GetById(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.LogID != *logID {
+		return nil, pkg.NewBadRequestError("Invalid Log ID", nil)
+	}
+
+	if comment.AuthorID != *userID {
+		authors, err := s.logRepository.FindAuthorRelations(ctx, logID)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.ContainsFunc(authors, func(author *entity.LogToUser) bool { return author.UserID == *userID }) {
+			return nil, pkg.NewForbiddenError("Only the comment author and log authors can view edit history")
+		}
+	}
+
+	return s.commentRepository.FindRevisions(ctx, commentID)
+}
+
+// GetById 는 지워진 자리만 남은 댓글을 없는 댓글로 취급합니다.
 func (s *CommentServiceImpl) GetById(ctx context.Context, commentID *entity.ID) (*entity.Comment, error) {
 	comment, err := s.commentRepository.FindByID(ctx, commentID)
 	if err != nil {
 		return nil, err
 	}
+	if comment.Deleted() {
+		return nil, sql.ErrNoRows
+	}
+
+	return comment, nil
+}
+
+// CanModerate 는 userID가 로그 작성자(owner, editor)라 댓글을 숨기거나 지울 수 있는지 확인합니다.
+func (s *CommentServiceImpl) CanModerate(ctx context.Context, logID *entity.ID, userID *entity.ID) (bool, error) {
+	log, err := s.logRepository.FindByID(ctx, logID)
+	if err != nil {
+		return false, err
+	}
+
+	return isModerator(log, userID), nil
+}
+
+// SetHidden 은 로그 작성자가 댓글을 숨기거나 다시 보이게 합니다.
+func (s *CommentServiceImpl) SetHidden(ctx context.Context, logID *entity.ID, commentID *entity.ID, moderatorID *entity.ID, hidden bool) (*entity.Comment, error) {
+	log, err := s.logRepository.FindByID(ctx, logID)
+	if err != nil {
+		return nil, err
+	}
+	if !isModerator(log, moderatorID) {
+		return nil, pkg.NewForbiddenError("Only log authors can moderate comments")
+	}
+
+	comment, err := s.GetById(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.LogID != *logID {
+		return nil, pkg.NewBadRequestError("Invalid Log ID", nil)
+	}
+
+	if hidden == comment.Hidden() {
+		return comment, nil
+	}
+
+	comment.HiddenAt, comment.HiddenBy = time.Time{}, 0
+	if hidden {
+		comment.HiddenAt, comment.HiddenBy = time.Now().UTC(), *moderatorID
+	}
+
+	if err := s.commentRepository.UpdateHidden(ctx, comment); err != nil {
+		return nil, err
+	}
 
 	return comment, nil
 }
+
+// SetCommentMode 는 로그 작성자가 로그의 댓글을 열거나, 잠그거나, 끕니다.
+func (s *CommentServiceImpl) SetCommentMode(ctx context.Context, logID *entity.ID, userID *entity.ID, mode string) (*entity.Log, error) {
+	log, err := s.logRepository.FindByID(ctx, logID)
+	if err != nil {
+		return nil, err
+	}
+	if !isModerator(log, userID) {
+		return nil, pkg.NewForbiddenError("Only log authors can change comment settings")
+	}
+
+	if err := s.logRepository.UpdateCommentMode(ctx, logID, mode); err != nil {
+		return nil, err
+	}
+
+	log.CommentMode = mode
+	return log, nil
+}
+
+// isModerator 는 userID가 로그 작성자(owner, editor)인지 확인합니다. 익명이면 nil입니다.
+func isModerator(log *entity.Log, userID *entity.ID) bool {
+	if userID == nil {
+		return false
+	}
+	return slices.ContainsFunc(log.LoggedBy, func(author *entity.User) bool { return author.ID == *userID })
+}
+
+// checkCommentMode 는 댓글을 끈 로그에서는 아무도, 잠근 로그에서는 로그 작성자만 댓글을 쓰거나 고칠 수 있게 합니다.
+func checkCommentMode(log *entity.Log, userID *entity.ID) error {
+	switch log.CommentMode {
+	case entity.CommentModeOff:
+		return pkg.NewForbiddenError("Comments are turned off for this log")
+	case entity.CommentModeLocked:
+		if !isModerator(log, userID) {
+			return pkg.NewForbiddenError("Comments are locked on this log")
+		}
+	}
+	return nil
+}
+
+// concealHidden 은 숨긴 댓글의 작성자와 내용을 비웁니다. 로그 작성자와 댓글 작성자 본인은 그대로 봅니다.
+func concealHidden(comments []*entity.Comment, viewerID *entity.ID, moderator bool) {
+	if moderator {
+		return
+	}
+
+	for _, comment := range comments {
+		if !comment.Hidden() || (viewerID != nil && comment.AuthorID == *viewerID) {
+			continue
+		}
+		comment.Content = ""
+		comment.PreRendered = ""
+		comment.Mentions = nil
+		comment.Author = nil
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"analog-be/repository"
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// EmailDigestLockKey 는 주간 소식을 준비하기 전에 잡는 advisory lock 키입니다. ("digest"의 ASCII)
+const EmailDigestLockKey int64 = 0x646967657374
+
+// 보낼 때가 된 주간 소식이 있는지 확인하는 간격
+const emailDigestInterval = time.Hour
+
+// EmailDigestRunner 는 주기적으로 보낼 때가 된 사용자의 주간 소식을 메일 outbox에 쌓습니다.
+// 여러 인스턴스가 동시에 실행되어도 advisory lock을 잡은 한 곳에서만 준비합니다.
+type EmailDigestRunner interface {
+	Run(ctx context.Context)
+	RunOnce(ctx context.Context) error
+}
+
+type EmailDigestRunnerImpl struct {
+	lockRepository repository.LockRepository
+	emailService   EmailService
+	logger         *zap.Logger
+}
+
+func NewEmailDigestRunner(lockRepository repository.LockRepository, emailService EmailService, logger *zap.Logger) EmailDigestRunner {
+	return &EmailDigestRunnerImpl{
+		lockRepository: lockRepository,
+		emailService:   emailService,
+		logger:         logger,
+	}
+}
+
+// Run 은 시작하자마자 한 번, 이후 ctx가 취소될 때까지 주기적으로 준비합니다.
+func (r *EmailDigestRunnerImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(emailDigestInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			r.logger.Error("Failed to prepare email digests", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *EmailDigestRunnerImpl) RunOnce(ctx context.Context) error {
+	unlock, err := r.lockRepository.TryLock(ctx, EmailDigestLockKey)
+	if err != nil {
+		return fmt.Errorf("failed to acquire email digest lock: %w", err)
+	}
+	if unlock == nil {
+		r.logger.Debug("Email digests are being prepared on another instance")
+		return nil
+	}
+	defer unlock()
+
+	started := time.Now()
+	n, err := r.emailService.SendDigests(ctx, started.UTC())
+	if n > 0 {
+		r.logger.Info("Queued email digests", zap.Int("count", n), zap.Duration("took", time.Since(started)))
+	}
+	return err
+}
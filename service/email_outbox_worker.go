@@ -0,0 +1,203 @@
+package service
+
+import (
+	"analog-be/entity"
+	"analog-be/pkg"
+	"analog-be/pkg/mailer"
+	"analog-be/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	emailOutboxInterval    = 5 * time.Second
+	emailOutboxBatchSize   = 20
+	emailOutboxLease       = 5 * time.Minute
+	emailOutboxMaxAttempts = 8
+	emailOutboxBaseBackoff = 30 * time.Second
+	emailOutboxMaxBackoff  = 6 * time.Hour
+
+	// 메일에 담은 수신 거부 링크를 쓸 수 있는 기간
+	emailUnsubscribeTokenTTL = 90 * 24 * time.Hour
+)
+
+// EmailOutboxWorker 는 email_outbox에 쌓인 메일을 sender로 보냅니다.
+// 여러 인스턴스가 동시에 실행되어도 Claim이 행을 선점하므로 같은 메일을 두 번 보내지 않습니다.
+type EmailOutboxWorker interface {
+	Run(ctx context.Context)
+	ProcessBatch(ctx context.Context) (int, error)
+}
+
+type EmailOutboxWorkerImpl struct {
+	outboxRepository        repository.EmailOutboxRepository
+	emailSettingsRepository repository.EmailSettingsRepository
+	sender                  mailer.Sender
+	policy                  *pkg.EmailPolicy
+	logger                  *zap.Logger
+}
+
+func NewEmailOutboxWorker(outboxRepository repository.EmailOutboxRepository, emailSettingsRepository repository.EmailSettingsRepository, sender mailer.Sender, policy *pkg.EmailPolicy, logger *zap.Logger) EmailOutboxWorker {
+	return &EmailOutboxWorkerImpl{
+		outboxRepository:        outboxRepository,
+		emailSettingsRepository: emailSettingsRepository,
+		sender:                  sender,
+		policy:                  policy,
+		logger:                  logger,
+	}
+}
+
+// Run 은 ctx가 취소될 때까지 주기적으로 메일을 보냅니다.
+func (w *EmailOutboxWorkerImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(emailOutboxInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := w.ProcessBatch(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					w.logger.Error("Failed to process email outbox", zap.Error(err))
+				}
+				break
+			}
+			// 배치가 가득 찼으면 남은 메일이 있을 수 있으므로 바로 이어서 처리
+			if n < emailOutboxBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *EmailOutboxWorkerImpl) ProcessBatch(ctx context.Context) (int, error) {
+	entries, err := w.outboxRepository.Claim(ctx, emailOutboxBatchSize, emailOutboxLease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, entry := range entries {
+		w.process(ctx, entry)
+	}
+
+	return len(entries), nil
+}
+
+func (w *EmailOutboxWorkerImpl) process(ctx context.Context, entry *entity.EmailOutbox) {
+	reason, err := w.cancelReason(ctx, entry)
+	if err == nil && reason != "" {
+		if err := w.outboxRepository.MarkCancelled(ctx, entry.ID, reason); err != nil {
+			w.logger.Error("Failed to cancel email outbox entry", zap.Int64("id", entry.ID), zap.Error(err))
+		}
+		return
+	}
+	if err == nil {
+		err = w.send(ctx, entry)
+	}
+	if err == nil {
+		if err := w.outboxRepository.MarkDone(ctx, entry.ID); err != nil {
+			w.logger.Error("Failed to mark email outbox entry as done", zap.Int64("id", entry.ID), zap.Error(err))
+		}
+		return
+	}
+
+	fields := []zap.Field{
+		zap.Int64("id", entry.ID),
+		zap.String("kind", entry.Kind),
+		zap.Int64("userId", entry.UserID),
+		zap.Int("attempts", entry.Attempts),
+		zap.Error(err),
+	}
+
+	if entry.Attempts >= emailOutboxMaxAttempts {
+		w.logger.Error("Giving up on email outbox entry", fields...)
+		if err := w.outboxRepository.MarkFailed(ctx, entry.ID, err.Error()); err != nil {
+			w.logger.Error("Failed to mark email outbox entry as failed", zap.Int64("id", entry.ID), zap.Error(err))
+		}
+		return
+	}
+
+	w.logger.Warn("Email outbox entry failed, will retry", fields...)
+	next := time.Now().UTC().Add(emailOutboxBackoff(entry.Attempts))
+	if err := w.outboxRepository.MarkRetry(ctx, entry.ID, err.Error(), next); err != nil {
+		w.logger.Error("Failed to reschedule email outbox entry", zap.Int64("id", entry.ID), zap.Error(err))
+	}
+}
+
+// cancelReason 은 쌓은 뒤에 수신을 끄거나 주소를 바꿨으면 보내지 않을 이유를, 보내도 되면 빈 문자열을 돌려줍니다.
+// 재시도하는 동안에도 수신 거부가 바로 반영되고, 바뀐 주소로는 쓸 수 없는 수신 거부 링크를 보내지 않습니다.
+func (w *EmailOutboxWorkerImpl) cancelReason(ctx context.Context, entry *entity.EmailOutbox) (string, error) {
+	settings, err := w.emailSettingsRepository.FindByUserID(ctx, &entry.UserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "email settings removed", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.EqualFold(settings.Email, entry.ToAddress) {
+		return "email address changed", nil
+	}
+	switch {
+	case entry.Kind == entity.EmailNotifications && !settings.Notifications,
+		entry.Kind == entity.EmailDigest && !settings.Digest:
+		return "unsubscribed from " + entry.Kind, nil
+	}
+	return "", nil
+}
+
+// send 는 메일마다 새 수신 거부 토큰을 만들어 해시를 저장하고, 그 토큰을 담은 링크로 본문을 렌더링해 보냅니다.
+func (w *EmailOutboxWorkerImpl) send(ctx context.Context, entry *entity.EmailOutbox) error {
+	if !w.policy.Enabled() {
+		return errors.New("EMAIL_UNSUBSCRIBE_URL is not configured")
+	}
+
+	token, err := generateSecureToken(32)
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{"token": {token}, "list": {entry.Kind}}.Encode()
+	text, html, err := renderQueuedEmail(entry.Template, entry.Data, w.policy.UnsubscribePageURL+"?"+query)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().UTC().Add(emailUnsubscribeTokenTTL)
+	if err := w.emailSettingsRepository.CreateUnsubscribeToken(ctx, entry.UserID, entry.ToAddress, token, expiresAt); err != nil {
+		return err
+	}
+
+	return w.sender.Send(ctx, &mailer.Message{
+		To:      entry.ToAddress,
+		Subject: entry.Subject,
+		Text:    text,
+		HTML:    html,
+		Headers: map[string]string{
+			// RFC 8058 원클릭 수신 거부
+			"List-Unsubscribe":      "<" + w.policy.UnsubscribeURL + "?" + query + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	})
+}
+
+func emailOutboxBackoff(attempts int) time.Duration {
+	backoff := emailOutboxBaseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= emailOutboxMaxBackoff {
+			return emailOutboxMaxBackoff
+		}
+	}
+	return backoff
+}
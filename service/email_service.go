@@ -0,0 +1,396 @@
+package service
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"analog-be/pkg"
+	"analog-be/repository"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// 한 번에 주간 소식을 준비할 사용자 수
+const emailDigestBatchSize = 100
+
+type EmailService interface {
+	// Settings 는 메일 수신 설정과 고를 수 있는 주소를 돌려줍니다. 설정한 적 없으면 모두 꺼져 있습니다.
+	Settings(ctx context.Context, userID *entity.ID) (*entity.EmailSettings, []string, error)
+	UpdateSettings(ctx context.Context, userID *entity.ID, req *dto.EmailSettingsUpdateRequest) (*entity.EmailSettings, []string, error)
+	// FindByUnsubscribeToken 은 로그인 없이 수신 거부 토큰으로 설정을 찾습니다.
+	FindByUnsubscribeToken(ctx context.Context, token string) (*entity.EmailSettings, error)
+	// Unsubscribe 는 토큰 주인의 list 목록(notifications, digest, all) 수신을 끕니다.
+	Unsubscribe(ctx context.Context, token, list string) (*entity.EmailSettings, error)
+	// EnqueueNotifications 는 새로 만들어진 알림을 메일 알림을 켠 받는 사람에게 보낼 메일로 쌓습니다.
+	// 기존 알림에 묶인 알림은 다시 보내지 않고, 원클릭 수신 거부 주소가 설정되지 않았으면 아무것도 쌓지 않습니다.
+	EnqueueNotifications(ctx context.Context, notifications []*entity.Notification) error
+	// SendDigests 는 보낼 때가 된 사용자의 주간 소식을 쌓고 쌓은 메일 수를 돌려줍니다.
+	SendDigests(ctx context.Context, now time.Time) (int, error)
+}
+
+type EmailServiceImpl struct {
+	emailSettingsRepository repository.EmailSettingsRepository
+	emailOutboxRepository   repository.EmailOutboxRepository
+	notificationRepository  repository.NotificationRepository
+	logRepository           repository.LogRepository
+	userIdentityRepository  repository.UserIdentityRepository
+	policy                  *pkg.EmailPolicy
+	logger                  *zap.Logger
+}
+
+func NewEmailService(
+	emailSettingsRepository repository.EmailSettingsRepository,
+	emailOutboxRepository repository.EmailOutboxRepository,
+	notificationRepository repository.NotificationRepository,
+	logRepository repository.LogRepository,
+	userIdentityRepository repository.UserIdentityRepository,
+	policy *pkg.EmailPolicy,
+	logger *zap.Logger,
+) EmailService {
+	return &EmailServiceImpl{
+		emailSettingsRepository: emailSettingsRepository,
+		emailOutboxRepository:   emailOutboxRepository,
+		notificationRepository:  notificationRepository,
+		logRepository:           logRepository,
+		userIdentityRepository:  userIdentityRepository,
+		policy:                  policy,
+		logger:                  logger,
+	}
+}
+
+func (s *EmailServiceImpl) Settings(ctx context.Context, userID *entity.ID) (*entity.EmailSettings, []string, error) {
+	addresses, err := s.addresses(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	settings, err := s.emailSettingsRepository.FindByUserID(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		settings = &entity.EmailSettings{
+			UserID:            *userID,
+			DigestTopicIDs:    []entity.ID{},
+			DigestGenerations: []uint16{},
+		}
+		if len(addresses) > 0 {
+			settings.Email = addresses[0]
+		}
+		return settings, addresses, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return settings, addresses, nil
+}
+
+func (s *EmailServiceImpl) UpdateSettings(ctx context.Context, userID *entity.ID, req *dto.EmailSettingsUpdateRequest) (*entity.EmailSettings, []string, error) {
+	settings, addresses, err := s.Settings(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wasDigest := settings.Digest
+
+	if req.Email != nil {
+		settings.Email = *req.Email
+	}
+	if req.Notifications != nil {
+		settings.Notifications = *req.Notifications
+	}
+	if req.Digest != nil {
+		settings.Digest = *req.Digest
+	}
+	if req.DigestTopicIDs != nil {
+		settings.DigestTopicIDs = uniqueIDs(*req.DigestTopicIDs)
+	}
+	if req.DigestGenerations != nil {
+		settings.DigestGenerations = append([]uint16{}, slices.Compact(slices.Sorted(slices.Values(*req.DigestGenerations)))...)
+	}
+
+	if settings.Email == "" {
+		return nil, nil, pkg.NewBadRequestError("No email address is linked to your account", nil)
+	}
+	// 주소를 확인하는 절차가 없으므로 로그인 제공자가 확인한 주소만 허용
+	if !slices.ContainsFunc(addresses, func(a string) bool { return strings.EqualFold(a, settings.Email) }) {
+		return nil, nil, pkg.NewBadRequestError("Email must be an address of one of your linked accounts", map[string][]string{"allowed": addresses})
+	}
+
+	now := time.Now().UTC()
+	// 새로 켠 주간 소식은 켠 뒤에 올라온 로그부터 담음
+	if settings.Digest && !wasDigest {
+		settings.LastDigestAt = now
+	}
+	if settings.CreatedAt.IsZero() {
+		settings.CreatedAt = now
+	}
+	if settings.LastDigestAt.IsZero() {
+		settings.LastDigestAt = now
+	}
+	settings.UpdatedAt = now
+
+	if err := s.emailSettingsRepository.Save(ctx, settings); err != nil {
+		return nil, nil, err
+	}
+
+	return settings, addresses, nil
+}
+
+func (s *EmailServiceImpl) FindByUnsubscribeToken(ctx context.Context, token string) (*entity.EmailSettings, error) {
+	if token == "" {
+		return nil, pkg.NewBadRequestError("token is required", nil)
+	}
+
+	settings, err := s.emailSettingsRepository.FindByUnsubscribeToken(ctx, token)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, pkg.NewNotFoundError("Email subscription")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func (s *EmailServiceImpl) Unsubscribe(ctx context.Context, token, list string) (*entity.EmailSettings, error) {
+	if list == "" {
+		list = entity.EmailAll
+	}
+	if !slices.Contains(entity.EmailLists, list) {
+		return nil, pkg.NewBadRequestError("Unknown email list", map[string][]string{"allowed": entity.EmailLists})
+	}
+
+	settings, err := s.FindByUnsubscribeToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if list == entity.EmailNotifications || list == entity.EmailAll {
+		settings.Notifications = false
+	}
+	if list == entity.EmailDigest || list == entity.EmailAll {
+		settings.Digest = false
+	}
+	settings.UpdatedAt = time.Now().UTC()
+
+	if err := s.emailSettingsRepository.Save(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func (s *EmailServiceImpl) EnqueueNotifications(ctx context.Context, notifications []*entity.Notification) error {
+	if !s.policy.Enabled() {
+		return nil
+	}
+
+	var recipientIDs, logIDs []entity.ID
+	var fresh []*entity.Notification
+	for _, notification := range notifications {
+		if !notification.CreatedAt.Equal(notification.UpdatedAt) {
+			continue
+		}
+		fresh = append(fresh, notification)
+		recipientIDs = append(recipientIDs, notification.UserID)
+		if notification.LogID != 0 {
+			logIDs = append(logIDs, notification.LogID)
+		}
+	}
+
+	recipients, err := s.emailSettingsRepository.FindNotificationRecipients(ctx, recipientIDs)
+	if err != nil || len(recipients) == 0 {
+		return err
+	}
+
+	preferences, err := s.notificationRepository.FindPreferencesByUserIDs(ctx, recipientIDs)
+	if err != nil {
+		return err
+	}
+
+	logs, err := s.logRepository.FindAllByIDs(ctx, logIDs)
+	if err != nil {
+		return err
+	}
+
+	var entries []*entity.EmailOutbox
+	for _, notification := range fresh {
+		i := slices.IndexFunc(recipients, func(r *entity.EmailSettings) bool { return r.UserID == notification.UserID })
+		if i < 0 {
+			continue
+		}
+		muted := slices.ContainsFunc(preferences, func(p *entity.NotificationPreference) bool {
+			return p.UserID == notification.UserID && p.Type == notification.Type && !p.Email
+		})
+		if muted {
+			continue
+		}
+
+		var log *entity.Log
+		if j := slices.IndexFunc(logs, func(l *entity.Log) bool { return l.ID == notification.LogID }); j >= 0 {
+			log = logs[j]
+		}
+
+		entry, err := s.notificationEmail(recipients[i], notification, log)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+
+	return s.emailOutboxRepository.Enqueue(ctx, entries)
+}
+
+func (s *EmailServiceImpl) notificationEmail(settings *entity.EmailSettings, notification *entity.Notification, log *entity.Log) (*entity.EmailOutbox, error) {
+	actor := "누군가"
+	if notification.Actor != nil {
+		actor = notification.Actor.Name
+	}
+
+	data := notificationEmailData{Heading: notificationHeading(notification.Type, actor)}
+	if log != nil {
+		data.LogTitle = log.Title
+		data.LogURL = BuildLogURL(log)
+	}
+	data.Subject = "[AnAlog] " + data.Heading
+	data.Reason = "AnAlog 알림을 메일로 받도록 설정해서 보내 드린 메일이에요."
+
+	return s.newEmail(settings, entity.EmailNotifications, &data.emailTemplateData, "notification", &data)
+}
+
+func notificationHeading(kind, actor string) string {
+	switch kind {
+	case entity.NotificationComment:
+		return fmt.Sprintf("%s님이 내 로그에 댓글을 남겼어요", actor)
+	case entity.NotificationReply:
+		return fmt.Sprintf("%s님이 내 댓글에 답글을 남겼어요", actor)
+	case entity.NotificationMention:
+		return fmt.Sprintf("%s님이 댓글에서 나를 언급했어요", actor)
+	case entity.NotificationCoAuthor:
+		return fmt.Sprintf("%s님이 나를 로그의 공동 작성자로 추가했어요", actor)
+	case entity.NotificationReaction:
+		return fmt.Sprintf("%s님이 반응을 남겼어요", actor)
+	default:
+		return "새 알림이 있어요"
+	}
+}
+
+func (s *EmailServiceImpl) SendDigests(ctx context.Context, now time.Time) (int, error) {
+	// 보낸 시각을 기록하지 않으므로 주소를 설정하면 밀린 소식을 보냄
+	if !s.policy.Enabled() {
+		return 0, nil
+	}
+
+	sent := 0
+	for {
+		due, err := s.emailSettingsRepository.FindDigestDue(ctx, now.Add(-s.policy.DigestInterval), emailDigestBatchSize)
+		if err != nil {
+			return sent, err
+		}
+
+		for _, settings := range due {
+			if ctx.Err() != nil {
+				return sent, ctx.Err()
+			}
+
+			logs, err := s.logRepository.FindAllForDigest(ctx, settings.LastDigestAt, settings.DigestTopicIDs, settings.DigestGenerations, settings.UserID, s.policy.DigestMaxLogs)
+			if err != nil {
+				return sent, err
+			}
+
+			// 새 로그가 없으면 보내지 않고 다음 주기로 넘김
+			var entry *entity.EmailOutbox
+			if len(logs) > 0 {
+				entry, err = s.digestEmail(settings, logs)
+				if err != nil {
+					return sent, err
+				}
+				sent++
+			}
+
+			if err := s.emailSettingsRepository.CompleteDigest(ctx, settings.UserID, now, entry); err != nil {
+				return sent, err
+			}
+		}
+
+		// 처리한 사용자는 보낸 시각이 바뀌어 다시 조회되지 않음
+		if len(due) < emailDigestBatchSize {
+			return sent, nil
+		}
+	}
+}
+
+func (s *EmailServiceImpl) digestEmail(settings *entity.EmailSettings, logs []*entity.Log) (*entity.EmailOutbox, error) {
+	data := digestEmailData{Logs: make([]digestEmailLog, len(logs))}
+	for i, log := range logs {
+		authors := make([]string, len(log.LoggedBy))
+		for j, user := range log.LoggedBy {
+			authors[j] = user.Name
+		}
+		topics := make([]string, len(log.Topics))
+		for j, topic := range log.Topics {
+			topics[j] = topic.Name
+		}
+
+		data.Logs[i] = digestEmailLog{
+			Title:       log.Title,
+			Description: log.Description,
+			URL:         BuildLogURL(log),
+			Authors:     strings.Join(authors, ", "),
+			Topics:      strings.Join(topics, ", "),
+		}
+	}
+	data.Subject = fmt.Sprintf("[AnAlog] 이번 주 새 로그 %d개", len(logs))
+	data.Reason = "AnAlog 주간 소식을 받도록 설정해서 보내 드린 메일이에요."
+
+	return s.newEmail(settings, entity.EmailDigest, &data.emailTemplateData, "digest", &data)
+}
+
+// newEmail 은 name 템플릿에 넣을 내용을 메일로 쌓습니다.
+// 토큰 원문을 DB에 남기지 않도록 본문은 워커가 보낼 때 새 수신 거부 토큰과 함께 렌더링합니다.
+func (s *EmailServiceImpl) newEmail(settings *entity.EmailSettings, list string, common *emailTemplateData, name string, data any) (*entity.EmailOutbox, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	return &entity.EmailOutbox{
+		UserID:        settings.UserID,
+		Kind:          list,
+		ToAddress:     settings.Email,
+		Subject:       common.Subject,
+		Template:      name,
+		Data:          encoded,
+		Status:        entity.EmailOutboxPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+// addresses 는 연결된 계정에 등록된 메일 주소를 중복 없이 돌려줍니다.
+func (s *EmailServiceImpl) addresses(ctx context.Context, userID *entity.ID) ([]string, error) {
+	identities, err := s.userIdentityRepository.FindAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := []string{}
+	for _, identity := range identities {
+		if identity.Email != "" && !slices.ContainsFunc(addresses, func(a string) bool { return strings.EqualFold(a, identity.Email) }) {
+			addresses = append(addresses, identity.Email)
+		}
+	}
+	return addresses, nil
+}
+
+// uniqueIDs 는 중복을 뺀 ids를 정렬해 돌려줍니다. 비어 있어도 NOT NULL 배열 컬럼에 '{}'로 저장되도록 nil을 돌려주지 않습니다.
+func uniqueIDs(ids []entity.ID) []entity.ID {
+	return append([]entity.ID{}, slices.Compact(slices.Sorted(slices.Values(ids)))...)
+}
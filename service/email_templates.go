@@ -0,0 +1,84 @@
+package service
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+)
+
+//go:embed templates/email/*.tmpl
+var emailTemplateFS embed.FS
+
+var (
+	emailHTMLTemplates = htmltemplate.Must(htmltemplate.ParseFS(emailTemplateFS, "templates/email/*.html.tmpl"))
+	emailTextTemplates = texttemplate.Must(texttemplate.ParseFS(emailTemplateFS, "templates/email/*.txt.tmpl"))
+)
+
+// 모든 메일 템플릿이 받는 공통 내용입니다.
+type emailTemplateData struct {
+	Subject string
+	// 이 메일을 받는 이유
+	Reason string
+	// 메일마다 새 토큰을 담으므로 outbox에 저장하지 않고 보낼 때 채움
+	UnsubscribeURL string `json:"-"`
+}
+
+type notificationEmailData struct {
+	emailTemplateData
+	Heading  string
+	LogTitle string
+	LogURL   string
+}
+
+type digestEmailData struct {
+	emailTemplateData
+	Logs []digestEmailLog
+}
+
+type digestEmailLog struct {
+	Title       string
+	Description string
+	URL         string
+	Authors     string
+	Topics      string
+}
+
+// renderQueuedEmail 은 outbox에 저장한 name 템플릿 내용에 수신 거부 링크를 넣어 텍스트와 HTML 본문을 만듭니다.
+func renderQueuedEmail(name string, data []byte, unsubscribeURL string) (string, string, error) {
+	var v any
+	var common *emailTemplateData
+	switch name {
+	case "notification":
+		d := new(notificationEmailData)
+		v, common = d, &d.emailTemplateData
+	case "digest":
+		d := new(digestEmailData)
+		v, common = d, &d.emailTemplateData
+	default:
+		return "", "", fmt.Errorf("unknown email template %q", name)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return "", "", err
+	}
+	common.UnsubscribeURL = unsubscribeURL
+
+	return renderEmail(name, v)
+}
+
+// renderEmail 은 name.txt.tmpl과 name.html.tmpl로 텍스트와 HTML 본문을 만듭니다.
+func renderEmail(name string, data any) (string, string, error) {
+	var text, html bytes.Buffer
+
+	if err := emailTextTemplates.ExecuteTemplate(&text, name+".txt.tmpl", data); err != nil {
+		return "", "", err
+	}
+	if err := emailHTMLTemplates.ExecuteTemplate(&html, name+".html.tmpl", data); err != nil {
+		return "", "", err
+	}
+
+	return text.String(), html.String(), nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRenderDigestEmail(t *testing.T) {
+	data := &digestEmailData{
+		emailTemplateData: emailTemplateData{
+			Subject:        "[AnAlog] 이번 주 새 로그 1개",
+			Reason:         "주간 소식을 받도록 설정했어요.",
+			UnsubscribeURL: "https://log.ana.st/email/unsubscribe?list=digest&token=abc",
+		},
+		Logs: []digestEmailLog{{
+			Title:   "<script>alert(1)</script>",
+			URL:     "https://log.ana.st/user/logs/hello-1",
+			Authors: "홍길동",
+			Topics:  "Go",
+		}},
+	}
+
+	text, html, err := renderEmail("digest", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(text, "<script>alert(1)</script>") || !strings.Contains(text, "수신 거부: https://log.ana.st/email/unsubscribe?list=digest&token=abc") {
+		t.Fatalf("text = %s", text)
+	}
+	if strings.Contains(html, "<script>") || !strings.Contains(html, "&lt;script&gt;") {
+		t.Fatalf("html title is not escaped: %s", html)
+	}
+	if !strings.Contains(html, `href="https://log.ana.st/email/unsubscribe?list=digest&amp;token=abc"`) || !strings.Contains(html, "홍길동 · Go") {
+		t.Fatalf("html = %s", html)
+	}
+}
+
+func TestRenderNotificationEmail(t *testing.T) {
+	data := &notificationEmailData{
+		emailTemplateData: emailTemplateData{Subject: "제목", Reason: "이유", UnsubscribeURL: "https://log.ana.st/u"},
+		Heading:           notificationHeading("reply", "홍길동"),
+	}
+
+	text, _, err := renderEmail("notification", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(text, "홍길동님이 내 댓글에 답글을 남겼어요\n") || strings.Contains(text, "로그 보기") {
+		t.Fatalf("text = %q", text)
+	}
+}
+
+func TestRenderQueuedEmail(t *testing.T) {
+	data := &notificationEmailData{
+		emailTemplateData: emailTemplateData{Subject: "제목", Reason: "이유", UnsubscribeURL: "https://log.ana.st/stale"},
+		Heading:           "새 알림이 있어요",
+		LogTitle:          "UNSUBSCRIBE_TOKEN 이야기",
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 수신 거부 링크는 보낼 때마다 새로 채우므로 저장하지 않음
+	if strings.Contains(string(encoded), "stale") {
+		t.Fatalf("stored data contains the unsubscribe URL: %s", encoded)
+	}
+
+	text, html, err := renderQueuedEmail("notification", encoded, "https://log.ana.st/u?token=abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text, "UNSUBSCRIBE_TOKEN 이야기") || !strings.Contains(text, "수신 거부: https://log.ana.st/u?token=abc") {
+		t.Fatalf("text = %s", text)
+	}
+	if !strings.Contains(html, `href="https://log.ana.st/u?token=abc"`) {
+		t.Fatalf("html = %s", html)
+	}
+
+	if _, _, err := renderQueuedEmail("unknown", encoded, ""); err == nil {
+		t.Fatal("expected an error for an unknown template")
+	}
+}
@@ -302,9 +302,14 @@ func WriteSitemap(idx int, urls []dto.SitemapURL) error {
 }
 
 func BuildLogURL(log *entity.Log) string {
+	handle := ""
+	if len(log.LoggedBy) > 0 {
+		handle = log.LoggedBy[0].Handle
+	}
+
 	return fmt.Sprintf(
 		os.Getenv("ARITCLE_URL_FORMAT"),
-		log.LoggedBy[0].Handle,
+		handle,
 		fmt.Sprintf("%s-%X", url.PathEscape(strings.ReplaceAll(log.Title, " ", "-")), log.ID), // Help Me (ID: 1) -> Help-Me-1; (제목)-(아이디 HEX)
 	)
 }
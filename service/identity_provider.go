@@ -0,0 +1,94 @@
+package service
+
+import (
+	"analog-be/entity"
+	"context"
+	"os"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// ExternalIdentity 는 신원 제공자가 확인해 준 사용자 정보입니다.
+type ExternalIdentity struct {
+	Provider string
+	// 제공자 안에서 바뀌지 않는 사용자 아이디
+	Subject string
+	Name    string
+	Email   string
+	Picture string
+	// 동아리 소속 정보. 제공자가 알려 줄 때만 채워짐
+	PartOf     string
+	Generation uint16
+}
+
+// IdentityProvider 는 OAuth2 인가 코드 흐름(PKCE)으로 사용자를 확인하는 외부 신원 제공자입니다.
+type IdentityProvider interface {
+	Name() string
+	// AuthorizationURL 은 사용자를 보낼 인가 주소입니다. nonce는 ID 토큰을 발급하는 제공자만 사용합니다.
+	AuthorizationURL(ctx context.Context, state, codeChallenge, nonce, redirectUri string) (string, error)
+	// Exchange 는 인가 코드를 교환하고 사용자를 확인합니다. 확인에 실패하면 pkg.AppError(401)를 반환합니다.
+	Exchange(ctx context.Context, code, codeVerifier, nonce, redirectUri string) (*ExternalIdentity, error)
+}
+
+var providerNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+// IdentityProviders 는 로그인에 쓸 수 있는 신원 제공자 목록입니다. An-Account는 항상 포함됩니다.
+type IdentityProviders struct {
+	providers map[string]IdentityProvider
+	names     []string
+}
+
+// NewIdentityProviders 는 An-Account와 IDP_PROVIDERS(,로 구분)에 적은 제공자로 목록을 만듭니다.
+// 제공자 설정은 IDP_<이름>_CLIENT_ID 같은 환경 변수로 읽고, 잘못된 제공자는 경고를 남기고 건너뜁니다.
+func NewIdentityProviders(logger *zap.Logger) *IdentityProviders {
+	providers := NewIdentityProviderList(NewAnAccountProvider())
+
+	for _, name := range strings.Split(os.Getenv("IDP_PROVIDERS"), ",") {
+		name = strings.TrimSpace(strings.ToLower(name))
+		if name == "" {
+			continue
+		}
+		if !providerNamePattern.MatchString(name) || name == entity.ProviderAnAccount {
+			logger.Warn("Ignoring invalid identity provider name", zap.String("provider", name))
+			continue
+		}
+		if _, exists := providers.providers[name]; exists {
+			continue
+		}
+
+		provider, err := NewOAuthProvider(oauthProviderConfigFromEnv(name))
+		if err != nil {
+			logger.Warn("Ignoring misconfigured identity provider", zap.String("provider", name), zap.Error(err))
+			continue
+		}
+		providers.add(provider)
+	}
+
+	return providers
+}
+
+// NewIdentityProviderList 는 주어진 제공자로 목록을 만듭니다.
+func NewIdentityProviderList(providers ...IdentityProvider) *IdentityProviders {
+	list := &IdentityProviders{providers: make(map[string]IdentityProvider)}
+	for _, p := range providers {
+		list.add(p)
+	}
+	return list
+}
+
+func (p *IdentityProviders) add(provider IdentityProvider) {
+	p.providers[provider.Name()] = provider
+	p.names = append(p.names, provider.Name())
+}
+
+func (p *IdentityProviders) Get(name string) (IdentityProvider, bool) {
+	provider, ok := p.providers[name]
+	return provider, ok
+}
+
+// Names 는 등록된 순서대로 제공자 이름을 반환합니다.
+func (p *IdentityProviders) Names() []string {
+	return append([]string(nil), p.names...)
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"analog-be/entity"
+	"analog-be/pkg"
+	"analog-be/repository"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+type IdentityService interface {
+	List(ctx context.Context, userID *entity.ID) ([]*entity.UserIdentity, error)
+	Unlink(ctx context.Context, userID *entity.ID, identityID *entity.ID) error
+}
+
+type IdentityServiceImpl struct {
+	identityRepo repository.UserIdentityRepository
+}
+
+func NewIdentityService(identityRepo repository.UserIdentityRepository) IdentityService {
+	return &IdentityServiceImpl{identityRepo: identityRepo}
+}
+
+func (s *IdentityServiceImpl) List(ctx context.Context, userID *entity.ID) ([]*entity.UserIdentity, error) {
+	return s.identityRepo.FindAllByUserID(ctx, userID)
+}
+
+// Unlink 는 외부 계정 연결을 끊습니다. 로그인할 수단이 없어지지 않도록 마지막 계정은 끊을 수 없습니다.
+func (s *IdentityServiceImpl) Unlink(ctx context.Context, userID *entity.ID, identityID *entity.ID) error {
+	err := s.identityRepo.DeleteByID(ctx, userID, identityID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return pkg.NewNotFoundError("Identity")
+	}
+	if errors.Is(err, repository.ErrLastIdentity) {
+		return pkg.NewConflictError("Cannot unlink the only identity of a user")
+	}
+	return err
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"analog-be/pkg"
+	"analog-be/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type ImpersonationService interface {
+	Start(ctx context.Context, adminID *entity.ID, req *dto.ImpersonationStartRequest) (*entity.Session, error)
+	End(ctx context.Context, adminID *entity.ID, sessionID *entity.ID) error
+	ListAudits(ctx context.Context, adminID *entity.ID, targetUserID *entity.ID, limit, offset int) (*dto.PaginatedResult[*entity.ImpersonationAudit], error)
+}
+
+// ImpersonationServiceImpl 은 사용자 제보를 확인하려는 관리자가 그 사용자로 보는 대리 세션을 관리합니다.
+// 관리자는 An-Americano의 analog_system:analog#admin 관계로 확인합니다.
+type ImpersonationServiceImpl struct {
+	sessionRepo repository.SessionRepository
+	userRepo    repository.UserRepository
+	auditRepo   repository.ImpersonationAuditRepository
+	anAmericano AnAmericanoService
+	policy      *pkg.ImpersonationPolicy
+}
+
+func NewImpersonationService(
+	sessionRepo repository.SessionRepository,
+	userRepo repository.UserRepository,
+	auditRepo repository.ImpersonationAuditRepository,
+	anAmericano AnAmericanoService,
+	policy *pkg.ImpersonationPolicy,
+) ImpersonationService {
+	return &ImpersonationServiceImpl{
+		sessionRepo: sessionRepo,
+		userRepo:    userRepo,
+		auditRepo:   auditRepo,
+		anAmericano: anAmericano,
+		policy:      policy,
+	}
+}
+
+// Start 는 대상 사용자의 대리 세션을 만듭니다. 다른 관리자로는 볼 수 없고, 대리 세션은 늘어나지 않고 정한 시각에 끝납니다.
+func (s *ImpersonationServiceImpl) Start(ctx context.Context, adminID *entity.ID, req *dto.ImpersonationStartRequest) (*entity.Session, error) {
+	if err := s.requireAdmin(*adminID); err != nil {
+		return nil, err
+	}
+
+	duration, ok := s.policy.Duration(req.DurationMinutes)
+	if !ok {
+		return nil, pkg.NewBadRequestError(fmt.Sprintf("durationMinutes must be at most %d", int(s.policy.MaxDuration.Minutes())), nil)
+	}
+
+	if req.UserID == *adminID {
+		return nil, pkg.NewBadRequestError("Cannot impersonate yourself", nil)
+	}
+	if _, err := s.userRepo.FindByID(ctx, &req.UserID); errors.Is(err, sql.ErrNoRows) {
+		return nil, pkg.NewNotFoundError("User")
+	} else if err != nil {
+		return nil, err
+	}
+
+	targetIsAdmin, err := s.isAdmin(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if targetIsAdmin {
+		return nil, pkg.NewForbiddenError("Cannot impersonate another admin")
+	}
+
+	sessionToken, err := generateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	client, _ := pkg.GetClientInfo(ctx)
+
+	session := &entity.Session{
+		SessionToken:        sessionToken,
+		UserID:              req.UserID,
+		ExpiresAt:           now.Add(duration),
+		CreatedAt:           now,
+		LastSeenAt:          now,
+		IPAddress:           client.IP,
+		UserAgent:           client.UserAgent,
+		ImpersonatorID:      *adminID,
+		ImpersonationReason: req.Reason,
+		AllowWrites:         req.AllowWrites,
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create impersonation session: %w", err)
+	}
+
+	audit := entity.NewImpersonationAudit(session, entity.ImpersonationActionStart)
+	audit.Reason = req.Reason
+	if err := s.auditRepo.Create(ctx, audit); err != nil {
+		// 기록 없이 대리 세션이 남지 않도록 지움
+		_ = s.sessionRepo.DeleteByID(ctx, &session.ID)
+		return nil, fmt.Errorf("failed to write impersonation audit: %w", err)
+	}
+
+	return session, nil
+}
+
+// End 는 관리자가 시작한 대리 세션을 끝냅니다. 다른 관리자의 대리 세션이거나 대리 세션이 아니면 sql.ErrNoRows를 반환합니다.
+func (s *ImpersonationServiceImpl) End(ctx context.Context, adminID *entity.ID, sessionID *entity.ID) error {
+	if err := s.requireAdmin(*adminID); err != nil {
+		return err
+	}
+
+	session, err := s.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session.ImpersonatorID != *adminID {
+		return sql.ErrNoRows
+	}
+
+	if err := s.sessionRepo.DeleteByID(ctx, sessionID); err != nil {
+		return err
+	}
+
+	return s.auditRepo.Create(ctx, entity.NewImpersonationAudit(session, entity.ImpersonationActionEnd))
+}
+
+func (s *ImpersonationServiceImpl) ListAudits(ctx context.Context, adminID *entity.ID, targetUserID *entity.ID, limit, offset int) (*dto.PaginatedResult[*entity.ImpersonationAudit], error) {
+	if err := s.requireAdmin(*adminID); err != nil {
+		return nil, err
+	}
+
+	audits, total, err := s.auditRepo.FindAll(ctx, targetUserID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list impersonation audits: %w", err)
+	}
+
+	return &dto.PaginatedResult[*entity.ImpersonationAudit]{
+		Items:  audits,
+		Total:  *total,
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
+
+func (s *ImpersonationServiceImpl) requireAdmin(userID entity.ID) error {
+	admin, err := s.isAdmin(userID)
+	if err != nil {
+		return err
+	}
+	if !admin {
+		return pkg.NewForbiddenError("Admin permission is required")
+	}
+	return nil
+}
+
+func (s *ImpersonationServiceImpl) isAdmin(userID entity.ID) (bool, error) {
+	admin, err := s.anAmericano.Check(userID, entity.RelationAdmin, entity.SystemPermissionNamespace, entity.SystemObjectID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check admin permission for user %d: %w", userID, err)
+	}
+	return admin, nil
+}
@@ -6,6 +6,8 @@ import (
 	"analog-be/repository"
 	"bytes"
 	"context"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/huantt/plaintext-extractor"
@@ -28,22 +30,26 @@ type LogService interface {
 }
 
 type LogServiceImpl struct {
-	logRepository      repository.LogRepository
-	commentRepository  repository.CommentRepository
-	anamericanoService AnAmericanoService
-	feedService        FeedService
-	plainExtractor     *plaintext.Extractor
-	prerenderJobs      *semaphore.Weighted
+	logRepository     repository.LogRepository
+	commentRepository repository.CommentRepository
+	feedService       FeedService
+	plainExtractor    *plaintext.Extractor
+	prerenderJobs     *semaphore.Weighted
+
+	notificationService NotificationService
+	realtimeService     RealtimeService
 }
 
-func NewLogService(logRepository repository.LogRepository, commentRepository repository.CommentRepository, anamericanoService AnAmericanoService, feedService FeedService) LogService {
+// An-Americano 권한 반영은 LogRepository가 outbox에 기록하고 PermissionOutboxWorker가 처리합니다.
+func NewLogService(logRepository repository.LogRepository, commentRepository repository.CommentRepository, feedService FeedService, notificationService NotificationService, realtimeService RealtimeService) LogService {
 	return &LogServiceImpl{
-		logRepository:      logRepository,
-		commentRepository:  commentRepository,
-		anamericanoService: anamericanoService,
-		feedService:        feedService,
-		plainExtractor:     plaintext.NewMarkdownExtractor(),
-		prerenderJobs:      semaphore.NewWeighted(4),
+		logRepository:       logRepository,
+		commentRepository:   commentRepository,
+		feedService:         feedService,
+		plainExtractor:      plaintext.NewMarkdownExtractor(),
+		prerenderJobs:       semaphore.NewWeighted(4),
+		notificationService: notificationService,
+		realtimeService:     realtimeService,
 	}
 }
 
@@ -129,39 +135,44 @@ func (s *LogServiceImpl) Search(ctx context.Context, query string, limit int, of
 func (s *LogServiceImpl) Create(ctx context.Context, req *dto.LogCreateRequest, authorID *entity.ID) (*entity.Log, error) {
 	now := time.Now().UTC()
 
+	var rendered bytes.Buffer
+	if err := goldmark.Convert([]byte(req.Content), &rendered); err != nil {
+		return nil, err
+	}
+
 	log := &entity.Log{
 		Title:       req.Title,
 		Description: s.BuildDescription(req.Content),
 		Generations: req.Generations,
 		Content:     req.Content,
+		PreRendered: rendered.String(),
 		CreatedAt:   now,
 	}
 
-	authorIDs := make([]entity.ID, len(req.CoAuthorIDs)+1)
+	// 첫 번째가 owner, 나머지는 editor
+	authorIDs := make([]entity.ID, 0, len(req.CoAuthorIDs)+1)
 	authorIDs = append(authorIDs, *authorID)
-	for _, c := range req.CoAuthorIDs {
-		authorIDs = append(authorIDs, c)
-	}
+	authorIDs = append(authorIDs, req.CoAuthorIDs...)
 
-	log, err := s.logRepository.Create(ctx, log, &req.TopicIDs, &req.CoAuthorIDs)
+	log, err := s.logRepository.Create(ctx, log, &req.TopicIDs, &authorIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = s.anamericanoService.Write(*authorID, "owner", "analog_log", log.ID)
+	s.feedService.UpdateFeed()
+	s.notifyCoAuthors(ctx, log.ID, authorID, req.CoAuthorIDs)
+
+	// 작성자와 토픽을 포함해 다시 조회
+	log, err = s.logRepository.FindByID(ctx, &log.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, id := range authorIDs[1:] {
-		_, err = s.anamericanoService.Write(id, "editor", "analog_log", log.ID)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	s.feedService.UpdateFeed()
-
+	s.realtimeService.Publish(ctx, RealtimeMessage{
+		Channel: entity.RealtimeLogsChannel,
+		Type:    entity.RealtimeLog,
+		Data:    dto.NewLogResponse(log),
+	})
 	return log, nil
 }
 
@@ -181,7 +192,35 @@ func (s *LogServiceImpl) Update(ctx context.Context, id *entity.ID, req *dto.Log
 	if req.Content != nil {
 		log.Content = *req.Content
 		log.Description = s.BuildDescription(*req.Content)
+	}
+
+	previousAuthors := userIDs(log.LoggedBy)
+
+	if req.CoAuthorIDs != nil {
+		authorIDs := make([]entity.ID, 0, len(*req.CoAuthorIDs)+1)
+		authorIDs = append(authorIDs, *authorID)
+		authorIDs = append(authorIDs, *req.CoAuthorIDs...)
+		log, err = s.logRepository.Update(ctx, log, req.TopicIDs, &authorIDs)
+	} else {
+		log, err = s.logRepository.Update(ctx, log, req.TopicIDs, nil)
+	}
+
+	if err != nil {
+		return nil, err
+	}
 
+	if req.CoAuthorIDs != nil {
+		var added []entity.ID
+		for _, uid := range *req.CoAuthorIDs {
+			if !slices.Contains(previousAuthors, uid) {
+				added = append(added, uid)
+			}
+		}
+		s.notifyCoAuthors(ctx, log.ID, authorID, added)
+	}
+
+	// 저장이 끝난 뒤에 렌더링해야 렌더링 작업이 이전 내용으로 덮어쓰지 않음
+	if req.Content != nil {
 		go func() {
 			gctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
@@ -199,22 +238,18 @@ func (s *LogServiceImpl) Update(ctx context.Context, id *entity.ID, req *dto.Log
 		}()
 	}
 
-	if req.CoAuthorIDs != nil {
-		authorIDs := make([]entity.ID, len(*req.CoAuthorIDs)+1)
-		authorIDs = append(authorIDs, *authorID)
-		for _, c := range *req.CoAuthorIDs {
-			authorIDs = append(authorIDs, c)
-		}
-		log, err = s.logRepository.Update(ctx, log, req.TopicIDs, &authorIDs)
-	} else {
-		log, err = s.logRepository.Update(ctx, log, req.TopicIDs, nil)
-	}
-
-	if err != nil {
-		return nil, err
-	}
+	return s.logRepository.FindByID(ctx, &log.ID)
+}
 
-	return log, nil
+// notifyCoAuthors 는 공동 작성자로 새로 추가된 사람에게 알립니다.
+func (s *LogServiceImpl) notifyCoAuthors(ctx context.Context, logID entity.ID, actorID *entity.ID, coAuthorIDs []entity.ID) {
+	s.notificationService.Notify(ctx, NotificationEvent{
+		Type:         entity.NotificationCoAuthor,
+		ActorID:      *actorID,
+		RecipientIDs: coAuthorIDs,
+		LogID:        logID,
+		GroupKey:     fmt.Sprintf("coauthor:log:%d", logID),
+	})
 }
 
 func (s *LogServiceImpl) Delete(ctx context.Context, id *entity.ID) error {
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"analog-be/repository"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// MaintenanceLockKey 는 모든 인스턴스가 유지보수 작업 전에 잡는 advisory lock 키입니다. ("analog"의 ASCII)
+const MaintenanceLockKey int64 = 0x616e616c6f67
+
+const (
+	maintenanceInterval = 10 * time.Minute
+
+	// 반영이 끝난 권한 outbox 항목을 보관하는 기간
+	permissionOutboxRetention = 7 * 24 * time.Hour
+
+	// 만료된 개인 액세스 토큰을 목록에 남겨 두는 기간
+	expiredAccessTokenRetention = 30 * 24 * time.Hour
+
+	// 다시 연결한 클라이언트에게 되돌려 줄 수 있도록 실시간 이벤트를 보관하는 기간
+	realtimeEventRetention = 24 * time.Hour
+
+	// 보낸 메일을 보관하는 기간
+	emailOutboxRetention = 7 * 24 * time.Hour
+)
+
+// MaintenanceRunner 는 만료된 세션, OAuth state 같은 쌓이기만 하는 데이터를 주기적으로 정리합니다.
+// 여러 인스턴스가 동시에 실행되어도 advisory lock을 잡은 한 곳에서만 정리합니다.
+type MaintenanceRunner interface {
+	Run(ctx context.Context)
+	RunOnce(ctx context.Context) error
+}
+
+type maintenanceTask struct {
+	name string
+	run  func(ctx context.Context) (int64, error)
+}
+
+type MaintenanceRunnerImpl struct {
+	lockRepository repository.LockRepository
+	tasks          []maintenanceTask
+	logger         *zap.Logger
+}
+
+func NewMaintenanceRunner(
+	lockRepository repository.LockRepository,
+	sessionRepository repository.SessionRepository,
+	stateRepository repository.OAuthStateRepository,
+	outboxRepository repository.PermissionOutboxRepository,
+	accessTokenRepository repository.AccessTokenRepository,
+	realtimeEventRepository repository.RealtimeEventRepository,
+	emailOutboxRepository repository.EmailOutboxRepository,
+	emailSettingsRepository repository.EmailSettingsRepository,
+	logger *zap.Logger,
+) MaintenanceRunner {
+	return &MaintenanceRunnerImpl{
+		lockRepository: lockRepository,
+		tasks: []maintenanceTask{
+			{name: "expired sessions", run: sessionRepository.DeleteExpired},
+			{name: "expired oauth states", run: stateRepository.DeleteExpired},
+			{name: "processed permission outbox", run: func(ctx context.Context) (int64, error) {
+				return outboxRepository.DeleteDoneBefore(ctx, time.Now().UTC().Add(-permissionOutboxRetention))
+			}},
+			{name: "expired access tokens", run: func(ctx context.Context) (int64, error) {
+				return accessTokenRepository.DeleteExpiredBefore(ctx, time.Now().UTC().Add(-expiredAccessTokenRetention))
+			}},
+			{name: "old realtime events", run: func(ctx context.Context) (int64, error) {
+				return realtimeEventRepository.DeleteBefore(ctx, time.Now().UTC().Add(-realtimeEventRetention))
+			}},
+			{name: "sent emails", run: func(ctx context.Context) (int64, error) {
+				return emailOutboxRepository.DeleteDoneBefore(ctx, time.Now().UTC().Add(-emailOutboxRetention))
+			}},
+			{name: "expired unsubscribe tokens", run: emailSettingsRepository.DeleteExpiredUnsubscribeTokens},
+		},
+		logger: logger,
+	}
+}
+
+// Run 은 시작하자마자 한 번, 이후 ctx가 취소될 때까지 주기적으로 정리합니다.
+func (r *MaintenanceRunnerImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(maintenanceInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			r.logger.Error("Maintenance failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce 는 잠금을 잡을 수 있으면 모든 정리 작업을 실행합니다. 한 작업이 실패해도 나머지는 계속합니다.
+func (r *MaintenanceRunnerImpl) RunOnce(ctx context.Context) error {
+	unlock, err := r.lockRepository.TryLock(ctx, MaintenanceLockKey)
+	if err != nil {
+		return fmt.Errorf("failed to acquire maintenance lock: %w", err)
+	}
+	if unlock == nil {
+		r.logger.Debug("Maintenance is running on another instance")
+		return nil
+	}
+	defer unlock()
+
+	var errs []error
+	for _, task := range r.tasks {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		started := time.Now()
+		n, err := task.run(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", task.name, err))
+			continue
+		}
+
+		if n > 0 {
+			r.logger.Info("Removed stale records",
+				zap.String("task", task.name),
+				zap.Int64("count", n),
+				zap.Duration("took", time.Since(started)),
+			)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"analog-be/dto"
+	"analog-be/entity"
+	"analog-be/pkg"
+	"analog-be/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// NotificationEvent 는 다른 서비스가 알림을 만들 때 넘기는 내용입니다.
+// GroupKey가 같은 읽지 않은 알림은 받는 사람마다 하나로 묶입니다.
+type NotificationEvent struct {
+	Type         string
+	ActorID      entity.ID
+	RecipientIDs []entity.ID
+	LogID        entity.ID
+	CommentID    entity.ID
+	GroupKey     string
+}
+
+type NotificationService interface {
+	// Notify 는 이벤트를 앞에서부터 처리하며, 한 사람은 처음 나온 이벤트 하나로만 알림을 받습니다.
+	// 행동한 본인과 그 종류의 알림을 끈 사람은 빠지고, 실패해도 호출한 쪽의 요청은 실패시키지 않고 기록만 남깁니다.
+	// 새로 만들어진 알림은 메일 알림을 켠 받는 사람에게 메일로도 보냅니다.
+	Notify(ctx context.Context, events ...NotificationEvent)
+	List(ctx context.Context, userID *entity.ID, unreadOnly bool, limit, offset int) (*dto.PaginatedResult[*entity.Notification], error)
+	UnreadCount(ctx context.Context, userID *entity.ID) (int, error)
+	MarkRead(ctx context.Context, userID *entity.ID, id *entity.ID) error
+	MarkAllRead(ctx context.Context, userID *entity.ID) (int, error)
+	// Preferences 는 모든 알림 종류의 수신 설정을 돌려줍니다. 바꾼 적 없는 종류는 받는 것으로 봅니다.
+	Preferences(ctx context.Context, userID *entity.ID) ([]*entity.NotificationPreference, error)
+	UpdatePreferences(ctx context.Context, userID *entity.ID, req *dto.NotificationPreferencesUpdateRequest) ([]*entity.NotificationPreference, error)
+}
+
+type NotificationServiceImpl struct {
+	notificationRepository repository.NotificationRepository
+	realtimeService        RealtimeService
+	emailService           EmailService
+	logger                 *zap.Logger
+}
+
+func NewNotificationService(notificationRepository repository.NotificationRepository, realtimeService RealtimeService, emailService EmailService, logger *zap.Logger) NotificationService {
+	return &NotificationServiceImpl{
+		notificationRepository: notificationRepository,
+		realtimeService:        realtimeService,
+		emailService:           emailService,
+		logger:                 logger,
+	}
+}
+
+func (s *NotificationServiceImpl) Notify(ctx context.Context, events ...NotificationEvent) {
+	if err := s.notify(ctx, events); err != nil {
+		s.logger.Error("Failed to create notifications", zap.Error(err))
+	}
+}
+
+func (s *NotificationServiceImpl) notify(ctx context.Context, events []NotificationEvent) error {
+	now := time.Now().UTC()
+	notified := make(map[entity.ID]bool)
+
+	var notifications []*entity.Notification
+	for _, event := range events {
+		recipients := make([]entity.ID, 0, len(event.RecipientIDs))
+		for _, id := range event.RecipientIDs {
+			if id == 0 || id == event.ActorID || notified[id] {
+				continue
+			}
+			notified[id] = true
+			recipients = append(recipients, id)
+		}
+
+		muted, err := s.notificationRepository.FindMutedUserIDs(ctx, recipients, event.Type)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range recipients {
+			if slices.Contains(muted, id) {
+				continue
+			}
+			notifications = append(notifications, &entity.Notification{
+				UserID:     id,
+				Type:       event.Type,
+				GroupKey:   event.GroupKey,
+				LogID:      event.LogID,
+				CommentID:  event.CommentID,
+				ActorID:    event.ActorID,
+				ActorIDs:   []entity.ID{event.ActorID},
+				ActorCount: 1,
+				CreatedAt:  now,
+				UpdatedAt:  now,
+			})
+		}
+	}
+
+	if err := s.notificationRepository.Create(ctx, notifications); err != nil {
+		return err
+	}
+
+	ids := make([]entity.ID, len(notifications))
+	for i, notification := range notifications {
+		ids[i] = notification.ID
+	}
+
+	// 묶인 알림의 행동한 사람 수 등을 반영하기 위해 다시 읽음
+	saved, err := s.notificationRepository.FindAllByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	s.publish(ctx, saved)
+	return s.emailService.EnqueueNotifications(ctx, saved)
+}
+
+// publish 는 받는 사람의 실시간 스트림에 새로 만들어지거나 묶인 알림을 보냅니다.
+func (s *NotificationServiceImpl) publish(ctx context.Context, notifications []*entity.Notification) {
+	messages := make([]RealtimeMessage, len(notifications))
+	for i, notification := range notifications {
+		messages[i] = RealtimeMessage{
+			Channel: entity.UserRealtimeChannel(notification.UserID),
+			Type:    entity.RealtimeNotification,
+			Data:    dto.NewNotificationResponse(notification),
+		}
+	}
+	s.realtimeService.Publish(ctx, messages...)
+}
+
+func (s *NotificationServiceImpl) List(ctx context.Context, userID *entity.ID, unreadOnly bool, limit, offset int) (*dto.PaginatedResult[*entity.Notification], error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	notifications, total, err := s.notificationRepository.FindByUserID(ctx, userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.PaginatedResult[*entity.Notification]{
+		Items:  notifications,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
+
+func (s *NotificationServiceImpl) UnreadCount(ctx context.Context, userID *entity.ID) (int, error) {
+	return s.notificationRepository.CountUnread(ctx, userID)
+}
+
+func (s *NotificationServiceImpl) MarkRead(ctx context.Context, userID *entity.ID, id *entity.ID) error {
+	err := s.notificationRepository.MarkRead(ctx, userID, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return pkg.NewNotFoundError("Notification")
+	}
+	return err
+}
+
+func (s *NotificationServiceImpl) MarkAllRead(ctx context.Context, userID *entity.ID) (int, error) {
+	return s.notificationRepository.MarkAllRead(ctx, userID)
+}
+
+func (s *NotificationServiceImpl) Preferences(ctx context.Context, userID *entity.ID) ([]*entity.NotificationPreference, error) {
+	saved, err := s.notificationRepository.FindPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := make([]*entity.NotificationPreference, 0, len(entity.NotificationTypes))
+	for _, kind := range entity.NotificationTypes {
+		preference := &entity.NotificationPreference{UserID: *userID, Type: kind, InApp: true, Email: true}
+		for _, p := range saved {
+			if p.Type == kind {
+				preference = p
+				break
+			}
+		}
+		preferences = append(preferences, preference)
+	}
+
+	return preferences, nil
+}
+
+func (s *NotificationServiceImpl) UpdatePreferences(ctx context.Context, userID *entity.ID, req *dto.NotificationPreferencesUpdateRequest) ([]*entity.NotificationPreference, error) {
+	current, err := s.Preferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := make([]*entity.NotificationPreference, 0, len(req.Preferences))
+	seen := make(map[string]bool, len(req.Preferences))
+	for _, p := range req.Preferences {
+		if !slices.Contains(entity.NotificationTypes, p.Type) {
+			return nil, pkg.NewBadRequestError("Unknown notification type", map[string][]string{"allowed": entity.NotificationTypes})
+		}
+		if seen[p.Type] {
+			return nil, pkg.NewBadRequestError(fmt.Sprintf("Duplicate notification type %q", p.Type), nil)
+		}
+		seen[p.Type] = true
+
+		i := slices.IndexFunc(current, func(c *entity.NotificationPreference) bool { return c.Type == p.Type })
+		preference := current[i]
+		if p.InApp != nil {
+			preference.InApp = *p.InApp
+		}
+		if p.Email != nil {
+			preference.Email = *p.Email
+		}
+		preferences = append(preferences, preference)
+	}
+
+	if err := s.notificationRepository.SavePreferences(ctx, preferences); err != nil {
+		return nil, err
+	}
+
+	return s.Preferences(ctx, userID)
+}
+
+func userIDs(users []*entity.User) []entity.ID {
+	ids := make([]entity.ID, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	return ids
+}
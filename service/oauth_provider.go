@@ -0,0 +1,316 @@
+package service
+
+import (
+	"analog-be/pkg"
+	"analog-be/pkg/oidc"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// OAuthProviderConfig 는 An-Account 외의 신원 제공자 설정입니다.
+//
+// Issuer가 있으면 OIDC 제공자로 보고 디스커버리 문서에서 엔드포인트를 찾아 ID 토큰으로 사용자를 확인합니다.
+// 없으면 GitHub처럼 OAuth2만 지원하는 제공자로 보고 UserInfoURL 응답의 필드로 사용자를 확인합니다.
+type OAuthProviderConfig struct {
+	Name         string
+	ClientID     string
+	ClientSecret string
+	Issuer       string
+
+	AuthURL     string
+	TokenURL    string
+	UserInfoURL string
+	Scopes      []string
+
+	// UserInfoURL 응답에서 값을 꺼낼 필드 이름. 여러 개면 처음으로 값이 있는 필드를 사용
+	SubjectFields []string
+	NameFields    []string
+	EmailFields   []string
+	PictureFields []string
+}
+
+// oauthProviderPresets 는 IDP_PROVIDERS에 이름만 적어도 쓸 수 있는 제공자 기본값입니다.
+var oauthProviderPresets = map[string]OAuthProviderConfig{
+	"github": {
+		AuthURL:       "https://github.com/login/oauth/authorize",
+		TokenURL:      "https://github.com/login/oauth/access_token",
+		UserInfoURL:   "https://api.github.com/user",
+		Scopes:        []string{"read:user", "user:email"},
+		SubjectFields: []string{"id"},
+		NameFields:    []string{"name", "login"},
+		EmailFields:   []string{"email"},
+		PictureFields: []string{"avatar_url"},
+	},
+	"google": {
+		Issuer: "https://accounts.google.com",
+	},
+}
+
+// oauthProviderConfigFromEnv 는 IDP_<이름>_CLIENT_ID, _CLIENT_SECRET, _ISSUER, _AUTH_URL, _TOKEN_URL, _USERINFO_URL, _SCOPES로
+// 프리셋을 덮어써 설정을 만듭니다. 이름의 하이픈은 밑줄로 바꿉니다.
+func oauthProviderConfigFromEnv(name string) OAuthProviderConfig {
+	config := oauthProviderPresets[name]
+	config.Name = name
+
+	prefix := "IDP_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+	env := func(key string, target *string) {
+		if value := os.Getenv(prefix + key); value != "" {
+			*target = value
+		}
+	}
+	env("CLIENT_ID", &config.ClientID)
+	env("CLIENT_SECRET", &config.ClientSecret)
+	env("ISSUER", &config.Issuer)
+	env("AUTH_URL", &config.AuthURL)
+	env("TOKEN_URL", &config.TokenURL)
+	env("USERINFO_URL", &config.UserInfoURL)
+	if scopes := os.Getenv(prefix + "SCOPES"); scopes != "" {
+		config.Scopes = strings.Fields(strings.ReplaceAll(scopes, ",", " "))
+	}
+
+	return config
+}
+
+// OAuthProvider 는 설정만으로 붙일 수 있는 범용 OAuth2 / OIDC 제공자입니다.
+type OAuthProvider struct {
+	config     OAuthProviderConfig
+	httpClient *http.Client
+	// OIDC 제공자일 때만 있음
+	verifier *oidc.Verifier
+}
+
+func NewOAuthProvider(config OAuthProviderConfig) (*OAuthProvider, error) {
+	if config.ClientID == "" || config.ClientSecret == "" {
+		return nil, errors.New("client id and secret are required")
+	}
+
+	httpClient := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+	provider := &OAuthProvider{config: config, httpClient: httpClient}
+
+	if config.Issuer != "" {
+		if len(config.Scopes) == 0 {
+			provider.config.Scopes = []string{"openid", "profile", "email"}
+		}
+		provider.verifier = oidc.NewVerifier(oidc.Config{
+			Issuer:     config.Issuer,
+			ClientID:   config.ClientID,
+			HTTPClient: httpClient,
+		})
+		return provider, nil
+	}
+
+	if config.AuthURL == "" || config.TokenURL == "" || config.UserInfoURL == "" {
+		return nil, errors.New("either an issuer or auth, token and userinfo urls are required")
+	}
+	if len(config.SubjectFields) == 0 {
+		provider.config.SubjectFields = []string{"sub", "id"}
+	}
+	if len(config.NameFields) == 0 {
+		provider.config.NameFields = []string{"name", "preferred_username", "login"}
+	}
+	if len(config.EmailFields) == 0 {
+		provider.config.EmailFields = []string{"email"}
+	}
+	if len(config.PictureFields) == 0 {
+		provider.config.PictureFields = []string{"picture", "avatar_url"}
+	}
+	return provider, nil
+}
+
+func (p *OAuthProvider) Name() string {
+	return p.config.Name
+}
+
+func (p *OAuthProvider) AuthorizationURL(ctx context.Context, state, codeChallenge, nonce, redirectUri string) (string, error) {
+	authURL := p.config.AuthURL
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", p.config.ClientID)
+	params.Set("redirect_uri", redirectUri)
+	params.Set("scope", strings.Join(p.config.Scopes, " "))
+	params.Set("state", state)
+	params.Set("code_challenge", codeChallenge)
+	params.Set("code_challenge_method", "S256")
+
+	if p.verifier != nil {
+		discovery, err := p.verifier.Discovery(ctx)
+		if err != nil {
+			return "", err
+		}
+		authURL = discovery.AuthorizationEndpoint
+		params.Set("nonce", nonce)
+	}
+
+	separator := "?"
+	if strings.Contains(authURL, "?") {
+		separator = "&"
+	}
+	return authURL + separator + params.Encode(), nil
+}
+
+func (p *OAuthProvider) Exchange(ctx context.Context, code, codeVerifier, nonce, redirectUri string) (*ExternalIdentity, error) {
+	tokenURL := p.config.TokenURL
+	if p.verifier != nil {
+		discovery, err := p.verifier.Discovery(ctx)
+		if err != nil {
+			return nil, err
+		}
+		tokenURL = discovery.TokenEndpoint
+	}
+
+	tokenResp, err := p.exchangeCodeForToken(ctx, tokenURL, code, codeVerifier, redirectUri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+
+	if p.verifier != nil {
+		return p.identityFromIDToken(ctx, tokenResp.IDToken, nonce)
+	}
+	return p.identityFromUserInfo(ctx, tokenResp.AccessToken)
+}
+
+func (p *OAuthProvider) identityFromIDToken(ctx context.Context, idToken, nonce string) (*ExternalIdentity, error) {
+	if idToken == "" {
+		return nil, pkg.NewUnauthorizedError("ID token is missing from the token response")
+	}
+
+	claims, err := p.verifier.Verify(ctx, idToken, nonce)
+	if errors.Is(err, oidc.ErrDiscovery) {
+		return nil, fmt.Errorf("failed to verify ID token: %w", err)
+	}
+	if err != nil {
+		return nil, pkg.NewUnauthorizedError(fmt.Sprintf("Invalid ID token: %v", err))
+	}
+	if claims.Subject == "" {
+		return nil, pkg.NewUnauthorizedError("ID token has no subject")
+	}
+
+	name := claims.Name
+	if name == "" {
+		name = claims.PreferredUsername
+	}
+
+	return &ExternalIdentity{
+		Provider: p.config.Name,
+		Subject:  claims.Subject,
+		Name:     name,
+		Email:    claims.Email,
+		Picture:  claims.Picture,
+	}, nil
+}
+
+func (p *OAuthProvider) identityFromUserInfo(ctx context.Context, accessToken string) (*ExternalIdentity, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.UserInfoURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("userinfo request failed: %s", string(body))
+	}
+
+	// 숫자 아이디(GitHub의 id 등)가 실수로 바뀌지 않도록 json.Number로 읽음
+	var info map[string]any
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to decode user info: %w", err)
+	}
+
+	subject := firstField(info, p.config.SubjectFields)
+	if subject == "" {
+		return nil, pkg.NewUnauthorizedError("userinfo response has no subject")
+	}
+
+	return &ExternalIdentity{
+		Provider: p.config.Name,
+		Subject:  subject,
+		Name:     firstField(info, p.config.NameFields),
+		Email:    firstField(info, p.config.EmailFields),
+		Picture:  firstField(info, p.config.PictureFields),
+	}, nil
+}
+
+type oauthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	IDToken     string `json:"id_token"`
+	Error       string `json:"error"`
+}
+
+func (p *OAuthProvider) exchangeCodeForToken(ctx context.Context, tokenURL, code, codeVerifier, redirectUri string) (*oauthTokenResponse, error) {
+	data := url.Values{}
+	data.Set("grant_type", "authorization_code")
+	data.Set("code", code)
+	data.Set("redirect_uri", redirectUri)
+	data.Set("client_id", p.config.ClientID)
+	data.Set("client_secret", p.config.ClientSecret)
+	data.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// GitHub은 Accept가 없으면 form 형식으로 응답
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token request failed: %s", string(body))
+	}
+
+	var tokenResp oauthTokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, err
+	}
+	// GitHub은 실패해도 200으로 error를 담아 응답
+	if tokenResp.Error != "" || tokenResp.AccessToken == "" {
+		return nil, fmt.Errorf("token request failed: %s", string(body))
+	}
+
+	return &tokenResp, nil
+}
+
+// firstField 는 fields 중 처음으로 값이 있는 필드를 문자열로 반환합니다.
+func firstField(info map[string]any, fields []string) string {
+	for _, field := range fields {
+		switch v := info[field].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case json.Number:
+			return v.String()
+		}
+	}
+	return ""
+}
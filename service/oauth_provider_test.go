@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// GitHub처럼 ID 토큰 없이 userinfo로 사용자를 확인하는 제공자
+func TestOAuthProviderWithUserInfo(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != "good" {
+			json.NewEncoder(w).Encode(map[string]string{"error": "bad_verification_code"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "at"})
+	})
+	mux.HandleFunc("GET /user", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer at" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"id": 12345678901, "login": "octocat", "name": "", "avatar_url": "https://example.com/a.png"}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	config := oauthProviderPresets["github"]
+	config.Name = "github"
+	config.ClientID = "id"
+	config.ClientSecret = "secret"
+	config.AuthURL = srv.URL + "/authorize"
+	config.TokenURL = srv.URL + "/token"
+	config.UserInfoURL = srv.URL + "/user"
+
+	p, err := NewOAuthProvider(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authURL, err := p.AuthorizationURL(context.Background(), "state", "challenge", "nonce", "https://log.ana.st/callback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(authURL)
+	if q := u.Query(); q.Get("state") != "state" || q.Get("scope") != "read:user user:email" || q.Has("nonce") {
+		t.Fatalf("unexpected authorization url: %s", authURL)
+	}
+
+	identity, err := p.Exchange(context.Background(), "good", "verifier", "nonce", "https://log.ana.st/callback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.Provider != "github" || identity.Subject != "12345678901" || identity.Name != "octocat" || identity.Picture != "https://example.com/a.png" {
+		t.Fatalf("unexpected identity: %+v", identity)
+	}
+
+	if _, err := p.Exchange(context.Background(), "bad", "verifier", "nonce", "https://log.ana.st/callback"); err == nil {
+		t.Fatal("expected error for a token response with an error field")
+	}
+}
+
+func TestNewOAuthProviderRequiresEndpoints(t *testing.T) {
+	if _, err := NewOAuthProvider(OAuthProviderConfig{Name: "x", ClientID: "id", ClientSecret: "secret"}); err == nil {
+		t.Fatal("expected error without issuer or endpoints")
+	}
+	if _, err := NewOAuthProvider(OAuthProviderConfig{Name: "x", Issuer: "https://example.com"}); err == nil {
+		t.Fatal("expected error without client credentials")
+	}
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"analog-be/entity"
+	"analog-be/repository"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sunrin-ana/anamericano-golang"
+	"go.uber.org/zap"
+)
+
+const (
+	permissionOutboxInterval    = 5 * time.Second
+	permissionOutboxBatchSize   = 50
+	permissionOutboxLease       = 2 * time.Minute
+	permissionOutboxMaxAttempts = 10
+	permissionOutboxBaseBackoff = 5 * time.Second
+	permissionOutboxMaxBackoff  = 30 * time.Minute
+)
+
+// PermissionOutboxWorker 는 permission_outbox에 쌓인 권한 변경을 An-Americano에 반영합니다.
+// 여러 인스턴스가 동시에 실행되어도 Claim이 행을 선점하므로 같은 항목을 중복 처리하지 않습니다.
+type PermissionOutboxWorker interface {
+	Run(ctx context.Context)
+	ProcessBatch(ctx context.Context) (int, error)
+}
+
+type PermissionOutboxWorkerImpl struct {
+	outboxRepository   repository.PermissionOutboxRepository
+	anamericanoService AnAmericanoService
+	logger             *zap.Logger
+}
+
+func NewPermissionOutboxWorker(outboxRepository repository.PermissionOutboxRepository, anamericanoService AnAmericanoService, logger *zap.Logger) PermissionOutboxWorker {
+	return &PermissionOutboxWorkerImpl{
+		outboxRepository:   outboxRepository,
+		anamericanoService: anamericanoService,
+		logger:             logger,
+	}
+}
+
+// Run 은 ctx가 취소될 때까지 주기적으로 outbox를 처리합니다.
+func (w *PermissionOutboxWorkerImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(permissionOutboxInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := w.ProcessBatch(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					w.logger.Error("Failed to process permission outbox", zap.Error(err))
+				}
+				break
+			}
+			// 배치가 가득 찼으면 남은 항목이 있을 수 있으므로 바로 이어서 처리
+			if n < permissionOutboxBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *PermissionOutboxWorkerImpl) ProcessBatch(ctx context.Context) (int, error) {
+	entries, err := w.outboxRepository.Claim(ctx, permissionOutboxBatchSize, permissionOutboxLease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, entry := range entries {
+		w.process(ctx, entry)
+	}
+
+	return len(entries), nil
+}
+
+func (w *PermissionOutboxWorkerImpl) process(ctx context.Context, entry *entity.PermissionOutbox) {
+	err := w.apply(entry)
+	if err == nil {
+		if err := w.outboxRepository.MarkDone(ctx, entry.ID); err != nil {
+			w.logger.Error("Failed to mark permission outbox entry as done", zap.Int64("id", entry.ID), zap.Error(err))
+		}
+		return
+	}
+
+	fields := []zap.Field{
+		zap.Int64("id", entry.ID),
+		zap.String("operation", entry.Operation),
+		zap.String("relation", entry.Relation),
+		zap.Int64("objectId", entry.ObjectID),
+		zap.Int64("userId", entry.UserID),
+		zap.Int("attempts", entry.Attempts),
+		zap.Error(err),
+	}
+
+	if entry.Attempts >= permissionOutboxMaxAttempts {
+		w.logger.Error("Giving up on permission outbox entry", fields...)
+		if err := w.outboxRepository.MarkFailed(ctx, entry.ID, err.Error()); err != nil {
+			w.logger.Error("Failed to mark permission outbox entry as failed", zap.Int64("id", entry.ID), zap.Error(err))
+		}
+		return
+	}
+
+	w.logger.Warn("Permission outbox entry failed, will retry", fields...)
+	next := time.Now().UTC().Add(permissionOutboxBackoff(entry.Attempts))
+	if err := w.outboxRepository.MarkRetry(ctx, entry.ID, err.Error(), next); err != nil {
+		w.logger.Error("Failed to reschedule permission outbox entry", zap.Int64("id", entry.ID), zap.Error(err))
+	}
+}
+
+func (w *PermissionOutboxWorkerImpl) apply(entry *entity.PermissionOutbox) error {
+	switch entry.Operation {
+	case entity.PermissionOpWrite:
+		_, err := w.anamericanoService.Write(entry.UserID, entry.Relation, entry.Namespace, entry.ObjectID)
+		// 이미 존재하는 권한이면 반영된 것으로 간주
+		if isAnAmericanoStatus(err, http.StatusConflict) {
+			return nil
+		}
+		return err
+	case entity.PermissionOpDelete:
+		err := w.anamericanoService.Delete(entry.UserID, entry.Relation, entry.Namespace, entry.ObjectID)
+		// 이미 없는 권한이면 반영된 것으로 간주
+		if isAnAmericanoStatus(err, http.StatusNotFound) {
+			return nil
+		}
+		return err
+	default:
+		return fmt.Errorf("unknown permission operation: %s", entry.Operation)
+	}
+}
+
+func permissionOutboxBackoff(attempts int) time.Duration {
+	backoff := permissionOutboxBaseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= permissionOutboxMaxBackoff {
+			return permissionOutboxMaxBackoff
+		}
+	}
+	return backoff
+}
+
+func isAnAmericanoStatus(err error, status int) bool {
+	var apiErr *anamericano.APIError
+	return errors.As(err, &apiErr) && apiErr.Status == status
+}
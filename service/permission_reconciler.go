@@ -0,0 +1,250 @@
+package service
+
+import (
+	"analog-be/entity"
+	"analog-be/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+const permissionReconcilePageSize = 100
+
+type PermissionReconcileReport struct {
+	CheckedLogs  int
+	CheckedUsers int
+	Written      int
+	Deleted      int
+	// An-Americano에서 작성자 관계를 가져온 예전 로그 수
+	Imported int
+	Errors   []string
+}
+
+// PermissionReconciler 는 log_to_users와 An-Americano의 analog_log 권한을 비교해 어긋난 부분을 바로잡습니다.
+type PermissionReconciler interface {
+	Reconcile(ctx context.Context, dryRun bool) (*PermissionReconcileReport, error)
+}
+
+type PermissionReconcilerImpl struct {
+	logRepository      repository.LogRepository
+	userRepository     repository.UserRepository
+	anamericanoService AnAmericanoService
+	logger             *zap.Logger
+}
+
+func NewPermissionReconciler(logRepository repository.LogRepository, userRepository repository.UserRepository, anamericanoService AnAmericanoService, logger *zap.Logger) PermissionReconciler {
+	return &PermissionReconcilerImpl{
+		logRepository:      logRepository,
+		userRepository:     userRepository,
+		anamericanoService: anamericanoService,
+		logger:             logger,
+	}
+}
+
+type permissionTuple struct {
+	userID   entity.ID
+	relation string
+}
+
+func (r *PermissionReconcilerImpl) Reconcile(ctx context.Context, dryRun bool) (*PermissionReconcileReport, error) {
+	report := &PermissionReconcileReport{}
+
+	if err := r.reconcileLogs(ctx, dryRun, report); err != nil {
+		return report, err
+	}
+
+	if err := r.reconcileOrphans(ctx, dryRun, report); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// reconcileLogs 는 존재하는 로그마다 기대하는 권한과 실제 권한을 비교합니다.
+func (r *PermissionReconcilerImpl) reconcileLogs(ctx context.Context, dryRun bool, report *PermissionReconcileReport) error {
+	legacyIDs, err := r.logRepository.FindLegacyAuthorLogIDs(ctx)
+	if err != nil {
+		return err
+	}
+	legacy := make(map[entity.ID]bool, len(legacyIDs))
+	for _, id := range legacyIDs {
+		legacy[id] = true
+	}
+
+	for offset := 0; ; offset += permissionReconcilePageSize {
+		logs, _, err := r.logRepository.FindAll(ctx, permissionReconcilePageSize, offset)
+		if err != nil {
+			return err
+		}
+
+		for _, log := range logs {
+			report.CheckedLogs++
+
+			authors, err := r.logRepository.FindAuthorRelations(ctx, &log.ID)
+			if err != nil {
+				return err
+			}
+
+			expected := make(map[permissionTuple]bool, len(authors))
+			for _, author := range authors {
+				expected[permissionTuple{author.UserID, author.Relation}] = true
+			}
+
+			perms, err := r.anamericanoService.Read(entity.LogPermissionNamespace, log.ID)
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("log %d: read permissions: %v", log.ID, err))
+				continue
+			}
+
+			actual := make(map[permissionTuple]bool, len(perms))
+			for _, perm := range perms {
+				if perm.SubjectType != "user" || perm.SubjectRelation != nil || !isLogRelation(perm.Relation) {
+					continue
+				}
+				userID, err := strconv.ParseInt(perm.SubjectID, 10, 64)
+				if err != nil {
+					report.Errors = append(report.Errors, fmt.Sprintf("log %d: unexpected subject %q", log.ID, perm.SubjectID))
+					continue
+				}
+				actual[permissionTuple{userID, perm.Relation}] = true
+			}
+
+			if legacy[log.ID] {
+				if err := r.importLegacyAuthors(ctx, log.ID, authors, actual, dryRun, report); err != nil {
+					return err
+				}
+				continue
+			}
+
+			for tuple := range expected {
+				if !actual[tuple] {
+					r.write(log.ID, tuple, dryRun, report)
+				}
+			}
+			for tuple := range actual {
+				if !expected[tuple] {
+					r.delete(log.ID, tuple, dryRun, report)
+				}
+			}
+		}
+
+		if len(logs) < permissionReconcilePageSize {
+			return nil
+		}
+	}
+}
+
+// importLegacyAuthors 는 작성자 관계를 기록하기 전에 만들어진 로그의 작성자를 An-Americano 권한에서 가져옵니다.
+// 예전에는 작성자 행이 빠지거나 지워질 수 있었으므로 An-Americano의 권한을 기준으로 삼고, 권한은 회수하지 않습니다.
+func (r *PermissionReconcilerImpl) importLegacyAuthors(ctx context.Context, logID entity.ID, authors []*entity.LogToUser, actual map[permissionTuple]bool, dryRun bool, report *PermissionReconcileReport) error {
+	relations := make(map[entity.ID]string, len(actual)+len(authors))
+	for tuple := range actual {
+		if relations[tuple.userID] != entity.RelationOwner {
+			relations[tuple.userID] = tuple.relation
+		}
+	}
+	// 행은 있지만 권한이 없는 작성자는 예전처럼 공동 작성자로 보고 권한을 채움
+	for _, author := range authors {
+		if _, ok := relations[author.UserID]; !ok {
+			relations[author.UserID] = entity.RelationEditor
+			r.write(logID, permissionTuple{author.UserID, entity.RelationEditor}, dryRun, report)
+		}
+	}
+
+	imported := make([]*entity.LogToUser, 0, len(relations))
+	for userID, relation := range relations {
+		imported = append(imported, &entity.LogToUser{LogID: logID, UserID: userID, Relation: relation})
+	}
+
+	r.logger.Info("Importing legacy log authors",
+		zap.Int64("logId", logID), zap.Int("authors", len(imported)), zap.Bool("dryRun", dryRun))
+
+	if !dryRun {
+		if err := r.logRepository.ImportAuthorRelations(ctx, &logID, imported); err != nil {
+			return err
+		}
+	}
+	report.Imported++
+	return nil
+}
+
+// reconcileOrphans 는 삭제된 로그에 남아 있는 권한을 사용자 기준으로 찾아 회수합니다.
+func (r *PermissionReconcilerImpl) reconcileOrphans(ctx context.Context, dryRun bool, report *PermissionReconcileReport) error {
+	for offset := 0; ; offset += permissionReconcilePageSize {
+		users, _, err := r.userRepository.FindAll(ctx, permissionReconcilePageSize, offset)
+		if err != nil {
+			return err
+		}
+
+		for _, user := range users {
+			report.CheckedUsers++
+
+			for _, relation := range []string{entity.RelationOwner, entity.RelationEditor} {
+				objects, err := r.anamericanoService.ListObjects(user.ID, relation, entity.LogPermissionNamespace)
+				if err != nil {
+					report.Errors = append(report.Errors, fmt.Sprintf("user %d: list %s objects: %v", user.ID, relation, err))
+					continue
+				}
+
+				for _, object := range objects {
+					logID, err := strconv.ParseInt(object, 10, 64)
+					if err != nil {
+						report.Errors = append(report.Errors, fmt.Sprintf("user %d: unexpected object %q", user.ID, object))
+						continue
+					}
+
+					_, err = r.logRepository.FindByID(ctx, &logID)
+					if err == nil {
+						continue
+					}
+					if !errors.Is(err, sql.ErrNoRows) {
+						return err
+					}
+
+					r.delete(logID, permissionTuple{user.ID, relation}, dryRun, report)
+				}
+			}
+		}
+
+		if len(users) < permissionReconcilePageSize {
+			return nil
+		}
+	}
+}
+
+func (r *PermissionReconcilerImpl) write(logID entity.ID, tuple permissionTuple, dryRun bool, report *PermissionReconcileReport) {
+	r.logger.Info("Missing permission",
+		zap.Int64("logId", logID), zap.Int64("userId", tuple.userID), zap.String("relation", tuple.relation), zap.Bool("dryRun", dryRun))
+
+	if !dryRun {
+		_, err := r.anamericanoService.Write(tuple.userID, tuple.relation, entity.LogPermissionNamespace, logID)
+		if err != nil && !isAnAmericanoStatus(err, http.StatusConflict) {
+			report.Errors = append(report.Errors, fmt.Sprintf("log %d: write %s for user %d: %v", logID, tuple.relation, tuple.userID, err))
+			return
+		}
+	}
+	report.Written++
+}
+
+func (r *PermissionReconcilerImpl) delete(logID entity.ID, tuple permissionTuple, dryRun bool, report *PermissionReconcileReport) {
+	r.logger.Info("Stale permission",
+		zap.Int64("logId", logID), zap.Int64("userId", tuple.userID), zap.String("relation", tuple.relation), zap.Bool("dryRun", dryRun))
+
+	if !dryRun {
+		err := r.anamericanoService.Delete(tuple.userID, tuple.relation, entity.LogPermissionNamespace, logID)
+		if err != nil && !isAnAmericanoStatus(err, http.StatusNotFound) {
+			report.Errors = append(report.Errors, fmt.Sprintf("log %d: delete %s for user %d: %v", logID, tuple.relation, tuple.userID, err))
+			return
+		}
+	}
+	report.Deleted++
+}
+
+func isLogRelation(relation string) bool {
+	return relation == entity.RelationOwner || relation == entity.RelationEditor
+}
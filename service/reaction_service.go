@@ -0,0 +1,193 @@
+package service
+
+import (
+	"analog-be/entity"
+	"analog-be/pkg"
+	"analog-be/repository"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type ReactionService interface {
+	// Kinds 는 달 수 있는 반응 종류를 돌려줍니다.
+	Kinds() []string
+	// SetLogReaction 은 userID의 반응을 달거나 떼고, 바뀐 개수와 userID가 단 반응을 담은 로그를 돌려줍니다.
+	SetLogReaction(ctx context.Context, logID *entity.ID, userID *entity.ID, kind string, reacted bool) (*entity.Log, error)
+	SetCommentReaction(ctx context.Context, logID *entity.ID, commentID *entity.ID, userID *entity.ID, kind string, reacted bool) (*entity.Comment, error)
+	// MarkLogReactions 는 viewerID가 단 반응을 MyReactions에 채웁니다. 익명이면 아무것도 하지 않습니다.
+	MarkLogReactions(ctx context.Context, logs []*entity.Log, viewerID *entity.ID) error
+	MarkCommentReactions(ctx context.Context, comments []*entity.Comment, viewerID *entity.ID) error
+}
+
+type ReactionServiceImpl struct {
+	reactionRepository repository.ReactionRepository
+	logRepository      repository.LogRepository
+	commentRepository  repository.CommentRepository
+	reactionSet        *pkg.ReactionSet
+
+	notificationService NotificationService
+}
+
+func NewReactionService(reactionRepository repository.ReactionRepository, logRepository repository.LogRepository, commentRepository repository.CommentRepository, reactionSet *pkg.ReactionSet, notificationService NotificationService) ReactionService {
+	return &ReactionServiceImpl{
+		reactionRepository:  reactionRepository,
+		logRepository:       logRepository,
+		commentRepository:   commentRepository,
+		reactionSet:         reactionSet,
+		notificationService: notificationService,
+	}
+}
+
+func (s *ReactionServiceImpl) Kinds() []string {
+	return s.reactionSet.Kinds()
+}
+
+func (s *ReactionServiceImpl) SetLogReaction(ctx context.Context, logID *entity.ID, userID *entity.ID, kind string, reacted bool) (*entity.Log, error) {
+	if err := s.checkKind(kind); err != nil {
+		return nil, err
+	}
+
+	log, err := s.logRepository.FindByID(ctx, logID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 이미 단 반응을 다시 달면 알림을 보내지 않음
+	added := false
+	reaction := &entity.LogReaction{LogID: log.ID, UserID: *userID, Kind: kind}
+	if reacted {
+		log.ReactionCounts, added, err = s.reactionRepository.AddLogReaction(ctx, reaction)
+	} else {
+		log.ReactionCounts, err = s.reactionRepository.RemoveLogReaction(ctx, reaction)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if added {
+		s.notificationService.Notify(ctx, NotificationEvent{
+			Type:         entity.NotificationReaction,
+			ActorID:      *userID,
+			RecipientIDs: userIDs(log.LoggedBy),
+			LogID:        log.ID,
+			GroupKey:     fmt.Sprintf("reaction:log:%d", log.ID),
+		})
+	}
+
+	if err := s.MarkLogReactions(ctx, []*entity.Log{log}, userID); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+// SetCommentReaction 은 지워진 댓글에는 반응할 수 없고, 숨긴 댓글이나 댓글을 잠그거나 끈 로그에서는 새 반응을 달 수 없습니다.
+// 이미 단 반응은 언제든 뗄 수 있습니다.
+func (s *ReactionServiceImpl) SetCommentReaction(ctx context.Context, logID *entity.ID, commentID *entity.ID, userID *entity.ID, kind string, reacted bool) (*entity.Comment, error) {
+	if err := s.checkKind(kind); err != nil {
+		return nil, err
+	}
+
+	log, err := s.logRepository.FindByID(ctx, logID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.commentRepository.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Deleted() {
+		return nil, sql.ErrNoRows
+	}
+	if comment.LogID != log.ID {
+		return nil, pkg.NewBadRequestError("Invalid Log ID", nil)
+	}
+
+	added := false
+	reaction := &entity.CommentReaction{CommentID: comment.ID, UserID: *userID, Kind: kind}
+	if reacted {
+		if err := checkCommentMode(log, userID); err != nil {
+			return nil, err
+		}
+		if comment.Hidden() {
+			return nil, pkg.NewBadRequestError("Cannot react to a hidden comment", nil)
+		}
+		comment.ReactionCounts, added, err = s.reactionRepository.AddCommentReaction(ctx, reaction)
+	} else {
+		comment.ReactionCounts, err = s.reactionRepository.RemoveCommentReaction(ctx, reaction)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if added {
+		s.notificationService.Notify(ctx, NotificationEvent{
+			Type:         entity.NotificationReaction,
+			ActorID:      *userID,
+			RecipientIDs: []entity.ID{comment.AuthorID},
+			LogID:        log.ID,
+			CommentID:    comment.ID,
+			GroupKey:     fmt.Sprintf("reaction:comment:%d", comment.ID),
+		})
+	}
+
+	comment.ReactionCount = 0
+	for _, n := range comment.ReactionCounts {
+		comment.ReactionCount += n
+	}
+
+	if err := s.MarkCommentReactions(ctx, []*entity.Comment{comment}, userID); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (s *ReactionServiceImpl) checkKind(kind string) error {
+	if !s.reactionSet.Allowed(kind) {
+		return pkg.NewBadRequestError("Unknown reaction", map[string][]string{"allowed": s.reactionSet.Kinds()})
+	}
+	return nil
+}
+
+func (s *ReactionServiceImpl) MarkLogReactions(ctx context.Context, logs []*entity.Log, viewerID *entity.ID) error {
+	if viewerID == nil || len(logs) == 0 {
+		return nil
+	}
+
+	ids := make([]entity.ID, len(logs))
+	for i, log := range logs {
+		ids[i] = log.ID
+	}
+
+	mine, err := s.reactionRepository.FindLogReactionsByUser(ctx, viewerID, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, log := range logs {
+		log.MyReactions = mine[log.ID]
+	}
+	return nil
+}
+
+func (s *ReactionServiceImpl) MarkCommentReactions(ctx context.Context, comments []*entity.Comment, viewerID *entity.ID) error {
+	if viewerID == nil || len(comments) == 0 {
+		return nil
+	}
+
+	ids := make([]entity.ID, len(comments))
+	for i, comment := range comments {
+		ids[i] = comment.ID
+	}
+
+	mine, err := s.reactionRepository.FindCommentReactionsByUser(ctx, viewerID, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, comment := range comments {
+		comment.MyReactions = mine[comment.ID]
+	}
+	return nil
+}
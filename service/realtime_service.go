@@ -0,0 +1,311 @@
+package service
+
+import (
+	"analog-be/entity"
+	"analog-be/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+	"go.uber.org/zap"
+)
+
+const (
+	// 구독 하나에 쌓아 둘 수 있는 이벤트 수. 넘치면 구독을 끊고 클라이언트가 다시 연결해 Last-Event-ID로 이어 받게 함
+	realtimeSubscriberBuffer = 64
+
+	// 리스너가 끊긴 동안 놓친 이벤트를 확인하는 간격과 다시 연결하기 전에 기다리는 시간
+	realtimeReceiveTimeout = 10 * time.Second
+	realtimeReconnectDelay = time.Second
+
+	// 리스너가 다시 연결된 뒤 한 번에 읽어 올 놓친 이벤트 수
+	realtimeCatchUpBatch = 500
+)
+
+// RealtimeMessage 는 다른 서비스가 실시간 이벤트를 보낼 때 넘기는 내용입니다. Data는 JSON으로 보냅니다.
+type RealtimeMessage struct {
+	Channel string
+	Type    string
+	Data    any
+}
+
+// RealtimeSubscription 은 구독한 채널의 새 이벤트를 받습니다.
+// 서버가 종료되거나 이벤트를 제때 가져가지 않아 구독이 끊기면 Events가 닫힙니다.
+type RealtimeSubscription struct {
+	Events <-chan *entity.RealtimeEvent
+
+	close func()
+}
+
+func (s *RealtimeSubscription) Close() {
+	s.close()
+}
+
+// RealtimeService 는 Postgres LISTEN/NOTIFY로 여러 인스턴스의 실시간 이벤트를 이 인스턴스의 구독자에게 나눠 줍니다.
+type RealtimeService interface {
+	// Publish 는 이벤트를 저장하고 모든 인스턴스에 알립니다. 실패해도 호출한 쪽의 요청은 실패시키지 않고 기록만 남깁니다.
+	Publish(ctx context.Context, messages ...RealtimeMessage)
+	// Subscribe 는 channels의 새 이벤트를 받는 구독을 만듭니다. 처음 구독할 때 LISTEN을 시작합니다.
+	Subscribe(channels []string) *RealtimeSubscription
+	// Replay 는 afterID 이후 channels에 온 이벤트를 limit개까지 찾습니다.
+	// 더 남아 있거나 afterID 다음 이벤트가 이미 정리되었으면 complete가 false입니다.
+	Replay(ctx context.Context, channels []string, afterID entity.ID, limit int) (events []*entity.RealtimeEvent, complete bool, err error)
+	// LastEventID 는 지금까지 만든 마지막 이벤트 아이디입니다. 새로 연결한 클라이언트가 이어 받을 기준이 됩니다.
+	LastEventID(ctx context.Context) (entity.ID, error)
+	// Close 는 LISTEN을 멈추고 모든 구독을 끝냅니다. 서버가 종료될 때 열린 스트림을 닫기 위해 호출합니다.
+	Close()
+}
+
+type realtimeSubscriber struct {
+	channels map[string]bool
+	events   chan *entity.RealtimeEvent
+}
+
+type RealtimeServiceImpl struct {
+	db                      *bun.DB
+	realtimeEventRepository repository.RealtimeEventRepository
+	logger                  *zap.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	start  sync.Once
+
+	mu          sync.Mutex
+	subscribers map[*realtimeSubscriber]bool
+	closed      bool
+}
+
+func NewRealtimeService(db *bun.DB, realtimeEventRepository repository.RealtimeEventRepository, logger *zap.Logger) RealtimeService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &RealtimeServiceImpl{
+		db:                      db,
+		realtimeEventRepository: realtimeEventRepository,
+		logger:                  logger,
+		ctx:                     ctx,
+		cancel:                  cancel,
+		subscribers:             make(map[*realtimeSubscriber]bool),
+	}
+}
+
+func (s *RealtimeServiceImpl) Publish(ctx context.Context, messages ...RealtimeMessage) {
+	events := make([]*entity.RealtimeEvent, 0, len(messages))
+	for _, message := range messages {
+		data, err := json.Marshal(message.Data)
+		if err != nil {
+			s.logger.Error("Failed to encode realtime event", zap.String("type", message.Type), zap.Error(err))
+			continue
+		}
+		events = append(events, &entity.RealtimeEvent{
+			Channel: message.Channel,
+			Type:    message.Type,
+			Data:    data,
+		})
+	}
+
+	if err := s.realtimeEventRepository.Create(ctx, events); err != nil {
+		s.logger.Error("Failed to publish realtime events", zap.Error(err))
+	}
+}
+
+func (s *RealtimeServiceImpl) Subscribe(channels []string) *RealtimeSubscription {
+	sub := &realtimeSubscriber{
+		channels: make(map[string]bool, len(channels)),
+		events:   make(chan *entity.RealtimeEvent, realtimeSubscriberBuffer),
+	}
+	for _, channel := range channels {
+		sub.channels[channel] = true
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		close(sub.events)
+	} else {
+		s.subscribers[sub] = true
+	}
+	s.mu.Unlock()
+
+	s.start.Do(func() { go s.listen(s.ctx) })
+
+	return &RealtimeSubscription{
+		Events: sub.events,
+		close:  func() { s.unsubscribe(sub) },
+	}
+}
+
+func (s *RealtimeServiceImpl) unsubscribe(sub *realtimeSubscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.subscribers[sub] {
+		delete(s.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+func (s *RealtimeServiceImpl) Replay(ctx context.Context, channels []string, afterID entity.ID, limit int) ([]*entity.RealtimeEvent, bool, error) {
+	// 이벤트는 만든 순서대로 정리되므로 afterID가 남아 있으면 그 뒤의 이벤트도 모두 남아 있음
+	if afterID > 0 {
+		exists, err := s.realtimeEventRepository.Exists(ctx, afterID)
+		if err != nil {
+			return nil, false, err
+		}
+		lastID, err := s.realtimeEventRepository.LastID(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		if !exists && afterID < lastID {
+			return nil, false, nil
+		}
+	}
+
+	events, err := s.realtimeEventRepository.FindAfter(ctx, afterID, channels, limit+1)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(events) > limit {
+		return events[:limit], false, nil
+	}
+	return events, true, nil
+}
+
+func (s *RealtimeServiceImpl) LastEventID(ctx context.Context) (entity.ID, error) {
+	return s.realtimeEventRepository.LastID(ctx)
+}
+
+func (s *RealtimeServiceImpl) Close() {
+	s.cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for sub := range s.subscribers {
+		delete(s.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+// listen 은 NOTIFY로 받은 아이디의 이벤트를 구독자에게 나눠 줍니다.
+// 리스너 연결이 끊겼다가 다시 붙으면 마지막으로 나눠 준 아이디 이후의 이벤트를 읽어 놓친 것을 채웁니다.
+func (s *RealtimeServiceImpl) listen(ctx context.Context) {
+	ln := pgdriver.NewListener(s.db)
+	defer ln.Close()
+
+	// LISTEN을 먼저 해야 마지막 아이디를 읽은 뒤 만든 이벤트를 놓치지 않음
+	resync := false
+	if err := ln.Listen(ctx, repository.RealtimeNotifyChannel); err != nil {
+		s.logger.Warn("Failed to listen for realtime events", zap.Error(err))
+		resync = true
+	}
+
+	var lastID entity.ID
+	for {
+		id, err := s.realtimeEventRepository.LastID(ctx)
+		if err == nil {
+			lastID = id
+			break
+		}
+		s.logger.Warn("Failed to read last realtime event", zap.Error(err))
+		if !sleepContext(ctx, realtimeReconnectDelay) {
+			return
+		}
+	}
+
+	for {
+		_, payload, err := ln.ReceiveTimeout(ctx, realtimeReceiveTimeout)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err != nil && !isTimeout(err) {
+			s.logger.Warn("Realtime listener disconnected", zap.Error(err))
+			resync = true
+			if !sleepContext(ctx, realtimeReconnectDelay) {
+				return
+			}
+			continue
+		}
+
+		if resync {
+			lastID, err = s.catchUp(ctx, lastID)
+			if err != nil {
+				s.logger.Warn("Failed to catch up on realtime events", zap.Error(err))
+				continue
+			}
+			resync = false
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		id, err := strconv.ParseInt(payload, 10, 64)
+		if err != nil {
+			continue
+		}
+		events, err := s.realtimeEventRepository.FindAllByIDs(ctx, []entity.ID{id})
+		if err != nil {
+			s.logger.Warn("Failed to load realtime event", zap.Int64("id", id), zap.Error(err))
+			continue
+		}
+		s.dispatch(events)
+		lastID = max(lastID, id)
+	}
+}
+
+func (s *RealtimeServiceImpl) catchUp(ctx context.Context, lastID entity.ID) (entity.ID, error) {
+	for {
+		events, err := s.realtimeEventRepository.FindAfter(ctx, lastID, nil, realtimeCatchUpBatch)
+		if err != nil {
+			return lastID, err
+		}
+		s.dispatch(events)
+		if len(events) > 0 {
+			lastID = events[len(events)-1].ID
+		}
+		if len(events) < realtimeCatchUpBatch {
+			return lastID, nil
+		}
+	}
+}
+
+func (s *RealtimeServiceImpl) dispatch(events []*entity.RealtimeEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, event := range events {
+		for sub := range s.subscribers {
+			if !sub.channels[event.Channel] {
+				continue
+			}
+			select {
+			case sub.events <- event:
+			default:
+				// 밀린 구독은 끊어서 클라이언트가 다시 연결해 이어 받게 함
+				delete(s.subscribers, sub)
+				close(sub.events)
+			}
+		}
+	}
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// sleepContext 는 d만큼 기다립니다. 그 전에 ctx가 취소되면 false를 반환합니다.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
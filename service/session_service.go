@@ -0,0 +1,77 @@
+package service
+
+import (
+	"analog-be/entity"
+	"analog-be/pkg"
+	"analog-be/repository"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type SessionService interface {
+	List(ctx context.Context, userID *entity.ID) ([]*entity.Session, error)
+	Revoke(ctx context.Context, userID *entity.ID, sessionID *entity.ID) error
+	RevokeOthers(ctx context.Context, userID *entity.ID, currentToken string) error
+	Rotate(ctx context.Context, currentToken string) (*entity.Session, error)
+}
+
+type SessionServiceImpl struct {
+	sessionRepo   repository.SessionRepository
+	sessionPolicy *pkg.SessionPolicy
+}
+
+func NewSessionService(sessionRepo repository.SessionRepository, sessionPolicy *pkg.SessionPolicy) SessionService {
+	return &SessionServiceImpl{sessionRepo: sessionRepo, sessionPolicy: sessionPolicy}
+}
+
+func (s *SessionServiceImpl) List(ctx context.Context, userID *entity.ID) ([]*entity.Session, error) {
+	return s.sessionRepo.FindAllByUserID(ctx, userID)
+}
+
+// Revoke 는 사용자의 세션 하나를 끊습니다. 다른 사용자의 세션이면 존재를 드러내지 않도록 sql.ErrNoRows를 반환합니다.
+func (s *SessionServiceImpl) Revoke(ctx context.Context, userID *entity.ID, sessionID *entity.ID) error {
+	session, err := s.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if session.UserID != *userID {
+		return sql.ErrNoRows
+	}
+
+	return s.sessionRepo.DeleteByID(ctx, sessionID)
+}
+
+// RevokeOthers 는 지금 사용 중인 세션을 제외한 사용자의 모든 세션을 끊습니다.
+func (s *SessionServiceImpl) RevokeOthers(ctx context.Context, userID *entity.ID, currentToken string) error {
+	return s.sessionRepo.DeleteByUserID(ctx, *userID, currentToken)
+}
+
+// Rotate 는 현재 세션의 토큰을 새로 발급하고 기존 토큰은 더 이상 쓸 수 없게 합니다.
+// 세션 자체는 유지되므로 최대 수명은 처음 로그인한 시각부터 계산됩니다.
+func (s *SessionServiceImpl) Rotate(ctx context.Context, currentToken string) (*entity.Session, error) {
+	session, err := s.sessionRepo.FindByToken(ctx, currentToken)
+	if err != nil {
+		return nil, err
+	}
+
+	newToken, err := generateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	expiresAt := s.sessionPolicy.ExpiresAt(session.CreatedAt, now)
+
+	err = s.sessionRepo.Rotate(ctx, &session.ID, currentToken, newToken, now, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	session.SessionToken = newToken
+	session.LastSeenAt = now
+	session.ExpiresAt = expiresAt
+
+	return session, nil
+}
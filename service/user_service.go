@@ -3,9 +3,13 @@ package service
 import (
 	"analog-be/dto"
 	"analog-be/entity"
+	"analog-be/pkg"
 	"analog-be/repository"
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 )
 
@@ -16,15 +20,21 @@ type UserService interface {
 	Delete(ctx context.Context, id *entity.ID) error
 	List(ctx context.Context, limit, offset int) (*dto.PaginatedResult[*entity.User], error)
 	Search(ctx context.Context, query string, limit, offset int) (*dto.PaginatedResult[*entity.User], error)
+	GetByHandle(ctx context.Context, handle string) (*entity.User, bool, error)
+	CheckHandle(ctx context.Context, handle string) (*dto.HandleAvailabilityResponse, error)
 }
 
 type UserServiceImpl struct {
-	repository repository.UserRepository
+	repository   repository.UserRepository
+	identityRepo repository.UserIdentityRepository
+	sessionCache *pkg.SessionCache
 }
 
-func NewUserService(repository repository.UserRepository) UserService {
+func NewUserService(repository repository.UserRepository, identityRepo repository.UserIdentityRepository, sessionCache *pkg.SessionCache) UserService {
 	return &UserServiceImpl{
-		repository: repository,
+		repository:   repository,
+		identityRepo: identityRepo,
+		sessionCache: sessionCache,
 	}
 }
 
@@ -43,6 +53,7 @@ func (s *UserServiceImpl) Create(ctx context.Context, req *dto.UserCreateRequest
 	user := &entity.User{
 		ID:           req.ID,
 		Name:         req.Name,
+		Handle:       defaultHandle(req.ID),
 		ProfileImage: req.ProfileImage,
 		JoinedAt:     now,
 		PartOf:       req.PartOf,
@@ -54,8 +65,14 @@ func (s *UserServiceImpl) Create(ctx context.Context, req *dto.UserCreateRequest
 		user.Connections = []string{}
 	}
 
-	user, err := s.repository.Create(ctx, user)
-	if err != nil {
+	// 아이디가 An-Account 아이디이므로 An-Account 계정을 함께 연결
+	identity := &entity.UserIdentity{
+		Provider:   entity.ProviderAnAccount,
+		Subject:    strconv.FormatInt(int64(req.ID), 10),
+		CreatedAt:  now.UTC(),
+		LastUsedAt: now.UTC(),
+	}
+	if err := s.identityRepo.CreateWithUser(ctx, user, identity); err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
@@ -83,12 +100,29 @@ func (s *UserServiceImpl) Update(ctx context.Context, id *entity.ID, req *dto.Us
 	if req.Connections != nil {
 		user.Connections = *req.Connections
 	}
+	if req.Handle != nil {
+		if handle := pkg.NormalizeHandle(*req.Handle); handle != user.Handle {
+			if err := ensureHandleAvailable(ctx, s.repository, handle, &user.ID); err != nil {
+				return nil, err
+			}
+			err := s.repository.ChangeHandle(ctx, user, handle)
+			if errors.Is(err, repository.ErrHandleTaken) {
+				return nil, pkg.NewConflictError("Handle is already taken")
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to change handle: %w", err)
+			}
+		}
+	}
 
 	user, err = s.repository.Update(ctx, user)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 
+	// 캐시된 세션에 담긴 사용자 정보도 바뀌었으므로 뺌
+	s.sessionCache.InvalidateUser(user.ID)
+
 	return user, nil
 }
 
@@ -103,6 +137,9 @@ func (s *UserServiceImpl) Delete(ctx context.Context, id *entity.ID) error {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
 
+	// 세션은 디비에서 함께 지워지므로 캐시에서도 뺌
+	s.sessionCache.InvalidateUser(*id)
+
 	return nil
 }
 
@@ -147,3 +184,78 @@ func (s *UserServiceImpl) Search(ctx context.Context, query string, limit, offse
 		Offset: offset,
 	}, nil
 }
+
+// GetByHandle 은 핸들로 사용자를 찾습니다. 예전 핸들이면 지금 사용자와 함께 true를 반환합니다.
+func (s *UserServiceImpl) GetByHandle(ctx context.Context, handle string) (*entity.User, bool, error) {
+	handle = pkg.NormalizeHandle(handle)
+
+	user, err := s.repository.FindByHandle(ctx, handle)
+	if err == nil {
+		return user, false, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, err
+	}
+
+	history, err := s.repository.FindHandleHistory(ctx, handle)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, pkg.NewNotFoundError("User")
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	user, err = s.repository.FindByID(ctx, &history.UserID)
+	if err != nil {
+		return nil, false, err
+	}
+	return user, true, nil
+}
+
+// CheckHandle 은 가입하거나 핸들을 바꾸기 전에 핸들을 쓸 수 있는지 확인합니다.
+func (s *UserServiceImpl) CheckHandle(ctx context.Context, handle string) (*dto.HandleAvailabilityResponse, error) {
+	handle = pkg.NormalizeHandle(handle)
+	res := &dto.HandleAvailabilityResponse{Handle: handle}
+
+	if err := pkg.ValidateHandle(handle); err != nil {
+		res.Reason = err.Error()
+		return res, nil
+	}
+
+	taken, err := s.repository.IsHandleTaken(ctx, handle, nil)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		res.Reason = repository.ErrHandleTaken.Error()
+		return res, nil
+	}
+
+	res.Available = true
+	return res, nil
+}
+
+// ensureHandleAvailable 은 핸들이 형식에 맞고 userID 외의 사용자가 쓰거나 예전에 쓰지 않았는지 확인합니다.
+func ensureHandleAvailable(ctx context.Context, userRepo repository.UserRepository, handle string, userID *entity.ID) error {
+	if err := pkg.ValidateHandle(handle); err != nil {
+		return pkg.NewBadRequestError(err.Error(), map[string]string{"handle": handle})
+	}
+
+	taken, err := userRepo.IsHandleTaken(ctx, handle, userID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return pkg.NewConflictError("Handle is already taken")
+	}
+	return nil
+}
+
+// defaultHandle 은 handle이 정해지지 않은 사용자에게 아이디 기반 handle을 부여합니다.
+// An-Account 외의 제공자로 가입한 사용자(음수 아이디)는 guest 접두사를 씁니다.
+func defaultHandle(id entity.ID) string {
+	if id < 0 {
+		return fmt.Sprintf("guest%d", -id)
+	}
+	return fmt.Sprintf("user%d", id)
+}